@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"time"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	cacheredis "katseye/internal/infrastructure/cache/redis"
+	"katseye/internal/infrastructure/config"
+	"katseye/internal/infrastructure/crypto/envelope"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	"katseye/internal/infrastructure/persistence/mongodb"
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
+	"katseye/internal/infrastructure/persistence/rediscache"
+)
+
+func main() {
+	asOfFlag := flag.String("as-of", "", "Data de referência no formato YYYY-MM-DD (padrão: hoje)")
+	flag.Parse()
+
+	asOf := time.Now()
+	if *asOfFlag != "" {
+		parsed, err := time.Parse(time.DateOnly, *asOfFlag)
+		if err != nil {
+			log.Fatalf("data inválida %q: %v", *asOfFlag, err)
+		}
+		asOf = parsed
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("carregando configuração: %v", err)
+	}
+
+	client, err := mongodb.NewMongoClient(cfg.Mongo.URI)
+	if err != nil {
+		log.Fatalf("conectando ao mongo: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			log.Printf("erro ao fechar conexão com mongo: %v", err)
+		}
+	}()
+
+	database := client.Database(cfg.Mongo.Database)
+	ctx := context.Background()
+
+	// The job rewrites the consumer's outstanding debt, so consumers must go through field
+	// encryption just like in the API.
+	var consumers repositories.ConsumerRepository = mongorepositories.NewConsumerRepositoryMongo(database.Collection("consumers"))
+	if cfg.Encryption.Enabled {
+		policy, err := fieldencryption.NewPolicy(cfg.Encryption.Fields, cfg.Encryption.DeterministicFields)
+		if err != nil {
+			log.Fatalf("validando campos criptografados: %v", err)
+		}
+		masterKey, err := envelope.LoadMasterKey(cfg.Encryption.MasterKey, cfg.Encryption.MasterKeyFile)
+		if err != nil {
+			log.Fatalf("carregando chave mestra: %v", err)
+		}
+		keyRing, err := envelope.NewKeyRing(ctx, masterKey, mongorepositories.NewDataKeyStoreMongo(database.Collection("encryption_keys")))
+		if err != nil {
+			log.Fatalf("carregando chaves de dados: %v", err)
+		}
+		// The API caches sealed consumers, so the rewritten ones are evicted through the same decorator.
+		if cfg.Cache.Enabled {
+			redisClient, err := cacheredis.NewClient(cfg.Cache.Redis.ClientConfig())
+			if err != nil {
+				log.Fatalf("conectando ao redis: %v", err)
+			}
+			defer redisClient.Close()
+			options, err := cfg.Cache.Redis.CacheOptions()
+			if err != nil {
+				log.Fatalf("configurando o cache: %v", err)
+			}
+			options.Local = cfg.Cache.Redis.NewLocalCache(redisClient)
+			defer options.Local.Close()
+			consumers = rediscache.NewConsumerRepository(redisClient, options, consumers)
+		}
+		consumers = fieldencryption.NewConsumerRepository(keyRing, policy, consumers)
+	}
+
+	service := services.NewRepaymentService(
+		mongorepositories.NewInstallmentRepositoryMongo(database.Collection("installments")),
+		mongorepositories.NewContractRepositoryMongo(database.Collection("contracts")),
+		mongorepositories.NewPaymentRepositoryMongo(database.Collection("payments")),
+		consumers,
+		nil,
+		mongorepositories.NewJobLeaseMongo(database.Collection("job_leases")),
+	)
+
+	report, err := service.AgeInstallments(ctx, asOf)
+	if errors.Is(err, services.ErrAgingInProgress) {
+		log.Printf("outro processo já está atualizando as parcelas; nada a fazer")
+		return
+	}
+	if err != nil {
+		log.Fatalf("atualizando parcelas em atraso: %v", err)
+	}
+
+	log.Printf("referência %s: %d parcelas verificadas, %d atualizadas, %d alteradas por pagamentos durante a execução, saldo devedor recalculado para %d consumidores",
+		report.AsOf.Format(time.DateOnly), report.Checked, report.Updated, report.Skipped, report.Consumers)
+}
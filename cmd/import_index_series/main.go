@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/config"
+	"katseye/internal/infrastructure/persistence/mongodb"
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
+)
+
+func main() {
+	file := flag.String("file", "", "Arquivo CSV com os valores do indexador (obrigatório)")
+	indexName := flag.String("index", "", "Indexador usado quando o arquivo não tem coluna de índice (cdi, selic, ipca)")
+	delimiter := flag.String("delimiter", ",", "Separador de colunas do CSV (use ';' para exportações do SGS/BCB)")
+	source := flag.String("source", "csv_import", "Origem registrada junto a cada valor")
+	dryRun := flag.Bool("dry-run", false, "Apenas valida o arquivo e conta os valores")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("informe o arquivo com -file")
+	}
+	if len([]rune(*delimiter)) != 1 {
+		log.Fatal("-delimiter deve ter exatamente um caractere")
+	}
+
+	var index valueobjects.RateIndex
+	if *indexName != "" {
+		parsed, err := valueobjects.NewRateIndex(*indexName)
+		if err != nil {
+			log.Fatalf("indexador inválido %q: %v", *indexName, err)
+		}
+		index = parsed
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("abrindo arquivo: %v", err)
+	}
+	defer input.Close()
+
+	values, err := services.ParseIndexSeriesCSV(input, services.IndexSeriesCSVOptions{
+		Index: index,
+		Comma: []rune(*delimiter)[0],
+	})
+	if err != nil {
+		log.Fatalf("lendo CSV: %v", err)
+	}
+
+	if *dryRun {
+		log.Printf("%d valores seriam importados", len(values))
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("carregando configuração: %v", err)
+	}
+
+	client, err := mongodb.NewMongoClient(cfg.Mongo.URI)
+	if err != nil {
+		log.Fatalf("conectando ao mongo: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			log.Printf("erro ao fechar conexão com mongo: %v", err)
+		}
+	}()
+
+	database := client.Database(cfg.Mongo.Database)
+	service := services.NewIndexSeriesService(mongorepositories.NewIndexSeriesRepositoryMongo(database.Collection("index_series")))
+
+	if err := service.RecordIndexValues(context.Background(), values, *source); err != nil {
+		log.Fatalf("gravando valores: %v", err)
+	}
+
+	log.Printf("%d valores importados na coleção index_series", len(values))
+}
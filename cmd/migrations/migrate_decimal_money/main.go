@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"katseye/internal/infrastructure/config"
+	"katseye/internal/infrastructure/persistence/mongodb"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Apenas conta quantos documentos seriam convertidos")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("carregando configuração: %v", err)
+	}
+
+	client, err := mongodb.NewMongoClient(cfg.Mongo.URI)
+	if err != nil {
+		log.Fatalf("conectando ao mongo: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			log.Printf("erro ao fechar conexão com mongo: %v", err)
+		}
+	}()
+
+	database := client.Database(cfg.Mongo.Database)
+	ctx := context.Background()
+
+	steps := []struct {
+		name    string
+		migrate func(context.Context, *mongo.Collection, bool) (int, error)
+	}{
+		{"products", migrateCollection[models.ProductDocument]},
+		{"partners", migrateCollection[models.PartnerDocument]},
+		{"consumers", migrateCollection[models.ConsumerDocument]},
+		{"contracts", migrateCollection[models.ContractDocument]},
+		{"index_series", migrateCollection[models.IndexValueDocument]},
+		{"product_search", migrateCollection[models.ProductSearchDocument]},
+	}
+
+	for _, step := range steps {
+		converted, err := step.migrate(ctx, database.Collection(step.name), *dryRun)
+		if err != nil {
+			log.Fatalf("migrando coleção %s: %v", step.name, err)
+		}
+		if *dryRun {
+			log.Printf("%s: %d documentos seriam convertidos", step.name, converted)
+			continue
+		}
+		log.Printf("%s: %d documentos convertidos", step.name, converted)
+	}
+}
+
+// migrateCollection decodifica cada documento no modelo, que aceita os valores antigos (double, int
+// ou string), e grava com $set apenas os caminhos cuja nova codificação Decimal128 difere da
+// armazenada: valores monetários passam a Decimal128 arredondados para centavos e taxas para seis
+// casas decimais. Os demais campos, inclusive os que o modelo não conhece, ficam como estão, e
+// documentos já convertidos são mantidos, o que torna a migração idempotente.
+func migrateCollection[T any](ctx context.Context, collection *mongo.Collection, dryRun bool) (int, error) {
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	converted := 0
+	for cursor.Next(ctx) {
+		raw := cursor.Current
+
+		var doc T
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return converted, err
+		}
+		encoded, err := bson.Marshal(doc)
+		if err != nil {
+			return converted, err
+		}
+		if bytes.Equal(encoded, raw) {
+			continue
+		}
+
+		set, err := decimalChanges("", raw, encoded)
+		if err != nil {
+			return converted, err
+		}
+		if len(set) == 0 {
+			continue
+		}
+
+		converted++
+		if dryRun {
+			continue
+		}
+
+		id := raw.Lookup("_id")
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set}); err != nil {
+			return converted, err
+		}
+	}
+
+	return converted, cursor.Err()
+}
+
+// decimalChanges percorre o documento codificado, inclusive subdocumentos e arrays, e lista os
+// caminhos Decimal128 cujo valor difere do armazenado. Campos ausentes no documento armazenado
+// não entram: são zeros preenchidos pelo modelo, não valores a converter.
+func decimalChanges(prefix string, stored, encoded bson.Raw) (bson.D, error) {
+	elements, err := encoded.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	var set bson.D
+	for _, element := range elements {
+		current, err := stored.LookupErr(element.Key())
+		if err != nil {
+			continue
+		}
+
+		path := element.Key()
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		value := element.Value()
+		switch value.Type {
+		case bson.TypeDecimal128:
+			if !current.Equal(value) {
+				set = append(set, bson.E{Key: path, Value: value})
+			}
+		case bson.TypeEmbeddedDocument, bson.TypeArray:
+			if current.Type != value.Type {
+				continue
+			}
+			nested, err := decimalChanges(path, bson.Raw(current.Value), bson.Raw(value.Value))
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, nested...)
+		}
+	}
+	return set, nil
+}
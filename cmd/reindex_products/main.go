@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/config"
+	"katseye/internal/infrastructure/persistence/mongodb"
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Apenas conta quantos produtos seriam indexados")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("carregando configuração: %v", err)
+	}
+
+	client, err := mongodb.NewMongoClient(cfg.Mongo.URI)
+	if err != nil {
+		log.Fatalf("conectando ao mongo: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			log.Printf("erro ao fechar conexão com mongo: %v", err)
+		}
+	}()
+
+	database := client.Database(cfg.Mongo.Database)
+	ctx := context.Background()
+
+	index := mongorepositories.NewProductSearchMongo(database.Collection("product_search"))
+	if err := index.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("criando índices de busca: %v", err)
+	}
+
+	products := mongorepositories.NewProductRepositoryMongo(database.Collection("products"))
+	partners := mongorepositories.NewPartnerRepositoryMongo(database.Collection("partners"))
+	partnerCache := make(map[primitive.ObjectID]*entities.Partner)
+
+	query := repositories.ProductQuery{Page: repositories.Pagination{Limit: repositories.MaxPageLimit}}
+	total := 0
+	for {
+		list, page, err := products.ListProducts(ctx, query)
+		if err != nil {
+			log.Fatalf("listando produtos: %v", err)
+		}
+
+		for _, product := range list {
+			total++
+			if *dryRun {
+				continue
+			}
+
+			partner, cached := partnerCache[product.PartnerID]
+			if !cached {
+				partner, err = partners.GetPartnerByID(ctx, product.PartnerID)
+				if err != nil {
+					log.Fatalf("buscando parceiro %s: %v", product.PartnerID.Hex(), err)
+				}
+				partnerCache[product.PartnerID] = partner
+			}
+
+			if err := index.IndexProduct(ctx, product, partner); err != nil {
+				log.Fatalf("indexando produto %s: %v", product.ID.Hex(), err)
+			}
+		}
+
+		if !page.HasMore {
+			break
+		}
+		query.Page.Cursor = page.NextCursor
+	}
+
+	if *dryRun {
+		log.Printf("%d produtos seriam indexados", total)
+		return
+	}
+
+	log.Printf("%d produtos indexados na coleção product_search", total)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/config"
+	"katseye/internal/infrastructure/crypto/envelope"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	"katseye/internal/infrastructure/persistence/mongodb"
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
+)
+
+func main() {
+	rotate := flag.Bool("rotate", true, "Gera uma nova chave de dados ativa antes de recriptografar")
+	newMasterKey := flag.String("new-master-key", "", "Nova chave mestra em base64 para recifrar as chaves de dados")
+	newMasterKeyFile := flag.String("new-master-key-file", "", "Arquivo com a nova chave mestra em base64")
+	dryRun := flag.Bool("dry-run", false, "Apenas lista quantos consumidores seriam recriptografados")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("carregando configuração: %v", err)
+	}
+
+	policy, err := fieldencryption.NewPolicy(cfg.Encryption.Fields, cfg.Encryption.DeterministicFields)
+	if err != nil {
+		log.Fatalf("validando campos criptografados: %v", err)
+	}
+
+	masterKey, err := envelope.LoadMasterKey(cfg.Encryption.MasterKey, cfg.Encryption.MasterKeyFile)
+	if err != nil {
+		log.Fatalf("carregando chave mestra: %v", err)
+	}
+
+	client, err := mongodb.NewMongoClient(cfg.Mongo.URI)
+	if err != nil {
+		log.Fatalf("conectando ao mongo: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			log.Printf("erro ao fechar conexão com mongo: %v", err)
+		}
+	}()
+
+	database := client.Database(cfg.Mongo.Database)
+	ctx := context.Background()
+
+	keyRing, err := envelope.NewKeyRing(ctx, masterKey, mongorepositories.NewDataKeyStoreMongo(database.Collection("encryption_keys")))
+	if err != nil {
+		log.Fatalf("carregando chaves de dados: %v", err)
+	}
+
+	if strings.TrimSpace(*newMasterKey) != "" || strings.TrimSpace(*newMasterKeyFile) != "" {
+		replacement, err := envelope.LoadMasterKey(*newMasterKey, *newMasterKeyFile)
+		if err != nil {
+			log.Fatalf("carregando nova chave mestra: %v", err)
+		}
+		if !*dryRun {
+			if err := keyRing.Rewrap(ctx, replacement); err != nil {
+				log.Fatalf("recifrando chaves de dados: %v", err)
+			}
+			log.Println("chaves de dados recifradas com a nova chave mestra; atualize FIELD_ENCRYPTION_MASTER_KEY antes de reiniciar a API")
+		}
+	}
+
+	if *rotate && !*dryRun {
+		keyID, err := keyRing.Rotate(ctx)
+		if err != nil {
+			log.Fatalf("gerando nova chave de dados: %v", err)
+		}
+		log.Printf("nova chave de dados ativa: %s", keyID)
+	}
+
+	// Mongo is accessed directly so the cache never receives intermediate values; cached entries
+	// remain readable because retired keys are kept in the key ring.
+	consumers := fieldencryption.NewConsumerRepository(keyRing, policy, mongorepositories.NewConsumerRepositoryMongo(database.Collection("consumers")))
+
+	// Pages are walked by identifier so rewritten documents never move ahead of the cursor.
+	query := repositories.ConsumerQuery{Page: repositories.Pagination{Limit: repositories.MaxPageLimit}}
+	total := 0
+	for {
+		list, page, err := consumers.ListConsumers(ctx, query)
+		if err != nil {
+			log.Fatalf("listando consumidores: %v", err)
+		}
+
+		if !*dryRun {
+			for _, consumer := range list {
+				if err := consumers.UpdateConsumer(ctx, consumer); err != nil {
+					log.Fatalf("recriptografando consumidor %s: %v", consumer.ID.Hex(), err)
+				}
+			}
+		}
+		total += len(list)
+
+		if !page.HasMore {
+			break
+		}
+		query.Page.Cursor = page.NextCursor
+	}
+
+	if *dryRun {
+		log.Printf("%d consumidores seriam recriptografados com a chave %s", total, keyRing.ActiveKeyID())
+		return
+	}
+
+	log.Printf("%d consumidores recriptografados com a chave %s", total, keyRing.ActiveKeyID())
+}
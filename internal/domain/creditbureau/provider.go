@@ -0,0 +1,82 @@
+package creditbureau
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+var (
+	// ErrDocumentNotFound means the bureau holds no data for the CPF/CNPJ.
+	ErrDocumentNotFound = errors.New("document not found in credit bureau")
+	ErrInvalidDocument  = errors.New("document must be a CPF (11 digits) or CNPJ (14 digits)")
+)
+
+// Provider queries a credit bureau by CPF or CNPJ. Documents are passed as digits only.
+type Provider interface {
+	// Name identifies the bureau in the attribution stored with the consumer profile.
+	Name() string
+	// Score returns the credit score (0-1000) and the probability of default.
+	Score(ctx context.Context, document string) (ScoreReport, error)
+	// NegativeRecords returns the unpaid debts and protests registered against the document.
+	NegativeRecords(ctx context.Context, document string) (NegativeRecordsReport, error)
+	// EstimateIncome returns the bureau's estimate of monthly income, or monthly revenue for
+	// companies.
+	EstimateIncome(ctx context.Context, document string) (IncomeEstimate, error)
+}
+
+// Attribution tells where a report came from. TTL is how long the bureau allows the answer
+// to be reused; zero means it must not be cached.
+type Attribution struct {
+	Source      string        `json:"source"`
+	RetrievedAt time.Time     `json:"retrieved_at"`
+	TTL         time.Duration `json:"ttl"`
+}
+
+type ScoreReport struct {
+	Attribution
+	Score                  int     `json:"score"`
+	DelinquencyProbability float64 `json:"delinquency_probability"`
+}
+
+type NegativeRecord struct {
+	Creditor     string             `json:"creditor"`
+	Kind         string             `json:"kind"`
+	Amount       valueobjects.Money `json:"amount"`
+	RegisteredAt time.Time          `json:"registered_at"`
+}
+
+type NegativeRecordsReport struct {
+	Attribution
+	Records []NegativeRecord `json:"records"`
+}
+
+// Total sums the amount of every record.
+func (r NegativeRecordsReport) Total() valueobjects.Money {
+	var total valueobjects.Money
+	for _, record := range r.Records {
+		total = total.Add(record.Amount)
+	}
+	return total
+}
+
+type IncomeEstimate struct {
+	Attribution
+	MonthlyIncome valueobjects.Money `json:"monthly_income"`
+}
+
+// NormalizeDocument strips punctuation from a CPF/CNPJ and checks its length.
+func NormalizeDocument(document string) (string, error) {
+	digits := make([]byte, 0, len(document))
+	for i := 0; i < len(document); i++ {
+		if document[i] >= '0' && document[i] <= '9' {
+			digits = append(digits, document[i])
+		}
+	}
+	if len(digits) != 11 && len(digits) != 14 {
+		return "", ErrInvalidDocument
+	}
+	return string(digits), nil
+}
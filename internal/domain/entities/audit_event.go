@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditSubjectConsumer = "consumer"
+
+	AuditActionDataExported = "lgpd.data_exported"
+	AuditActionDataErased   = "lgpd.data_erased"
+)
+
+var ErrAuditEventNil = errors.New("audit event is nil")
+
+// AuditEvent records an action performed over a data subject for accountability purposes.
+type AuditEvent struct {
+	ID          primitive.ObjectID
+	SubjectType string
+	SubjectID   primitive.ObjectID
+	Action      string
+	ActorID     primitive.ObjectID
+	Details     map[string]string
+	OccurredAt  time.Time
+}
+
+// Validate performs validation on the audit event entity
+func (e *AuditEvent) Validate() error {
+	if e == nil {
+		return ErrAuditEventNil
+	}
+	if strings.TrimSpace(e.SubjectType) == "" {
+		return errors.New("audit subject type is required")
+	}
+	if e.SubjectID.IsZero() {
+		return errors.New("audit subject id is required")
+	}
+	if strings.TrimSpace(e.Action) == "" {
+		return errors.New("audit action is required")
+	}
+	if e.OccurredAt.IsZero() {
+		return errors.New("audit occurrence time is required")
+	}
+	return nil
+}
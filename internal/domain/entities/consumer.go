@@ -19,6 +19,7 @@ var (
 	ErrConsumerPrimaryAddressRequired = errors.New("consumer primary address id is required")
 	ErrConsumerProductAlreadyLinked   = errors.New("product already contracted by consumer")
 	ErrConsumerProductNotLinked       = errors.New("product not linked to consumer")
+	ErrConsumerErased                 = errors.New("consumer personal data has been erased")
 )
 
 type Consumer struct {
@@ -31,8 +32,13 @@ type Consumer struct {
 	AdditionalAddressIDs []primitive.ObjectID
 	ContractedProducts   []primitive.ObjectID
 	UserID               primitive.ObjectID
+	Screening            ConsumerScreening
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
+	ErasedAt             time.Time
+	// SealedFields holds encrypted values of sensitive attributes keyed by field name while the
+	// consumer crosses the persistence boundary; the matching plaintext attributes are zeroed.
+	SealedFields map[string]string
 }
 
 func (c *Consumer) Validate() error {
@@ -85,6 +91,69 @@ func (c *Consumer) HasLinkedUser() bool {
 	return !c.UserID.IsZero()
 }
 
+// IsErased reports whether the consumer went through a data erasure request.
+func (c *Consumer) IsErased() bool {
+	if c == nil {
+		return false
+	}
+	return !c.ErasedAt.IsZero()
+}
+
+// Pseudonymize replaces direct identifiers with the provided pseudonym and document token,
+// returning the paths of the fields that were overwritten. Financial data is left untouched.
+func (c *Consumer) Pseudonymize(pseudonym, documentToken string, erasedAt time.Time) ([]string, error) {
+	if c == nil {
+		return nil, ErrConsumerNil
+	}
+	if c.IsErased() {
+		return nil, ErrConsumerErased
+	}
+
+	var fields []string
+
+	if individual := c.PersonalData.Individual; individual != nil {
+		individual.FullName = pseudonym
+		individual.SocialName = ""
+		individual.DocumentNumber = documentToken
+		individual.BirthDate = time.Time{}
+		individual.Nationality = ""
+		individual.MaritalStatus = ""
+		individual.Occupation = ""
+		fields = append(fields,
+			"personal_data.individual.full_name",
+			"personal_data.individual.social_name",
+			"personal_data.individual.document_number",
+			"personal_data.individual.birth_date",
+			"personal_data.individual.nationality",
+			"personal_data.individual.marital_status",
+			"personal_data.individual.occupation",
+		)
+	}
+
+	if business := c.PersonalData.Business; business != nil {
+		business.CorporateName = pseudonym
+		business.TradeName = ""
+		business.DocumentNumber = documentToken
+		business.StateRegistration = ""
+		business.MunicipalRegistry = ""
+		fields = append(fields,
+			"personal_data.business.corporate_name",
+			"personal_data.business.trade_name",
+			"personal_data.business.document_number",
+			"personal_data.business.state_registration",
+			"personal_data.business.municipal_registry",
+		)
+	}
+
+	c.Contact = ConsumerContactInformation{}
+	fields = append(fields, "contact.email", "contact.phone", "contact.secondary_phone")
+
+	c.ErasedAt = erasedAt
+	c.UpdatedAt = erasedAt
+
+	return fields, nil
+}
+
 func (c *Consumer) AddContractedProduct(productID primitive.ObjectID) error {
 	if c == nil {
 		return ErrConsumerNil
@@ -247,17 +316,40 @@ func (ci ConsumerContactInformation) Validate() error {
 // ConsumerCreditProfile aggregates financial indicators used for risk assessment and approvals.
 type ConsumerCreditProfile struct {
 	CreditScore             int
-	MonthlyIncome           float64
-	AnnualRevenue           float64
-	CreditLimitRequested    float64
-	CreditLimitApproved     float64
-	OutstandingDebt         float64
+	MonthlyIncome           valueobjects.Money
+	AnnualRevenue           valueobjects.Money
+	CreditLimitRequested    valueobjects.Money
+	CreditLimitApproved     valueobjects.Money
+	OutstandingDebt         valueobjects.Money
 	RiskLevel               string
 	DelinquencyProbability  float64
 	EmploymentStatus        string
 	YearsInCurrentJob       int
 	YearsInBusiness         int
 	BankingRelationshipRank string
+	// Bureau is filled by credit profile refreshes; everything else above was declared when the
+	// consumer was registered, except for the score, risk level and delinquency probability,
+	// which a refresh overwrites, and the outstanding debt, which follows the installments.
+	// Consumer updates keep all four.
+	Bureau CreditBureauData
+}
+
+// CreditBureauData records what the credit bureau reported and when each answer was obtained.
+// Answers may come from cache, so their retrieval times can precede RefreshedAt.
+type CreditBureauData struct {
+	Source                     string
+	RefreshedAt                time.Time
+	ScoreRetrievedAt           time.Time
+	NegativeRecords            int
+	NegativeRecordsAmount      valueobjects.Money
+	NegativeRecordsRetrievedAt time.Time
+	EstimatedIncome            valueobjects.Money
+	IncomeRetrievedAt          time.Time
+}
+
+// IsZero reports whether the profile was never refreshed from a bureau.
+func (d CreditBureauData) IsZero() bool {
+	return d == CreditBureauData{}
 }
 
 func (cp ConsumerCreditProfile) Validate(consumerType valueobjects.ConsumerType) error {
@@ -268,19 +360,19 @@ func (cp ConsumerCreditProfile) Validate(consumerType valueobjects.ConsumerType)
 	if cp.CreditScore < 0 || cp.CreditScore > 1000 {
 		return fmt.Errorf("credit score must be between 0 and 1000")
 	}
-	if cp.MonthlyIncome < 0 {
+	if cp.MonthlyIncome.IsNegative() {
 		return fmt.Errorf("monthly income cannot be negative")
 	}
-	if cp.AnnualRevenue < 0 {
+	if cp.AnnualRevenue.IsNegative() {
 		return fmt.Errorf("annual revenue cannot be negative")
 	}
-	if cp.CreditLimitRequested < 0 {
+	if cp.CreditLimitRequested.IsNegative() {
 		return fmt.Errorf("requested credit limit cannot be negative")
 	}
-	if cp.CreditLimitApproved < 0 {
+	if cp.CreditLimitApproved.IsNegative() {
 		return fmt.Errorf("approved credit limit cannot be negative")
 	}
-	if cp.OutstandingDebt < 0 {
+	if cp.OutstandingDebt.IsNegative() {
 		return fmt.Errorf("outstanding debt cannot be negative")
 	}
 	if cp.DelinquencyProbability < 0 || cp.DelinquencyProbability > 1 {
@@ -294,13 +386,13 @@ func (cp ConsumerCreditProfile) Validate(consumerType valueobjects.ConsumerType)
 	}
 
 	if consumerType == valueobjects.ConsumerTypeIndividual {
-		if cp.MonthlyIncome == 0 {
+		if cp.MonthlyIncome.IsZero() {
 			return fmt.Errorf("monthly income is required for individual consumers")
 		}
 	}
 
 	if consumerType == valueobjects.ConsumerTypeBusiness {
-		if cp.AnnualRevenue == 0 {
+		if cp.AnnualRevenue.IsZero() {
 			return fmt.Errorf("annual revenue is required for business consumers")
 		}
 	}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestConsumer_Pseudonymize(t *testing.T) {
+	erasedAt := time.Date(2026, time.May, 4, 15, 0, 0, 0, time.UTC)
+	income := valueobjects.MustParseMoney("8500")
+
+	tests := []struct {
+		name     string
+		consumer *Consumer
+		want     ConsumerPersonalData
+		fields   int
+	}{
+		{
+			name: "individual",
+			consumer: &Consumer{
+				Type: valueobjects.ConsumerTypeIndividual,
+				PersonalData: ConsumerPersonalData{Individual: &ConsumerIndividualData{
+					FullName:       "Maria Silva",
+					SocialName:     "Maria",
+					DocumentNumber: "52998224725",
+					BirthDate:      time.Date(1988, time.May, 17, 0, 0, 0, 0, time.UTC),
+					Nationality:    "brasileira",
+					MaritalStatus:  "casada",
+					Occupation:     "engenheira",
+				}},
+			},
+			want: ConsumerPersonalData{Individual: &ConsumerIndividualData{
+				FullName:       "titular-1",
+				DocumentNumber: "pseudo:1",
+			}},
+			fields: 10,
+		},
+		{
+			name: "business",
+			consumer: &Consumer{
+				Type: valueobjects.ConsumerTypeBusiness,
+				PersonalData: ConsumerPersonalData{Business: &ConsumerBusinessData{
+					CorporateName:     "Padaria Central Ltda",
+					TradeName:         "Padaria Central",
+					DocumentNumber:    "11222333000181",
+					IncorporationDate: time.Date(2010, time.March, 1, 0, 0, 0, 0, time.UTC),
+					StateRegistration: "123456789",
+					MunicipalRegistry: "987654",
+				}},
+			},
+			want: ConsumerPersonalData{Business: &ConsumerBusinessData{
+				CorporateName:     "titular-1",
+				DocumentNumber:    "pseudo:1",
+				IncorporationDate: time.Date(2010, time.March, 1, 0, 0, 0, 0, time.UTC),
+			}},
+			fields: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := tt.consumer
+			consumer.ID = primitive.NewObjectID()
+			consumer.Contact = ConsumerContactInformation{Email: "maria@example.com", Phone: "+5511988887777", SecondaryPhone: "+5511977776666"}
+			consumer.CreditProfile.MonthlyIncome = income
+
+			fields, err := consumer.Pseudonymize("titular-1", "pseudo:1", erasedAt)
+			if err != nil {
+				t.Fatalf("Pseudonymize returned error: %v", err)
+			}
+			if len(fields) != tt.fields {
+				t.Fatalf("expected %d overwritten fields, got %v", tt.fields, fields)
+			}
+			if !reflect.DeepEqual(consumer.PersonalData, tt.want) {
+				t.Fatalf("expected personal data %+v, got %+v", tt.want, consumer.PersonalData)
+			}
+			if consumer.Contact != (ConsumerContactInformation{}) {
+				t.Fatalf("expected the contact to be cleared, got %+v", consumer.Contact)
+			}
+			if consumer.CreditProfile.MonthlyIncome != income {
+				t.Fatalf("expected the credit profile to be kept, got income %s", consumer.CreditProfile.MonthlyIncome)
+			}
+			if !consumer.IsErased() || !consumer.ErasedAt.Equal(erasedAt) || !consumer.UpdatedAt.Equal(erasedAt) {
+				t.Fatalf("expected the consumer to be erased at %s, got erased_at=%s updated_at=%s", erasedAt, consumer.ErasedAt, consumer.UpdatedAt)
+			}
+
+			if _, err := consumer.Pseudonymize("titular-2", "pseudo:2", erasedAt); !errors.Is(err, ErrConsumerErased) {
+				t.Fatalf("expected ErrConsumerErased on a second erasure, got %v", err)
+			}
+		})
+	}
+}
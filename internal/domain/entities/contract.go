@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	valueObjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ContractStatus string
+
+const (
+	ContractStatusActive    ContractStatus = "active"
+	ContractStatusCancelled ContractStatus = "cancelled"
+	// ContractStatusSettled marks a contract whose installments have all been paid.
+	ContractStatusSettled ContractStatus = "settled"
+	// ContractStatusPendingReview holds a contract flagged by screening until an analyst
+	// decides on it; installments are only generated once it is approved.
+	ContractStatusPendingReview ContractStatus = "pending_review"
+)
+
+var ErrContractNil = errors.New("contract is nil")
+
+// Contract records the terms a consumer accepted when contracting a product, including the
+// pricing tier in force at that moment so later catalog changes do not alter it.
+type Contract struct {
+	ID          primitive.ObjectID
+	ConsumerID  primitive.ObjectID
+	ProductID   primitive.ObjectID
+	PartnerID   primitive.ObjectID
+	ProductType valueObjects.ProductType
+	Status      ContractStatus
+	// Amount and TermMonths are zero when the product was contracted without a simulation.
+	Amount       valueObjects.Money
+	TermMonths   int
+	InterestRate valueObjects.Rate
+	Installment  valueObjects.Money
+	CETMonthly   valueObjects.Rate
+	PricingTier  *PricingTier
+	// IndexedRate and IndexValue are set for floating-rate products; InterestRate is then the
+	// monthly rate projected from IndexValue at contracting time.
+	IndexedRate *IndexedRate
+	IndexValue  *IndexValue
+	// FirstDueDate and EarlyRepaymentAllowed come from the product terms at contracting time
+	// and drive the installments generated for the contract.
+	FirstDueDate          time.Time
+	EarlyRepaymentAllowed bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	CancelledAt           time.Time
+	SettledAt             time.Time
+}
+
+func (c *Contract) Validate() error {
+	if c == nil {
+		return ErrContractNil
+	}
+	if c.ConsumerID.IsZero() {
+		return errors.New("contract consumer id is required")
+	}
+	if c.ProductID.IsZero() {
+		return errors.New("contract product id is required")
+	}
+	if !c.InterestRate.IsPositive() {
+		return errors.New("contract interest rate is required")
+	}
+	if c.Amount.IsNegative() || c.TermMonths < 0 {
+		return errors.New("contract amount and term cannot be negative")
+	}
+	return nil
+}
+
+func (c *Contract) IsActive() bool {
+	return c != nil && c.Status == ContractStatusActive
+}
+
+// IsPendingReview reports whether the contract is held for manual review.
+func (c *Contract) IsPendingReview() bool {
+	return c != nil && c.Status == ContractStatusPendingReview
+}
+
+// Cancel marks the contract as cancelled at the given instant.
+func (c *Contract) Cancel(at time.Time) {
+	if c == nil {
+		return
+	}
+	c.Status = ContractStatusCancelled
+	c.CancelledAt = at
+	c.UpdatedAt = at
+}
+
+// Activate releases a contract held for review. The first due date is pushed back by the days
+// the contract spent in review so the consumer keeps the grace period it was priced with.
+func (c *Contract) Activate(at time.Time) {
+	if c == nil {
+		return
+	}
+	if !c.FirstDueDate.IsZero() {
+		c.FirstDueDate = c.FirstDueDate.AddDate(0, 0, daysBetween(c.CreatedAt, at))
+	}
+	c.Status = ContractStatusActive
+	c.UpdatedAt = at
+}
+
+// Settle marks the contract as fully repaid at the given instant.
+func (c *Contract) Settle(at time.Time) {
+	if c == nil {
+		return
+	}
+	c.Status = ContractStatusSettled
+	c.SettledAt = at
+	c.UpdatedAt = at
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain event types. Product and contracting events double as webhook event types.
+const (
+	EventConsumerCreated        = "consumer.created"
+	EventProductContracted      = "consumer.product_contracted"
+	EventProductRemoved         = "consumer.product_removed"
+	EventConsumerUserAttached   = "consumer.user_attached"
+	EventConsumerUserDetached   = "consumer.user_detached"
+	EventProductCreated         = "product.created"
+	EventProductUpdated         = "product.updated"
+	EventProductDeleted         = "product.deleted"
+	EventPartnerManagerAssigned = "partner.manager_assigned"
+	EventPartnerManagerRemoved  = "partner.manager_removed"
+	EventUserCreated            = "user.created"
+	EventUserDeleted            = "user.deleted"
+)
+
+var ErrDomainEventNil = errors.New("domain event is nil")
+
+// DomainEvent records a state change other parts of the system react to. Data holds the JSON
+// payload, which identifies records by ID and carries no personal data.
+type DomainEvent struct {
+	ID          primitive.ObjectID
+	Type        string
+	AggregateID primitive.ObjectID
+	// PartnerID is the partner the event concerns, if any; webhooks are routed by it.
+	PartnerID  primitive.ObjectID
+	Data       []byte
+	OccurredAt time.Time
+}
+
+// NewDomainEvent builds an event with a fresh ID and data encoded as JSON.
+func NewDomainEvent(eventType string, aggregateID, partnerID primitive.ObjectID, data interface{}, at time.Time) (*DomainEvent, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s event: %w", eventType, err)
+	}
+
+	event := &DomainEvent{
+		ID:          primitive.NewObjectID(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		PartnerID:   partnerID,
+		Data:        encoded,
+		OccurredAt:  at,
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *DomainEvent) Validate() error {
+	if e == nil {
+		return ErrDomainEventNil
+	}
+	if strings.TrimSpace(e.Type) == "" {
+		return errors.New("domain event type is required")
+	}
+	if e.AggregateID.IsZero() {
+		return errors.New("domain event aggregate id is required")
+	}
+	if e.OccurredAt.IsZero() {
+		return errors.New("domain event occurrence time is required")
+	}
+	return nil
+}
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxPublished OutboxStatus = "published"
+	// OutboxFailed marks an event some sink kept rejecting until the attempts ran out.
+	OutboxFailed OutboxStatus = "failed"
+)
+
+// OutboxEvent is a domain event waiting in the outbox to be published. PublishedTo lists the
+// sinks that already accepted it, so a retry after a partial failure only goes to the rest.
+type OutboxEvent struct {
+	Event         DomainEvent
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	PublishedTo   []string
+	LastError     string
+	PublishedAt   time.Time
+}
+
+// NewOutboxEvent queues the event for publishing right away.
+func NewOutboxEvent(event DomainEvent) *OutboxEvent {
+	return &OutboxEvent{
+		Event:         event,
+		Status:        OutboxPending,
+		NextAttemptAt: event.OccurredAt,
+	}
+}
+
+func (e *OutboxEvent) IsPublishedTo(sink string) bool {
+	if e == nil {
+		return false
+	}
+	for _, name := range e.PublishedTo {
+		if name == sink {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordSinkPublished notes that the sink accepted the event.
+func (e *OutboxEvent) RecordSinkPublished(sink string) {
+	if e == nil || e.IsPublishedTo(sink) {
+		return
+	}
+	e.PublishedTo = append(e.PublishedTo, sink)
+}
+
+// RecordPublished marks the event as accepted by every sink.
+func (e *OutboxEvent) RecordPublished(at time.Time) {
+	if e == nil {
+		return
+	}
+	e.Attempts++
+	e.Status = OutboxPublished
+	e.LastError = ""
+	e.PublishedAt = at
+	e.NextAttemptAt = time.Time{}
+}
+
+// RecordFailure counts a pass in which some sink failed and schedules the next one after
+// backoff, or marks the event failed once maxAttempts is reached.
+func (e *OutboxEvent) RecordFailure(cause error, at time.Time, maxAttempts int, backoff time.Duration) {
+	if e == nil {
+		return
+	}
+	e.Attempts++
+	e.LastError = ""
+	if cause != nil {
+		e.LastError = cause.Error()
+	}
+
+	if e.Attempts >= maxAttempts {
+		e.Status = OutboxFailed
+		e.NextAttemptAt = time.Time{}
+		return
+	}
+	e.Status = OutboxPending
+	e.NextAttemptAt = at.Add(backoff)
+}
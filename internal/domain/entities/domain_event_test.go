@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOutboxEvent_RetriesOnlyPendingSinks(t *testing.T) {
+	at := time.Date(2026, time.May, 4, 12, 0, 0, 0, time.UTC)
+	event, err := NewDomainEvent(EventConsumerCreated, primitive.NewObjectID(), primitive.NilObjectID, map[string]string{"consumer_id": "c1"}, at)
+	if err != nil {
+		t.Fatalf("NewDomainEvent returned error: %v", err)
+	}
+
+	outbox := NewOutboxEvent(*event)
+	if outbox.Status != OutboxPending || !outbox.NextAttemptAt.Equal(at) {
+		t.Fatalf("expected an event due right away, got %+v", outbox)
+	}
+
+	outbox.RecordSinkPublished("in_process")
+	outbox.RecordFailure(errors.New("redis_stream: connection refused"), at, 2, time.Minute)
+	if outbox.Status != OutboxPending || !outbox.IsPublishedTo("in_process") || outbox.IsPublishedTo("redis_stream") {
+		t.Fatalf("expected a retry for the failed sink only, got %+v", outbox)
+	}
+
+	outbox.RecordSinkPublished("redis_stream")
+	outbox.RecordPublished(at.Add(time.Minute))
+	if outbox.Status != OutboxPublished || outbox.LastError != "" || len(outbox.PublishedTo) != 2 {
+		t.Fatalf("expected a published event, got %+v", outbox)
+	}
+}
+
+func TestOutboxEvent_FailsAfterMaxAttempts(t *testing.T) {
+	at := time.Date(2026, time.May, 4, 12, 0, 0, 0, time.UTC)
+	outbox := NewOutboxEvent(DomainEvent{ID: primitive.NewObjectID(), Type: EventUserDeleted, AggregateID: primitive.NewObjectID(), OccurredAt: at})
+
+	outbox.RecordFailure(errors.New("webhooks: timeout"), at, 1, time.Minute)
+	if outbox.Status != OutboxFailed || !outbox.NextAttemptAt.IsZero() {
+		t.Fatalf("expected a failed event, got %+v", outbox)
+	}
+}
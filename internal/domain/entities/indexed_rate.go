@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+var ErrIndexValueUnavailable = errors.New("no stored value for rate index")
+
+// minimumSpread bounds spreads and index values: a rate of -100% or less would zero or invert
+// the compounding factor.
+var minimumSpread = valueObjects.MustParseRate("-100")
+
+// IndexedRate prices a product as a reference index plus a spread. Spread, Cap and Floor are
+// annual percentages; the effective annual rate is (1+index)*(1+spread)-1, clamped to
+// [Floor, Cap] when those are set.
+type IndexedRate struct {
+	Index  valueObjects.RateIndex `json:"index" bson:"index"`
+	Spread valueObjects.Rate      `json:"spread" bson:"spread"`
+	Cap    valueObjects.Rate      `json:"cap,omitzero" bson:"cap,omitempty"`
+	Floor  valueObjects.Rate      `json:"floor,omitzero" bson:"floor,omitempty"`
+}
+
+func (r *IndexedRate) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if err := r.Index.Validate(); err != nil {
+		return fmt.Errorf("indexed_rate: %w", err)
+	}
+	if r.Spread.Cmp(minimumSpread) <= 0 {
+		return errors.New("indexed_rate: spread must be greater than -100")
+	}
+	if r.Cap.IsNegative() || r.Floor.IsNegative() {
+		return errors.New("indexed_rate: cap and floor cannot be negative")
+	}
+	if r.Cap.IsPositive() && r.Floor.GreaterThan(r.Cap) {
+		return errors.New("indexed_rate: floor must not exceed cap")
+	}
+	return nil
+}
+
+// AnnualRate combines the index value (annual percentage) with the spread and applies cap and floor.
+func (r IndexedRate) AnnualRate(indexRate valueObjects.Rate) valueObjects.Rate {
+	annual := valueObjects.RateFromFloat(((1+indexRate.Fraction())*(1+r.Spread.Fraction()) - 1) * 100)
+	if r.Floor.IsPositive() && annual.LessThan(r.Floor) {
+		annual = r.Floor
+	}
+	if r.Cap.IsPositive() && annual.GreaterThan(r.Cap) {
+		annual = r.Cap
+	}
+	return annual
+}
+
+// MonthlyRate converts AnnualRate into the equivalent monthly percentage used by quotes.
+func (r IndexedRate) MonthlyRate(indexRate valueObjects.Rate) valueObjects.Rate {
+	return valueObjects.RateFromFloat((math.Pow(1+r.AnnualRate(indexRate).Fraction(), 1.0/12) - 1) * 100)
+}
+
+// IndexValue is one observation of a rate index. Rate is an annual percentage: the annualized
+// CDI/SELIC or the IPCA accumulated over the last twelve months.
+type IndexValue struct {
+	Index      valueObjects.RateIndex
+	Date       time.Time
+	Rate       valueObjects.Rate
+	Source     string
+	RecordedAt time.Time
+}
+
+func (v *IndexValue) Validate() error {
+	if v == nil {
+		return errors.New("index value is nil")
+	}
+	if err := v.Index.Validate(); err != nil {
+		return err
+	}
+	if v.Date.IsZero() {
+		return errors.New("index value date is required")
+	}
+	if v.Rate.Cmp(minimumSpread) <= 0 {
+		return fmt.Errorf("invalid %s rate %v", v.Index, v.Rate)
+	}
+	return nil
+}
+
+// WithIndexValue returns the attributes with InterestRate projected from the index value. Fixed
+// rate attributes are returned unchanged.
+func (b BaseProductAttributes) WithIndexValue(value *IndexValue) (BaseProductAttributes, error) {
+	if b.IndexedRate == nil {
+		return b, nil
+	}
+	if value == nil || value.Index != b.IndexedRate.Index {
+		return b, fmt.Errorf("%w: %s", ErrIndexValueUnavailable, b.IndexedRate.Index)
+	}
+	b.InterestRate = b.IndexedRate.MonthlyRate(value.Rate)
+	return b, nil
+}
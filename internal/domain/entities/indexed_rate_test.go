@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+func TestIndexedRate_ProjectsIndexPlusSpreadWithinBounds(t *testing.T) {
+	percent := valueObjects.MustParseRate
+	rate := IndexedRate{Index: valueObjects.RateIndexCDI, Spread: percent("2"), Cap: percent("16"), Floor: percent("8")}
+
+	if annual := rate.AnnualRate(percent("10")); annual != percent("12.2") {
+		t.Fatalf("expected (1.10*1.02-1) = 12.2%% a.a., got %s", annual)
+	}
+	if annual := rate.AnnualRate(percent("15")); annual != percent("16") {
+		t.Fatalf("expected the cap to apply, got %s", annual)
+	}
+	if annual := rate.AnnualRate(percent("2")); annual != percent("8") {
+		t.Fatalf("expected the floor to apply, got %s", annual)
+	}
+
+	base := BaseProductAttributes{IndexedRate: &rate}
+	if _, err := base.WithIndexValue(&IndexValue{Index: valueObjects.RateIndexIPCA, Date: time.Now(), Rate: percent("4")}); !errors.Is(err, ErrIndexValueUnavailable) {
+		t.Fatalf("expected ErrIndexValueUnavailable for a value of another index, got %v", err)
+	}
+
+	projected, err := base.WithIndexValue(&IndexValue{Index: valueObjects.RateIndexCDI, Date: time.Now(), Rate: percent("10")})
+	if err != nil {
+		t.Fatalf("WithIndexValue returned error: %v", err)
+	}
+	if projected.InterestRate.Round(4) != percent("0.9639") {
+		t.Fatalf("expected about 0.9639%% a.m. for 12.2%% a.a., got %s", projected.InterestRate)
+	}
+
+	quote, err := NewLoanQuote(valueObjects.MustParseMoney("12000"), 12, projected)
+	if err != nil {
+		t.Fatalf("NewLoanQuote returned error: %v", err)
+	}
+	schedule := quote.Schedule()
+	if len(schedule) != 12 || !schedule[11].Balance.IsZero() {
+		t.Fatalf("expected 12 installments closing the balance, got %+v", schedule[len(schedule)-1])
+	}
+}
@@ -0,0 +1,243 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	valueObjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type InstallmentStatus string
+
+const (
+	InstallmentStatusPending       InstallmentStatus = "pending"
+	InstallmentStatusPartiallyPaid InstallmentStatus = "partially_paid"
+	InstallmentStatusOverdue       InstallmentStatus = "overdue"
+	InstallmentStatusPaid          InstallmentStatus = "paid"
+	InstallmentStatusCancelled     InstallmentStatus = "cancelled"
+)
+
+var ErrInstallmentNil = errors.New("installment is nil")
+
+// LateChargePolicy prices late payments: Fine is charged once when the installment becomes
+// overdue and MonthlyInterest accrues pro rata die over 30-day months, both on the amount still
+// due.
+type LateChargePolicy struct {
+	Fine            valueObjects.Rate
+	MonthlyInterest valueObjects.Rate
+}
+
+// DefaultLateChargePolicy applies the usual consumer credit limits: a 2% fine and 1% a.m. of
+// late interest.
+var DefaultLateChargePolicy = LateChargePolicy{
+	Fine:            valueObjects.MustParseRate("2"),
+	MonthlyInterest: valueObjects.MustParseRate("1"),
+}
+
+// Installment is one scheduled payment of a contract. Payments settle late charges first and
+// then the installment itself, interest before principal. Prepaying an installment before its
+// due date waives the interest not yet paid, recorded as Discount.
+type Installment struct {
+	ID           primitive.ObjectID
+	ContractID   primitive.ObjectID
+	ConsumerID   primitive.ObjectID
+	Number       int
+	DueDate      time.Time
+	Amount       valueObjects.Money
+	Principal    valueObjects.Money
+	Interest     valueObjects.Money
+	Discount     valueObjects.Money
+	Paid         valueObjects.Money
+	Fine         valueObjects.Money
+	LateInterest valueObjects.Money
+	ChargesPaid  valueObjects.Money
+	Status       InstallmentStatus
+	DaysOverdue  int
+	// AgedThrough is the day up to which late interest has been accrued, so aging the same day
+	// twice charges nothing more.
+	AgedThrough time.Time
+	PaidAt      time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// Version counts the stored writes of the installment. An update made from an older read is
+	// refused, so a payment and the aging job cannot overwrite each other.
+	Version int64
+}
+
+// NewInstallments builds the installments of a contract from its amortization schedule, the
+// first one due on the contract's FirstDueDate and the others monthly after it. Contracts
+// without simulated amount and term have no schedule.
+func NewInstallments(contract *Contract) []*Installment {
+	if contract == nil || !contract.Amount.IsPositive() || contract.TermMonths <= 0 || !contract.Installment.IsPositive() {
+		return nil
+	}
+
+	quote := LoanQuote{
+		Amount:              contract.Amount,
+		TermMonths:          contract.TermMonths,
+		MonthlyInterestRate: contract.InterestRate,
+		Installment:         contract.Installment,
+	}
+
+	schedule := quote.Schedule()
+	installments := make([]*Installment, 0, len(schedule))
+	for _, scheduled := range schedule {
+		installments = append(installments, &Installment{
+			ID:         primitive.NewObjectID(),
+			ContractID: contract.ID,
+			ConsumerID: contract.ConsumerID,
+			Number:     scheduled.Number,
+			DueDate:    contract.FirstDueDate.AddDate(0, scheduled.Number-1, 0),
+			Amount:     scheduled.Payment,
+			Principal:  scheduled.Principal,
+			Interest:   scheduled.Interest,
+			Status:     InstallmentStatusPending,
+			CreatedAt:  contract.CreatedAt,
+			UpdatedAt:  contract.CreatedAt,
+		})
+	}
+	return installments
+}
+
+func (i *Installment) IsOpen() bool {
+	return i != nil && i.Status != InstallmentStatusPaid && i.Status != InstallmentStatusCancelled
+}
+
+// IsDue reports whether the installment's due date is on or before the given day.
+func (i *Installment) IsDue(asOf time.Time) bool {
+	return i != nil && !i.DueDate.After(startOfDay(asOf))
+}
+
+// AmountDue is what remains of the installment itself, without late charges.
+func (i *Installment) AmountDue() valueObjects.Money {
+	return i.Amount.Sub(i.Discount).Sub(i.Paid)
+}
+
+// ChargesDue is the unpaid part of the fine and late interest.
+func (i *Installment) ChargesDue() valueObjects.Money {
+	return i.Fine.Add(i.LateInterest).Sub(i.ChargesPaid)
+}
+
+// Outstanding is everything still owed on the installment.
+func (i *Installment) Outstanding() valueObjects.Money {
+	if !i.IsOpen() {
+		return valueObjects.Money{}
+	}
+	return i.AmountDue().Add(i.ChargesDue())
+}
+
+// PrepaymentValue is what settles the installment today when prepaid: its principal not yet paid.
+func (i *Installment) PrepaymentValue() valueObjects.Money {
+	if !i.IsOpen() {
+		return valueObjects.Money{}
+	}
+	return i.Amount.Sub(i.interestToWaive()).Sub(i.Paid).Add(i.ChargesDue())
+}
+
+// Pay applies up to amount to the installment and returns the part actually used.
+func (i *Installment) Pay(amount valueObjects.Money, at time.Time) valueObjects.Money {
+	if !i.IsOpen() || !amount.IsPositive() {
+		return valueObjects.Money{}
+	}
+
+	applied := minMoney(amount, i.ChargesDue())
+	i.ChargesPaid = i.ChargesPaid.Add(applied)
+
+	toInstallment := minMoney(amount.Sub(applied), i.AmountDue())
+	i.Paid = i.Paid.Add(toInstallment)
+	applied = applied.Add(toInstallment)
+
+	i.refreshStatus(at)
+	return applied
+}
+
+// Prepay waives the interest not yet paid and then applies the amount like Pay.
+func (i *Installment) Prepay(amount valueObjects.Money, at time.Time) valueObjects.Money {
+	if !i.IsOpen() || !amount.IsPositive() {
+		return valueObjects.Money{}
+	}
+	i.Discount = i.interestToWaive()
+	return i.Pay(amount, at)
+}
+
+// Age marks the installment overdue when its due date has passed, charging the fine once and
+// accruing late interest up to asOf. It reports whether anything changed.
+func (i *Installment) Age(asOf time.Time, policy LateChargePolicy) bool {
+	day := startOfDay(asOf)
+	if !i.IsOpen() || !day.After(i.DueDate) {
+		return false
+	}
+
+	changed := false
+	if i.Status != InstallmentStatusOverdue {
+		i.Status = InstallmentStatusOverdue
+		i.Fine = i.AmountDue().Percent(policy.Fine)
+		changed = true
+	}
+
+	from := i.DueDate
+	if i.AgedThrough.After(from) {
+		from = i.AgedThrough
+	}
+	if days := daysBetween(from, day); days > 0 {
+		i.LateInterest = i.LateInterest.Add(i.AmountDue().Mul(int64(days)).Percent(policy.MonthlyInterest).Div(30))
+		i.AgedThrough = day
+		changed = true
+	}
+
+	if overdue := daysBetween(i.DueDate, day); overdue != i.DaysOverdue {
+		i.DaysOverdue = overdue
+		changed = true
+	}
+	if changed {
+		i.UpdatedAt = asOf
+	}
+	return changed
+}
+
+// Cancel drops an open installment, e.g. when its contract is cancelled.
+func (i *Installment) Cancel(at time.Time) {
+	if !i.IsOpen() {
+		return
+	}
+	i.Status = InstallmentStatusCancelled
+	i.UpdatedAt = at
+}
+
+func (i *Installment) interestToWaive() valueObjects.Money {
+	// Payments settle interest before principal, so only the unpaid interest can be waived.
+	if i.Paid.Cmp(i.Interest) >= 0 {
+		return valueObjects.Money{}
+	}
+	return i.Interest.Sub(i.Paid)
+}
+
+func (i *Installment) refreshStatus(at time.Time) {
+	i.UpdatedAt = at
+	switch {
+	case !i.AmountDue().IsPositive() && !i.ChargesDue().IsPositive():
+		i.Status = InstallmentStatusPaid
+		i.PaidAt = at
+	case i.Status == InstallmentStatusOverdue:
+	case i.Paid.IsPositive():
+		i.Status = InstallmentStatusPartiallyPaid
+	}
+}
+
+func minMoney(a, b valueObjects.Money) valueObjects.Money {
+	if b.LessThan(a) {
+		return b
+	}
+	return a
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(startOfDay(to).Sub(startOfDay(from)).Hours() / 24)
+}
@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+func newTestInstallments(t *testing.T) []*Installment {
+	t.Helper()
+	contract := &Contract{
+		Amount:       valueObjects.MustParseMoney("10000"),
+		TermMonths:   12,
+		InterestRate: valueObjects.MustParseRate("2"),
+		Installment:  valueObjects.MustParseMoney("945.60"),
+		FirstDueDate: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+	}
+	installments := NewInstallments(contract)
+	if len(installments) != 12 {
+		t.Fatalf("expected 12 installments, got %d", len(installments))
+	}
+	return installments
+}
+
+func TestNewInstallments_FollowSchedule(t *testing.T) {
+	installments := newTestInstallments(t)
+
+	var principal valueObjects.Money
+	for _, installment := range installments {
+		principal = principal.Add(installment.Principal)
+	}
+	if principal != valueObjects.MustParseMoney("10000") {
+		t.Fatalf("expected principal to add up to the amount, got %s", principal)
+	}
+	if due := installments[11].DueDate; !due.Equal(time.Date(2027, time.February, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected last installment due on 2027-02-10, got %s", due)
+	}
+}
+
+func TestInstallment_AgeChargesFineOnceAndAccruesInterest(t *testing.T) {
+	installment := newTestInstallments(t)[0]
+	asOf := installment.DueDate.AddDate(0, 0, 30)
+
+	if !installment.Age(asOf, DefaultLateChargePolicy) {
+		t.Fatalf("expected aging an installment past due to change it")
+	}
+	if installment.Status != InstallmentStatusOverdue || installment.DaysOverdue != 30 {
+		t.Fatalf("expected overdue for 30 days, got %s/%d", installment.Status, installment.DaysOverdue)
+	}
+	if installment.Fine != valueObjects.MustParseMoney("18.91") {
+		t.Fatalf("expected 2%% fine of 18.91, got %s", installment.Fine)
+	}
+	if installment.LateInterest != valueObjects.MustParseMoney("9.46") {
+		t.Fatalf("expected a month of 1%% late interest (9.46), got %s", installment.LateInterest)
+	}
+	if installment.Age(asOf.Add(5*time.Hour), DefaultLateChargePolicy) {
+		t.Fatalf("aging twice on the same day must not change the installment")
+	}
+
+	paid := installment.Pay(valueObjects.MustParseMoney("2000"), asOf)
+	if paid != valueObjects.MustParseMoney("973.97") || installment.Status != InstallmentStatusPaid {
+		t.Fatalf("expected the payment to settle 973.97 including charges, got %s (%s)", paid, installment.Status)
+	}
+}
+
+func TestInstallment_PartialPaymentAndPrepayment(t *testing.T) {
+	installments := newTestInstallments(t)
+	first, last := installments[0], installments[11]
+	at := first.DueDate.AddDate(0, 0, -5)
+
+	if paid := first.Pay(valueObjects.MustParseMoney("100"), at); paid != valueObjects.MustParseMoney("100") {
+		t.Fatalf("expected 100 to be applied, got %s", paid)
+	}
+	if first.Status != InstallmentStatusPartiallyPaid || first.AmountDue() != valueObjects.MustParseMoney("845.60") {
+		t.Fatalf("expected partially paid with 845.60 due, got %s/%s", first.Status, first.AmountDue())
+	}
+
+	value := last.PrepaymentValue()
+	if value != last.Principal {
+		t.Fatalf("expected prepayment to cost only the principal %s, got %s", last.Principal, value)
+	}
+	if paid := last.Prepay(value, at); paid != value || last.Status != InstallmentStatusPaid {
+		t.Fatalf("expected prepayment to settle the installment, got %s (%s)", paid, last.Status)
+	}
+	if last.Discount != last.Interest {
+		t.Fatalf("expected the interest %s to be waived, got %s", last.Interest, last.Discount)
+	}
+}
+
+func TestInstallment_Prepay(t *testing.T) {
+	tests := []struct {
+		name       string
+		prepare    func(*Installment)
+		wantValue  string
+		wantWaived string
+	}{
+		{
+			name:       "untouched installment waives all of its interest",
+			prepare:    func(*Installment) {},
+			wantValue:  "927.01",
+			wantWaived: "18.54",
+		},
+		{
+			name: "partial payment below the interest waives the rest of it",
+			prepare: func(i *Installment) {
+				i.Pay(valueObjects.MustParseMoney("10"), i.DueDate.AddDate(0, -1, 0))
+			},
+			wantValue:  "927.01",
+			wantWaived: "8.54",
+		},
+		{
+			name: "partial payment above the interest waives nothing",
+			prepare: func(i *Installment) {
+				i.Pay(valueObjects.MustParseMoney("100"), i.DueDate.AddDate(0, -1, 0))
+			},
+			wantValue:  "845.55",
+			wantWaived: "0",
+		},
+		{
+			name: "unpaid late charges are not waived",
+			prepare: func(i *Installment) {
+				i.Fine = valueObjects.MustParseMoney("18.91")
+			},
+			wantValue:  "945.92",
+			wantWaived: "18.54",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installment := newTestInstallments(t)[11]
+			tt.prepare(installment)
+			at := installment.DueDate.AddDate(0, -2, 0)
+
+			value := installment.PrepaymentValue()
+			if value != valueObjects.MustParseMoney(tt.wantValue) {
+				t.Fatalf("expected prepayment value %s, got %s", tt.wantValue, value)
+			}
+			if paid := installment.Prepay(value, at); paid != value || installment.Status != InstallmentStatusPaid {
+				t.Fatalf("expected prepaying %s to settle the installment, got %s (%s)", value, paid, installment.Status)
+			}
+			if installment.Discount != valueObjects.MustParseMoney(tt.wantWaived) {
+				t.Fatalf("expected %s of interest waived, got %s", tt.wantWaived, installment.Discount)
+			}
+		})
+	}
+
+	settled := newTestInstallments(t)[0]
+	settled.Pay(settled.Amount, settled.DueDate)
+	if value := settled.PrepaymentValue(); !value.IsZero() {
+		t.Fatalf("expected nothing to prepay on a paid installment, got %s", value)
+	}
+	if paid := settled.Prepay(valueObjects.MustParseMoney("10"), settled.DueDate); !paid.IsZero() {
+		t.Fatalf("expected a paid installment to take no prepayment, got %s", paid)
+	}
+}
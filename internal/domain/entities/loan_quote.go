@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"errors"
+	"math"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+var (
+	ErrQuoteAmountRequired = errors.New("loan amount must be greater than zero")
+	ErrQuoteTermRequired   = errors.New("loan term must be at least one month")
+	ErrQuoteRateRequired   = errors.New("product does not define an interest rate")
+)
+
+// LoanQuote is the price of an amortized loan (Price table) for a given amount and term. Rates
+// follow the catalog convention of monthly percentages, e.g. 2.3 means 2.3% a.m.
+type LoanQuote struct {
+	Amount              valueObjects.Money
+	TermMonths          int
+	MonthlyInterestRate valueObjects.Rate
+	Installment         valueObjects.Money
+	// UpfrontFees are the processing fee and IOF, deducted from the amount released.
+	UpfrontFees valueObjects.Money
+	TotalPaid   valueObjects.Money
+	TotalCost   valueObjects.Money
+	// CETMonthly and CETAnnual are the effective total cost: the rate that discounts the
+	// installments back to the amount actually released to the borrower.
+	CETMonthly valueObjects.Rate
+	CETAnnual  valueObjects.Rate
+}
+
+// NewLoanQuote prices a loan using the interest rate, processing fee and IOF of the product.
+// The compounding formulas run in floating point; every amount is then rounded to exact cents,
+// so TotalPaid is always Installment times the term.
+func NewLoanQuote(amount valueObjects.Money, termMonths int, attrs BaseProductAttributes) (LoanQuote, error) {
+	if !amount.IsPositive() {
+		return LoanQuote{}, ErrQuoteAmountRequired
+	}
+	if termMonths <= 0 {
+		return LoanQuote{}, ErrQuoteTermRequired
+	}
+	if !attrs.InterestRate.IsPositive() {
+		return LoanQuote{}, ErrQuoteRateRequired
+	}
+
+	exactInstallment := amortizedInstallment(amount.Float64(), attrs.InterestRate.Fraction(), termMonths)
+	installment := valueObjects.MoneyFromFloat(exactInstallment)
+	fees := amount.Percent(attrs.ProcessingFee.Add(attrs.IofRate))
+	totalPaid := installment.Mul(int64(termMonths))
+
+	cetMonthly := effectiveMonthlyRate(amount.Sub(fees).Float64(), exactInstallment, termMonths)
+
+	return LoanQuote{
+		Amount:              amount,
+		TermMonths:          termMonths,
+		MonthlyInterestRate: attrs.InterestRate,
+		Installment:         installment,
+		UpfrontFees:         fees,
+		TotalPaid:           totalPaid,
+		TotalCost:           totalPaid.Add(fees).Sub(amount),
+		CETMonthly:          valueObjects.RateFromFloat(cetMonthly * 100).Round(4),
+		CETAnnual:           valueObjects.RateFromFloat((math.Pow(1+cetMonthly, 12) - 1) * 100).Round(4),
+	}, nil
+}
+
+// ScheduledInstallment is one projected payment of a quote.
+type ScheduledInstallment struct {
+	Number    int
+	Payment   valueObjects.Money
+	Interest  valueObjects.Money
+	Principal valueObjects.Money
+	Balance   valueObjects.Money
+}
+
+// Schedule projects every installment of the quote at its monthly rate. For floating-rate
+// products that rate comes from the latest stored index value, so later payments are estimates.
+// The last installment absorbs rounding so the balance closes at zero.
+func (q LoanQuote) Schedule() []ScheduledInstallment {
+	if q.TermMonths <= 0 || !q.Amount.IsPositive() {
+		return nil
+	}
+
+	balance := q.Amount
+	schedule := make([]ScheduledInstallment, 0, q.TermMonths)
+	for number := 1; number <= q.TermMonths; number++ {
+		interest := balance.Percent(q.MonthlyInterestRate)
+		payment := q.Installment
+		principal := payment.Sub(interest)
+		if number == q.TermMonths {
+			principal = balance
+			payment = principal.Add(interest)
+		}
+		balance = balance.Sub(principal)
+
+		schedule = append(schedule, ScheduledInstallment{
+			Number:    number,
+			Payment:   payment,
+			Interest:  interest,
+			Principal: principal,
+			Balance:   balance,
+		})
+	}
+
+	return schedule
+}
+
+func amortizedInstallment(principal, rate float64, periods int) float64 {
+	if rate == 0 {
+		return principal / float64(periods)
+	}
+	return principal * rate / (1 - math.Pow(1+rate, -float64(periods)))
+}
+
+// effectiveMonthlyRate finds by bisection the rate at which the installments are worth the
+// released amount.
+func effectiveMonthlyRate(released, installment float64, periods int) float64 {
+	if released <= 0 || installment <= 0 {
+		return 0
+	}
+
+	presentValue := func(rate float64) float64 {
+		if rate == 0 {
+			return installment * float64(periods)
+		}
+		return installment * (1 - math.Pow(1+rate, -float64(periods))) / rate
+	}
+
+	low, high := 0.0, 1.0
+	for i := 0; i < 100; i++ {
+		mid := (low + high) / 2
+		if presentValue(mid) > released {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return (low + high) / 2
+}
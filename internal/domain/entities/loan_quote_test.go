@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"testing"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+func TestNewLoanQuote_PriceTableAndCET(t *testing.T) {
+	amount := valueObjects.MustParseMoney("10000")
+	quote, err := NewLoanQuote(amount, 12, BaseProductAttributes{InterestRate: valueObjects.MustParseRate("2")})
+	if err != nil {
+		t.Fatalf("NewLoanQuote returned error: %v", err)
+	}
+	if quote.Installment != valueObjects.MustParseMoney("945.60") {
+		t.Fatalf("expected installment 945.60, got %s", quote.Installment)
+	}
+	if quote.CETMonthly != valueObjects.MustParseRate("2") {
+		t.Fatalf("expected CET equal to the interest rate without fees, got %s", quote.CETMonthly)
+	}
+
+	withFees, err := NewLoanQuote(amount, 12, BaseProductAttributes{
+		InterestRate:  valueObjects.MustParseRate("2"),
+		ProcessingFee: valueObjects.MustParseRate("1.5"),
+		IofRate:       valueObjects.MustParseRate("0.38"),
+	})
+	if err != nil {
+		t.Fatalf("NewLoanQuote returned error: %v", err)
+	}
+	if withFees.Installment != quote.Installment {
+		t.Fatalf("fees must not change the installment")
+	}
+	if !withFees.CETMonthly.GreaterThan(quote.CETMonthly) {
+		t.Fatalf("expected upfront fees to raise the CET, got %s", withFees.CETMonthly)
+	}
+	if withFees.UpfrontFees != valueObjects.MustParseMoney("188") {
+		t.Fatalf("expected upfront fees of 188.00, got %s", withFees.UpfrontFees)
+	}
+	if withFees.TotalPaid != withFees.Installment.Mul(12) {
+		t.Fatalf("expected total paid to be exactly twelve installments, got %s", withFees.TotalPaid)
+	}
+}
@@ -8,11 +8,11 @@ import (
 
 // BasePartnerAttributes contains common attributes for all partner types
 type BasePartnerAttributes struct {
-	RegulatoryLicense  string  `json:"regulatory_license,omitempty" bson:"regulatory_license,omitempty"`
-	MinimumLoanAmount  float64 `json:"minimum_loan_amount,omitempty" bson:"minimum_loan_amount,omitempty"`
-	MaximumLoanAmount  float64 `json:"maximum_loan_amount,omitempty" bson:"maximum_loan_amount,omitempty"`
-	InterestRateRange  string  `json:"interest_rate_range,omitempty" bson:"interest_rate_range,omitempty"`
-	ProcessingTimeDays int     `json:"processing_time_days,omitempty" bson:"processing_time_days,omitempty"`
+	RegulatoryLicense  string             `json:"regulatory_license,omitempty" bson:"regulatory_license,omitempty"`
+	MinimumLoanAmount  valueObjects.Money `json:"minimum_loan_amount,omitzero" bson:"minimum_loan_amount,omitempty"`
+	MaximumLoanAmount  valueObjects.Money `json:"maximum_loan_amount,omitzero" bson:"maximum_loan_amount,omitempty"`
+	InterestRateRange  string             `json:"interest_rate_range,omitempty" bson:"interest_rate_range,omitempty"`
+	ProcessingTimeDays int                `json:"processing_time_days,omitempty" bson:"processing_time_days,omitempty"`
 }
 
 // BankPartnerAttributes contains bank-specific attributes
@@ -42,9 +42,9 @@ type FintechSCDAttributes struct {
 // FinanceiraAttributes contains financeira-specific attributes
 type FinanceiraAttributes struct {
 	BasePartnerAttributes `bson:",inline"`
-	SCFILicense           string  `json:"scfi_license" bson:"scfi_license"`
-	RiskRating            string  `json:"risk_rating,omitempty" bson:"risk_rating,omitempty"`
-	CapitalAdequacy       float64 `json:"capital_adequacy,omitempty" bson:"capital_adequacy,omitempty"`
+	SCFILicense           string            `json:"scfi_license" bson:"scfi_license"`
+	RiskRating            string            `json:"risk_rating,omitempty" bson:"risk_rating,omitempty"`
+	CapitalAdequacy       valueObjects.Rate `json:"capital_adequacy,omitzero" bson:"capital_adequacy,omitempty"`
 }
 
 // PaymentInstitutionAttributes contains payment institution-specific attributes
@@ -124,6 +124,29 @@ func (pa *PartnerAttributes) GetAttributesForPartnerType(partnerType valueObject
 	}
 }
 
+// BaseAttributes returns the common lending attributes for the partner type without allocating
+// missing sections on the receiver.
+func (pa PartnerAttributes) BaseAttributes(partnerType valueObjects.PartnerType) (BasePartnerAttributes, bool) {
+	switch attrs := pa.GetAttributesForPartnerType(partnerType).(type) {
+	case *BankPartnerAttributes:
+		return attrs.BasePartnerAttributes, true
+	case *CooperativePartnerAttributes:
+		return attrs.BasePartnerAttributes, true
+	case *FintechSCDAttributes:
+		return attrs.BasePartnerAttributes, true
+	case *FinanceiraAttributes:
+		return attrs.BasePartnerAttributes, true
+	case *PaymentInstitutionAttributes:
+		return attrs.BasePartnerAttributes, true
+	case *SavingsBankAttributes:
+		return attrs.BasePartnerAttributes, true
+	case *DevelopmentBankAttributes:
+		return attrs.BasePartnerAttributes, true
+	default:
+		return BasePartnerAttributes{}, false
+	}
+}
+
 // Validate checks if the partner attributes are valid for the given partner type
 func (pa *PartnerAttributes) Validate(partnerType valueObjects.PartnerType) error {
 	switch partnerType {
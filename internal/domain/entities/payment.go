@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	valueObjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PaymentType string
+
+const (
+	// PaymentTypeRegular settles installments in order, at their full value.
+	PaymentTypeRegular PaymentType = "regular"
+	// PaymentTypeEarlyRepayment settles what is due and prepays the remaining installments from
+	// the last one backwards, waiving their unpaid interest.
+	PaymentTypeEarlyRepayment PaymentType = "early_repayment"
+)
+
+var ErrPaymentNil = errors.New("payment is nil")
+
+func (t PaymentType) Validate() error {
+	switch t {
+	case PaymentTypeRegular, PaymentTypeEarlyRepayment:
+		return nil
+	default:
+		return fmt.Errorf("invalid payment type %q", t)
+	}
+}
+
+// PaymentAllocation is the part of a payment applied to one installment.
+type PaymentAllocation struct {
+	InstallmentNumber int
+	Amount            valueObjects.Money
+	// InterestWaived is the interest discounted when the installment was prepaid.
+	InterestWaived valueObjects.Money
+}
+
+// Payment records money received for a contract and how it was spread over the installments.
+type Payment struct {
+	ID          primitive.ObjectID
+	ContractID  primitive.ObjectID
+	ConsumerID  primitive.ObjectID
+	Type        PaymentType
+	Amount      valueObjects.Money
+	Allocations []PaymentAllocation
+	PaidAt      time.Time
+	CreatedAt   time.Time
+}
+
+func (p *Payment) Validate() error {
+	if p == nil {
+		return ErrPaymentNil
+	}
+	if p.ContractID.IsZero() {
+		return errors.New("payment contract id is required")
+	}
+	if !p.Amount.IsPositive() {
+		return errors.New("payment amount must be greater than zero")
+	}
+	return p.Type.Validate()
+}
+
+// InterestWaived totals the interest discounted by the payment.
+func (p *Payment) InterestWaived() valueObjects.Money {
+	var total valueObjects.Money
+	for _, allocation := range p.Allocations {
+		total = total.Add(allocation.InterestWaived)
+	}
+	return total
+}
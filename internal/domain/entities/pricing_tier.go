@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+var ErrNoMatchingPricingTier = errors.New("no pricing tier matches the consumer credit profile")
+
+// PricingTier prices a product for a band of consumers, selected by credit score and/or risk
+// level. Zero-valued limits inherit the product's base attributes. On floating-rate products a
+// tier may set Spread instead of InterestRate to keep the index and change only the spread.
+type PricingTier struct {
+	Name           string                   `json:"name" bson:"name"`
+	MinCreditScore int                      `json:"min_credit_score,omitempty" bson:"min_credit_score,omitempty"`
+	MaxCreditScore int                      `json:"max_credit_score,omitempty" bson:"max_credit_score,omitempty"`
+	RiskLevels     []valueObjects.RiskLevel `json:"risk_levels,omitempty" bson:"risk_levels,omitempty"`
+	InterestRate   valueObjects.Rate        `json:"interest_rate,omitzero" bson:"interest_rate,omitempty"`
+	Spread         *valueObjects.Rate       `json:"spread,omitempty" bson:"spread,omitempty"`
+	MaxAmount      valueObjects.Money       `json:"max_amount,omitzero" bson:"max_amount,omitempty"`
+	TermMonths     int                      `json:"term_months,omitempty" bson:"term_months,omitempty"`
+}
+
+func (t PricingTier) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("pricing tier name is required")
+	}
+	if t.InterestRate.IsNegative() || (t.InterestRate.IsZero() && t.Spread == nil) {
+		return fmt.Errorf("pricing tier %s: interest_rate must be greater than zero", t.Name)
+	}
+	if t.Spread != nil && t.Spread.Cmp(minimumSpread) <= 0 {
+		return fmt.Errorf("pricing tier %s: spread must be greater than -100", t.Name)
+	}
+	if t.MinCreditScore < 0 || t.MaxCreditScore < 0 || t.MinCreditScore > 1000 || t.MaxCreditScore > 1000 {
+		return fmt.Errorf("pricing tier %s: credit score band must be between 0 and 1000", t.Name)
+	}
+	if t.MaxCreditScore > 0 && t.MinCreditScore > t.MaxCreditScore {
+		return fmt.Errorf("pricing tier %s: min_credit_score must not exceed max_credit_score", t.Name)
+	}
+	if t.MaxAmount.IsNegative() || t.TermMonths < 0 {
+		return fmt.Errorf("pricing tier %s: max_amount and term_months cannot be negative", t.Name)
+	}
+	for _, level := range t.RiskLevels {
+		switch level {
+		case valueObjects.RiskLevelLow, valueObjects.RiskLevelMedium, valueObjects.RiskLevelHigh:
+		default:
+			return fmt.Errorf("pricing tier %s: invalid risk level %q", t.Name, level)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the consumer falls in the tier's score band and risk levels.
+func (t PricingTier) Matches(profile ConsumerCreditProfile) bool {
+	if profile.CreditScore < t.MinCreditScore {
+		return false
+	}
+	if t.MaxCreditScore > 0 && profile.CreditScore > t.MaxCreditScore {
+		return false
+	}
+
+	if len(t.RiskLevels) == 0 {
+		return true
+	}
+
+	risk := valueObjects.RiskLevel(strings.ToLower(strings.TrimSpace(profile.RiskLevel)))
+	for _, level := range t.RiskLevels {
+		if level == risk {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply overrides the base attributes with the tier's rate, maximum amount and term. A fixed
+// tier rate takes precedence over the product's index; a tier spread keeps the index.
+func (t PricingTier) Apply(base BaseProductAttributes) BaseProductAttributes {
+	switch {
+	case t.InterestRate.IsPositive():
+		base.InterestRate = t.InterestRate
+		base.IndexedRate = nil
+	case t.Spread != nil && base.IndexedRate != nil:
+		indexed := *base.IndexedRate
+		indexed.Spread = *t.Spread
+		base.IndexedRate = &indexed
+	}
+	if t.MaxAmount.IsPositive() {
+		base.MaxAmount = t.MaxAmount
+	}
+	if t.TermMonths > 0 {
+		base.TermMonths = t.TermMonths
+	}
+	return base
+}
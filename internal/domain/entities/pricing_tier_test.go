@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	valueObjects "katseye/internal/domain/value_objects"
+)
+
+func TestProductPricingFor_FirstMatchingTierWins(t *testing.T) {
+	product := &Product{
+		ProductType: valueObjects.ProductTypePersonalLoan,
+		Attributes: ProductAttributes{PersonalLoan: &PersonalLoanAttributes{
+			BaseProductAttributes: BaseProductAttributes{
+				InterestRate: valueObjects.MustParseRate("4"),
+				MaxAmount:    valueObjects.MustParseMoney("20000"),
+				TermMonths:   48,
+			},
+		}},
+		PricingTiers: []PricingTier{
+			{Name: "prime", MinCreditScore: 800, InterestRate: valueObjects.MustParseRate("1.9"), MaxAmount: valueObjects.MustParseMoney("50000")},
+			{Name: "standard", MinCreditScore: 500, MaxCreditScore: 799, RiskLevels: []valueObjects.RiskLevel{valueObjects.RiskLevelLow, valueObjects.RiskLevelMedium}, InterestRate: valueObjects.MustParseRate("3.1"), TermMonths: 24},
+		},
+	}
+
+	attrs, tier, err := product.PricingFor(ConsumerCreditProfile{CreditScore: 650, RiskLevel: "Medium"})
+	if err != nil {
+		t.Fatalf("PricingFor returned error: %v", err)
+	}
+	if tier == nil || tier.Name != "standard" {
+		t.Fatalf("expected the standard tier, got %+v", tier)
+	}
+	if attrs.InterestRate.String() != "3.1" || attrs.TermMonths != 24 || attrs.MaxAmount.String() != "20000.00" {
+		t.Fatalf("expected tier overrides on top of the base attributes, got %+v", attrs)
+	}
+
+	if _, _, err := product.PricingFor(ConsumerCreditProfile{CreditScore: 650, RiskLevel: "high"}); !errors.Is(err, ErrNoMatchingPricingTier) {
+		t.Fatalf("expected ErrNoMatchingPricingTier for an uncovered risk level, got %v", err)
+	}
+
+	product.PricingTiers = nil
+	attrs, tier, err = product.PricingFor(ConsumerCreditProfile{CreditScore: 300})
+	if err != nil || tier != nil || attrs.InterestRate.String() != "4" {
+		t.Fatalf("expected base pricing without tiers, got %+v %+v %v", attrs, tier, err)
+	}
+}
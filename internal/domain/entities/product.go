@@ -15,6 +15,8 @@ type Product struct {
 	Attributes  ProductAttributes
 	PartnerID   primitive.ObjectID
 	ProductType valueObjects.ProductType
+	// PricingTiers are evaluated in order; the first tier matching the consumer wins.
+	PricingTiers []PricingTier
 }
 
 // Validate performs validation on the product entity
@@ -39,5 +41,46 @@ func (p *Product) Validate() error {
 		return err
 	}
 
+	base, hasBase := p.Attributes.BaseAttributes(p.ProductType)
+	if err := base.IndexedRate.Validate(); err != nil {
+		return err
+	}
+
+	if len(p.PricingTiers) > 0 && !hasBase {
+		return errors.New("pricing tiers are not supported for this product type")
+	}
+
+	names := make(map[string]bool, len(p.PricingTiers))
+	for _, tier := range p.PricingTiers {
+		if err := tier.Validate(); err != nil {
+			return err
+		}
+		if tier.InterestRate.IsZero() && base.IndexedRate == nil {
+			return errors.New("pricing tier " + tier.Name + ": spread requires a product with indexed_rate")
+		}
+		if names[tier.Name] {
+			return errors.New("pricing tier names must be unique")
+		}
+		names[tier.Name] = true
+	}
+
 	return nil
 }
+
+// PricingFor returns the attributes that apply to the consumer: the base attributes when the
+// product has no tiers, otherwise those of the first matching tier.
+func (p *Product) PricingFor(profile ConsumerCreditProfile) (BaseProductAttributes, *PricingTier, error) {
+	base, _ := p.Attributes.BaseAttributes(p.ProductType)
+	if len(p.PricingTiers) == 0 {
+		return base, nil, nil
+	}
+
+	for i := range p.PricingTiers {
+		if p.PricingTiers[i].Matches(profile) {
+			tier := p.PricingTiers[i]
+			return tier.Apply(base), &tier, nil
+		}
+	}
+
+	return base, nil, ErrNoMatchingPricingTier
+}
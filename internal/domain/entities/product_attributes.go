@@ -7,16 +7,18 @@ import (
 )
 
 type BaseProductAttributes struct {
-	InterestRate          float64                         `json:"interest_rate,omitempty" bson:"interest_rate,omitempty"`
+	InterestRate          valueObjects.Rate               `json:"interest_rate,omitzero" bson:"interest_rate,omitempty"`
 	TermMonths            int                             `json:"term_months,omitempty" bson:"term_months,omitempty"`
-	MinAmount             float64                         `json:"min_amount,omitempty" bson:"min_amount,omitempty"`
-	MaxAmount             float64                         `json:"max_amount,omitempty" bson:"max_amount,omitempty"`
-	ProcessingFee         float64                         `json:"processing_fee,omitempty" bson:"processing_fee,omitempty"`
+	MinAmount             valueObjects.Money              `json:"min_amount,omitzero" bson:"min_amount,omitempty"`
+	MaxAmount             valueObjects.Money              `json:"max_amount,omitzero" bson:"max_amount,omitempty"`
+	ProcessingFee         valueObjects.Rate               `json:"processing_fee,omitzero" bson:"processing_fee,omitempty"`
 	EarlyRepaymentAllowed bool                            `json:"early_repayment_allowed,omitempty" bson:"early_repayment_allowed,omitempty"`
 	RequiredDocuments     []valueObjects.RequiredDocument `json:"required_documents,omitempty" bson:"required_documents,omitempty"`
 	GracePeriodDays       int                             `json:"grace_period_days,omitempty" bson:"grace_period_days,omitempty"`
-	IofRate               float64                         `json:"iof_rate,omitempty" bson:"iof_rate,omitempty"`
-	CETRate               float64                         `json:"cet_rate,omitempty" bson:"cet_rate,omitempty"`
+	IofRate               valueObjects.Rate               `json:"iof_rate,omitzero" bson:"iof_rate,omitempty"`
+	CETRate               valueObjects.Rate               `json:"cet_rate,omitzero" bson:"cet_rate,omitempty"`
+	// IndexedRate, when set, replaces InterestRate with the index plus spread at quote time.
+	IndexedRate *IndexedRate `json:"indexed_rate,omitempty" bson:"indexed_rate,omitempty"`
 }
 
 // PersonalLoanAttributes contains personal loan specific attributes
@@ -31,53 +33,53 @@ type PersonalLoanAttributes struct {
 // PayrollLoanAttributes contains payroll loan specific attributes
 type PayrollLoanAttributes struct {
 	BaseProductAttributes `bson:",inline"`
-	DiscountOnPayroll     bool    `json:"discount_on_payroll" bson:"discount_on_payroll"`
-	MaximumInstallmentPct float64 `json:"maximum_installment_pct,omitempty" bson:"maximum_installment_pct,omitempty"`
-	OnlyForPublicServants bool    `json:"only_for_public_servants,omitempty" bson:"only_for_public_servants,omitempty"`
-	RetirementBenefit     bool    `json:"retirement_benefit,omitempty" bson:"retirement_benefit,omitempty"`
+	DiscountOnPayroll     bool              `json:"discount_on_payroll" bson:"discount_on_payroll"`
+	MaximumInstallmentPct valueObjects.Rate `json:"maximum_installment_pct,omitzero" bson:"maximum_installment_pct,omitempty"`
+	OnlyForPublicServants bool              `json:"only_for_public_servants,omitempty" bson:"only_for_public_servants,omitempty"`
+	RetirementBenefit     bool              `json:"retirement_benefit,omitempty" bson:"retirement_benefit,omitempty"`
 }
 
 // CreditCardAttributes contains credit card specific attributes
 type CreditCardAttributes struct {
-	AnnualFee           float64 `json:"annual_fee" bson:"annual_fee"`
-	CreditLimit         float64 `json:"credit_limit,omitempty" bson:"credit_limit,omitempty"`
-	GracePeriod         int     `json:"grace_period" bson:"grace_period"`
-	RevolvingInterest   float64 `json:"revolving_interest" bson:"revolving_interest"`
-	CashAdvanceInterest float64 `json:"cash_advance_interest" bson:"cash_advance_interest"`
-	CashAdvanceFee      float64 `json:"cash_advance_fee,omitempty" bson:"cash_advance_fee,omitempty"`
-	InternationalUse    bool    `json:"international_use,omitempty" bson:"international_use,omitempty"`
-	RewardsProgram      bool    `json:"rewards_program,omitempty" bson:"rewards_program,omitempty"`
-	InsuranceIncluded   bool    `json:"insurance_included,omitempty" bson:"insurance_included,omitempty"`
+	AnnualFee           valueObjects.Money `json:"annual_fee" bson:"annual_fee"`
+	CreditLimit         valueObjects.Money `json:"credit_limit,omitzero" bson:"credit_limit,omitempty"`
+	GracePeriod         int                `json:"grace_period" bson:"grace_period"`
+	RevolvingInterest   valueObjects.Rate  `json:"revolving_interest" bson:"revolving_interest"`
+	CashAdvanceInterest valueObjects.Rate  `json:"cash_advance_interest" bson:"cash_advance_interest"`
+	CashAdvanceFee      valueObjects.Money `json:"cash_advance_fee,omitzero" bson:"cash_advance_fee,omitempty"`
+	InternationalUse    bool               `json:"international_use,omitempty" bson:"international_use,omitempty"`
+	RewardsProgram      bool               `json:"rewards_program,omitempty" bson:"rewards_program,omitempty"`
+	InsuranceIncluded   bool               `json:"insurance_included,omitempty" bson:"insurance_included,omitempty"`
 }
 
 // VehicleFinancingAttributes contains vehicle financing specific attributes
 type VehicleFinancingAttributes struct {
 	BaseProductAttributes `bson:",inline"`
-	DownPaymentRequired   bool    `json:"down_payment_required" bson:"down_payment_required"`
-	MinDownPaymentPct     float64 `json:"min_down_payment_pct,omitempty" bson:"min_down_payment_pct,omitempty"`
-	VehicleAgeLimit       int     `json:"vehicle_age_limit,omitempty" bson:"vehicle_age_limit,omitempty"`
-	InsuranceRequired     bool    `json:"insurance_required" bson:"insurance_required"`
-	BalloonPayment        bool    `json:"balloon_payment,omitempty" bson:"balloon_payment,omitempty"`
+	DownPaymentRequired   bool              `json:"down_payment_required" bson:"down_payment_required"`
+	MinDownPaymentPct     valueObjects.Rate `json:"min_down_payment_pct,omitzero" bson:"min_down_payment_pct,omitempty"`
+	VehicleAgeLimit       int               `json:"vehicle_age_limit,omitempty" bson:"vehicle_age_limit,omitempty"`
+	InsuranceRequired     bool              `json:"insurance_required" bson:"insurance_required"`
+	BalloonPayment        bool              `json:"balloon_payment,omitempty" bson:"balloon_payment,omitempty"`
 }
 
 // MortgageLoanAttributes contains mortgage loan specific attributes
 type MortgageLoanAttributes struct {
 	BaseProductAttributes     `bson:",inline"`
-	PropertyValue             float64 `json:"property_value" bson:"property_value"`
-	MinDownPaymentPct         float64 `json:"min_down_payment_pct" bson:"min_down_payment_pct"`
-	LtvRatio                  float64 `json:"ltv_ratio,omitempty" bson:"ltv_ratio,omitempty"`
-	PropertyInsuranceRequired bool    `json:"property_insurance_required" bson:"property_insurance_required"`
-	AppraisalRequired         bool    `json:"appraisal_required" bson:"appraisal_required"`
-	FixedRatePeriod           int     `json:"fixed_rate_period,omitempty" bson:"fixed_rate_period,omitempty"`
+	PropertyValue             valueObjects.Money `json:"property_value" bson:"property_value"`
+	MinDownPaymentPct         valueObjects.Rate  `json:"min_down_payment_pct" bson:"min_down_payment_pct"`
+	LtvRatio                  valueObjects.Rate  `json:"ltv_ratio,omitzero" bson:"ltv_ratio,omitempty"`
+	PropertyInsuranceRequired bool               `json:"property_insurance_required" bson:"property_insurance_required"`
+	AppraisalRequired         bool               `json:"appraisal_required" bson:"appraisal_required"`
+	FixedRatePeriod           int                `json:"fixed_rate_period,omitempty" bson:"fixed_rate_period,omitempty"`
 }
 
 // WorkingCapitalAttributes contains working capital loan specific attributes
 type WorkingCapitalAttributes struct {
 	BaseProductAttributes    `bson:",inline"`
-	BusinessAgeRequirement   int     `json:"business_age_requirement,omitempty" bson:"business_age_requirement,omitempty"`
-	AnnualRevenueRequirement float64 `json:"annual_revenue_requirement,omitempty" bson:"annual_revenue_requirement,omitempty"`
-	CollateralRequired       bool    `json:"collateral_required,omitempty" bson:"collateral_required,omitempty"`
-	ReceivablesAsCollateral  bool    `json:"receivables_as_collateral,omitempty" bson:"receivables_as_collateral,omitempty"`
+	BusinessAgeRequirement   int                `json:"business_age_requirement,omitempty" bson:"business_age_requirement,omitempty"`
+	AnnualRevenueRequirement valueObjects.Money `json:"annual_revenue_requirement,omitzero" bson:"annual_revenue_requirement,omitempty"`
+	CollateralRequired       bool               `json:"collateral_required,omitempty" bson:"collateral_required,omitempty"`
+	ReceivablesAsCollateral  bool               `json:"receivables_as_collateral,omitempty" bson:"receivables_as_collateral,omitempty"`
 }
 
 // StudentLoanAttributes contains student loan specific attributes
@@ -243,6 +245,31 @@ func (pa *ProductAttributes) GetAttributesForProductType(productType valueObject
 	}
 }
 
+// BaseAttributes returns the common pricing attributes for the product type without allocating
+// missing sections on the receiver. Credit cards have no base attributes.
+func (pa ProductAttributes) BaseAttributes(productType valueObjects.ProductType) (BaseProductAttributes, bool) {
+	switch attrs := pa.GetAttributesForProductType(productType).(type) {
+	case *BaseProductAttributes:
+		return *attrs, true
+	case *PersonalLoanAttributes:
+		return attrs.BaseProductAttributes, true
+	case *PayrollLoanAttributes:
+		return attrs.BaseProductAttributes, true
+	case *VehicleFinancingAttributes:
+		return attrs.BaseProductAttributes, true
+	case *MortgageLoanAttributes:
+		return attrs.BaseProductAttributes, true
+	case *WorkingCapitalAttributes:
+		return attrs.BaseProductAttributes, true
+	case *StudentLoanAttributes:
+		return attrs.BaseProductAttributes, true
+	case *GreenLoanAttributes:
+		return attrs.BaseProductAttributes, true
+	default:
+		return BaseProductAttributes{}, false
+	}
+}
+
 // Validate checks if the product attributes are valid for the given product type
 func (pa *ProductAttributes) Validate(productType valueObjects.ProductType) error {
 	switch productType {
@@ -250,8 +277,8 @@ func (pa *ProductAttributes) Validate(productType valueObjects.ProductType) erro
 		if pa.PersonalLoan == nil {
 			return errors.New("personal_loan attributes are required for personal loan products")
 		}
-		if pa.PersonalLoan.InterestRate <= 0 {
-			return errors.New("interest_rate is required for personal loan products")
+		if !pa.PersonalLoan.InterestRate.IsPositive() && pa.PersonalLoan.IndexedRate == nil {
+			return errors.New("interest_rate or indexed_rate is required for personal loan products")
 		}
 		// Validate required documents
 		if err := valueObjects.ValidateDocumentSet(pa.PersonalLoan.RequiredDocuments); err != nil {
@@ -270,7 +297,7 @@ func (pa *ProductAttributes) Validate(productType valueObjects.ProductType) erro
 		if pa.CreditCard == nil {
 			return errors.New("credit_card attributes are required for credit card products")
 		}
-		if pa.CreditCard.AnnualFee < 0 {
+		if pa.CreditCard.AnnualFee.IsNegative() {
 			return errors.New("annual_fee is required for credit card products")
 		}
 
@@ -286,7 +313,7 @@ func (pa *ProductAttributes) Validate(productType valueObjects.ProductType) erro
 		if pa.MortgageLoan == nil {
 			return errors.New("mortgage_loan attributes are required for mortgage loan products")
 		}
-		if pa.MortgageLoan.PropertyValue <= 0 {
+		if !pa.MortgageLoan.PropertyValue.IsPositive() {
 			return errors.New("property_value is required for mortgage loan products")
 		}
 		if err := valueObjects.ValidateDocumentSet(pa.MortgageLoan.RequiredDocuments); err != nil {
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ScreeningStage string
+
+const (
+	ScreeningStageOnboarding  ScreeningStage = "onboarding"
+	ScreeningStageContracting ScreeningStage = "contracting"
+)
+
+func (s ScreeningStage) Validate() error {
+	switch s {
+	case ScreeningStageOnboarding, ScreeningStageContracting:
+		return nil
+	default:
+		return errors.New("invalid screening stage")
+	}
+}
+
+// ReviewStatus is the outcome of screening a consumer or contract. Clear records passed the
+// rules automatically; pending ones wait for an analyst.
+type ReviewStatus string
+
+const (
+	ReviewStatusClear    ReviewStatus = "clear"
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+func (s ReviewStatus) Validate() error {
+	switch s {
+	case ReviewStatusClear, ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return nil
+	default:
+		return errors.New("invalid review status")
+	}
+}
+
+// Screening signal kinds. Values are fingerprints, never the raw contact data or document.
+const (
+	ScreeningSignalEmail       = "email"
+	ScreeningSignalPhone       = "phone"
+	ScreeningSignalAddress     = "address"
+	ScreeningSignalApplication = "application"
+)
+
+var (
+	ErrScreeningReviewNil     = errors.New("screening review is nil")
+	ErrReviewAlreadyDecided   = errors.New("review has already been decided")
+	ErrConsumerUnderReview    = errors.New("consumer is held for manual review")
+	ErrConsumerReviewRejected = errors.New("consumer was rejected on manual review")
+)
+
+// ScreeningSignal records that a consumer used some piece of data (an e-mail, a phone, an
+// address or, for applications, its document) so the rules can tell how often it recurs.
+type ScreeningSignal struct {
+	ID         primitive.ObjectID
+	Kind       string
+	Value      string
+	ConsumerID primitive.ObjectID
+	Stage      ScreeningStage
+	RecordedAt time.Time
+}
+
+// ScreeningFingerprint keys a normalized value with an HMAC so signals can be matched without
+// storing it. A plain hash would not hide e-mails, phones or documents, which can be guessed and
+// hashed one by one; without the key they cannot.
+func ScreeningFingerprint(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ScreeningFlag is a rule that fired, with the points it added to the risk score.
+type ScreeningFlag struct {
+	Rule   string
+	Score  int
+	Detail string
+}
+
+// ConsumerScreening summarizes the onboarding screening of a consumer.
+type ConsumerScreening struct {
+	Score      int
+	Status     ReviewStatus
+	ScreenedAt time.Time
+}
+
+// Blocks reports whether the consumer may not contract products yet.
+func (s ConsumerScreening) Blocks() error {
+	switch s.Status {
+	case ReviewStatusPending:
+		return ErrConsumerUnderReview
+	case ReviewStatusRejected:
+		return ErrConsumerReviewRejected
+	default:
+		return nil
+	}
+}
+
+// ScreeningReview is an entry of the manual review queue: a consumer held at onboarding or a
+// contract held at contracting, with the flags that caused it.
+type ScreeningReview struct {
+	ID         primitive.ObjectID
+	Stage      ScreeningStage
+	ConsumerID primitive.ObjectID
+	// ContractID is set for reviews opened when contracting.
+	ContractID primitive.ObjectID
+	Score      int
+	Flags      []ScreeningFlag
+	Status     ReviewStatus
+	ReviewerID primitive.ObjectID
+	Notes      string
+	CreatedAt  time.Time
+	ReviewedAt time.Time
+}
+
+func (r *ScreeningReview) Validate() error {
+	if r == nil {
+		return ErrScreeningReviewNil
+	}
+	if err := r.Stage.Validate(); err != nil {
+		return err
+	}
+	if r.ConsumerID.IsZero() {
+		return errors.New("review consumer id is required")
+	}
+	if r.Stage == ScreeningStageContracting && r.ContractID.IsZero() {
+		return errors.New("review contract id is required when contracting")
+	}
+	return r.Status.Validate()
+}
+
+// Decide records the analyst's decision on a pending review.
+func (r *ScreeningReview) Decide(approved bool, reviewerID primitive.ObjectID, notes string, at time.Time) error {
+	if r == nil {
+		return ErrScreeningReviewNil
+	}
+	if r.Status != ReviewStatusPending {
+		return ErrReviewAlreadyDecided
+	}
+
+	r.Status = ReviewStatusRejected
+	if approved {
+		r.Status = ReviewStatusApproved
+	}
+	r.ReviewerID = reviewerID
+	r.Notes = strings.TrimSpace(notes)
+	r.ReviewedAt = at
+	return nil
+}
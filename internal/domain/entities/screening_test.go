@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestScreeningReview_DecideOnlyOnce(t *testing.T) {
+	review := &ScreeningReview{
+		Stage:      ScreeningStageOnboarding,
+		ConsumerID: primitive.NewObjectID(),
+		Status:     ReviewStatusPending,
+	}
+	reviewer := primitive.NewObjectID()
+	at := time.Date(2026, time.May, 4, 15, 0, 0, 0, time.UTC)
+
+	if err := review.Decide(false, reviewer, "  shared phone with known fraud ring ", at); err != nil {
+		t.Fatalf("Decide returned error: %v", err)
+	}
+	if review.Status != ReviewStatusRejected || review.ReviewerID != reviewer || review.Notes != "shared phone with known fraud ring" {
+		t.Fatalf("unexpected decided review: %+v", review)
+	}
+	if err := review.Decide(true, reviewer, "", at); err != ErrReviewAlreadyDecided {
+		t.Fatalf("expected ErrReviewAlreadyDecided, got %v", err)
+	}
+}
+
+func TestContract_ActivateShiftsFirstDueDate(t *testing.T) {
+	contract := &Contract{
+		Status:       ContractStatusPendingReview,
+		FirstDueDate: time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:    time.Date(2026, time.May, 1, 18, 30, 0, 0, time.UTC),
+	}
+
+	contract.Activate(time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC))
+
+	if !contract.IsActive() {
+		t.Fatalf("expected an active contract, got %s", contract.Status)
+	}
+	if want := time.Date(2026, time.June, 4, 0, 0, 0, 0, time.UTC); !contract.FirstDueDate.Equal(want) {
+		t.Fatalf("expected first due date %s, got %s", want, contract.FirstDueDate)
+	}
+}
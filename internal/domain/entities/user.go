@@ -28,6 +28,14 @@ const (
 	PermissionViewUsers    = "users:view"
 	PermissionViewProducts = "products:view"
 	PermissionViewPartners = "partners:view"
+
+	PermissionManagePrivacy = "privacy:manage"
+
+	PermissionViewConsumerPII = "consumers:pii:view"
+
+	PermissionReviewScreening = "screening:review"
+
+	PermissionManageCache = "cache:manage"
 )
 
 // rolePermissions defines the base permissions for each role
@@ -42,6 +50,10 @@ var rolePermissions = map[Role][]string{
 		PermissionViewUsers,
 		PermissionViewProducts,
 		PermissionViewPartners,
+		PermissionManagePrivacy,
+		PermissionViewConsumerPII,
+		PermissionReviewScreening,
+		PermissionManageCache,
 	},
 	RoleManager: {
 		PermissionEditProducts,
@@ -0,0 +1,221 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Webhook event types partners can subscribe to, a subset of the domain events.
+const (
+	WebhookEventProductContracted = EventProductContracted
+	WebhookEventProductRemoved    = EventProductRemoved
+	WebhookEventProductCreated    = EventProductCreated
+	WebhookEventProductUpdated    = EventProductUpdated
+	WebhookEventProductDeleted    = EventProductDeleted
+)
+
+// WebhookEventTypes lists every event type a subscription may select.
+var WebhookEventTypes = []string{
+	WebhookEventProductContracted,
+	WebhookEventProductRemoved,
+	WebhookEventProductCreated,
+	WebhookEventProductUpdated,
+	WebhookEventProductDeleted,
+}
+
+// MinWebhookSecretLength is the shortest signing secret accepted for a subscription.
+const MinWebhookSecretLength = 16
+
+var (
+	ErrWebhookSubscriptionNil = errors.New("webhook subscription is nil")
+	ErrInvalidWebhookURL      = errors.New("webhook url must be an absolute https url")
+	ErrInvalidWebhookEvent    = errors.New("invalid webhook event type")
+	ErrWebhookSecretTooShort  = errors.New("webhook secret is too short")
+)
+
+// WebhookSubscription is a partner endpoint notified of the selected event types. Deliveries
+// are signed with Secret.
+type WebhookSubscription struct {
+	ID         primitive.ObjectID
+	PartnerID  primitive.ObjectID
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (s *WebhookSubscription) Validate() error {
+	if s == nil {
+		return ErrWebhookSubscriptionNil
+	}
+	if s.PartnerID.IsZero() {
+		return errors.New("webhook partner id is required")
+	}
+
+	if err := validateWebhookURL(s.URL); err != nil {
+		return err
+	}
+
+	if len(s.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one is required", ErrInvalidWebhookEvent)
+	}
+	for _, eventType := range s.EventTypes {
+		if !IsWebhookEventType(eventType) {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, eventType)
+		}
+	}
+
+	if len(s.Secret) < MinWebhookSecretLength {
+		return ErrWebhookSecretTooShort
+	}
+	return nil
+}
+
+// validateWebhookURL accepts https URLs whose host is not obviously internal. Host names are
+// resolved only when delivering, where the sender checks the address it dials again.
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("%w: credentials are not allowed in the url", ErrInvalidWebhookURL)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s is not a public host", ErrInvalidWebhookURL, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicWebhookAddress(addr) {
+		return fmt.Errorf("%w: %s is not a public address", ErrInvalidWebhookURL, host)
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, routable only inside a provider's network.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicWebhookAddress reports whether deliveries may be sent to the address: loopback,
+// private, link-local (cloud metadata endpoints included), multicast and unspecified addresses
+// are refused.
+func IsPublicWebhookAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// Subscribes reports whether the subscription is active and selected the event type.
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	if s == nil || !s.Active {
+		return false
+	}
+	for _, candidate := range s.EventTypes {
+		if candidate == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWebhookEventType reports whether partners can subscribe to the event type.
+func IsWebhookEventType(eventType string) bool {
+	for _, candidate := range WebhookEventTypes {
+		if candidate == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDeadLetter marks a delivery that exhausted its attempts; it is only sent
+	// again when redelivered manually.
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+func (s WebhookDeliveryStatus) Validate() error {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDeadLetter:
+		return nil
+	default:
+		return errors.New("invalid webhook delivery status")
+	}
+}
+
+// WebhookDelivery is one event queued for one subscription. Payload holds the exact JSON body
+// that is signed and posted, so retries send the same bytes.
+type WebhookDelivery struct {
+	ID             primitive.ObjectID
+	SubscriptionID primitive.ObjectID
+	PartnerID      primitive.ObjectID
+	EventID        primitive.ObjectID
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    time.Time
+	CreatedAt      time.Time
+}
+
+// RecordSuccess marks the delivery as accepted by the subscriber.
+func (d *WebhookDelivery) RecordSuccess(statusCode int, at time.Time) {
+	if d == nil {
+		return
+	}
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.LastAttemptAt = at
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = at
+	d.NextAttemptAt = time.Time{}
+}
+
+// RecordFailure counts a failed attempt and schedules the next one after backoff, or moves the
+// delivery to the dead-letter list once maxAttempts is reached.
+func (d *WebhookDelivery) RecordFailure(statusCode int, cause error, at time.Time, maxAttempts int, backoff time.Duration) {
+	if d == nil {
+		return
+	}
+	d.Attempts++
+	d.LastAttemptAt = at
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	if cause != nil {
+		d.LastError = cause.Error()
+	}
+
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryDeadLetter
+		d.NextAttemptAt = time.Time{}
+		return
+	}
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = at.Add(backoff)
+}
+
+// Redeliver queues the delivery again with a fresh set of attempts.
+func (d *WebhookDelivery) Redeliver(at time.Time) {
+	if d == nil {
+		return
+	}
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	d.DeliveredAt = time.Time{}
+}
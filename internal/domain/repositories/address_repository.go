@@ -12,5 +12,5 @@ type AddressRepository interface {
 	CreateAddress(ctx context.Context, address *entities.Address) error
 	UpdateAddress(ctx context.Context, address *entities.Address) error
 	DeleteAddress(ctx context.Context, id primitive.ObjectID) error
-	ListAddresses(ctx context.Context, filter map[string]interface{}) ([]*entities.Address, error)
+	ListAddresses(ctx context.Context, query AddressQuery) ([]*entities.Address, PageInfo, error)
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditRepository interface {
+	RecordEvent(ctx context.Context, event *entities.AuditEvent) error
+	ListEventsBySubject(ctx context.Context, subjectType string, subjectID primitive.ObjectID) ([]*entities.AuditEvent, error)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownCacheResource     = errors.New("unknown cache resource")
+	ErrCacheResourceNotWarmable = errors.New("cache resource cannot be warmed")
+)
+
+// CacheAdmin inspects and manages the read cache kept in front of the repositories.
+type CacheAdmin interface {
+	Stats(ctx context.Context) (CacheStats, error)
+	// InspectKey returns nil when the key is not cached.
+	InspectKey(ctx context.Context, key string) (*CacheKeyInfo, error)
+	// FlushResource drops every cached entry and list page of the resource, on every replica.
+	FlushResource(ctx context.Context, resource string) (int64, error)
+	// WarmResource loads every entity of the resource from the database into the cache.
+	WarmResource(ctx context.Context, resource string) (int, error)
+}
+
+// CacheCounter is how many lookups of one resource and operation ended with an outcome: hit,
+// miss, stale or error.
+type CacheCounter struct {
+	Resource  string
+	Operation string
+	Outcome   string
+	Count     uint64
+}
+
+// CacheTierStats describes the in-process tier of one resource on the replica answering.
+type CacheTierStats struct {
+	Resource string
+	Entries  int
+	Capacity int
+	TTL      time.Duration
+}
+
+// CacheStats is a cache-wide snapshot. Available is false when the cache server could not be
+// reached, in which case Keys and UsedMemoryBytes are unknown.
+type CacheStats struct {
+	Available       bool
+	Keys            int64
+	UsedMemoryBytes int64
+	Counters        []CacheCounter
+	Local           []CacheTierStats
+}
+
+// CacheKeyInfo describes one cached key. Codec and Schema say which encoding and entity version
+// wrote it. Value is omitted for resources holding credentials.
+type CacheKeyInfo struct {
+	Key      string
+	TTL      time.Duration
+	Size     int
+	Missing  bool
+	Expires  time.Time
+	Codec    string
+	Schema   string
+	Local    bool
+	Redacted bool
+	Value    json.RawMessage
+}
@@ -10,8 +10,9 @@ import (
 
 type ConsumerRepository interface {
 	GetConsumerByID(ctx context.Context, id primitive.ObjectID) (*entities.Consumer, error)
+	GetConsumerByDocument(ctx context.Context, documentNumber string) (*entities.Consumer, error)
 	CreateConsumer(ctx context.Context, consumer *entities.Consumer) error
 	UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error
 	DeleteConsumer(ctx context.Context, id primitive.ObjectID) error
-	ListConsumers(ctx context.Context, filter map[string]interface{}) ([]*entities.Consumer, error)
+	ListConsumers(ctx context.Context, query ConsumerQuery) ([]*entities.Consumer, PageInfo, error)
 }
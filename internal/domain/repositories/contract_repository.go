@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ContractRepository interface {
+	GetContractByID(ctx context.Context, id primitive.ObjectID) (*entities.Contract, error)
+	CreateContract(ctx context.Context, contract *entities.Contract) error
+	UpdateContract(ctx context.Context, contract *entities.Contract) error
+	ListContractsByConsumer(ctx context.Context, consumerID primitive.ObjectID) ([]*entities.Contract, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import "context"
+
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+// HealthCheck reports on a dependency of the API. A degraded dependency still lets requests be
+// served, more slowly or with fewer guarantees; a down one does not.
+type HealthCheck interface {
+	Check(ctx context.Context) HealthComponent
+}
+
+// HealthComponent is the state of one dependency; Details carries what an operator needs to
+// tell why, such as the state of the Redis circuit breaker.
+type HealthComponent struct {
+	Name    string
+	Status  string
+	Details map[string]string
+}
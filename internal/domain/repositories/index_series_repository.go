@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// IndexSeriesRepository stores daily observations of the reference rates used by floating-rate
+// products. Saving a value for an existing index and date replaces it.
+type IndexSeriesRepository interface {
+	SaveIndexValues(ctx context.Context, values []*entities.IndexValue) error
+	GetLatestIndexValue(ctx context.Context, index valueobjects.RateIndex) (*entities.IndexValue, error)
+	ListIndexValues(ctx context.Context, index valueobjects.RateIndex, from, to time.Time) ([]*entities.IndexValue, error)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrConcurrentUpdate is returned when a record changed between the read and the update based on
+// it.
+var ErrConcurrentUpdate = errors.New("record was changed by another update")
+
+type InstallmentRepository interface {
+	CreateInstallments(ctx context.Context, installments []*entities.Installment) error
+	// UpdateInstallment stores the installment if it still has the version it was read with and
+	// increments Version; otherwise it fails with ErrConcurrentUpdate.
+	UpdateInstallment(ctx context.Context, installment *entities.Installment) error
+	// ListInstallmentsByContract returns the installments ordered by number.
+	ListInstallmentsByContract(ctx context.Context, contractID primitive.ObjectID) ([]*entities.Installment, error)
+	ListInstallmentsByConsumer(ctx context.Context, consumerID primitive.ObjectID) ([]*entities.Installment, error)
+	// ListOpenInstallmentsDueBefore returns unpaid, non-cancelled installments due before asOf.
+	ListOpenInstallmentsDueBefore(ctx context.Context, asOf time.Time) ([]*entities.Installment, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// JobLease lets a single process at a time run a periodic job that every replica schedules.
+type JobLease interface {
+	// Acquire takes the lease on name for ttl, or extends it when this process already holds it,
+	// and reports false while another process does.
+	Acquire(ctx context.Context, name string, ttl time.Duration) (bool, error)
+	// Release gives up the lease before it expires.
+	Release(ctx context.Context, name string) error
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+)
+
+// OutboxRepository stores domain events until the relay has published them. Events must be
+// appended with the context of the transaction that made the change they describe.
+type OutboxRepository interface {
+	AppendEvents(ctx context.Context, events []*entities.DomainEvent) error
+	// ClaimPendingEvents returns up to limit pending events due at now, oldest first, and pushes
+	// their next attempt lease into the future so concurrent relays do not publish them twice.
+	ClaimPendingEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.OutboxEvent, error)
+	UpdateOutboxEvent(ctx context.Context, event *entities.OutboxEvent) error
+}
@@ -12,5 +12,5 @@ type PartnerRepository interface {
 	CreatePartner(ctx context.Context, partner *entities.Partner) error
 	UpdatePartner(ctx context.Context, partner *entities.Partner) error
 	DeletePartner(ctx context.Context, id primitive.ObjectID) error
-	ListPartners(ctx context.Context, filter map[string]interface{}) ([]*entities.Partner, error)
+	ListPartners(ctx context.Context, query PartnerQuery) ([]*entities.Partner, PageInfo, error)
 }
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PaymentRepository interface {
+	CreatePayment(ctx context.Context, payment *entities.Payment) error
+	// ListPaymentsByContract returns the payments oldest first.
+	ListPaymentsByContract(ctx context.Context, contractID primitive.ObjectID) ([]*entities.Payment, error)
+}
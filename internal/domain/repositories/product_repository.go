@@ -12,5 +12,5 @@ type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *entities.Product) error
 	UpdateProduct(ctx context.Context, product *entities.Product) error
 	DeleteProduct(ctx context.Context, id primitive.ObjectID) error
-	ListProducts(ctx context.Context, filter map[string]interface{}) ([]*entities.Product, error)
+	ListProducts(ctx context.Context, query ProductQuery) ([]*entities.Product, PageInfo, error)
 }
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SortByRelevance orders search results by text score; without search text it falls back to name.
+const SortByRelevance = "relevance"
+
+var ErrInvalidRange = errors.New("range minimum must not exceed maximum")
+
+// ProductSearchSortFields lists the orderings accepted by SearchProducts.
+var ProductSearchSortFields = []string{SortByID, SortByRelevance, "name", "interest_rate", "term_months"}
+
+// ProductSearchIndex keeps a searchable projection of the catalog. The Mongo implementation uses
+// text indexes; other engines only need to honour the same query and facet semantics.
+type ProductSearchIndex interface {
+	IndexProduct(ctx context.Context, product *entities.Product, partner *entities.Partner) error
+	RemoveProduct(ctx context.Context, productID primitive.ObjectID) error
+	UpdatePartner(ctx context.Context, partner *entities.Partner) error
+	// RemovePartner drops every indexed product of the partner.
+	RemovePartner(ctx context.Context, partnerID primitive.ObjectID) error
+	SearchProducts(ctx context.Context, query ProductSearchQuery) (ProductSearchResult, error)
+}
+
+// FloatRange is an inclusive range; nil bounds are open.
+type FloatRange struct {
+	Min *float64
+	Max *float64
+}
+
+func (r FloatRange) IsZero() bool {
+	return r.Min == nil && r.Max == nil
+}
+
+func (r FloatRange) Validate() error {
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+// IntRange is an inclusive range; nil bounds are open.
+type IntRange struct {
+	Min *int
+	Max *int
+}
+
+func (r IntRange) IsZero() bool {
+	return r.Min == nil && r.Max == nil
+}
+
+func (r IntRange) Validate() error {
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+// ProductSearchQuery combines free text with exact filters and numeric ranges. Amount matches
+// products whose [MinAmount, MaxAmount] interval overlaps the requested range.
+type ProductSearchQuery struct {
+	Text         string
+	PartnerID    primitive.ObjectID
+	ProductTypes []valueobjects.ProductType
+	Categories   []valueobjects.ProductCategory
+	PartnerTypes []valueobjects.PartnerType
+	RiskLevels   []valueobjects.RiskLevel
+	InterestRate FloatRange
+	Amount       FloatRange
+	TermMonths   IntRange
+	Page         Pagination
+}
+
+func (q ProductSearchQuery) Validate() error {
+	for _, r := range []interface{ Validate() error }{q.InterestRate, q.Amount, q.TermMonths} {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if q.Page.Limit < 0 {
+		return ErrInvalidPageLimit
+	}
+
+	page := Pagination{Limit: q.Page.Limit, Sort: q.Sort()}
+	if err := page.Validate(ProductSearchSortFields); err != nil {
+		return err
+	}
+
+	if q.Page.Cursor != "" {
+		if _, err := DecodeOffsetCursor(q.Page.Cursor, q.Sort()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sort returns the requested ordering, defaulting to relevance.
+func (q ProductSearchQuery) Sort() Sort {
+	if strings.TrimSpace(q.Page.Sort.Field) == "" {
+		return Sort{Field: SortByRelevance}
+	}
+	return q.Page.Sort
+}
+
+// FacetCount is the number of matching products sharing a value.
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+type ProductFacets struct {
+	ProductTypes []FacetCount
+	Categories   []FacetCount
+	PartnerTypes []FacetCount
+	RiskLevels   []FacetCount
+}
+
+type ProductSearchResult struct {
+	Products []*entities.Product
+	Total    int64
+	Facets   ProductFacets
+	Page     PageInfo
+}
+
+type offsetCursor struct {
+	Sort   string `json:"s"`
+	Offset int    `json:"o"`
+}
+
+// EncodeOffsetCursor produces the opaque token for relevance-ordered results, which cannot be
+// resumed by key.
+func EncodeOffsetCursor(sort Sort, offset int) string {
+	payload, err := json.Marshal(offsetCursor{Sort: sort.String(), Offset: offset})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeOffsetCursor returns the offset stored in a token produced by EncodeOffsetCursor.
+func DecodeOffsetCursor(token string, sort Sort) (int, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	var cursor offsetCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.Offset <= 0 {
+		return 0, ErrInvalidCursor
+	}
+	if cursor.Sort != sort.String() {
+		return 0, ErrInvalidCursor
+	}
+
+	return cursor.Offset, nil
+}
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+
+	// SortByID orders results by identifier and is always accepted.
+	SortByID = "id"
+)
+
+var (
+	ErrInvalidPageLimit = errors.New("page limit must be a positive number")
+	ErrInvalidSortField = errors.New("sort field not allowed")
+	ErrInvalidCursor    = errors.New("invalid pagination cursor")
+)
+
+// Sort fields accepted by each list query, using API field names.
+var (
+	ProductSortFields  = []string{SortByID, "name", "product_type", "category"}
+	PartnerSortFields  = []string{SortByID, "name", "type"}
+	AddressSortFields  = []string{SortByID, "city", "state", "postal_code"}
+	ConsumerSortFields = []string{SortByID, "created_at", "updated_at"}
+	ReviewSortFields   = []string{SortByID, "created_at", "score"}
+	DeliverySortFields = []string{SortByID, "created_at"}
+)
+
+// Sort describes the ordering of a list query.
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSort reads expressions such as "name" or "-name" (descending).
+func ParseSort(expression string) Sort {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "-") {
+		return Sort{Field: strings.TrimSpace(expression[1:]), Descending: true}
+	}
+	return Sort{Field: strings.TrimPrefix(expression, "+")}
+}
+
+// String returns the sort in the same notation accepted by ParseSort.
+func (s Sort) String() string {
+	if s.Descending {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Pagination carries the keyset pagination parameters shared by every list query.
+type Pagination struct {
+	Limit  int
+	Cursor string
+	Sort   Sort
+}
+
+// Normalize applies the default sort and limit, clamping the limit to MaxPageLimit.
+func (p Pagination) Normalize() Pagination {
+	if p.Limit == 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if strings.TrimSpace(p.Sort.Field) == "" {
+		p.Sort.Field = SortByID
+	}
+	return p
+}
+
+// Validate ensures the limit is usable, the sort field is whitelisted and the cursor is well formed.
+func (p Pagination) Validate(allowedSortFields []string) error {
+	if p.Limit < 0 {
+		return ErrInvalidPageLimit
+	}
+
+	field := p.Sort.Field
+	if field == "" {
+		field = SortByID
+	}
+
+	allowed := false
+	for _, candidate := range allowedSortFields {
+		if candidate == field {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s", ErrInvalidSortField, field)
+	}
+
+	if p.Cursor != "" {
+		if _, err := DecodeCursor(p.Cursor, p.Normalize().Sort); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PageInfo describes the position of a returned page.
+type PageInfo struct {
+	Limit      int
+	NextCursor string
+	HasMore    bool
+}
+
+// Cursor marks the last item of a page: its sort value and identifier.
+type Cursor struct {
+	Sort  string             `json:"s"`
+	Value string             `json:"v,omitempty"`
+	ID    primitive.ObjectID `json:"id"`
+}
+
+// EncodeCursor produces the opaque token returned to clients.
+func EncodeCursor(cursor Cursor) string {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor parses a token produced by EncodeCursor, rejecting cursors issued for another sort.
+func DecodeCursor(token string, sort Sort) (Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if cursor.Sort != sort.String() {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+type ProductQuery struct {
+	PartnerID   primitive.ObjectID
+	ProductType valueobjects.ProductType
+	Category    valueobjects.ProductCategory
+	Page        Pagination
+}
+
+func (q ProductQuery) Validate() error {
+	return q.Page.Validate(ProductSortFields)
+}
+
+type PartnerQuery struct {
+	Type         valueobjects.PartnerType
+	AcceptedType valueobjects.ProductType
+	Page         Pagination
+}
+
+func (q PartnerQuery) Validate() error {
+	return q.Page.Validate(PartnerSortFields)
+}
+
+type AddressQuery struct {
+	City       string
+	State      string
+	PostalCode string
+	Type       valueobjects.AddressType
+	Page       Pagination
+}
+
+func (q AddressQuery) Validate() error {
+	return q.Page.Validate(AddressSortFields)
+}
+
+type ConsumerQuery struct {
+	Type valueobjects.ConsumerType
+	Page Pagination
+}
+
+func (q ConsumerQuery) Validate() error {
+	return q.Page.Validate(ConsumerSortFields)
+}
+
+type ScreeningReviewQuery struct {
+	Status entities.ReviewStatus
+	Stage  entities.ScreeningStage
+	Page   Pagination
+}
+
+func (q ScreeningReviewQuery) Validate() error {
+	return q.Page.Validate(ReviewSortFields)
+}
+
+type WebhookDeliveryQuery struct {
+	PartnerID primitive.ObjectID
+	Status    entities.WebhookDeliveryStatus
+	Page      Pagination
+}
+
+func (q WebhookDeliveryQuery) Validate() error {
+	return q.Page.Validate(DeliverySortFields)
+}
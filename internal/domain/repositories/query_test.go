@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursor_RoundTripIsBoundToSort(t *testing.T) {
+	sort := ParseSort("-name")
+	token := EncodeCursor(Cursor{Sort: sort.String(), Value: "Alpha", ID: primitive.NewObjectID()})
+
+	cursor, err := DecodeCursor(token, sort)
+	if err != nil {
+		t.Fatalf("DecodeCursor returned error: %v", err)
+	}
+	if cursor.Value != "Alpha" {
+		t.Fatalf("unexpected cursor value %q", cursor.Value)
+	}
+
+	if _, err := DecodeCursor(token, ParseSort("name")); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected cursor issued for another sort to be rejected, got %v", err)
+	}
+	if _, err := DecodeCursor("not-a-cursor", sort); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected malformed cursor to be rejected, got %v", err)
+	}
+}
+
+func TestPagination_ValidateRejectsUnknownSortField(t *testing.T) {
+	page := Pagination{Sort: ParseSort("-password")}
+	if err := page.Validate(ProductSortFields); !errors.Is(err, ErrInvalidSortField) {
+		t.Fatalf("expected ErrInvalidSortField, got %v", err)
+	}
+
+	normalized := Pagination{Limit: MaxPageLimit * 2}.Normalize()
+	if normalized.Limit != MaxPageLimit || normalized.Sort.Field != SortByID {
+		t.Fatalf("unexpected normalized pagination %+v", normalized)
+	}
+}
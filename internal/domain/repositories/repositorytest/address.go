@@ -0,0 +1,246 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddressRepository runs the AddressRepository conformance suite.
+func AddressRepository(t *testing.T, newRepository func(t *testing.T) repositories.AddressRepository) {
+	t.Run("GetMissingReturnsNil", func(t *testing.T) {
+		repo := newRepository(t)
+
+		address, err := repo.GetAddressByID(context.Background(), primitive.NewObjectID())
+		if err != nil || address != nil {
+			t.Fatalf("expected (nil, nil) for a missing address, got (%v, %v)", address, err)
+		}
+	})
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		created := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		if err := repo.CreateAddress(ctx, created); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+
+		stored := getAddress(t, repo, created.ID)
+		if stored == nil {
+			t.Fatalf("expected created address to be found")
+		}
+		if stored.City != created.City || stored.State != created.State || stored.PostalCode != created.PostalCode || stored.Street != created.Street || stored.Type != created.Type {
+			t.Fatalf("stored address %+v does not match created %+v", stored, created)
+		}
+	})
+
+	t.Run("CreateDuplicateIDFails", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		address := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		if err := repo.CreateAddress(ctx, address); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+		if err := repo.CreateAddress(ctx, address); err == nil {
+			t.Fatalf("expected creating an address with a taken ID to fail")
+		}
+	})
+
+	t.Run("UpdateReplacesStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		address := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		if err := repo.CreateAddress(ctx, address); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+		getAddress(t, repo, address.ID)
+
+		address.Number = "2000"
+		if err := repo.UpdateAddress(ctx, address); err != nil {
+			t.Fatalf("UpdateAddress returned error: %v", err)
+		}
+
+		if stored := getAddress(t, repo, address.ID); stored == nil || stored.Number != address.Number {
+			t.Fatalf("expected updated number %q, got %+v", address.Number, stored)
+		}
+	})
+
+	t.Run("UpdateMissingCreatesNothing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		address := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		if err := repo.UpdateAddress(ctx, address); err != nil {
+			t.Fatalf("UpdateAddress of a missing address returned error: %v", err)
+		}
+
+		if stored := getAddress(t, repo, address.ID); stored != nil {
+			t.Fatalf("expected update of a missing address not to create it, got %+v", stored)
+		}
+	})
+
+	t.Run("DeleteRemoves", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		address := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		if err := repo.CreateAddress(ctx, address); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+		getAddress(t, repo, address.ID)
+
+		if err := repo.DeleteAddress(ctx, address.ID); err != nil {
+			t.Fatalf("DeleteAddress returned error: %v", err)
+		}
+		if stored := getAddress(t, repo, address.ID); stored != nil {
+			t.Fatalf("expected deleted address to be gone, got %+v", stored)
+		}
+		if err := repo.DeleteAddress(ctx, address.ID); err != nil {
+			t.Fatalf("expected deleting a missing address to succeed, got %v", err)
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		paulista := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		moema := newAddress("São Paulo", "SP", "04077-000", valueobjects.AddressTypeHome)
+		copacabana := newAddress("Rio de Janeiro", "RJ", "22070-000", valueobjects.AddressTypeHome)
+		for _, address := range []*entities.Address{paulista, moema, copacabana} {
+			if err := repo.CreateAddress(ctx, address); err != nil {
+				t.Fatalf("CreateAddress returned error: %v", err)
+			}
+		}
+
+		cases := []struct {
+			name  string
+			query repositories.AddressQuery
+			want  []*entities.Address
+		}{
+			{"all", repositories.AddressQuery{}, []*entities.Address{paulista, moema, copacabana}},
+			{"city", repositories.AddressQuery{City: "São Paulo"}, []*entities.Address{paulista, moema}},
+			{"state", repositories.AddressQuery{State: "RJ"}, []*entities.Address{copacabana}},
+			{"postal code", repositories.AddressQuery{PostalCode: "04077-000"}, []*entities.Address{moema}},
+			{"type", repositories.AddressQuery{Type: valueobjects.AddressTypeHome}, []*entities.Address{moema, copacabana}},
+			{"combined", repositories.AddressQuery{State: "RJ", Type: valueobjects.AddressTypeWork}, nil},
+		}
+		for _, tc := range cases {
+			addresses, _, err := repo.ListAddresses(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("%s: ListAddresses returned error: %v", tc.name, err)
+			}
+			expectSameElements(t, tc.name, addressIDs(addresses), addressIDs(tc.want))
+		}
+	})
+
+	t.Run("ListObservesWrites", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		query := repositories.AddressQuery{State: "SP"}
+
+		first := newAddress("São Paulo", "SP", "01310-100", valueobjects.AddressTypeWork)
+		if err := repo.CreateAddress(ctx, first); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+		listAddressCities(t, repo, query)
+
+		second := newAddress("Campinas", "SP", "13010-000", valueobjects.AddressTypeHome)
+		if err := repo.CreateAddress(ctx, second); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+		expectSameElements(t, "after create", listAddressCities(t, repo, query), []string{"São Paulo", "Campinas"})
+
+		first.City = "Santos"
+		if err := repo.UpdateAddress(ctx, first); err != nil {
+			t.Fatalf("UpdateAddress returned error: %v", err)
+		}
+		expectSameElements(t, "after update", listAddressCities(t, repo, query), []string{"Santos", "Campinas"})
+
+		if err := repo.DeleteAddress(ctx, second.ID); err != nil {
+			t.Fatalf("DeleteAddress returned error: %v", err)
+		}
+		expectSameElements(t, "after delete", listAddressCities(t, repo, query), []string{"Santos"})
+	})
+
+	t.Run("ListPaginates", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		for _, city := range []string{"Recife", "Belém", "Natal"} {
+			if err := repo.CreateAddress(ctx, newAddress(city, "XX", "00000-000", valueobjects.AddressTypeHome)); err != nil {
+				t.Fatalf("CreateAddress returned error: %v", err)
+			}
+		}
+
+		pages := pageThrough(t, func(page repositories.Pagination) ([]*entities.Address, repositories.PageInfo, error) {
+			return repo.ListAddresses(ctx, repositories.AddressQuery{Page: page})
+		}, repositories.Pagination{Limit: 1, Sort: repositories.ParseSort("-city")})
+
+		var cities []string
+		for _, items := range pages {
+			for _, address := range items {
+				cities = append(cities, address.City)
+			}
+		}
+		expectOrder(t, cities, []string{"Recife", "Natal", "Belém"})
+
+		_, _, err := repo.ListAddresses(ctx, repositories.AddressQuery{Page: repositories.Pagination{Sort: repositories.ParseSort("bogus")}})
+		expectInvalidSort(t, err)
+	})
+}
+
+func newAddress(city, state, postalCode string, addressType valueobjects.AddressType) *entities.Address {
+	return &entities.Address{
+		ID:         primitive.NewObjectID(),
+		Country:    "BR",
+		State:      state,
+		City:       city,
+		District:   "Centro",
+		Street:     "Avenida Principal",
+		Number:     "1000",
+		PostalCode: postalCode,
+		Type:       addressType,
+	}
+}
+
+func getAddress(t *testing.T, repo repositories.AddressRepository, id primitive.ObjectID) *entities.Address {
+	t.Helper()
+
+	address, err := repo.GetAddressByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetAddressByID returned error: %v", err)
+	}
+	return address
+}
+
+func listAddressCities(t *testing.T, repo repositories.AddressRepository, query repositories.AddressQuery) []string {
+	t.Helper()
+
+	addresses, _, err := repo.ListAddresses(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ListAddresses returned error: %v", err)
+	}
+
+	cities := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		cities = append(cities, address.City)
+	}
+	return cities
+}
+
+func addressIDs(addresses []*entities.Address) []string {
+	ids := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		ids = append(ids, address.ID.Hex())
+	}
+	return ids
+}
@@ -0,0 +1,335 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ConsumerRepository runs the ConsumerRepository conformance suite.
+func ConsumerRepository(t *testing.T, newRepository func(t *testing.T) repositories.ConsumerRepository) {
+	t.Run("GetMissingReturnsNil", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		consumer, err := repo.GetConsumerByID(ctx, primitive.NewObjectID())
+		if err != nil || consumer != nil {
+			t.Fatalf("expected (nil, nil) for a missing consumer, got (%v, %v)", consumer, err)
+		}
+
+		consumer, err = repo.GetConsumerByDocument(ctx, "00000000000")
+		if err != nil || consumer != nil {
+			t.Fatalf("expected (nil, nil) for an unknown document, got (%v, %v)", consumer, err)
+		}
+	})
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		created := newIndividualConsumer("52998224725", testTime(0))
+		created.ContractedProducts = []primitive.ObjectID{primitive.NewObjectID()}
+		if err := repo.CreateConsumer(ctx, created); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+
+		stored := getConsumer(t, repo, created.ID)
+		if stored == nil || stored.PersonalData.Individual == nil {
+			t.Fatalf("expected created consumer with individual data, got %+v", stored)
+		}
+		individual := stored.PersonalData.Individual
+		if stored.Type != created.Type || individual.FullName != "Maria Silva" || individual.DocumentNumber != "52998224725" {
+			t.Fatalf("stored consumer %+v does not match created %+v", stored, created)
+		}
+		if !stored.CreatedAt.Equal(created.CreatedAt) || !individual.BirthDate.Equal(created.PersonalData.Individual.BirthDate) {
+			t.Fatalf("expected timestamps to round-trip, got created_at=%s birth_date=%s", stored.CreatedAt, individual.BirthDate)
+		}
+		if stored.CreditProfile.MonthlyIncome != created.CreditProfile.MonthlyIncome {
+			t.Fatalf("expected monthly income %s, got %s", created.CreditProfile.MonthlyIncome, stored.CreditProfile.MonthlyIncome)
+		}
+		if len(stored.ContractedProducts) != 1 || stored.ContractedProducts[0] != created.ContractedProducts[0] {
+			t.Fatalf("expected contracted products %v, got %v", created.ContractedProducts, stored.ContractedProducts)
+		}
+	})
+
+	t.Run("GetByDocument", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		individual := newIndividualConsumer("52998224725", testTime(0))
+		business := newBusinessConsumer("11222333000181", testTime(time.Minute))
+		for _, consumer := range []*entities.Consumer{individual, business} {
+			if err := repo.CreateConsumer(ctx, consumer); err != nil {
+				t.Fatalf("CreateConsumer returned error: %v", err)
+			}
+		}
+
+		for document, want := range map[string]primitive.ObjectID{"52998224725": individual.ID, "11222333000181": business.ID} {
+			found, err := repo.GetConsumerByDocument(ctx, document)
+			if err != nil {
+				t.Fatalf("GetConsumerByDocument returned error: %v", err)
+			}
+			if found == nil || found.ID != want {
+				t.Fatalf("expected document %s to find consumer %s, got %+v", document, want.Hex(), found)
+			}
+		}
+	})
+
+	t.Run("CreateDuplicateIDFails", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		consumer := newIndividualConsumer("52998224725", testTime(0))
+		if err := repo.CreateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+		if err := repo.CreateConsumer(ctx, consumer); err == nil {
+			t.Fatalf("expected creating a consumer with a taken ID to fail")
+		}
+	})
+
+	t.Run("UpdateReplacesStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		consumer := newIndividualConsumer("52998224725", testTime(0))
+		consumer.UserID = primitive.NewObjectID()
+		if err := repo.CreateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+		getConsumer(t, repo, consumer.ID)
+
+		consumer.Contact.Email = "maria@example.com"
+		consumer.UserID = primitive.NilObjectID
+		consumer.UpdatedAt = testTime(time.Hour)
+		if err := repo.UpdateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("UpdateConsumer returned error: %v", err)
+		}
+
+		stored := getConsumer(t, repo, consumer.ID)
+		if stored == nil || stored.Contact.Email != consumer.Contact.Email || !stored.UpdatedAt.Equal(consumer.UpdatedAt) {
+			t.Fatalf("expected updated consumer %+v, got %+v", consumer, stored)
+		}
+		if !stored.UserID.IsZero() {
+			t.Fatalf("expected detached user link to be cleared, got %s", stored.UserID.Hex())
+		}
+	})
+
+	t.Run("UpdateClearsLists", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		consumer := newIndividualConsumer("52998224725", testTime(0))
+		consumer.AdditionalAddressIDs = []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+		consumer.ContractedProducts = []primitive.ObjectID{primitive.NewObjectID()}
+		if err := repo.CreateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+		getConsumer(t, repo, consumer.ID)
+
+		consumer.AdditionalAddressIDs = consumer.AdditionalAddressIDs[:1]
+		if err := repo.UpdateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("UpdateConsumer returned error: %v", err)
+		}
+		stored := getConsumer(t, repo, consumer.ID)
+		if stored == nil || len(stored.AdditionalAddressIDs) != 1 || stored.AdditionalAddressIDs[0] != consumer.AdditionalAddressIDs[0] {
+			t.Fatalf("expected additional addresses %v, got %+v", consumer.AdditionalAddressIDs, stored)
+		}
+
+		consumer.AdditionalAddressIDs = nil
+		consumer.ContractedProducts = []primitive.ObjectID{}
+		if err := repo.UpdateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("UpdateConsumer returned error: %v", err)
+		}
+		stored = getConsumer(t, repo, consumer.ID)
+		if stored == nil || len(stored.AdditionalAddressIDs) != 0 || len(stored.ContractedProducts) != 0 {
+			t.Fatalf("expected emptied address and product lists, got %+v", stored)
+		}
+	})
+
+	t.Run("UpdateClearsSealedFields", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		// As written by the field encryption decorator: the contact only exists as ciphertext.
+		consumer := newIndividualConsumer("52998224725", testTime(0))
+		consumer.SealedFields = map[string]string{"email": "sealed-email", "phone": "sealed-phone"}
+		if err := repo.CreateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+		getConsumer(t, repo, consumer.ID)
+
+		// Erasing the contact leaves nothing to seal.
+		consumer.SealedFields = nil
+		consumer.UpdatedAt = testTime(time.Hour)
+		if err := repo.UpdateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("UpdateConsumer returned error: %v", err)
+		}
+
+		stored := getConsumer(t, repo, consumer.ID)
+		if stored == nil || len(stored.SealedFields) != 0 {
+			t.Fatalf("expected the old ciphertext to be removed, got %+v", stored)
+		}
+		if stored.Contact.Email != "" || stored.Contact.Phone != "" {
+			t.Fatalf("expected an empty contact, got %+v", stored.Contact)
+		}
+	})
+
+	t.Run("UpdateMissingCreatesNothing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		consumer := newIndividualConsumer("52998224725", testTime(0))
+		if err := repo.UpdateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("UpdateConsumer of a missing consumer returned error: %v", err)
+		}
+
+		if stored := getConsumer(t, repo, consumer.ID); stored != nil {
+			t.Fatalf("expected update of a missing consumer not to create it, got %+v", stored)
+		}
+	})
+
+	t.Run("DeleteRemoves", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		consumer := newIndividualConsumer("52998224725", testTime(0))
+		if err := repo.CreateConsumer(ctx, consumer); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+		getConsumer(t, repo, consumer.ID)
+
+		if err := repo.DeleteConsumer(ctx, consumer.ID); err != nil {
+			t.Fatalf("DeleteConsumer returned error: %v", err)
+		}
+		if stored := getConsumer(t, repo, consumer.ID); stored != nil {
+			t.Fatalf("expected deleted consumer to be gone, got %+v", stored)
+		}
+		if found, err := repo.GetConsumerByDocument(ctx, "52998224725"); err != nil || found != nil {
+			t.Fatalf("expected deleted consumer not to be found by document, got (%v, %v)", found, err)
+		}
+		if err := repo.DeleteConsumer(ctx, consumer.ID); err != nil {
+			t.Fatalf("expected deleting a missing consumer to succeed, got %v", err)
+		}
+	})
+
+	t.Run("ListFiltersAndObservesWrites", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		individual := newIndividualConsumer("52998224725", testTime(0))
+		business := newBusinessConsumer("11222333000181", testTime(time.Minute))
+		for _, consumer := range []*entities.Consumer{individual, business} {
+			if err := repo.CreateConsumer(ctx, consumer); err != nil {
+				t.Fatalf("CreateConsumer returned error: %v", err)
+			}
+		}
+
+		query := repositories.ConsumerQuery{Type: valueobjects.ConsumerTypeIndividual}
+		expectSameElements(t, "individuals", listConsumerIDs(t, repo, query), []string{individual.ID.Hex()})
+		expectSameElements(t, "all", listConsumerIDs(t, repo, repositories.ConsumerQuery{}), []string{individual.ID.Hex(), business.ID.Hex()})
+
+		another := newIndividualConsumer("39053344705", testTime(2*time.Minute))
+		if err := repo.CreateConsumer(ctx, another); err != nil {
+			t.Fatalf("CreateConsumer returned error: %v", err)
+		}
+		expectSameElements(t, "after create", listConsumerIDs(t, repo, query), []string{individual.ID.Hex(), another.ID.Hex()})
+
+		if err := repo.DeleteConsumer(ctx, individual.ID); err != nil {
+			t.Fatalf("DeleteConsumer returned error: %v", err)
+		}
+		expectSameElements(t, "after delete", listConsumerIDs(t, repo, query), []string{another.ID.Hex()})
+	})
+
+	t.Run("ListPaginates", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		var want []string
+		for i, document := range []string{"52998224725", "39053344705", "11144477735"} {
+			consumer := newIndividualConsumer(document, testTime(time.Duration(i)*time.Hour))
+			if err := repo.CreateConsumer(ctx, consumer); err != nil {
+				t.Fatalf("CreateConsumer returned error: %v", err)
+			}
+			want = append([]string{consumer.ID.Hex()}, want...)
+		}
+
+		pages := pageThrough(t, func(page repositories.Pagination) ([]*entities.Consumer, repositories.PageInfo, error) {
+			return repo.ListConsumers(ctx, repositories.ConsumerQuery{Page: page})
+		}, repositories.Pagination{Limit: 2, Sort: repositories.ParseSort("-created_at")})
+
+		var got []string
+		for _, items := range pages {
+			for _, consumer := range items {
+				got = append(got, consumer.ID.Hex())
+			}
+		}
+		expectOrder(t, got, want)
+
+		_, _, err := repo.ListConsumers(ctx, repositories.ConsumerQuery{Page: repositories.Pagination{Sort: repositories.ParseSort("bogus")}})
+		expectInvalidSort(t, err)
+	})
+}
+
+func newIndividualConsumer(document string, createdAt time.Time) *entities.Consumer {
+	return &entities.Consumer{
+		ID:   primitive.NewObjectID(),
+		Type: valueobjects.ConsumerTypeIndividual,
+		PersonalData: entities.ConsumerPersonalData{
+			Individual: &entities.ConsumerIndividualData{
+				FullName:       "Maria Silva",
+				DocumentNumber: document,
+				BirthDate:      time.Date(1988, 5, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		CreditProfile: entities.ConsumerCreditProfile{MonthlyIncome: valueobjects.NewMoney(850000, valueobjects.DefaultCurrency)},
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+}
+
+func newBusinessConsumer(document string, createdAt time.Time) *entities.Consumer {
+	return &entities.Consumer{
+		ID:   primitive.NewObjectID(),
+		Type: valueobjects.ConsumerTypeBusiness,
+		PersonalData: entities.ConsumerPersonalData{
+			Business: &entities.ConsumerBusinessData{
+				CorporateName:  "Padaria Central Ltda",
+				DocumentNumber: document,
+			},
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func getConsumer(t *testing.T, repo repositories.ConsumerRepository, id primitive.ObjectID) *entities.Consumer {
+	t.Helper()
+
+	consumer, err := repo.GetConsumerByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetConsumerByID returned error: %v", err)
+	}
+	return consumer
+}
+
+func listConsumerIDs(t *testing.T, repo repositories.ConsumerRepository, query repositories.ConsumerQuery) []string {
+	t.Helper()
+
+	consumers, _, err := repo.ListConsumers(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ListConsumers returned error: %v", err)
+	}
+
+	ids := make([]string, 0, len(consumers))
+	for _, consumer := range consumers {
+		ids = append(ids, consumer.ID.Hex())
+	}
+	return ids
+}
@@ -0,0 +1,245 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PartnerRepository runs the PartnerRepository conformance suite.
+func PartnerRepository(t *testing.T, newRepository func(t *testing.T) repositories.PartnerRepository) {
+	t.Run("GetMissingReturnsNil", func(t *testing.T) {
+		repo := newRepository(t)
+
+		partner, err := repo.GetPartnerByID(context.Background(), primitive.NewObjectID())
+		if err != nil || partner != nil {
+			t.Fatalf("expected (nil, nil) for a missing partner, got (%v, %v)", partner, err)
+		}
+	})
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		created := newPartner("Banco A", valueobjects.PartnerTypeBank, valueobjects.ProductTypePersonalLoan, valueobjects.ProductTypeCreditCard)
+		if err := repo.CreatePartner(ctx, created); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+
+		stored := getPartner(t, repo, created.ID)
+		if stored == nil {
+			t.Fatalf("expected created partner to be found")
+		}
+		if stored.Name != created.Name || stored.Type != created.Type || len(stored.AcceptedTypes) != 2 {
+			t.Fatalf("stored partner %+v does not match created %+v", stored, created)
+		}
+		if len(stored.ManagerProfileIDs) != 1 || stored.ManagerProfileIDs[0] != created.ManagerProfileIDs[0] {
+			t.Fatalf("expected manager %s, got %v", created.ManagerProfileIDs[0].Hex(), stored.ManagerProfileIDs)
+		}
+	})
+
+	t.Run("CreateDuplicateIDFails", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		partner := newPartner("Banco A", valueobjects.PartnerTypeBank)
+		if err := repo.CreatePartner(ctx, partner); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+		if err := repo.CreatePartner(ctx, partner); err == nil {
+			t.Fatalf("expected creating a partner with a taken ID to fail")
+		}
+	})
+
+	t.Run("UpdateReplacesStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		partner := newPartner("Banco A", valueobjects.PartnerTypeBank)
+		if err := repo.CreatePartner(ctx, partner); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+		getPartner(t, repo, partner.ID)
+
+		partner.Name = "Banco A Digital"
+		partner.ManagerProfileIDs = append(partner.ManagerProfileIDs, primitive.NewObjectID())
+		if err := repo.UpdatePartner(ctx, partner); err != nil {
+			t.Fatalf("UpdatePartner returned error: %v", err)
+		}
+
+		stored := getPartner(t, repo, partner.ID)
+		if stored == nil || stored.Name != partner.Name || len(stored.ManagerProfileIDs) != 2 {
+			t.Fatalf("expected updated partner %+v, got %+v", partner, stored)
+		}
+	})
+
+	t.Run("UpdateMissingCreatesNothing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		partner := newPartner("Fantasma", valueobjects.PartnerTypeBank)
+		if err := repo.UpdatePartner(ctx, partner); err != nil {
+			t.Fatalf("UpdatePartner of a missing partner returned error: %v", err)
+		}
+
+		if stored := getPartner(t, repo, partner.ID); stored != nil {
+			t.Fatalf("expected update of a missing partner not to create it, got %+v", stored)
+		}
+	})
+
+	t.Run("DeleteRemoves", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		partner := newPartner("Banco A", valueobjects.PartnerTypeBank)
+		if err := repo.CreatePartner(ctx, partner); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+		getPartner(t, repo, partner.ID)
+
+		if err := repo.DeletePartner(ctx, partner.ID); err != nil {
+			t.Fatalf("DeletePartner returned error: %v", err)
+		}
+		if stored := getPartner(t, repo, partner.ID); stored != nil {
+			t.Fatalf("expected deleted partner to be gone, got %+v", stored)
+		}
+		if err := repo.DeletePartner(ctx, partner.ID); err != nil {
+			t.Fatalf("expected deleting a missing partner to succeed, got %v", err)
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		bank := newPartner("Banco A", valueobjects.PartnerTypeBank, valueobjects.ProductTypePersonalLoan, valueobjects.ProductTypeCreditCard)
+		cooperative := newPartner("Cooperativa B", valueobjects.PartnerTypeCooperative, valueobjects.ProductTypePersonalLoan)
+		fintech := newPartner("Fintech C", valueobjects.PartnerTypeFintechSCD, valueobjects.ProductTypeWorkingCapitalLoan)
+		for _, partner := range []*entities.Partner{bank, cooperative, fintech} {
+			if err := repo.CreatePartner(ctx, partner); err != nil {
+				t.Fatalf("CreatePartner returned error: %v", err)
+			}
+		}
+
+		cases := []struct {
+			name  string
+			query repositories.PartnerQuery
+			want  []*entities.Partner
+		}{
+			{"all", repositories.PartnerQuery{}, []*entities.Partner{bank, cooperative, fintech}},
+			{"type", repositories.PartnerQuery{Type: valueobjects.PartnerTypeCooperative}, []*entities.Partner{cooperative}},
+			{"accepted type", repositories.PartnerQuery{AcceptedType: valueobjects.ProductTypePersonalLoan}, []*entities.Partner{bank, cooperative}},
+			{"combined", repositories.PartnerQuery{Type: valueobjects.PartnerTypeBank, AcceptedType: valueobjects.ProductTypeWorkingCapitalLoan}, nil},
+		}
+		for _, tc := range cases {
+			partners, _, err := repo.ListPartners(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("%s: ListPartners returned error: %v", tc.name, err)
+			}
+			expectSameElements(t, tc.name, partnerIDs(partners), partnerIDs(tc.want))
+		}
+	})
+
+	t.Run("ListObservesWrites", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		query := repositories.PartnerQuery{Type: valueobjects.PartnerTypeBank}
+
+		first := newPartner("Banco A", valueobjects.PartnerTypeBank)
+		if err := repo.CreatePartner(ctx, first); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+		listPartnerNames(t, repo, query)
+
+		second := newPartner("Banco B", valueobjects.PartnerTypeBank)
+		if err := repo.CreatePartner(ctx, second); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+		expectSameElements(t, "after create", listPartnerNames(t, repo, query), []string{"Banco A", "Banco B"})
+
+		first.Name = "Banco A2"
+		if err := repo.UpdatePartner(ctx, first); err != nil {
+			t.Fatalf("UpdatePartner returned error: %v", err)
+		}
+		expectSameElements(t, "after update", listPartnerNames(t, repo, query), []string{"Banco A2", "Banco B"})
+
+		if err := repo.DeletePartner(ctx, second.ID); err != nil {
+			t.Fatalf("DeletePartner returned error: %v", err)
+		}
+		expectSameElements(t, "after delete", listPartnerNames(t, repo, query), []string{"Banco A2"})
+	})
+
+	t.Run("ListPaginates", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		for _, name := range []string{"Banco B", "Banco A", "Banco C"} {
+			if err := repo.CreatePartner(ctx, newPartner(name, valueobjects.PartnerTypeBank)); err != nil {
+				t.Fatalf("CreatePartner returned error: %v", err)
+			}
+		}
+
+		pages := pageThrough(t, func(page repositories.Pagination) ([]*entities.Partner, repositories.PageInfo, error) {
+			return repo.ListPartners(ctx, repositories.PartnerQuery{Page: page})
+		}, repositories.Pagination{Limit: 2, Sort: repositories.ParseSort("name")})
+
+		var names []string
+		for _, items := range pages {
+			for _, partner := range items {
+				names = append(names, partner.Name)
+			}
+		}
+		expectOrder(t, names, []string{"Banco A", "Banco B", "Banco C"})
+
+		_, _, err := repo.ListPartners(ctx, repositories.PartnerQuery{Page: repositories.Pagination{Sort: repositories.ParseSort("bogus")}})
+		expectInvalidSort(t, err)
+	})
+}
+
+func newPartner(name string, partnerType valueobjects.PartnerType, accepted ...valueobjects.ProductType) *entities.Partner {
+	return &entities.Partner{
+		ID:                primitive.NewObjectID(),
+		Name:              name,
+		Type:              partnerType,
+		ManagerProfileIDs: []primitive.ObjectID{primitive.NewObjectID()},
+		AcceptedTypes:     accepted,
+	}
+}
+
+func getPartner(t *testing.T, repo repositories.PartnerRepository, id primitive.ObjectID) *entities.Partner {
+	t.Helper()
+
+	partner, err := repo.GetPartnerByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetPartnerByID returned error: %v", err)
+	}
+	return partner
+}
+
+func listPartnerNames(t *testing.T, repo repositories.PartnerRepository, query repositories.PartnerQuery) []string {
+	t.Helper()
+
+	partners, _, err := repo.ListPartners(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ListPartners returned error: %v", err)
+	}
+
+	names := make([]string, 0, len(partners))
+	for _, partner := range partners {
+		names = append(names, partner.Name)
+	}
+	return names
+}
+
+func partnerIDs(partners []*entities.Partner) []string {
+	ids := make([]string, 0, len(partners))
+	for _, partner := range partners {
+		ids = append(ids, partner.ID.Hex())
+	}
+	return ids
+}
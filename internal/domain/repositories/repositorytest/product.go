@@ -0,0 +1,249 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProductRepository runs the ProductRepository conformance suite.
+func ProductRepository(t *testing.T, newRepository func(t *testing.T) repositories.ProductRepository) {
+	t.Run("GetMissingReturnsNil", func(t *testing.T) {
+		repo := newRepository(t)
+
+		product, err := repo.GetProductByID(context.Background(), primitive.NewObjectID())
+		if err != nil || product != nil {
+			t.Fatalf("expected (nil, nil) for a missing product, got (%v, %v)", product, err)
+		}
+	})
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		created := newProduct("Crédito Pessoal", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.CreateProduct(ctx, created); err != nil {
+			t.Fatalf("CreateProduct returned error: %v", err)
+		}
+
+		stored := getProduct(t, repo, created.ID)
+		if stored == nil {
+			t.Fatalf("expected created product to be found")
+		}
+		if stored.Name != created.Name || stored.PartnerID != created.PartnerID || stored.ProductType != created.ProductType || stored.Category != created.Category {
+			t.Fatalf("stored product %+v does not match created %+v", stored, created)
+		}
+	})
+
+	t.Run("CreateDuplicateIDFails", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		product := newProduct("Crédito Pessoal", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.CreateProduct(ctx, product); err != nil {
+			t.Fatalf("CreateProduct returned error: %v", err)
+		}
+		if err := repo.CreateProduct(ctx, product); err == nil {
+			t.Fatalf("expected creating a product with a taken ID to fail")
+		}
+	})
+
+	t.Run("UpdateReplacesStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		product := newProduct("Crédito Pessoal", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.CreateProduct(ctx, product); err != nil {
+			t.Fatalf("CreateProduct returned error: %v", err)
+		}
+		getProduct(t, repo, product.ID)
+
+		product.Name = "Crédito Pessoal Plus"
+		if err := repo.UpdateProduct(ctx, product); err != nil {
+			t.Fatalf("UpdateProduct returned error: %v", err)
+		}
+
+		if stored := getProduct(t, repo, product.ID); stored == nil || stored.Name != product.Name {
+			t.Fatalf("expected updated name %q, got %+v", product.Name, stored)
+		}
+	})
+
+	t.Run("UpdateMissingCreatesNothing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		product := newProduct("Fantasma", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.UpdateProduct(ctx, product); err != nil {
+			t.Fatalf("UpdateProduct of a missing product returned error: %v", err)
+		}
+
+		if stored := getProduct(t, repo, product.ID); stored != nil {
+			t.Fatalf("expected update of a missing product not to create it, got %+v", stored)
+		}
+	})
+
+	t.Run("DeleteRemoves", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		product := newProduct("Crédito Pessoal", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.CreateProduct(ctx, product); err != nil {
+			t.Fatalf("CreateProduct returned error: %v", err)
+		}
+		getProduct(t, repo, product.ID)
+
+		if err := repo.DeleteProduct(ctx, product.ID); err != nil {
+			t.Fatalf("DeleteProduct returned error: %v", err)
+		}
+		if stored := getProduct(t, repo, product.ID); stored != nil {
+			t.Fatalf("expected deleted product to be gone, got %+v", stored)
+		}
+		if err := repo.DeleteProduct(ctx, product.ID); err != nil {
+			t.Fatalf("expected deleting a missing product to succeed, got %v", err)
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		partnerA, partnerB := primitive.NewObjectID(), primitive.NewObjectID()
+		personal := newProduct("Pessoal A", partnerA, valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		card := newProduct("Cartão A", partnerA, valueobjects.ProductTypeCreditCard, valueobjects.ProductCategoryPersonal)
+		capital := newProduct("Capital de Giro B", partnerB, valueobjects.ProductTypeWorkingCapitalLoan, valueobjects.ProductCategoryBusiness)
+		for _, product := range []*entities.Product{personal, card, capital} {
+			if err := repo.CreateProduct(ctx, product); err != nil {
+				t.Fatalf("CreateProduct returned error: %v", err)
+			}
+		}
+
+		cases := []struct {
+			name  string
+			query repositories.ProductQuery
+			want  []*entities.Product
+		}{
+			{"all", repositories.ProductQuery{}, []*entities.Product{personal, card, capital}},
+			{"partner", repositories.ProductQuery{PartnerID: partnerA}, []*entities.Product{personal, card}},
+			{"type", repositories.ProductQuery{ProductType: valueobjects.ProductTypeCreditCard}, []*entities.Product{card}},
+			{"category", repositories.ProductQuery{Category: valueobjects.ProductCategoryBusiness}, []*entities.Product{capital}},
+			{"combined", repositories.ProductQuery{PartnerID: partnerB, Category: valueobjects.ProductCategoryPersonal}, nil},
+		}
+		for _, tc := range cases {
+			products, _, err := repo.ListProducts(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("%s: ListProducts returned error: %v", tc.name, err)
+			}
+			expectSameElements(t, tc.name, productIDs(products), productIDs(tc.want))
+		}
+	})
+
+	t.Run("ListObservesWrites", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		query := repositories.ProductQuery{ProductType: valueobjects.ProductTypePersonalLoan}
+
+		first := newProduct("Pessoal A", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.CreateProduct(ctx, first); err != nil {
+			t.Fatalf("CreateProduct returned error: %v", err)
+		}
+		listProductNames(t, repo, query)
+
+		second := newProduct("Pessoal B", primitive.NewObjectID(), valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)
+		if err := repo.CreateProduct(ctx, second); err != nil {
+			t.Fatalf("CreateProduct returned error: %v", err)
+		}
+		expectSameElements(t, "after create", listProductNames(t, repo, query), []string{"Pessoal A", "Pessoal B"})
+
+		first.Name = "Pessoal A2"
+		if err := repo.UpdateProduct(ctx, first); err != nil {
+			t.Fatalf("UpdateProduct returned error: %v", err)
+		}
+		expectSameElements(t, "after update", listProductNames(t, repo, query), []string{"Pessoal A2", "Pessoal B"})
+
+		if err := repo.DeleteProduct(ctx, second.ID); err != nil {
+			t.Fatalf("DeleteProduct returned error: %v", err)
+		}
+		expectSameElements(t, "after delete", listProductNames(t, repo, query), []string{"Pessoal A2"})
+	})
+
+	t.Run("ListPaginates", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		partnerID := primitive.NewObjectID()
+		for _, name := range []string{"Beta", "Alfa", "Gama", "Alfa", "Delta"} {
+			if err := repo.CreateProduct(ctx, newProduct(name, partnerID, valueobjects.ProductTypePersonalLoan, valueobjects.ProductCategoryPersonal)); err != nil {
+				t.Fatalf("CreateProduct returned error: %v", err)
+			}
+		}
+
+		page := repositories.Pagination{Limit: 2, Sort: repositories.ParseSort("-name")}
+		pages := pageThrough(t, func(page repositories.Pagination) ([]*entities.Product, repositories.PageInfo, error) {
+			return repo.ListProducts(ctx, repositories.ProductQuery{Page: page})
+		}, page)
+
+		var names []string
+		seen := make(map[primitive.ObjectID]bool)
+		for _, items := range pages {
+			for _, product := range items {
+				if seen[product.ID] {
+					t.Fatalf("product %s returned on more than one page", product.ID.Hex())
+				}
+				seen[product.ID] = true
+				names = append(names, product.Name)
+			}
+		}
+		expectOrder(t, names, []string{"Gama", "Delta", "Beta", "Alfa", "Alfa"})
+
+		_, _, err := repo.ListProducts(ctx, repositories.ProductQuery{Page: repositories.Pagination{Sort: repositories.ParseSort("bogus")}})
+		expectInvalidSort(t, err)
+	})
+}
+
+func newProduct(name string, partnerID primitive.ObjectID, productType valueobjects.ProductType, category valueobjects.ProductCategory) *entities.Product {
+	return &entities.Product{
+		ID:          primitive.NewObjectID(),
+		Name:        name,
+		PartnerID:   partnerID,
+		ProductType: productType,
+		Category:    category,
+	}
+}
+
+func getProduct(t *testing.T, repo repositories.ProductRepository, id primitive.ObjectID) *entities.Product {
+	t.Helper()
+
+	product, err := repo.GetProductByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetProductByID returned error: %v", err)
+	}
+	return product
+}
+
+func listProductNames(t *testing.T, repo repositories.ProductRepository, query repositories.ProductQuery) []string {
+	t.Helper()
+
+	products, _, err := repo.ListProducts(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ListProducts returned error: %v", err)
+	}
+
+	names := make([]string, 0, len(products))
+	for _, product := range products {
+		names = append(names, product.Name)
+	}
+	return names
+}
+
+func productIDs(products []*entities.Product) []string {
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID.Hex())
+	}
+	return ids
+}
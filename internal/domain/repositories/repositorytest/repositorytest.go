@@ -0,0 +1,88 @@
+// Package repositorytest holds the conformance suites every implementation of the repository
+// interfaces must pass, whether it stores data itself or decorates another repository.
+//
+// The suites pin down the conventions the services rely on: a lookup that finds nothing returns
+// (nil, nil), except where an interface declares a sentinel error; an update of a missing entity
+// changes nothing; deleting a missing entity succeeds; and reads observe every completed write,
+// so caching decorators must invalidate what a write makes stale. Each subtest asks the factory
+// for an empty repository.
+package repositorytest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/repositories"
+)
+
+// Timestamps are truncated to milliseconds, the precision Mongo keeps.
+func testTime(offset time.Duration) time.Time {
+	return time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC).Add(offset).Truncate(time.Millisecond)
+}
+
+// pageThrough follows the cursors of a paginated list and returns every page.
+func pageThrough[T any](t *testing.T, list func(page repositories.Pagination) ([]T, repositories.PageInfo, error), page repositories.Pagination) [][]T {
+	t.Helper()
+
+	var pages [][]T
+	for {
+		items, info, err := list(page)
+		if err != nil {
+			t.Fatalf("list returned error: %v", err)
+		}
+		if len(items) > page.Limit {
+			t.Fatalf("expected at most %d items per page, got %d", page.Limit, len(items))
+		}
+		if info.HasMore != (info.NextCursor != "") {
+			t.Fatalf("expected a next cursor exactly when more items exist, got %+v", info)
+		}
+		pages = append(pages, items)
+		if !info.HasMore {
+			return pages
+		}
+		if len(pages) > 10 {
+			t.Fatalf("pagination did not terminate")
+		}
+		page.Cursor = info.NextCursor
+	}
+}
+
+func expectInvalidSort(t *testing.T, err error) {
+	t.Helper()
+
+	if !errors.Is(err, repositories.ErrInvalidSortField) {
+		t.Fatalf("expected ErrInvalidSortField for an unknown sort field, got %v", err)
+	}
+}
+
+// expectSameElements compares two lists of strings, ignoring order.
+func expectSameElements(t *testing.T, label string, got, want []string) {
+	t.Helper()
+
+	counts := make(map[string]int, len(want))
+	for _, value := range want {
+		counts[value]++
+	}
+	for _, value := range got {
+		counts[value]--
+	}
+	for value, count := range counts {
+		if count != 0 {
+			t.Fatalf("%s: got %v, want %v (mismatch on %q)", label, got, want, value)
+		}
+	}
+}
+
+func expectOrder(t *testing.T, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
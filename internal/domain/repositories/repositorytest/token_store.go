@@ -0,0 +1,52 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/security"
+)
+
+// TokenStore runs the security.TokenStore conformance suite.
+func TokenStore(t *testing.T, newStore func(t *testing.T) security.TokenStore) {
+	t.Run("RevokeOnlyTheGivenToken", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStore(t)
+
+		if err := store.Revoke(ctx, "first.jwt.token", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Revoke returned error: %v", err)
+		}
+
+		if revoked, err := store.IsRevoked(ctx, "first.jwt.token"); err != nil || !revoked {
+			t.Fatalf("expected revoked token to be reported, got (%t, %v)", revoked, err)
+		}
+		if revoked, err := store.IsRevoked(ctx, "second.jwt.token"); err != nil || revoked {
+			t.Fatalf("expected other tokens not to be revoked, got (%t, %v)", revoked, err)
+		}
+	})
+
+	t.Run("PastExpirationStillRevokes", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStore(t)
+
+		if err := store.Revoke(ctx, "expired.jwt.token", time.Now().Add(-time.Hour)); err != nil {
+			t.Fatalf("Revoke returned error: %v", err)
+		}
+		if revoked, err := store.IsRevoked(ctx, "expired.jwt.token"); err != nil || !revoked {
+			t.Fatalf("expected token revoked after expiry to be reported, got (%t, %v)", revoked, err)
+		}
+	})
+
+	t.Run("BlankTokenIsIgnored", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStore(t)
+
+		if err := store.Revoke(ctx, "   ", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Revoke of a blank token returned error: %v", err)
+		}
+		if revoked, err := store.IsRevoked(ctx, ""); err != nil || revoked {
+			t.Fatalf("expected a blank token never to be revoked, got (%t, %v)", revoked, err)
+		}
+	})
+}
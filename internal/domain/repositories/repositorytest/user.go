@@ -0,0 +1,128 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserRepository runs the UserRepository conformance suite. Unlike the other repositories,
+// deleting a missing user fails with repositories.ErrUserNotFound and emails are unique.
+func UserRepository(t *testing.T, newRepository func(t *testing.T) repositories.UserRepository) {
+	t.Run("FindMissingReturnsNil", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		if user, err := repo.FindByID(ctx, primitive.NewObjectID()); err != nil || user != nil {
+			t.Fatalf("expected (nil, nil) for a missing ID, got (%v, %v)", user, err)
+		}
+		if user, err := repo.FindByID(ctx, primitive.NilObjectID); err != nil || user != nil {
+			t.Fatalf("expected (nil, nil) for the zero ID, got (%v, %v)", user, err)
+		}
+		if user, err := repo.FindByEmail(ctx, "nobody@example.com"); err != nil || user != nil {
+			t.Fatalf("expected (nil, nil) for an unknown email, got (%v, %v)", user, err)
+		}
+		if user, err := repo.FindByEmail(ctx, "  "); err != nil || user != nil {
+			t.Fatalf("expected (nil, nil) for a blank email, got (%v, %v)", user, err)
+		}
+	})
+
+	t.Run("CreateThenFind", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		created := newUser("Gestor@Example.com")
+		if err := repo.CreateUser(ctx, created); err != nil {
+			t.Fatalf("CreateUser returned error: %v", err)
+		}
+
+		byID, err := repo.FindByID(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("FindByID returned error: %v", err)
+		}
+		expectUser(t, "by id", byID, created)
+
+		byEmail, err := repo.FindByEmail(ctx, "  GESTOR@example.COM ")
+		if err != nil {
+			t.Fatalf("FindByEmail returned error: %v", err)
+		}
+		expectUser(t, "by email", byEmail, created)
+	})
+
+	t.Run("CreateDuplicateEmailFails", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		if err := repo.CreateUser(ctx, newUser("gestor@example.com")); err != nil {
+			t.Fatalf("CreateUser returned error: %v", err)
+		}
+		if err := repo.CreateUser(ctx, newUser("Gestor@Example.com")); !errors.Is(err, repositories.ErrUserAlreadyExists) {
+			t.Fatalf("expected ErrUserAlreadyExists for a taken email, got %v", err)
+		}
+	})
+
+	t.Run("DeleteRemoves", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		user := newUser("gestor@example.com")
+		if err := repo.CreateUser(ctx, user); err != nil {
+			t.Fatalf("CreateUser returned error: %v", err)
+		}
+		if _, err := repo.FindByEmail(ctx, user.Email); err != nil {
+			t.Fatalf("FindByEmail returned error: %v", err)
+		}
+
+		if err := repo.DeleteUser(ctx, user.ID); err != nil {
+			t.Fatalf("DeleteUser returned error: %v", err)
+		}
+		if found, err := repo.FindByID(ctx, user.ID); err != nil || found != nil {
+			t.Fatalf("expected deleted user to be gone by id, got (%v, %v)", found, err)
+		}
+		if found, err := repo.FindByEmail(ctx, user.Email); err != nil || found != nil {
+			t.Fatalf("expected deleted user to be gone by email, got (%v, %v)", found, err)
+		}
+
+		if err := repo.DeleteUser(ctx, user.ID); !errors.Is(err, repositories.ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound when deleting a missing user, got %v", err)
+		}
+		if err := repo.CreateUser(ctx, newUser(user.Email)); err != nil {
+			t.Fatalf("expected the email of a deleted user to be free again, got %v", err)
+		}
+	})
+}
+
+func newUser(email string) *entities.User {
+	return &entities.User{
+		ID:           primitive.NewObjectID(),
+		Email:        email,
+		PasswordHash: "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z2cHqh5V1aQYF8Rz6qKJY0eK",
+		Active:       true,
+		Role:         entities.RoleManager,
+		Permissions:  []string{entities.PermissionManageUsers},
+		ProfileType:  entities.ProfileTypePartnerManager,
+		ProfileID:    primitive.NewObjectID(),
+	}
+}
+
+func expectUser(t *testing.T, label string, got, want *entities.User) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("%s: expected user %s to be found", label, want.ID.Hex())
+	}
+	if got.ID != want.ID || got.Email != "gestor@example.com" || got.PasswordHash != want.PasswordHash || got.Active != want.Active {
+		t.Fatalf("%s: user %+v does not match %+v", label, got, want)
+	}
+	if got.Role != want.Role || got.ProfileType != want.ProfileType || got.ProfileID != want.ProfileID {
+		t.Fatalf("%s: expected role %s and profile %s/%s, got %s and %s/%s", label, want.Role, want.ProfileType, want.ProfileID.Hex(), got.Role, got.ProfileType, got.ProfileID.Hex())
+	}
+	if len(got.Permissions) != 1 || got.Permissions[0] != want.Permissions[0] {
+		t.Fatalf("%s: expected permissions %v, got %v", label, want.Permissions, got.Permissions)
+	}
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ScreeningReviewRepository interface {
+	CreateReview(ctx context.Context, review *entities.ScreeningReview) error
+	GetReviewByID(ctx context.Context, id primitive.ObjectID) (*entities.ScreeningReview, error)
+	UpdateReview(ctx context.Context, review *entities.ScreeningReview) error
+	ListReviews(ctx context.Context, query ScreeningReviewQuery) ([]*entities.ScreeningReview, PageInfo, error)
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ScreeningSignalRepository interface {
+	RecordSignals(ctx context.Context, signals []*entities.ScreeningSignal) error
+	// CountConsumers counts the distinct consumers, other than excludeConsumerID, that recorded
+	// the signal.
+	CountConsumers(ctx context.Context, kind, value string, excludeConsumerID primitive.ObjectID) (int, error)
+	// CountSince counts the signals recorded from the given instant on.
+	CountSince(ctx context.Context, kind, value string, since time.Time) (int, error)
+	// DeleteConsumerSignals removes every signal the consumer recorded and reports how many
+	// there were.
+	DeleteConsumerSignals(ctx context.Context, consumerID primitive.ObjectID) (int64, error)
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+)
+
+// UnitOfWork runs fn as one atomic unit. Repository calls made with the context fn receives
+// commit together when fn returns nil and are rolled back otherwise. fn may be run again when
+// the store retries a transient conflict, so it must not have effects outside the store; defer
+// those with AfterCommit. A call made while a unit of work is already open on ctx joins it.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type unitOfWorkKey struct{}
+
+// CommitHooks collects the work deferred until a unit of work commits. UnitOfWork
+// implementations open one per attempt with WithCommitHooks and run it after the commit.
+type CommitHooks struct {
+	mu    sync.Mutex
+	hooks []func(ctx context.Context)
+}
+
+// WithCommitHooks marks ctx as belonging to an open unit of work.
+func WithCommitHooks(ctx context.Context) (context.Context, *CommitHooks) {
+	hooks := &CommitHooks{}
+	return context.WithValue(ctx, unitOfWorkKey{}, hooks), hooks
+}
+
+// Run calls the deferred hooks in registration order. They run detached from the cancellation
+// of ctx, since the changes they follow are already committed.
+func (h *CommitHooks) Run(ctx context.Context) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+}
+
+// InUnitOfWork reports whether ctx belongs to an open unit of work. Caches should neither serve
+// nor store reads made inside one, as they may see uncommitted writes.
+func InUnitOfWork(ctx context.Context) bool {
+	_, ok := ctx.Value(unitOfWorkKey{}).(*CommitHooks)
+	return ok
+}
+
+// AfterCommit defers hook until the unit of work open on ctx commits and drops it if the unit
+// of work rolls back. Without an open unit of work hook runs right away.
+func AfterCommit(ctx context.Context, hook func(ctx context.Context)) {
+	hooks, ok := ctx.Value(unitOfWorkKey{}).(*CommitHooks)
+	if !ok {
+		hook(ctx)
+		return
+	}
+
+	hooks.mu.Lock()
+	hooks.hooks = append(hooks.hooks, hook)
+	hooks.mu.Unlock()
+}
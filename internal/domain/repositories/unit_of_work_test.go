@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAfterCommit_DefersHooksInsideUnitOfWork(t *testing.T) {
+	var ran []string
+
+	AfterCommit(context.Background(), func(context.Context) { ran = append(ran, "direct") })
+	if len(ran) != 1 {
+		t.Fatalf("expected the hook to run right away outside a unit of work, got %v", ran)
+	}
+
+	ctx, hooks := WithCommitHooks(context.Background())
+	if !InUnitOfWork(ctx) {
+		t.Fatal("expected the context to belong to a unit of work")
+	}
+	AfterCommit(ctx, func(context.Context) { ran = append(ran, "first") })
+	AfterCommit(ctx, func(context.Context) { ran = append(ran, "second") })
+	if len(ran) != 1 {
+		t.Fatalf("expected hooks to wait for the commit, got %v", ran)
+	}
+
+	hooks.Run(ctx)
+	hooks.Run(ctx)
+	if len(ran) != 3 || ran[1] != "first" || ran[2] != "second" {
+		t.Fatalf("expected each hook to run once in order, got %v", ran)
+	}
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookDeliveryRepository interface {
+	CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, id primitive.ObjectID) (*entities.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	// ClaimDueDeliveries returns up to limit pending deliveries due at now and pushes their next
+	// attempt lease into the future, so concurrent workers do not send the same delivery twice.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, query WebhookDeliveryQuery) ([]*entities.WebhookDelivery, PageInfo, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookSubscriptionRepository interface {
+	CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	GetSubscriptionByID(ctx context.Context, id primitive.ObjectID) (*entities.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id primitive.ObjectID) error
+	// ListSubscriptionsByPartner returns the partner's subscriptions, oldest first.
+	ListSubscriptionsByPartner(ctx context.Context, partnerID primitive.ObjectID) ([]*entities.WebhookSubscription, error)
+}
@@ -0,0 +1,13 @@
+package security
+
+// FieldCipher protects individual attribute values before they leave the application.
+type FieldCipher interface {
+	// Encrypt seals the plaintext bound to the given field name. Deterministic mode yields the
+	// same ciphertext for the same input under the same key, allowing equality lookups.
+	Encrypt(field string, plaintext []byte, deterministic bool) (string, error)
+	// Decrypt opens a value previously produced by Encrypt for the same field.
+	Decrypt(field string, ciphertext string) ([]byte, error)
+	// SearchTokens returns the deterministic ciphertexts of plaintext under every known key,
+	// starting with the active one, so lookups keep working while data is being re-encrypted.
+	SearchTokens(field string, plaintext []byte) ([]string, error)
+}
@@ -57,6 +57,10 @@ func (s *AddressService) DeleteAddress(ctx context.Context, id primitive.ObjectI
 	return s.addressRepo.DeleteAddress(ctx, id)
 }
 
-func (s *AddressService) ListAddresses(ctx context.Context, filter map[string]interface{}) ([]*entities.Address, error) {
-	return s.addressRepo.ListAddresses(ctx, filter)
+func (s *AddressService) ListAddresses(ctx context.Context, query repositories.AddressQuery) ([]*entities.Address, repositories.PageInfo, error) {
+	if err := query.Validate(); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return s.addressRepo.ListAddresses(ctx, query)
 }
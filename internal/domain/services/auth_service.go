@@ -31,10 +31,11 @@ var (
 // AuthService handles credential verification against persisted users.
 type AuthService struct {
 	userRepo repositories.UserRepository
+	events   *EventOutbox
 }
 
-func NewAuthService(userRepo repositories.UserRepository) *AuthService {
-	return &AuthService{userRepo: userRepo}
+func NewAuthService(userRepo repositories.UserRepository, events *EventOutbox) *AuthService {
+	return &AuthService{userRepo: userRepo, events: events}
 }
 
 // Authenticate validates credentials, returning the user on success.
@@ -127,7 +128,13 @@ func (s *AuthService) CreateUser(
 	}
 	user.Normalize()
 
-	if err := s.userRepo.CreateUser(ctx, user); err != nil {
+	err = s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventUserCreated, user.ID, userPartnerID(user), newUserEventData(user))
+	})
+	if err != nil {
 		if errors.Is(err, repositories.ErrUserAlreadyExists) {
 			return nil, ErrUserAlreadyExists
 		}
@@ -146,7 +153,21 @@ func (s *AuthService) DeleteUser(ctx context.Context, id primitive.ObjectID) err
 		return ErrInvalidUserData
 	}
 
-	if err := s.userRepo.DeleteUser(ctx, id); err != nil {
+	err := s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		// The user is loaded first so the event still describes the deleted profile.
+		user, err := s.userRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return repositories.ErrUserNotFound
+		}
+		if err := s.userRepo.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventUserDeleted, user.ID, userPartnerID(user), newUserEventData(user))
+	})
+	if err != nil {
 		if errors.Is(err, repositories.ErrUserNotFound) {
 			return ErrUserNotFound
 		}
@@ -156,6 +177,14 @@ func (s *AuthService) DeleteUser(ctx context.Context, id primitive.ObjectID) err
 	return nil
 }
 
+// userPartnerID is the partner a partner manager works for, the partner user events concern.
+func userPartnerID(user *entities.User) primitive.ObjectID {
+	if user.ProfileType == entities.ProfileTypePartnerManager {
+		return user.ProfileID
+	}
+	return primitive.NilObjectID
+}
+
 // GetUserByID retrieves a user by identifier.
 func (s *AuthService) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entities.User, error) {
 	if s == nil || s.userRepo == nil {
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"katseye/internal/domain/repositories"
+)
+
+var (
+	ErrCacheUnavailable = errors.New("cache unavailable")
+	ErrCacheKeyRequired = errors.New("cache key is required")
+)
+
+// CacheService backs the admin cache endpoints and the cache metrics.
+type CacheService struct {
+	admin repositories.CacheAdmin
+}
+
+func NewCacheService(admin repositories.CacheAdmin) *CacheService {
+	if admin == nil {
+		return nil
+	}
+
+	return &CacheService{admin: admin}
+}
+
+func (s *CacheService) Stats(ctx context.Context) (repositories.CacheStats, error) {
+	if s == nil || s.admin == nil {
+		return repositories.CacheStats{}, ErrCacheUnavailable
+	}
+
+	return s.admin.Stats(ctx)
+}
+
+func (s *CacheService) InspectKey(ctx context.Context, key string) (*repositories.CacheKeyInfo, error) {
+	if s == nil || s.admin == nil {
+		return nil, ErrCacheUnavailable
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, ErrCacheKeyRequired
+	}
+
+	return s.admin.InspectKey(ctx, key)
+}
+
+func (s *CacheService) FlushResource(ctx context.Context, resource string) (int64, error) {
+	if s == nil || s.admin == nil {
+		return 0, ErrCacheUnavailable
+	}
+
+	return s.admin.FlushResource(ctx, strings.TrimSpace(strings.ToLower(resource)))
+}
+
+func (s *CacheService) WarmResource(ctx context.Context, resource string) (int, error) {
+	if s == nil || s.admin == nil {
+		return 0, ErrCacheUnavailable
+	}
+
+	return s.admin.WarmResource(ctx, strings.TrimSpace(strings.ToLower(resource)))
+}
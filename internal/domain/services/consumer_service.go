@@ -3,10 +3,13 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"katseye/internal/domain/entities"
 	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -18,21 +21,71 @@ var (
 	ErrProductNotFound               = errors.New("product not found")
 	ErrConsumerUserAlreadyLinked     = errors.New("consumer already linked to user")
 	ErrConsumerUserNotLinked         = errors.New("consumer user not linked")
+	ErrContractRepositoryUnavailable = errors.New("contract repository unavailable")
+	ErrProductNotEligible            = errors.New("consumer is not eligible for the product")
+	ErrInvalidContractTerms          = errors.New("invalid contract terms")
+	ErrContractOutstandingBalance    = errors.New("contract has an outstanding balance")
 )
 
+// ContractTerms are the optional amount and term simulated before contracting. When both are
+// zero the product is contracted at the rate of the matching tier without an eligibility check
+// on amount, term or income.
+type ContractTerms struct {
+	Amount     valueobjects.Money
+	TermMonths int
+}
+
+func (t ContractTerms) IsZero() bool {
+	return t.Amount.IsZero() && t.TermMonths == 0
+}
+
+func (t ContractTerms) Validate() error {
+	if t.IsZero() {
+		return nil
+	}
+	if !t.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidContractTerms)
+	}
+	if t.TermMonths <= 0 {
+		return fmt.Errorf("%w: term must be at least one month", ErrInvalidContractTerms)
+	}
+	return nil
+}
+
 type ConsumerService struct {
-	consumerRepo repositories.ConsumerRepository
-	productRepo  repositories.ProductRepository
+	consumerRepo    repositories.ConsumerRepository
+	productRepo     repositories.ProductRepository
+	partnerRepo     repositories.PartnerRepository
+	contractRepo    repositories.ContractRepository
+	indexRepo       repositories.IndexSeriesRepository
+	installmentRepo repositories.InstallmentRepository
+	screening       *ScreeningService
+	events          *EventOutbox
 }
 
-func NewConsumerService(consumerRepo repositories.ConsumerRepository, productRepo repositories.ProductRepository) *ConsumerService {
+func NewConsumerService(
+	consumerRepo repositories.ConsumerRepository,
+	productRepo repositories.ProductRepository,
+	partnerRepo repositories.PartnerRepository,
+	contractRepo repositories.ContractRepository,
+	indexRepo repositories.IndexSeriesRepository,
+	installmentRepo repositories.InstallmentRepository,
+	screening *ScreeningService,
+	events *EventOutbox,
+) *ConsumerService {
 	if consumerRepo == nil {
 		return nil
 	}
 
 	return &ConsumerService{
-		consumerRepo: consumerRepo,
-		productRepo:  productRepo,
+		consumerRepo:    consumerRepo,
+		productRepo:     productRepo,
+		partnerRepo:     partnerRepo,
+		contractRepo:    contractRepo,
+		indexRepo:       indexRepo,
+		installmentRepo: installmentRepo,
+		screening:       screening,
+		events:          events,
 	}
 }
 
@@ -47,6 +100,20 @@ func (s *ConsumerService) GetConsumerByID(ctx context.Context, id primitive.Obje
 	return s.consumerRepo.GetConsumerByID(ctx, id)
 }
 
+func (s *ConsumerService) GetConsumerByDocument(ctx context.Context, documentNumber string) (*entities.Consumer, error) {
+	if s == nil || s.consumerRepo == nil {
+		return nil, ErrConsumerRepositoryUnavailable
+	}
+	documentNumber = strings.TrimSpace(documentNumber)
+	if documentNumber == "" {
+		return nil, errors.New("document number is required")
+	}
+
+	return s.consumerRepo.GetConsumerByDocument(ctx, documentNumber)
+}
+
+// CreateConsumer stores a new consumer. When screening is enabled the consumer is scored first
+// and, if held, stored with a pending review that blocks contracting until an analyst decides.
 func (s *ConsumerService) CreateConsumer(ctx context.Context, consumer *entities.Consumer) error {
 	if s == nil || s.consumerRepo == nil {
 		return ErrConsumerRepositoryUnavailable
@@ -70,7 +137,32 @@ func (s *ConsumerService) CreateConsumer(ctx context.Context, consumer *entities
 	}
 	consumer.UpdatedAt = now
 
-	return s.consumerRepo.CreateConsumer(ctx, consumer)
+	var screening ScreeningResult
+	if s.screening != nil {
+		result, err := s.screening.Screen(ctx, consumer, nil)
+		if err != nil {
+			return err
+		}
+		screening = result
+		consumer.Screening = entities.ConsumerScreening{
+			Score:      screening.Score,
+			Status:     screening.Status(),
+			ScreenedAt: now,
+		}
+	}
+
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.consumerRepo.CreateConsumer(ctx, consumer); err != nil {
+			return err
+		}
+		if s.screening != nil {
+			if err := s.screening.Record(ctx, consumer, nil, screening); err != nil {
+				return err
+			}
+		}
+		created := ConsumerEventData{ConsumerID: consumer.ID.Hex(), ScreeningStatus: string(consumer.Screening.Status)}
+		return s.events.Record(ctx, entities.EventConsumerCreated, consumer.ID, primitive.NilObjectID, created)
+	})
 }
 
 func (s *ConsumerService) UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error {
@@ -88,6 +180,26 @@ func (s *ConsumerService) UpdateConsumer(ctx context.Context, consumer *entities
 		return err
 	}
 
+	existing, err := s.consumerRepo.GetConsumerByID(ctx, consumer.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrConsumerNotFound
+	}
+	if existing.IsErased() {
+		return entities.ErrConsumerErased
+	}
+
+	// Bureau data, and the score, risk level and delinquency probability derived from it, only
+	// change through a credit profile refresh; the outstanding debt through contracts and
+	// payments; screening through a review.
+	consumer.CreditProfile.Bureau = existing.CreditProfile.Bureau
+	consumer.CreditProfile.CreditScore = existing.CreditProfile.CreditScore
+	consumer.CreditProfile.RiskLevel = existing.CreditProfile.RiskLevel
+	consumer.CreditProfile.DelinquencyProbability = existing.CreditProfile.DelinquencyProbability
+	consumer.CreditProfile.OutstandingDebt = existing.CreditProfile.OutstandingDebt
+	consumer.Screening = existing.Screening
 	consumer.UpdatedAt = time.Now().UTC()
 
 	return s.consumerRepo.UpdateConsumer(ctx, consumer)
@@ -104,54 +216,198 @@ func (s *ConsumerService) DeleteConsumer(ctx context.Context, id primitive.Objec
 	return s.consumerRepo.DeleteConsumer(ctx, id)
 }
 
-func (s *ConsumerService) ListConsumers(ctx context.Context, filter map[string]interface{}) ([]*entities.Consumer, error) {
+func (s *ConsumerService) ListConsumers(ctx context.Context, query repositories.ConsumerQuery) ([]*entities.Consumer, repositories.PageInfo, error) {
 	if s == nil || s.consumerRepo == nil {
-		return nil, ErrConsumerRepositoryUnavailable
+		return nil, repositories.PageInfo{}, ErrConsumerRepositoryUnavailable
 	}
 
-	return s.consumerRepo.ListConsumers(ctx, filter)
+	if err := query.Validate(); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return s.consumerRepo.ListConsumers(ctx, query)
 }
 
-func (s *ConsumerService) ContractProduct(ctx context.Context, consumerID, productID primitive.ObjectID) error {
+// ContractProduct links the product to the consumer and records a contract priced with the
+// pricing tier that matches the consumer's credit profile. Contracts flagged by screening are
+// recorded as pending review, without installments, until an analyst approves them.
+func (s *ConsumerService) ContractProduct(ctx context.Context, consumerID, productID primitive.ObjectID, terms ContractTerms) (*entities.Contract, error) {
 	if s == nil || s.consumerRepo == nil {
-		return ErrConsumerRepositoryUnavailable
+		return nil, ErrConsumerRepositoryUnavailable
 	}
 	if consumerID.IsZero() {
-		return errors.New("consumer id is required")
+		return nil, errors.New("consumer id is required")
 	}
 	if productID.IsZero() {
-		return errors.New("product id is required")
+		return nil, errors.New("product id is required")
+	}
+	if err := terms.Validate(); err != nil {
+		return nil, err
 	}
 
 	consumer, err := s.consumerRepo.GetConsumerByID(ctx, consumerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if consumer == nil {
-		return ErrConsumerNotFound
+		return nil, ErrConsumerNotFound
+	}
+	if consumer.IsErased() {
+		return nil, entities.ErrConsumerErased
+	}
+	if err := consumer.Screening.Blocks(); err != nil {
+		return nil, err
 	}
 
 	if s.productRepo == nil {
-		return ErrProductRepositoryUnavailable
+		return nil, ErrProductRepositoryUnavailable
+	}
+	if s.contractRepo == nil {
+		return nil, ErrContractRepositoryUnavailable
 	}
 
 	product, err := s.productRepo.GetProductByID(ctx, productID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if product == nil {
-		return ErrProductNotFound
+		return nil, ErrProductNotFound
 	}
 
 	if err := consumer.AddContractedProduct(productID); err != nil {
-		return err
+		return nil, err
 	}
 
-	consumer.UpdatedAt = time.Now().UTC()
+	contract, err := s.priceContract(ctx, consumer, product, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	var screening ScreeningResult
+	if s.screening != nil {
+		if screening, err = s.screening.Screen(ctx, consumer, contract); err != nil {
+			return nil, err
+		}
+		if screening.Hold {
+			contract.Status = entities.ContractStatusPendingReview
+		}
+	}
+
+	consumer.UpdatedAt = contract.CreatedAt
+
+	err = s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.contractRepo.CreateContract(ctx, contract); err != nil {
+			return err
+		}
+
+		if s.installmentRepo != nil && contract.IsActive() {
+			if installments := entities.NewInstallments(contract); len(installments) > 0 {
+				if err := s.installmentRepo.CreateInstallments(ctx, installments); err != nil {
+					return err
+				}
+				debt, err := outstandingDebt(ctx, s.installmentRepo, consumer.ID)
+				if err != nil {
+					return err
+				}
+				consumer.CreditProfile.OutstandingDebt = debt
+			}
+		}
+
+		if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+			return err
+		}
+
+		if s.screening != nil {
+			if err := s.screening.Record(ctx, consumer, contract, screening); err != nil {
+				return err
+			}
+		}
+
+		// Held contracts are announced once they are approved.
+		if !contract.IsActive() {
+			return nil
+		}
+		return s.events.Record(ctx, entities.EventProductContracted, contract.ID, contract.PartnerID, newContractEventData(contract))
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return s.consumerRepo.UpdateConsumer(ctx, consumer)
+	return contract, nil
+}
+
+// priceContract builds the contract for the tier matching the consumer. With terms, the full
+// eligibility rules apply and the quote is stored alongside the tier snapshot. Floating rates
+// are recorded with the index observation used to project InterestRate.
+func (s *ConsumerService) priceContract(ctx context.Context, consumer *entities.Consumer, product *entities.Product, terms ContractTerms) (*entities.Contract, error) {
+	now := time.Now().UTC()
+	contract := &entities.Contract{
+		ConsumerID:  consumer.ID,
+		ProductID:   product.ID,
+		PartnerID:   product.PartnerID,
+		ProductType: product.ProductType,
+		Status:      entities.ContractStatusActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	indexes, err := loadIndexValues(ctx, s.indexRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	if terms.IsZero() {
+		attrs, tier, err := product.PricingFor(consumer.CreditProfile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrProductNotEligible, err)
+		}
+		if attrs.IndexedRate != nil {
+			contract.IndexValue = indexes[attrs.IndexedRate.Index]
+			if attrs, err = attrs.WithIndexValue(contract.IndexValue); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrProductNotEligible, err)
+			}
+		}
+		contract.InterestRate = attrs.InterestRate
+		contract.IndexedRate = attrs.IndexedRate
+		contract.PricingTier = tier
+		contract.EarlyRepaymentAllowed = attrs.EarlyRepaymentAllowed
+	} else {
+		var partner *entities.Partner
+		if s.partnerRepo != nil && !product.PartnerID.IsZero() {
+			found, err := s.partnerRepo.GetPartnerByID(ctx, product.PartnerID)
+			if err != nil {
+				return nil, err
+			}
+			partner = found
+		}
+
+		assessment := assessEligibility(consumer, partner, product, terms.Amount, terms.TermMonths, indexes)
+		if !assessment.Eligible() {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotEligible, strings.Join(assessment.Reasons, "; "))
+		}
+
+		contract.Amount = terms.Amount
+		contract.TermMonths = terms.TermMonths
+		contract.InterestRate = assessment.Attributes.InterestRate
+		contract.IndexedRate = assessment.Attributes.IndexedRate
+		contract.IndexValue = assessment.IndexValue
+		contract.Installment = assessment.Quote.Installment
+		contract.CETMonthly = assessment.Quote.CETMonthly
+		contract.PricingTier = assessment.Tier
+		contract.EarlyRepaymentAllowed = assessment.Attributes.EarlyRepaymentAllowed
+		contract.FirstDueDate = firstDueDate(now, assessment.Attributes.GracePeriodDays)
+	}
+
+	if err := contract.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProductNotEligible, err)
+	}
+
+	return contract, nil
 }
 
+// RemoveContractedProduct unlinks the product and cancels the consumer's active or held contracts
+// for it. It is refused while any of those contracts has installments left to pay: the debt is
+// settled through an early repayment first, not waived by the removal.
 func (s *ConsumerService) RemoveContractedProduct(ctx context.Context, consumerID, productID primitive.ObjectID) error {
 	if s == nil || s.consumerRepo == nil {
 		return ErrConsumerRepositoryUnavailable
@@ -175,9 +431,104 @@ func (s *ConsumerService) RemoveContractedProduct(ctx context.Context, consumerI
 		return err
 	}
 
-	consumer.UpdatedAt = time.Now().UTC()
+	now := time.Now().UTC()
+	consumer.UpdatedAt = now
 
-	return s.consumerRepo.UpdateConsumer(ctx, consumer)
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		removed := ConsumerProductEventData{ConsumerID: consumerID.Hex(), ProductID: productID.Hex()}
+		partnerID := primitive.NilObjectID
+
+		if s.contractRepo != nil {
+			contracts, err := s.contractRepo.ListContractsByConsumer(ctx, consumerID)
+			if err != nil {
+				return err
+			}
+
+			// Every contract is checked before any is cancelled, so a refusal changes nothing.
+			cancelled := make([]*entities.Contract, 0, len(contracts))
+			for _, contract := range contracts {
+				if contract.ProductID != productID || (!contract.IsActive() && !contract.IsPendingReview()) {
+					continue
+				}
+				balance, err := s.contractBalance(ctx, contract.ID)
+				if err != nil {
+					return err
+				}
+				if balance.IsPositive() {
+					return fmt.Errorf("%w: %s", ErrContractOutstandingBalance, balance)
+				}
+				cancelled = append(cancelled, contract)
+			}
+
+			for _, contract := range cancelled {
+				removed, partnerID = newContractEventData(contract), contract.PartnerID
+				contract.Cancel(now)
+				if err := s.contractRepo.UpdateContract(ctx, contract); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+			return err
+		}
+
+		if partnerID.IsZero() && s.productRepo != nil {
+			product, err := s.productRepo.GetProductByID(ctx, productID)
+			if err != nil {
+				return err
+			}
+			if product != nil {
+				partnerID = product.PartnerID
+			}
+		}
+		removed.PartnerID = partnerID.Hex()
+
+		return s.events.Record(ctx, entities.EventProductRemoved, consumerID, partnerID, removed)
+	})
+}
+
+// contractBalance is what is still owed on the contract's installments, late charges included.
+func (s *ConsumerService) contractBalance(ctx context.Context, contractID primitive.ObjectID) (valueobjects.Money, error) {
+	var balance valueobjects.Money
+	if s.installmentRepo == nil {
+		return balance, nil
+	}
+
+	installments, err := s.installmentRepo.ListInstallmentsByContract(ctx, contractID)
+	if err != nil {
+		return balance, err
+	}
+	for _, installment := range installments {
+		balance = balance.Add(installment.Outstanding())
+	}
+	return balance, nil
+}
+
+// firstDueDate is one month after contracting, pushed back by the product's grace period.
+func firstDueDate(contractedAt time.Time, gracePeriodDays int) time.Time {
+	day := time.Date(contractedAt.Year(), contractedAt.Month(), contractedAt.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 1, gracePeriodDays)
+}
+
+// ListContracts returns every contract recorded for the consumer, oldest first.
+func (s *ConsumerService) ListContracts(ctx context.Context, consumerID primitive.ObjectID) ([]*entities.Contract, error) {
+	if s == nil || s.consumerRepo == nil {
+		return nil, ErrConsumerRepositoryUnavailable
+	}
+	if s.contractRepo == nil {
+		return nil, ErrContractRepositoryUnavailable
+	}
+
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, consumerID)
+	if err != nil {
+		return nil, err
+	}
+	if consumer == nil {
+		return nil, ErrConsumerNotFound
+	}
+
+	return s.contractRepo.ListContractsByConsumer(ctx, consumerID)
 }
 
 func (s *ConsumerService) AttachUserProfile(ctx context.Context, consumerID, userID primitive.ObjectID) error {
@@ -198,6 +549,9 @@ func (s *ConsumerService) AttachUserProfile(ctx context.Context, consumerID, use
 	if consumer == nil {
 		return ErrConsumerNotFound
 	}
+	if consumer.IsErased() {
+		return entities.ErrConsumerErased
+	}
 
 	if consumer.HasLinkedUser() {
 		return ErrConsumerUserAlreadyLinked
@@ -206,7 +560,13 @@ func (s *ConsumerService) AttachUserProfile(ctx context.Context, consumerID, use
 	consumer.UserID = userID
 	consumer.UpdatedAt = time.Now().UTC()
 
-	return s.consumerRepo.UpdateConsumer(ctx, consumer)
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+			return err
+		}
+		attached := ConsumerEventData{ConsumerID: consumerID.Hex(), UserID: userID.Hex()}
+		return s.events.Record(ctx, entities.EventConsumerUserAttached, consumerID, primitive.NilObjectID, attached)
+	})
 }
 
 func (s *ConsumerService) DetachUserProfile(ctx context.Context, consumerID primitive.ObjectID) error {
@@ -229,8 +589,14 @@ func (s *ConsumerService) DetachUserProfile(ctx context.Context, consumerID prim
 		return ErrConsumerUserNotLinked
 	}
 
+	detached := ConsumerEventData{ConsumerID: consumerID.Hex(), UserID: consumer.UserID.Hex()}
 	consumer.UserID = primitive.NilObjectID
 	consumer.UpdatedAt = time.Now().UTC()
 
-	return s.consumerRepo.UpdateConsumer(ctx, consumer)
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventConsumerUserDetached, consumerID, primitive.NilObjectID, detached)
+	})
 }
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"katseye/internal/domain/creditbureau"
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCreditBureauUnavailable = errors.New("credit bureau not configured")
+	ErrCreditBureauFailure     = errors.New("credit bureau query failed")
+)
+
+// Score bands used to classify consumers after a refresh. Any negative record puts the
+// consumer in the high risk band regardless of score.
+const (
+	lowRiskMinimumScore    = 700
+	mediumRiskMinimumScore = 400
+)
+
+// CreditProfileService replaces the self-declared risk indicators of a consumer with the ones
+// reported by a credit bureau.
+type CreditProfileService struct {
+	consumerRepo repositories.ConsumerRepository
+	bureau       creditbureau.Provider
+}
+
+func NewCreditProfileService(consumerRepo repositories.ConsumerRepository, bureau creditbureau.Provider) *CreditProfileService {
+	if consumerRepo == nil {
+		return nil
+	}
+
+	return &CreditProfileService{
+		consumerRepo: consumerRepo,
+		bureau:       bureau,
+	}
+}
+
+// RefreshCreditProfile queries the bureau by the consumer's CPF/CNPJ and stores the score,
+// delinquency probability and derived risk level, along with the negative records summary,
+// the income estimate and where and when each answer was obtained. Declared income is kept.
+func (s *CreditProfileService) RefreshCreditProfile(ctx context.Context, consumerID primitive.ObjectID) (*entities.Consumer, error) {
+	if s == nil || s.consumerRepo == nil {
+		return nil, ErrConsumerRepositoryUnavailable
+	}
+	if s.bureau == nil {
+		return nil, ErrCreditBureauUnavailable
+	}
+
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, consumerID)
+	if err != nil {
+		return nil, err
+	}
+	if consumer == nil {
+		return nil, ErrConsumerNotFound
+	}
+	if consumer.IsErased() {
+		return nil, entities.ErrConsumerErased
+	}
+
+	document, err := creditbureau.NormalizeDocument(consumerDocument(consumer))
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := s.bureau.Score(ctx, document)
+	if err != nil {
+		return nil, bureauError("score", err)
+	}
+	negatives, err := s.bureau.NegativeRecords(ctx, document)
+	if err != nil {
+		return nil, bureauError("negative records", err)
+	}
+	income, err := s.bureau.EstimateIncome(ctx, document)
+	if err != nil {
+		return nil, bureauError("income estimate", err)
+	}
+
+	now := time.Now().UTC()
+	profile := &consumer.CreditProfile
+	profile.CreditScore = score.Score
+	profile.DelinquencyProbability = score.DelinquencyProbability
+	profile.RiskLevel = string(riskLevelFor(score.Score, len(negatives.Records)))
+	profile.Bureau = entities.CreditBureauData{
+		Source:                     s.bureau.Name(),
+		RefreshedAt:                now,
+		ScoreRetrievedAt:           score.RetrievedAt,
+		NegativeRecords:            len(negatives.Records),
+		NegativeRecordsAmount:      negatives.Total(),
+		NegativeRecordsRetrievedAt: negatives.RetrievedAt,
+		EstimatedIncome:            income.MonthlyIncome,
+		IncomeRetrievedAt:          income.RetrievedAt,
+	}
+	consumer.UpdatedAt = now
+
+	if err := consumer.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCreditBureauFailure, err)
+	}
+	if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+func consumerDocument(consumer *entities.Consumer) string {
+	if individual := consumer.PersonalData.Individual; individual != nil {
+		return individual.DocumentNumber
+	}
+	if business := consumer.PersonalData.Business; business != nil {
+		return business.DocumentNumber
+	}
+	return ""
+}
+
+func bureauError(query string, err error) error {
+	if errors.Is(err, creditbureau.ErrDocumentNotFound) || errors.Is(err, creditbureau.ErrInvalidDocument) {
+		return err
+	}
+	return fmt.Errorf("%w: %s: %v", ErrCreditBureauFailure, query, err)
+}
+
+func riskLevelFor(score, negativeRecords int) valueobjects.RiskLevel {
+	switch {
+	case negativeRecords > 0 || score < mediumRiskMinimumScore:
+		return valueobjects.RiskLevelHigh
+	case score < lowRiskMinimumScore:
+		return valueobjects.RiskLevelMedium
+	default:
+		return valueobjects.RiskLevelLow
+	}
+}
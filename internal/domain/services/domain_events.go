@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProductEventData is the payload of product.* events.
+type ProductEventData struct {
+	ProductID   string `json:"product_id"`
+	PartnerID   string `json:"partner_id"`
+	ProductType string `json:"product_type"`
+	Name        string `json:"name,omitempty"`
+}
+
+// ConsumerProductEventData is the payload of consumer.product_* events. It identifies the
+// consumer by ID only; partners fetch personal data through the API under its access rules.
+type ConsumerProductEventData struct {
+	ConsumerID string `json:"consumer_id"`
+	ProductID  string `json:"product_id"`
+	PartnerID  string `json:"partner_id"`
+	ContractID string `json:"contract_id,omitempty"`
+}
+
+// ConsumerEventData is the payload of consumer.created and consumer.user_* events.
+type ConsumerEventData struct {
+	ConsumerID      string `json:"consumer_id"`
+	UserID          string `json:"user_id,omitempty"`
+	ScreeningStatus string `json:"screening_status,omitempty"`
+}
+
+// PartnerManagerEventData is the payload of partner.manager_* events.
+type PartnerManagerEventData struct {
+	PartnerID string `json:"partner_id"`
+	UserID    string `json:"user_id"`
+}
+
+// UserEventData is the payload of user.* events. The email is left out on purpose.
+type UserEventData struct {
+	UserID      string `json:"user_id"`
+	Role        string `json:"role"`
+	ProfileType string `json:"profile_type"`
+	ProfileID   string `json:"profile_reference_id,omitempty"`
+}
+
+// EventOutbox records domain events in the outbox within the unit of work of the change they
+// describe, so an event exists if and only if its change was committed. A nil EventOutbox still
+// runs units of work and drops events.
+type EventOutbox struct {
+	uow        repositories.UnitOfWork
+	outboxRepo repositories.OutboxRepository
+}
+
+func NewEventOutbox(uow repositories.UnitOfWork, outboxRepo repositories.OutboxRepository) *EventOutbox {
+	if outboxRepo == nil {
+		return nil
+	}
+
+	return &EventOutbox{
+		uow:        uow,
+		outboxRepo: outboxRepo,
+	}
+}
+
+// WithinTransaction runs fn in a unit of work.
+func (o *EventOutbox) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if o == nil {
+		return runUnitOfWork(ctx, nil, fn)
+	}
+	return runUnitOfWork(ctx, o.uow, fn)
+}
+
+// Record appends an event to the outbox. It must be called with the context handed out by
+// WithinTransaction.
+func (o *EventOutbox) Record(ctx context.Context, eventType string, aggregateID, partnerID primitive.ObjectID, data interface{}) error {
+	if o == nil || o.outboxRepo == nil {
+		return nil
+	}
+
+	event, err := entities.NewDomainEvent(eventType, aggregateID, partnerID, data, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	return o.outboxRepo.AppendEvents(ctx, []*entities.DomainEvent{event})
+}
+
+// runUnitOfWork runs fn in a unit of work when the store supports them; otherwise fn runs
+// directly, its writes are not atomic and commit hooks run as they are registered.
+func runUnitOfWork(ctx context.Context, uow repositories.UnitOfWork, fn func(ctx context.Context) error) error {
+	if uow == nil {
+		return fn(ctx)
+	}
+	return uow.Do(ctx, fn)
+}
+
+func newProductEventData(product *entities.Product) ProductEventData {
+	return ProductEventData{
+		ProductID:   product.ID.Hex(),
+		PartnerID:   product.PartnerID.Hex(),
+		ProductType: string(product.ProductType),
+		Name:        product.Name,
+	}
+}
+
+func newContractEventData(contract *entities.Contract) ConsumerProductEventData {
+	return ConsumerProductEventData{
+		ConsumerID: contract.ConsumerID.Hex(),
+		ProductID:  contract.ProductID.Hex(),
+		PartnerID:  contract.PartnerID.Hex(),
+		ContractID: contract.ID.Hex(),
+	}
+}
+
+func newUserEventData(user *entities.User) UserEventData {
+	data := UserEventData{
+		UserID:      user.ID.Hex(),
+		Role:        user.Role.String(),
+		ProfileType: user.ProfileType.String(),
+	}
+	if !user.ProfileID.IsZero() {
+		data.ProfileID = user.ProfileID.Hex()
+	}
+	return data
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// defaultMaxInstallmentIncomePct caps the installment as a share of the consumer's monthly
+// income (or monthly revenue for businesses) when the product does not define its own limit.
+var defaultMaxInstallmentIncomePct = valueobjects.MustParseRate("30")
+
+// indexValues holds the latest stored value of each rate index, used to project floating rates.
+type indexValues map[valueobjects.RateIndex]*entities.IndexValue
+
+// loadIndexValues reads the latest value of every index. Without a repository it returns an
+// empty set, which makes floating-rate products ineligible.
+func loadIndexValues(ctx context.Context, repo repositories.IndexSeriesRepository) (indexValues, error) {
+	values := make(indexValues, len(valueobjects.RateIndexes))
+	if repo == nil {
+		return values, nil
+	}
+
+	for _, index := range valueobjects.RateIndexes {
+		value, err := repo.GetLatestIndexValue(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			values[index] = value
+		}
+	}
+	return values, nil
+}
+
+// eligibility is the outcome of checking one product against one consumer. Attributes are the
+// product's base attributes with the matching pricing tier applied and, for floating-rate
+// products, InterestRate projected from IndexValue.
+type eligibility struct {
+	Attributes         entities.BaseProductAttributes
+	Tier               *entities.PricingTier
+	IndexValue         *entities.IndexValue
+	Quote              entities.LoanQuote
+	ProcessingTimeDays int
+	Reasons            []string
+}
+
+func (e eligibility) Eligible() bool {
+	return len(e.Reasons) == 0
+}
+
+// assessEligibility selects the pricing tier for the consumer, prices the amount and term with
+// it and collects every rule the request fails. The partner is optional.
+func assessEligibility(consumer *entities.Consumer, partner *entities.Partner, product *entities.Product, amount valueobjects.Money, termMonths int, indexes indexValues) eligibility {
+	var result eligibility
+
+	switch {
+	case consumer.Type.IsIndividual() && product.Category == valueobjects.ProductCategoryBusiness:
+		result.Reasons = append(result.Reasons, "product is restricted to business consumers")
+	case consumer.Type.IsBusiness() && product.Category == valueobjects.ProductCategoryPersonal:
+		result.Reasons = append(result.Reasons, "product is restricted to individual consumers")
+	}
+
+	if strings.EqualFold(consumer.CreditProfile.RiskLevel, string(valueobjects.RiskLevelHigh)) && product.ProductType.GetRiskLevel() == valueobjects.RiskLevelHigh {
+		result.Reasons = append(result.Reasons, "high risk products are not offered to high risk consumers")
+	}
+
+	attrs, tier, err := product.PricingFor(consumer.CreditProfile)
+	if errors.Is(err, entities.ErrNoMatchingPricingTier) {
+		result.Reasons = append(result.Reasons, "no pricing tier covers the consumer credit score and risk level")
+		return result
+	}
+	result.Tier = tier
+
+	if attrs.IndexedRate != nil {
+		value := indexes[attrs.IndexedRate.Index]
+		projected, err := attrs.WithIndexValue(value)
+		if err != nil {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("no stored value for index %s to project the rate", attrs.IndexedRate.Index))
+			return result
+		}
+		attrs = projected
+		result.IndexValue = value
+	}
+	result.Attributes = attrs
+
+	if !attrs.InterestRate.IsPositive() {
+		result.Reasons = append(result.Reasons, "product does not define an interest rate for installment credit")
+		return result
+	}
+
+	tierLabel := "product"
+	if tier != nil {
+		tierLabel = fmt.Sprintf("tier %s", tier.Name)
+	}
+	if attrs.MinAmount.IsPositive() && amount.LessThan(attrs.MinAmount) {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("amount below product minimum of %s", attrs.MinAmount))
+	}
+	if attrs.MaxAmount.IsPositive() && amount.GreaterThan(attrs.MaxAmount) {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("amount above %s maximum of %s", tierLabel, attrs.MaxAmount))
+	}
+	if attrs.TermMonths > 0 && termMonths > attrs.TermMonths {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("term above %s maximum of %d months", tierLabel, attrs.TermMonths))
+	}
+
+	if partner != nil {
+		partnerAttrs, _ := partner.Attributes.BaseAttributes(partner.Type)
+		if partnerAttrs.MinimumLoanAmount.IsPositive() && amount.LessThan(partnerAttrs.MinimumLoanAmount) {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("amount below partner minimum of %s", partnerAttrs.MinimumLoanAmount))
+		}
+		if partnerAttrs.MaximumLoanAmount.IsPositive() && amount.GreaterThan(partnerAttrs.MaximumLoanAmount) {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("amount above partner maximum of %s", partnerAttrs.MaximumLoanAmount))
+		}
+		result.ProcessingTimeDays = partnerAttrs.ProcessingTimeDays
+	}
+
+	quote, err := entities.NewLoanQuote(amount, termMonths, attrs)
+	if err != nil {
+		result.Reasons = append(result.Reasons, err.Error())
+		return result
+	}
+	result.Quote = quote
+
+	income := monthlyIncome(consumer)
+	limitPct := defaultMaxInstallmentIncomePct
+	if payroll := product.Attributes.PayrollLoan; product.ProductType == valueobjects.ProductTypePayrollLoan && payroll != nil && payroll.MaximumInstallmentPct.IsPositive() {
+		limitPct = payroll.MaximumInstallmentPct
+	}
+	if !income.IsPositive() {
+		result.Reasons = append(result.Reasons, "consumer income is not informed")
+	} else if quote.Installment.GreaterThan(income.Percent(limitPct)) {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("installment of %s exceeds %s%% of monthly income", quote.Installment, limitPct))
+	}
+
+	return result
+}
+
+func monthlyIncome(consumer *entities.Consumer) valueobjects.Money {
+	if consumer.Type.IsBusiness() {
+		return consumer.CreditProfile.AnnualRevenue.Div(12)
+	}
+	return consumer.CreditProfile.MonthlyIncome
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"katseye/internal/domain/entities"
+)
+
+// AllEvents subscribes an in-process handler to every event type.
+const AllEvents = "*"
+
+// EventHandler reacts in process to a domain event published by the relay.
+type EventHandler func(ctx context.Context, event *entities.DomainEvent) error
+
+// EventBus is the in-process event sink. A handler error fails the event for the whole bus, so
+// every handler of the event runs again on the retry and must be idempotent.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+var _ EventSink = (*EventBus)(nil)
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[string][]EventHandler)}
+}
+
+// Subscribe registers handler for the event type, or for every type with AllEvents.
+func (b *EventBus) Subscribe(eventType string, handler EventHandler) {
+	if b == nil || handler == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *EventBus) Name() string {
+	return "in_process"
+}
+
+// Publish calls the handlers subscribed to the event, in subscription order, and returns their
+// errors joined.
+func (b *EventBus) Publish(ctx context.Context, event *entities.DomainEvent) error {
+	if b == nil || event == nil {
+		return nil
+	}
+
+	b.mu.RLock()
+	handlers := append(append([]EventHandler(nil), b.handlers[event.Type]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+)
+
+var ErrOutboxRepositoryUnavailable = errors.New("outbox repository unavailable")
+
+// EventSink receives the domain events published by the relay. Name is stored in the outbox to
+// remember which sinks accepted an event, so it must not change between releases. Events may be
+// published more than once and out of order after retries; sinks and their consumers should
+// deduplicate by event ID.
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, event *entities.DomainEvent) error
+}
+
+// EventRelayPolicy controls how the relay retries events a sink rejected. The wait doubles
+// after each failed pass, from InitialBackoff up to MaxBackoff; after MaxAttempts the event is
+// marked failed and left in the outbox.
+type EventRelayPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Lease is how long a claimed event stays hidden from other relays while it is published.
+	Lease     time.Duration
+	BatchSize int
+}
+
+func DefaultEventRelayPolicy() EventRelayPolicy {
+	return EventRelayPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: 5 * time.Second,
+		MaxBackoff:     time.Hour,
+		Lease:          time.Minute,
+		BatchSize:      100,
+	}
+}
+
+// EventRelayReport summarizes one pass over the outbox.
+type EventRelayReport struct {
+	Claimed   int
+	Published int
+	Retried   int
+	Failed    int
+}
+
+// EventRelay publishes the events waiting in the outbox to every sink.
+type EventRelay struct {
+	outboxRepo repositories.OutboxRepository
+	sinks      []EventSink
+	policy     EventRelayPolicy
+}
+
+func NewEventRelay(outboxRepo repositories.OutboxRepository, policy EventRelayPolicy, sinks ...EventSink) *EventRelay {
+	if outboxRepo == nil {
+		return nil
+	}
+
+	return &EventRelay{
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+		policy:     policy,
+	}
+}
+
+// Sinks returns the names of the configured sinks.
+func (r *EventRelay) Sinks() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.sinks))
+	for _, sink := range r.sinks {
+		names = append(names, sink.Name())
+	}
+	return names
+}
+
+// Relay publishes the events due at now. An event is done once every sink accepted it; when
+// some sink fails, the pass is retried later for the sinks that have not accepted it yet.
+func (r *EventRelay) Relay(ctx context.Context, now time.Time) (EventRelayReport, error) {
+	var report EventRelayReport
+	if r == nil || r.outboxRepo == nil {
+		return report, ErrOutboxRepositoryUnavailable
+	}
+
+	now = now.UTC()
+	events, err := r.outboxRepo.ClaimPendingEvents(ctx, now, r.policy.Lease, r.policy.BatchSize)
+	if err != nil {
+		return report, err
+	}
+
+	for _, event := range events {
+		report.Claimed++
+
+		var failures []error
+		for _, sink := range r.sinks {
+			name := sink.Name()
+			if event.IsPublishedTo(name) {
+				continue
+			}
+			if err := sink.Publish(ctx, &event.Event); err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			event.RecordSinkPublished(name)
+		}
+
+		if len(failures) == 0 {
+			event.RecordPublished(time.Now().UTC())
+			report.Published++
+		} else {
+			event.RecordFailure(errors.Join(failures...), time.Now().UTC(), r.policy.MaxAttempts, exponentialBackoff(r.policy.InitialBackoff, r.policy.MaxBackoff, event.Attempts+1))
+			if event.Status == entities.OutboxFailed {
+				report.Failed++
+			} else {
+				report.Retried++
+			}
+		}
+
+		if err := r.outboxRepo.UpdateOutboxEvent(ctx, event); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// exponentialBackoff is the wait before the given attempt: initial for the first one, doubling
+// for each later attempt, capped at max.
+func exponentialBackoff(initial, max time.Duration, attempt int) time.Duration {
+	wait := initial
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
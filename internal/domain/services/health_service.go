@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/repositories"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// HealthReport is the worst status among the components, followed by each of them.
+type HealthReport struct {
+	Status     string
+	Components []repositories.HealthComponent
+}
+
+// HealthService runs the health checks of the API's dependencies.
+type HealthService struct {
+	checks []repositories.HealthCheck
+}
+
+func NewHealthService(checks ...repositories.HealthCheck) *HealthService {
+	filtered := make([]repositories.HealthCheck, 0, len(checks))
+	for _, check := range checks {
+		if check != nil {
+			filtered = append(filtered, check)
+		}
+	}
+
+	return &HealthService{checks: filtered}
+}
+
+// Check runs every check with a short deadline, so a hanging dependency cannot hang the probe.
+func (s *HealthService) Check(ctx context.Context) HealthReport {
+	report := HealthReport{Status: repositories.HealthStatusUp}
+	if s == nil {
+		return report
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	report.Components = make([]repositories.HealthComponent, 0, len(s.checks))
+	for _, check := range s.checks {
+		component := check.Check(ctx)
+		report.Components = append(report.Components, component)
+		if healthSeverity(component.Status) > healthSeverity(report.Status) {
+			report.Status = component.Status
+		}
+	}
+
+	return report
+}
+
+func healthSeverity(status string) int {
+	switch status {
+	case repositories.HealthStatusUp:
+		return 0
+	case repositories.HealthStatusDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
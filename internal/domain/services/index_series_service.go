@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+var (
+	ErrIndexSeriesRepositoryUnavailable = errors.New("index series repository unavailable")
+	ErrInvalidIndexValue                = errors.New("invalid index value")
+)
+
+var indexSeriesDateLayouts = []string{"2006-01-02", "02/01/2006"}
+
+type IndexSeriesService struct {
+	repo repositories.IndexSeriesRepository
+}
+
+func NewIndexSeriesService(repo repositories.IndexSeriesRepository) *IndexSeriesService {
+	if repo == nil {
+		return nil
+	}
+	return &IndexSeriesService{repo: repo}
+}
+
+// RecordIndexValues validates and stores the values, truncating dates to the day. Values for a
+// date already stored replace the previous observation.
+func (s *IndexSeriesService) RecordIndexValues(ctx context.Context, values []*entities.IndexValue, source string) error {
+	if s == nil || s.repo == nil {
+		return ErrIndexSeriesRepositoryUnavailable
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("%w: no values informed", ErrInvalidIndexValue)
+	}
+
+	now := time.Now().UTC()
+	for _, value := range values {
+		if err := value.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidIndexValue, err)
+		}
+		year, month, day := value.Date.Date()
+		value.Date = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if value.Source == "" {
+			value.Source = source
+		}
+		value.RecordedAt = now
+	}
+
+	return s.repo.SaveIndexValues(ctx, values)
+}
+
+func (s *IndexSeriesService) GetLatestIndexValue(ctx context.Context, index valueobjects.RateIndex) (*entities.IndexValue, error) {
+	if s == nil || s.repo == nil {
+		return nil, ErrIndexSeriesRepositoryUnavailable
+	}
+	if err := index.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetLatestIndexValue(ctx, index)
+}
+
+func (s *IndexSeriesService) ListIndexValues(ctx context.Context, index valueobjects.RateIndex, from, to time.Time) ([]*entities.IndexValue, error) {
+	if s == nil || s.repo == nil {
+		return nil, ErrIndexSeriesRepositoryUnavailable
+	}
+	if err := index.Validate(); err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, repositories.ErrInvalidRange
+	}
+	return s.repo.ListIndexValues(ctx, index, from, to)
+}
+
+// IndexSeriesCSVOptions controls ParseIndexSeriesCSV. Index is used for files without an index
+// column, such as the Banco Central SGS exports ("data;valor").
+type IndexSeriesCSVOptions struct {
+	Index valueobjects.RateIndex
+	Comma rune
+}
+
+// ParseIndexSeriesCSV reads index values from CSV. Columns are taken from a header naming
+// index/date/rate (or indice/data/valor); without a header the file must hold index,date,rate
+// or, when options.Index is set, date,rate. Dates may be ISO or dd/mm/yyyy and rates may use a
+// decimal comma.
+func ParseIndexSeriesCSV(r io.Reader, options IndexSeriesCSVOptions) ([]*entities.IndexValue, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIndexValue, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidIndexValue)
+	}
+
+	columns, hasHeader := indexSeriesColumns(records[0])
+	if !hasHeader {
+		if options.Index != "" {
+			columns = map[string]int{"date": 0, "rate": 1}
+		} else {
+			columns = map[string]int{"index": 0, "date": 1, "rate": 2}
+		}
+	} else {
+		records = records[1:]
+	}
+	if _, ok := columns["index"]; !ok && options.Index == "" {
+		return nil, fmt.Errorf("%w: no index column and no default index informed", ErrInvalidIndexValue)
+	}
+
+	offset := 1
+	if hasHeader {
+		offset = 2
+	}
+
+	values := make([]*entities.IndexValue, 0, len(records))
+	for i, record := range records {
+		line := i + offset
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		value, err := parseIndexSeriesRecord(record, columns, options.Index)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidIndexValue, line, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func indexSeriesColumns(header []string) (map[string]int, bool) {
+	aliases := map[string]string{
+		"index": "index", "indice": "index", "índice": "index", "indexador": "index",
+		"date": "date", "data": "date",
+		"rate": "rate", "value": "rate", "valor": "rate", "taxa": "rate",
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if column, ok := aliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+
+	_, hasDate := columns["date"]
+	_, hasRate := columns["rate"]
+	return columns, hasDate && hasRate
+}
+
+func parseIndexSeriesRecord(record []string, columns map[string]int, defaultIndex valueobjects.RateIndex) (*entities.IndexValue, error) {
+	field := func(name string) (string, bool) {
+		position, ok := columns[name]
+		if !ok || position >= len(record) {
+			return "", false
+		}
+		return strings.TrimSpace(record[position]), true
+	}
+
+	value := &entities.IndexValue{Index: defaultIndex}
+
+	if raw, ok := field("index"); ok {
+		index, err := valueobjects.NewRateIndex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%v %q", err, raw)
+		}
+		value.Index = index
+	}
+
+	rawDate, ok := field("date")
+	if !ok {
+		return nil, errors.New("missing date")
+	}
+	for _, layout := range indexSeriesDateLayouts {
+		if date, err := time.Parse(layout, rawDate); err == nil {
+			value.Date = date
+			break
+		}
+	}
+	if value.Date.IsZero() {
+		return nil, fmt.Errorf("invalid date %q", rawDate)
+	}
+
+	rawRate, ok := field("rate")
+	if !ok {
+		return nil, errors.New("missing rate")
+	}
+	rate, err := valueobjects.ParseRate(rawRate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate %q", rawRate)
+	}
+	value.Rate = rate
+
+	if err := value.Validate(); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
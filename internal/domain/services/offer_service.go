@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidOfferRequest     = errors.New("invalid offer request")
+	ErrOfferServiceUnavailable = errors.New("offer service unavailable")
+)
+
+// OfferRequest describes what the consumer wants to borrow.
+type OfferRequest struct {
+	ConsumerID  primitive.ObjectID
+	Amount      valueobjects.Money
+	TermMonths  int
+	ProductType valueobjects.ProductType
+}
+
+func (r OfferRequest) Validate() error {
+	if r.ConsumerID.IsZero() {
+		return fmt.Errorf("%w: consumer id is required", ErrInvalidOfferRequest)
+	}
+	if !r.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidOfferRequest)
+	}
+	if r.TermMonths <= 0 {
+		return fmt.Errorf("%w: term must be at least one month", ErrInvalidOfferRequest)
+	}
+	if err := r.ProductType.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOfferRequest, err)
+	}
+	return nil
+}
+
+// Offer is an eligible product priced for the request. Reasons explain its position.
+type Offer struct {
+	Rank               int
+	Product            *entities.Product
+	Partner            *entities.Partner
+	Tier               *entities.PricingTier
+	IndexValue         *entities.IndexValue
+	Quote              entities.LoanQuote
+	ProcessingTimeDays int
+	Reasons            []string
+}
+
+// RejectedOffer is a candidate product that failed eligibility, with every failed rule.
+type RejectedOffer struct {
+	Product *entities.Product
+	Partner *entities.Partner
+	Reasons []string
+}
+
+type OfferResult struct {
+	Request  OfferRequest
+	Offers   []Offer
+	Rejected []RejectedOffer
+}
+
+// SimulationRequest prices a single product for a consumer.
+type SimulationRequest struct {
+	ConsumerID primitive.ObjectID
+	ProductID  primitive.ObjectID
+	Amount     valueobjects.Money
+	TermMonths int
+}
+
+func (r SimulationRequest) Validate() error {
+	if r.ConsumerID.IsZero() {
+		return fmt.Errorf("%w: consumer id is required", ErrInvalidOfferRequest)
+	}
+	if r.ProductID.IsZero() {
+		return fmt.Errorf("%w: product id is required", ErrInvalidOfferRequest)
+	}
+	if !r.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidOfferRequest)
+	}
+	if r.TermMonths <= 0 {
+		return fmt.Errorf("%w: term must be at least one month", ErrInvalidOfferRequest)
+	}
+	return nil
+}
+
+// Simulation is the quote of a product under the pricing tier that matches the consumer. When
+// the consumer is not eligible, Reasons lists every failed rule and Quote may be empty.
+// IndexedRate and IndexValue describe how a floating rate was projected.
+type Simulation struct {
+	Request     SimulationRequest
+	Product     *entities.Product
+	Partner     *entities.Partner
+	Tier        *entities.PricingTier
+	IndexedRate *entities.IndexedRate
+	IndexValue  *entities.IndexValue
+	Quote       entities.LoanQuote
+	Eligible    bool
+	Reasons     []string
+}
+
+type OfferService struct {
+	consumerRepo repositories.ConsumerRepository
+	partnerRepo  repositories.PartnerRepository
+	productRepo  repositories.ProductRepository
+	indexRepo    repositories.IndexSeriesRepository
+}
+
+// NewOfferService builds the offer service. The index series repository is optional; without it
+// floating-rate products are rejected.
+func NewOfferService(
+	consumerRepo repositories.ConsumerRepository,
+	partnerRepo repositories.PartnerRepository,
+	productRepo repositories.ProductRepository,
+	indexRepo repositories.IndexSeriesRepository,
+) *OfferService {
+	if consumerRepo == nil || partnerRepo == nil || productRepo == nil {
+		return nil
+	}
+
+	return &OfferService{
+		consumerRepo: consumerRepo,
+		partnerRepo:  partnerRepo,
+		productRepo:  productRepo,
+		indexRepo:    indexRepo,
+	}
+}
+
+// FindBestOffers evaluates every product of the requested type offered by partners that accept
+// it and ranks the eligible ones by CET, then installment, then partner processing time.
+func (s *OfferService) FindBestOffers(ctx context.Context, request OfferRequest) (*OfferResult, error) {
+	if s == nil {
+		return nil, ErrOfferServiceUnavailable
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, request.ConsumerID)
+	if err != nil {
+		return nil, err
+	}
+	if consumer == nil {
+		return nil, ErrConsumerNotFound
+	}
+	if consumer.IsErased() {
+		return nil, entities.ErrConsumerErased
+	}
+
+	partners, err := s.acceptingPartners(ctx, request.ProductType)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := loadIndexValues(ctx, s.indexRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OfferResult{Request: request}
+	for _, partner := range partners {
+		products, err := s.partnerProducts(ctx, partner.ID, request.ProductType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range products {
+			offer, reasons := evaluateOffer(consumer, partner, product, request, indexes)
+			if len(reasons) > 0 {
+				result.Rejected = append(result.Rejected, RejectedOffer{Product: product, Partner: partner, Reasons: reasons})
+				continue
+			}
+			result.Offers = append(result.Offers, offer)
+		}
+	}
+
+	rankOffers(result.Offers)
+
+	return result, nil
+}
+
+// Simulate prices one product for the consumer using the pricing tier that matches their credit
+// profile and reports whether they are eligible for it.
+func (s *OfferService) Simulate(ctx context.Context, request SimulationRequest) (*Simulation, error) {
+	if s == nil {
+		return nil, ErrOfferServiceUnavailable
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, request.ConsumerID)
+	if err != nil {
+		return nil, err
+	}
+	if consumer == nil {
+		return nil, ErrConsumerNotFound
+	}
+	if consumer.IsErased() {
+		return nil, entities.ErrConsumerErased
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, ErrProductNotFound
+	}
+
+	partner, err := s.partnerRepo.GetPartnerByID(ctx, product.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := loadIndexValues(ctx, s.indexRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment := assessEligibility(consumer, partner, product, request.Amount, request.TermMonths, indexes)
+
+	return &Simulation{
+		Request:     request,
+		Product:     product,
+		Partner:     partner,
+		Tier:        assessment.Tier,
+		IndexedRate: assessment.Attributes.IndexedRate,
+		IndexValue:  assessment.IndexValue,
+		Quote:       assessment.Quote,
+		Eligible:    assessment.Eligible(),
+		Reasons:     assessment.Reasons,
+	}, nil
+}
+
+func (s *OfferService) acceptingPartners(ctx context.Context, productType valueobjects.ProductType) ([]*entities.Partner, error) {
+	query := repositories.PartnerQuery{
+		AcceptedType: productType,
+		Page:         repositories.Pagination{Limit: repositories.MaxPageLimit},
+	}
+
+	var partners []*entities.Partner
+	for {
+		page, info, err := s.partnerRepo.ListPartners(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		partners = append(partners, page...)
+
+		if !info.HasMore {
+			return partners, nil
+		}
+		query.Page.Cursor = info.NextCursor
+	}
+}
+
+func (s *OfferService) partnerProducts(ctx context.Context, partnerID primitive.ObjectID, productType valueobjects.ProductType) ([]*entities.Product, error) {
+	query := repositories.ProductQuery{
+		PartnerID:   partnerID,
+		ProductType: productType,
+		Page:        repositories.Pagination{Limit: repositories.MaxPageLimit},
+	}
+
+	var products []*entities.Product
+	for {
+		page, info, err := s.productRepo.ListProducts(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, page...)
+
+		if !info.HasMore {
+			return products, nil
+		}
+		query.Page.Cursor = info.NextCursor
+	}
+}
+
+// evaluateOffer prices the product and returns every eligibility rule it fails.
+func evaluateOffer(consumer *entities.Consumer, partner *entities.Partner, product *entities.Product, request OfferRequest, indexes indexValues) (Offer, []string) {
+	assessment := assessEligibility(consumer, partner, product, request.Amount, request.TermMonths, indexes)
+	if !assessment.Eligible() {
+		return Offer{}, assessment.Reasons
+	}
+
+	return Offer{
+		Product:            product,
+		Partner:            partner,
+		Tier:               assessment.Tier,
+		IndexValue:         assessment.IndexValue,
+		Quote:              assessment.Quote,
+		ProcessingTimeDays: assessment.ProcessingTimeDays,
+	}, nil
+}
+
+// rankOffers orders offers by CET, installment and processing time (unknown times last) and
+// records, for each one, how it compares to the best offer and why it beat the next one.
+func rankOffers(offers []Offer) {
+	processingDays := func(offer Offer) int {
+		if offer.ProcessingTimeDays <= 0 {
+			return int(^uint(0) >> 1)
+		}
+		return offer.ProcessingTimeDays
+	}
+
+	sort.SliceStable(offers, func(i, j int) bool {
+		a, b := offers[i], offers[j]
+		if a.Quote.CETMonthly != b.Quote.CETMonthly {
+			return a.Quote.CETMonthly.LessThan(b.Quote.CETMonthly)
+		}
+		if a.Quote.Installment != b.Quote.Installment {
+			return a.Quote.Installment.LessThan(b.Quote.Installment)
+		}
+		if processingDays(a) != processingDays(b) {
+			return processingDays(a) < processingDays(b)
+		}
+		return a.Product.ID.Hex() < b.Product.ID.Hex()
+	})
+
+	if len(offers) == 0 {
+		return
+	}
+
+	best := offers[0].Quote
+	for i := range offers {
+		offer := &offers[i]
+		offer.Rank = i + 1
+
+		if offer.Quote.CETMonthly == best.CETMonthly {
+			offer.Reasons = append(offer.Reasons, fmt.Sprintf("lowest CET: %s%% a.m. (%s%% a.a.)", offer.Quote.CETMonthly, offer.Quote.CETAnnual.Round(2)))
+		} else {
+			offer.Reasons = append(offer.Reasons, fmt.Sprintf("CET %s%% a.m. is %s p.p. above the best offer", offer.Quote.CETMonthly, offer.Quote.CETMonthly.Sub(best.CETMonthly)))
+		}
+
+		// The best offer is the one with the lowest CET, so a later offer may have a lower installment.
+		switch {
+		case offer.Quote.Installment == best.Installment:
+			offer.Reasons = append(offer.Reasons, fmt.Sprintf("installment of %s matches the best offer", offer.Quote.Installment))
+		case offer.Quote.Installment.LessThan(best.Installment):
+			offer.Reasons = append(offer.Reasons, fmt.Sprintf("installment of %s is %s below the best offer", offer.Quote.Installment, best.Installment.Sub(offer.Quote.Installment)))
+		default:
+			offer.Reasons = append(offer.Reasons, fmt.Sprintf("installment of %s is %s above the best offer", offer.Quote.Installment, offer.Quote.Installment.Sub(best.Installment)))
+		}
+
+		if offer.ProcessingTimeDays > 0 {
+			offer.Reasons = append(offer.Reasons, fmt.Sprintf("partner processes requests in %d days", offer.ProcessingTimeDays))
+		} else {
+			offer.Reasons = append(offer.Reasons, "partner processing time not informed")
+		}
+
+		if i+1 < len(offers) {
+			next := offers[i+1]
+			switch {
+			case offer.Quote.CETMonthly.LessThan(next.Quote.CETMonthly):
+				offer.Reasons = append(offer.Reasons, fmt.Sprintf("ranked above #%d on CET", i+2))
+			case offer.Quote.Installment.LessThan(next.Quote.Installment):
+				offer.Reasons = append(offer.Reasons, fmt.Sprintf("tied with #%d on CET, ranked above on installment", i+2))
+			case processingDays(*offer) < processingDays(next):
+				offer.Reasons = append(offer.Reasons, fmt.Sprintf("tied with #%d on CET and installment, ranked above on processing time", i+2))
+			}
+		}
+	}
+}
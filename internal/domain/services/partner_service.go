@@ -18,11 +18,13 @@ var (
 
 type PartnerService struct {
 	partnerRepo repositories.PartnerRepository
+	events      *EventOutbox
 }
 
-func NewPartnerService(partnerRepo repositories.PartnerRepository) *PartnerService {
+func NewPartnerService(partnerRepo repositories.PartnerRepository, events *EventOutbox) *PartnerService {
 	return &PartnerService{
 		partnerRepo: partnerRepo,
+		events:      events,
 	}
 }
 
@@ -64,8 +66,12 @@ func (s *PartnerService) DeletePartner(ctx context.Context, id primitive.ObjectI
 	return s.partnerRepo.DeletePartner(ctx, id)
 }
 
-func (s *PartnerService) ListPartners(ctx context.Context, filter map[string]interface{}) ([]*entities.Partner, error) {
-	return s.partnerRepo.ListPartners(ctx, filter)
+func (s *PartnerService) ListPartners(ctx context.Context, query repositories.PartnerQuery) ([]*entities.Partner, repositories.PageInfo, error) {
+	if err := query.Validate(); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return s.partnerRepo.ListPartners(ctx, query)
 }
 
 func (s *PartnerService) AssignManagerProfile(ctx context.Context, partnerID, userID primitive.ObjectID) error {
@@ -79,25 +85,30 @@ func (s *PartnerService) AssignManagerProfile(ctx context.Context, partnerID, us
 		return errors.New("user id is required")
 	}
 
-	partner, err := s.partnerRepo.GetPartnerByID(ctx, partnerID)
-	if err != nil {
-		return err
-	}
-	if partner == nil {
-		return ErrPartnerNotFound
-	}
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		partner, err := s.partnerRepo.GetPartnerByID(ctx, partnerID)
+		if err != nil {
+			return err
+		}
+		if partner == nil {
+			return ErrPartnerNotFound
+		}
 
-	if partner.HasManagerProfile(userID) {
-		return ErrPartnerManagerAlreadyLinked
-	}
+		if partner.HasManagerProfile(userID) {
+			return ErrPartnerManagerAlreadyLinked
+		}
 
-	partner.ManagerProfileIDs = append(partner.ManagerProfileIDs, userID)
+		partner.ManagerProfileIDs = append(partner.ManagerProfileIDs, userID)
 
-	if err := partner.Validate(); err != nil {
-		return err
-	}
+		if err := partner.Validate(); err != nil {
+			return err
+		}
 
-	return s.partnerRepo.UpdatePartner(ctx, partner)
+		if err := s.partnerRepo.UpdatePartner(ctx, partner); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventPartnerManagerAssigned, partnerID, partnerID, PartnerManagerEventData{PartnerID: partnerID.Hex(), UserID: userID.Hex()})
+	})
 }
 
 func (s *PartnerService) RemoveManagerProfile(ctx context.Context, partnerID, userID primitive.ObjectID) error {
@@ -111,27 +122,32 @@ func (s *PartnerService) RemoveManagerProfile(ctx context.Context, partnerID, us
 		return errors.New("user id is required")
 	}
 
-	partner, err := s.partnerRepo.GetPartnerByID(ctx, partnerID)
-	if err != nil {
-		return err
-	}
-	if partner == nil {
-		return ErrPartnerNotFound
-	}
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		partner, err := s.partnerRepo.GetPartnerByID(ctx, partnerID)
+		if err != nil {
+			return err
+		}
+		if partner == nil {
+			return ErrPartnerNotFound
+		}
 
-	if !partner.HasManagerProfile(userID) {
-		return ErrPartnerManagerNotLinked
-	}
+		if !partner.HasManagerProfile(userID) {
+			return ErrPartnerManagerNotLinked
+		}
 
-	if len(partner.ManagerProfileIDs) <= 1 {
-		return ErrPartnerManagerRequired
-	}
+		if len(partner.ManagerProfileIDs) <= 1 {
+			return ErrPartnerManagerRequired
+		}
 
-	partner.RemoveManagerProfile(userID)
+		partner.RemoveManagerProfile(userID)
 
-	if err := partner.Validate(); err != nil {
-		return err
-	}
+		if err := partner.Validate(); err != nil {
+			return err
+		}
 
-	return s.partnerRepo.UpdatePartner(ctx, partner)
+		if err := s.partnerRepo.UpdatePartner(ctx, partner); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventPartnerManagerRemoved, partnerID, partnerID, PartnerManagerEventData{PartnerID: partnerID.Hex(), UserID: userID.Hex()})
+	})
 }
@@ -0,0 +1,564 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ErasureActionDeleted       = "deleted"
+	ErasureActionPseudonymized = "pseudonymized"
+	ErasureActionRetained      = "retained"
+
+	ErasureSignatureAlgorithm = "HMAC-SHA256"
+
+	retentionReasonFinancialRecords = "retained to comply with legal and regulatory record-keeping obligations for credit operations (LGPD art. 16, I; Lei 9.613/1998 art. 10)"
+	retentionReasonActiveContracts  = "retained while credit contracts remain linked to the data subject (LGPD art. 7, V and art. 16, I)"
+	retentionReasonAccountability   = "retained as evidence of the processing operations performed over the data subject (LGPD art. 37)"
+	retentionReasonPseudonymized    = "record kept in pseudonymized form to preserve the integrity of retained financial records (LGPD art. 13, par. 4)"
+)
+
+var (
+	ErrPrivacySigningKeyUnavailable = errors.New("privacy report signing key not configured")
+	ErrConsumerAlreadyErased        = errors.New("consumer personal data already erased")
+)
+
+// ConsumerDataExport aggregates every record held about a consumer for data portability requests.
+type ConsumerDataExport struct {
+	GeneratedAt time.Time
+	Consumer    *entities.Consumer
+	Addresses   []*entities.Address
+	User        *entities.User
+	// ContractedProducts are the catalog entries linked to the consumer; Contracts hold the
+	// terms and pricing tier recorded when each product was contracted.
+	ContractedProducts []*entities.Product
+	Contracts          []*entities.Contract
+	AuditEvents        []*entities.AuditEvent
+}
+
+// ErasureReportItem describes what happened to a single record during an erasure request.
+type ErasureReportItem struct {
+	Resource string   `json:"resource"`
+	ID       string   `json:"id"`
+	Action   string   `json:"action"`
+	Fields   []string `json:"fields,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+// ErasureReport is the signed outcome of an erasure request.
+type ErasureReport struct {
+	ConsumerID         primitive.ObjectID
+	RequestedBy        primitive.ObjectID
+	CompletedAt        time.Time
+	Items              []ErasureReportItem
+	SignatureAlgorithm string
+	Signature          string
+}
+
+type erasureReportPayload struct {
+	ConsumerID  string              `json:"consumer_id"`
+	RequestedBy string              `json:"requested_by,omitempty"`
+	CompletedAt string              `json:"completed_at"`
+	Items       []ErasureReportItem `json:"items"`
+}
+
+type PrivacyService struct {
+	consumerRepo repositories.ConsumerRepository
+	addressRepo  repositories.AddressRepository
+	userRepo     repositories.UserRepository
+	productRepo  repositories.ProductRepository
+	contractRepo repositories.ContractRepository
+	auditRepo    repositories.AuditRepository
+	signalRepo   repositories.ScreeningSignalRepository
+	tokens       *TokenService
+	uow          repositories.UnitOfWork
+	signingKey   []byte
+}
+
+func NewPrivacyService(
+	consumerRepo repositories.ConsumerRepository,
+	addressRepo repositories.AddressRepository,
+	userRepo repositories.UserRepository,
+	productRepo repositories.ProductRepository,
+	contractRepo repositories.ContractRepository,
+	auditRepo repositories.AuditRepository,
+	signalRepo repositories.ScreeningSignalRepository,
+	tokens *TokenService,
+	uow repositories.UnitOfWork,
+	signingKey string,
+) *PrivacyService {
+	if consumerRepo == nil {
+		return nil
+	}
+
+	return &PrivacyService{
+		consumerRepo: consumerRepo,
+		addressRepo:  addressRepo,
+		userRepo:     userRepo,
+		productRepo:  productRepo,
+		contractRepo: contractRepo,
+		auditRepo:    auditRepo,
+		signalRepo:   signalRepo,
+		tokens:       tokens,
+		uow:          uow,
+		signingKey:   []byte(strings.TrimSpace(signingKey)),
+	}
+}
+
+// ExportConsumerData assembles the consumer record together with every linked resource.
+func (s *PrivacyService) ExportConsumerData(ctx context.Context, consumerID, actorID primitive.ObjectID) (*ConsumerDataExport, error) {
+	if s == nil || s.consumerRepo == nil {
+		return nil, ErrConsumerRepositoryUnavailable
+	}
+	if consumerID.IsZero() {
+		return nil, errors.New("consumer id is required")
+	}
+
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, consumerID)
+	if err != nil {
+		return nil, err
+	}
+	if consumer == nil {
+		return nil, ErrConsumerNotFound
+	}
+
+	addresses, err := s.loadAddresses(ctx, consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.loadUser(ctx, consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.loadContractedProducts(ctx, consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	var contracts []*entities.Contract
+	if s.contractRepo != nil {
+		contracts, err = s.contractRepo.ListContractsByConsumer(ctx, consumer.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.recordEvent(ctx, consumer.ID, actorID, entities.AuditActionDataExported, nil); err != nil {
+		return nil, err
+	}
+
+	var events []*entities.AuditEvent
+	if s.auditRepo != nil {
+		events, err = s.auditRepo.ListEventsBySubject(ctx, entities.AuditSubjectConsumer, consumer.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ConsumerDataExport{
+		GeneratedAt:        time.Now().UTC(),
+		Consumer:           consumer,
+		Addresses:          addresses,
+		User:               user,
+		ContractedProducts: products,
+		Contracts:          contracts,
+		AuditEvents:        events,
+	}, nil
+}
+
+// EraseConsumerData pseudonymizes the personal data of a consumer, deletes records that are no
+// longer needed and returns a signed report describing each decision. The writes run in one
+// unit of work, so a failed erasure leaves the consumer untouched.
+func (s *PrivacyService) EraseConsumerData(ctx context.Context, consumerID, actorID primitive.ObjectID) (*ErasureReport, error) {
+	if s == nil || s.consumerRepo == nil {
+		return nil, ErrConsumerRepositoryUnavailable
+	}
+	if len(s.signingKey) == 0 {
+		return nil, ErrPrivacySigningKeyUnavailable
+	}
+	if consumerID.IsZero() {
+		return nil, errors.New("consumer id is required")
+	}
+
+	consumer, err := s.erasableConsumer(ctx, consumerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The linked user's tokens are revoked before anything is written, so none outlives the
+	// erasure; should it fail, the user stays signed out until the revocation lapses.
+	if consumer.HasLinkedUser() && s.tokens != nil {
+		if err := s.tokens.RevokeSubject(ctx, consumer.UserID.Hex()); err != nil {
+			return nil, err
+		}
+	}
+
+	var report *ErasureReport
+	err = runUnitOfWork(ctx, s.uow, func(ctx context.Context) error {
+		consumer, err := s.erasableConsumer(ctx, consumerID)
+		if err != nil {
+			return err
+		}
+		report, err = s.erase(ctx, consumer, actorID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *PrivacyService) erasableConsumer(ctx context.Context, consumerID primitive.ObjectID) (*entities.Consumer, error) {
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, consumerID)
+	if err != nil {
+		return nil, err
+	}
+	if consumer == nil {
+		return nil, ErrConsumerNotFound
+	}
+	if consumer.IsErased() {
+		return nil, ErrConsumerAlreadyErased
+	}
+	return consumer, nil
+}
+
+func (s *PrivacyService) erase(ctx context.Context, consumer *entities.Consumer, actorID primitive.ObjectID) (*ErasureReport, error) {
+	now := time.Now().UTC()
+	report := &ErasureReport{
+		ConsumerID:         consumer.ID,
+		RequestedBy:        actorID,
+		CompletedAt:        now,
+		SignatureAlgorithm: ErasureSignatureAlgorithm,
+	}
+
+	if consumer.HasLinkedUser() {
+		item, err := s.eraseLinkedUser(ctx, consumer.UserID)
+		if err != nil {
+			return nil, err
+		}
+		report.Items = append(report.Items, item)
+		consumer.UserID = primitive.NilObjectID
+	}
+
+	addressItems, err := s.eraseAddresses(ctx, consumer)
+	if err != nil {
+		return nil, err
+	}
+	report.Items = append(report.Items, addressItems...)
+
+	if s.signalRepo != nil {
+		item, err := s.eraseSignals(ctx, consumer.ID)
+		if err != nil {
+			return nil, err
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	documentToken := s.documentToken(consumer)
+	fields, err := consumer.Pseudonymize(s.pseudonym(consumer.ID), documentToken, now)
+	if err != nil {
+		if errors.Is(err, entities.ErrConsumerErased) {
+			return nil, ErrConsumerAlreadyErased
+		}
+		return nil, err
+	}
+
+	if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+		return nil, err
+	}
+
+	report.Items = append(report.Items,
+		ErasureReportItem{
+			Resource: "consumer",
+			ID:       consumer.ID.Hex(),
+			Action:   ErasureActionPseudonymized,
+			Fields:   fields,
+			Reason:   retentionReasonPseudonymized,
+		},
+		ErasureReportItem{
+			Resource: "credit_profile",
+			ID:       consumer.ID.Hex(),
+			Action:   ErasureActionRetained,
+			Reason:   retentionReasonFinancialRecords,
+		},
+	)
+
+	for _, productID := range consumer.ContractedProducts {
+		report.Items = append(report.Items, ErasureReportItem{
+			Resource: "contracted_product",
+			ID:       productID.Hex(),
+			Action:   ErasureActionRetained,
+			Reason:   retentionReasonFinancialRecords,
+		})
+	}
+
+	if s.auditRepo != nil {
+		report.Items = append(report.Items, ErasureReportItem{
+			Resource: "audit_events",
+			ID:       consumer.ID.Hex(),
+			Action:   ErasureActionRetained,
+			Reason:   retentionReasonAccountability,
+		})
+	}
+
+	signature, err := s.signReport(report)
+	if err != nil {
+		return nil, err
+	}
+	report.Signature = signature
+
+	details := map[string]string{
+		"signature_algorithm": report.SignatureAlgorithm,
+		"signature":           signature,
+	}
+	if err := s.recordEvent(ctx, consumer.ID, actorID, entities.AuditActionDataErased, details); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// VerifyErasureReport reports whether the signature matches the report contents.
+func (s *PrivacyService) VerifyErasureReport(report *ErasureReport) bool {
+	if s == nil || report == nil || len(s.signingKey) == 0 {
+		return false
+	}
+
+	expected, err := s.signReport(report)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(expected), []byte(report.Signature))
+}
+
+func (s *PrivacyService) loadAddresses(ctx context.Context, consumer *entities.Consumer) ([]*entities.Address, error) {
+	if s.addressRepo == nil {
+		return nil, nil
+	}
+
+	var addresses []*entities.Address
+	for _, id := range consumerAddressIDs(consumer) {
+		address, err := s.addressRepo.GetAddressByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if address != nil {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses, nil
+}
+
+func (s *PrivacyService) loadUser(ctx context.Context, consumer *entities.Consumer) (*entities.User, error) {
+	if s.userRepo == nil || !consumer.HasLinkedUser() {
+		return nil, nil
+	}
+
+	user, err := s.userRepo.FindByID(ctx, consumer.UserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *PrivacyService) loadContractedProducts(ctx context.Context, consumer *entities.Consumer) ([]*entities.Product, error) {
+	if s.productRepo == nil {
+		return nil, nil
+	}
+
+	var products []*entities.Product
+	for _, id := range consumer.ContractedProducts {
+		product, err := s.productRepo.GetProductByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if product != nil {
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+func (s *PrivacyService) eraseLinkedUser(ctx context.Context, userID primitive.ObjectID) (ErasureReportItem, error) {
+	item := ErasureReportItem{
+		Resource: "user",
+		ID:       userID.Hex(),
+		Action:   ErasureActionDeleted,
+		Fields:   []string{"email", "password_hash", "permissions"},
+	}
+
+	if s.userRepo == nil {
+		return item, ErrInvalidUserData
+	}
+
+	if err := s.userRepo.DeleteUser(ctx, userID); err != nil && !errors.Is(err, repositories.ErrUserNotFound) {
+		return item, err
+	}
+
+	return item, nil
+}
+
+// eraseSignals deletes the fingerprints screening recorded for the consumer. They are derived
+// from the erased contact data and document, so they go with it.
+func (s *PrivacyService) eraseSignals(ctx context.Context, consumerID primitive.ObjectID) (ErasureReportItem, error) {
+	item := ErasureReportItem{
+		Resource: "screening_signals",
+		ID:       consumerID.Hex(),
+		Action:   ErasureActionDeleted,
+		Fields:   []string{"email", "phone", "address", "document_number"},
+	}
+
+	if _, err := s.signalRepo.DeleteConsumerSignals(ctx, consumerID); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+func (s *PrivacyService) eraseAddresses(ctx context.Context, consumer *entities.Consumer) ([]ErasureReportItem, error) {
+	ids := consumerAddressIDs(consumer)
+	items := make([]ErasureReportItem, 0, len(ids))
+
+	if len(consumer.ContractedProducts) > 0 || s.addressRepo == nil {
+		for _, id := range ids {
+			items = append(items, ErasureReportItem{
+				Resource: "address",
+				ID:       id.Hex(),
+				Action:   ErasureActionRetained,
+				Reason:   retentionReasonActiveContracts,
+			})
+		}
+		return items, nil
+	}
+
+	for _, id := range ids {
+		if err := s.addressRepo.DeleteAddress(ctx, id); err != nil {
+			return nil, err
+		}
+		items = append(items, ErasureReportItem{
+			Resource: "address",
+			ID:       id.Hex(),
+			Action:   ErasureActionDeleted,
+		})
+	}
+
+	return items, nil
+}
+
+func (s *PrivacyService) recordEvent(ctx context.Context, consumerID, actorID primitive.ObjectID, action string, details map[string]string) error {
+	if s.auditRepo == nil {
+		return nil
+	}
+
+	event := &entities.AuditEvent{
+		ID:          primitive.NewObjectID(),
+		SubjectType: entities.AuditSubjectConsumer,
+		SubjectID:   consumerID,
+		Action:      action,
+		ActorID:     actorID,
+		Details:     details,
+		OccurredAt:  time.Now().UTC(),
+	}
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	return s.auditRepo.RecordEvent(ctx, event)
+}
+
+func (s *PrivacyService) pseudonym(consumerID primitive.ObjectID) string {
+	return "titular-" + s.keyedDigest("consumer:" + consumerID.Hex())[:16]
+}
+
+func (s *PrivacyService) documentToken(consumer *entities.Consumer) string {
+	var document string
+	switch {
+	case consumer.PersonalData.Individual != nil:
+		document = consumer.PersonalData.Individual.DocumentNumber
+	case consumer.PersonalData.Business != nil:
+		document = consumer.PersonalData.Business.DocumentNumber
+	}
+
+	return "pseudo:" + s.keyedDigest("document:" + onlyDigits(document))[:32]
+}
+
+func (s *PrivacyService) keyedDigest(value string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *PrivacyService) signReport(report *ErasureReport) (string, error) {
+	if len(s.signingKey) == 0 {
+		return "", ErrPrivacySigningKeyUnavailable
+	}
+
+	payload := erasureReportPayload{
+		ConsumerID:  report.ConsumerID.Hex(),
+		CompletedAt: report.CompletedAt.UTC().Format(time.RFC3339Nano),
+		Items:       report.Items,
+	}
+	if !report.RequestedBy.IsZero() {
+		payload.RequestedBy = report.RequestedBy.Hex()
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write(encoded)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func consumerAddressIDs(consumer *entities.Consumer) []primitive.ObjectID {
+	if consumer == nil {
+		return nil
+	}
+
+	seen := make(map[primitive.ObjectID]struct{}, len(consumer.AdditionalAddressIDs)+1)
+	ids := make([]primitive.ObjectID, 0, len(consumer.AdditionalAddressIDs)+1)
+	for _, id := range append([]primitive.ObjectID{consumer.PrimaryAddressID}, consumer.AdditionalAddressIDs...) {
+		if id.IsZero() {
+			continue
+		}
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func onlyDigits(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/persistence/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testSigningKey = "privacy-signing-key"
+
+type privacyFixture struct {
+	consumers repositories.ConsumerRepository
+	addresses repositories.AddressRepository
+	users     repositories.UserRepository
+	tokens    *TokenService
+}
+
+func newPrivacyFixture() privacyFixture {
+	return privacyFixture{
+		consumers: memory.NewConsumerRepository(),
+		addresses: memory.NewAddressRepository(),
+		users:     memory.NewUserRepository(),
+		tokens:    NewTokenService(memory.NewTokenStore()),
+	}
+}
+
+func (f privacyFixture) service(consumers repositories.ConsumerRepository, signingKey string) *PrivacyService {
+	return NewPrivacyService(consumers, f.addresses, f.users, nil, nil, nil, nil, f.tokens, nil, signingKey)
+}
+
+// newErasableConsumer stores a consumer with two addresses and a linked user.
+func (f privacyFixture) newErasableConsumer(t *testing.T, contracted ...primitive.ObjectID) *entities.Consumer {
+	t.Helper()
+	ctx := context.Background()
+
+	var addressIDs []primitive.ObjectID
+	for _, number := range []string{"100", "200"} {
+		address := &entities.Address{
+			ID:         primitive.NewObjectID(),
+			Country:    "BR",
+			State:      "SP",
+			City:       "São Paulo",
+			Street:     "Rua Augusta",
+			Number:     number,
+			PostalCode: "01305-000",
+		}
+		if err := f.addresses.CreateAddress(ctx, address); err != nil {
+			t.Fatalf("CreateAddress returned error: %v", err)
+		}
+		addressIDs = append(addressIDs, address.ID)
+	}
+
+	consumer := &entities.Consumer{
+		ID:   primitive.NewObjectID(),
+		Type: valueobjects.ConsumerTypeIndividual,
+		PersonalData: entities.ConsumerPersonalData{Individual: &entities.ConsumerIndividualData{
+			FullName:       "Maria Silva",
+			DocumentNumber: "529.982.247-25",
+			BirthDate:      time.Date(1988, time.May, 17, 0, 0, 0, 0, time.UTC),
+		}},
+		CreditProfile:        entities.ConsumerCreditProfile{MonthlyIncome: valueobjects.MustParseMoney("8500")},
+		Contact:              entities.ConsumerContactInformation{Email: "maria@example.com", Phone: "+5511988887777"},
+		PrimaryAddressID:     addressIDs[0],
+		AdditionalAddressIDs: addressIDs[1:],
+		ContractedProducts:   contracted,
+	}
+
+	user, err := NewAuthService(f.users, nil).CreateUser(ctx, "maria@example.com", "s3cret-password", true, entities.RoleUser, nil, entities.ProfileTypeConsumer, consumer.ID)
+	if err != nil {
+		t.Fatalf("CreateUser returned error: %v", err)
+	}
+	consumer.UserID = user.ID
+
+	if err := f.consumers.CreateConsumer(ctx, consumer); err != nil {
+		t.Fatalf("CreateConsumer returned error: %v", err)
+	}
+	return consumer
+}
+
+func TestPrivacyService_EraseConsumerData(t *testing.T) {
+	tests := []struct {
+		name          string
+		contracted    []primitive.ObjectID
+		addressAction string
+	}{
+		{
+			name:          "consumer with contracts keeps the addresses",
+			contracted:    []primitive.ObjectID{primitive.NewObjectID()},
+			addressAction: ErasureActionRetained,
+		},
+		{
+			name:          "consumer without contracts has the addresses deleted",
+			addressAction: ErasureActionDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fixture := newPrivacyFixture()
+			consumer := fixture.newErasableConsumer(t, tt.contracted...)
+			service := fixture.service(fixture.consumers, testSigningKey)
+
+			report, err := service.EraseConsumerData(ctx, consumer.ID, primitive.NewObjectID())
+			if err != nil {
+				t.Fatalf("EraseConsumerData returned error: %v", err)
+			}
+			if !service.VerifyErasureReport(report) {
+				t.Fatalf("expected the report signature to verify")
+			}
+
+			actions := map[string]string{}
+			for _, item := range report.Items {
+				actions[item.Resource+":"+item.ID] = item.Action
+			}
+			for _, id := range consumerAddressIDs(consumer) {
+				if got := actions["address:"+id.Hex()]; got != tt.addressAction {
+					t.Fatalf("expected address %s to be %s, got %q", id.Hex(), tt.addressAction, got)
+				}
+				address, err := fixture.addresses.GetAddressByID(ctx, id)
+				if err != nil {
+					t.Fatalf("GetAddressByID returned error: %v", err)
+				}
+				if kept := address != nil; kept != (tt.addressAction == ErasureActionRetained) {
+					t.Fatalf("expected address %s kept=%t, got %+v", id.Hex(), !kept, address)
+				}
+			}
+			if got := actions["user:"+consumer.UserID.Hex()]; got != ErasureActionDeleted {
+				t.Fatalf("expected the linked user to be deleted, got %q", got)
+			}
+			if user, _ := fixture.users.FindByID(ctx, consumer.UserID); user != nil {
+				t.Fatalf("expected the linked user to be gone, found %+v", user)
+			}
+			if revoked, err := fixture.tokens.IsSubjectRevoked(ctx, consumer.UserID.Hex()); err != nil || !revoked {
+				t.Fatalf("expected the linked user's tokens to be revoked, got (%t, %v)", revoked, err)
+			}
+
+			stored, err := fixture.consumers.GetConsumerByID(ctx, consumer.ID)
+			if err != nil {
+				t.Fatalf("GetConsumerByID returned error: %v", err)
+			}
+			individual := stored.PersonalData.Individual
+			if !stored.IsErased() || !stored.UserID.IsZero() || stored.Contact != (entities.ConsumerContactInformation{}) {
+				t.Fatalf("expected an erased consumer without user or contact, got %+v", stored)
+			}
+			if !strings.HasPrefix(individual.FullName, "titular-") || !strings.HasPrefix(individual.DocumentNumber, "pseudo:") {
+				t.Fatalf("expected pseudonymized identifiers, got name %q document %q", individual.FullName, individual.DocumentNumber)
+			}
+			if stored.CreditProfile.MonthlyIncome != consumer.CreditProfile.MonthlyIncome {
+				t.Fatalf("expected the credit profile to be retained, got income %s", stored.CreditProfile.MonthlyIncome)
+			}
+
+			if _, err := service.EraseConsumerData(ctx, consumer.ID, primitive.NewObjectID()); !errors.Is(err, ErrConsumerAlreadyErased) {
+				t.Fatalf("expected ErrConsumerAlreadyErased on a second erasure, got %v", err)
+			}
+		})
+	}
+}
+
+// revocationProbe records whether the consumer's user was already signed out when the
+// pseudonymized consumer is written.
+type revocationProbe struct {
+	repositories.ConsumerRepository
+	tokens  *TokenService
+	revoked *bool
+}
+
+func (p revocationProbe) UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	stored, err := p.GetConsumerByID(ctx, consumer.ID)
+	if err != nil {
+		return err
+	}
+	if *p.revoked, err = p.tokens.IsSubjectRevoked(ctx, stored.UserID.Hex()); err != nil {
+		return err
+	}
+	return p.ConsumerRepository.UpdateConsumer(ctx, consumer)
+}
+
+func TestPrivacyService_EraseConsumerDataRevokesTokensFirst(t *testing.T) {
+	fixture := newPrivacyFixture()
+	consumer := fixture.newErasableConsumer(t)
+
+	var revoked bool
+	service := fixture.service(revocationProbe{fixture.consumers, fixture.tokens, &revoked}, testSigningKey)
+	if _, err := service.EraseConsumerData(context.Background(), consumer.ID, primitive.NewObjectID()); err != nil {
+		t.Fatalf("EraseConsumerData returned error: %v", err)
+	}
+	if !revoked {
+		t.Fatalf("expected the user's tokens to be revoked before the consumer is pseudonymized")
+	}
+}
+
+func TestPrivacyService_VerifyErasureReport(t *testing.T) {
+	fixture := newPrivacyFixture()
+	consumer := fixture.newErasableConsumer(t)
+	service := fixture.service(fixture.consumers, testSigningKey)
+
+	report, err := service.EraseConsumerData(context.Background(), consumer.ID, primitive.NewObjectID())
+	if err != nil {
+		t.Fatalf("EraseConsumerData returned error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		verify *PrivacyService
+		tamper func(*ErasureReport)
+		want   bool
+	}{
+		{name: "untouched report", verify: service, want: true},
+		{
+			name:   "action changed",
+			verify: service,
+			tamper: func(report *ErasureReport) { report.Items[0].Action = ErasureActionRetained },
+		},
+		{
+			name:   "item removed",
+			verify: service,
+			tamper: func(report *ErasureReport) { report.Items = report.Items[1:] },
+		},
+		{
+			name:   "completion time changed",
+			verify: service,
+			tamper: func(report *ErasureReport) { report.CompletedAt = report.CompletedAt.Add(-time.Hour) },
+		},
+		{
+			name:   "requester changed",
+			verify: service,
+			tamper: func(report *ErasureReport) { report.RequestedBy = primitive.NewObjectID() },
+		},
+		{name: "signed with another key", verify: fixture.service(fixture.consumers, "another-key")},
+		{name: "no signing key", verify: fixture.service(fixture.consumers, "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copied := *report
+			copied.Items = append([]ErasureReportItem(nil), report.Items...)
+			if tt.tamper != nil {
+				tt.tamper(&copied)
+			}
+			if got := tt.verify.VerifyErasureReport(&copied); got != tt.want {
+				t.Fatalf("expected VerifyErasureReport to return %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
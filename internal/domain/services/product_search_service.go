@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"katseye/internal/domain/repositories"
+)
+
+var ErrProductSearchUnavailable = errors.New("product search unavailable")
+
+type ProductSearchService struct {
+	index repositories.ProductSearchIndex
+}
+
+func NewProductSearchService(index repositories.ProductSearchIndex) *ProductSearchService {
+	if index == nil {
+		return nil
+	}
+
+	return &ProductSearchService{index: index}
+}
+
+func (s *ProductSearchService) SearchProducts(ctx context.Context, query repositories.ProductSearchQuery) (repositories.ProductSearchResult, error) {
+	if s == nil || s.index == nil {
+		return repositories.ProductSearchResult{}, ErrProductSearchUnavailable
+	}
+
+	if err := query.Validate(); err != nil {
+		return repositories.ProductSearchResult{}, err
+	}
+
+	return s.index.SearchProducts(ctx, query)
+}
@@ -13,9 +13,10 @@ import (
 type ProductService struct {
 	productRepo repositories.ProductRepository
 	partnerRepo repositories.PartnerRepository
+	events      *EventOutbox
 }
 
-func NewProductService(productRepo repositories.ProductRepository, partnerRepo repositories.PartnerRepository) *ProductService {
+func NewProductService(productRepo repositories.ProductRepository, partnerRepo repositories.PartnerRepository, events *EventOutbox) *ProductService {
 	if productRepo == nil {
 		return nil
 	}
@@ -23,6 +24,7 @@ func NewProductService(productRepo repositories.ProductRepository, partnerRepo r
 	return &ProductService{
 		productRepo: productRepo,
 		partnerRepo: partnerRepo,
+		events:      events,
 	}
 }
 
@@ -48,7 +50,12 @@ func (s *ProductService) CreateProduct(ctx context.Context, product *entities.Pr
 	if product.ID.IsZero() {
 		product.ID = primitive.NewObjectID()
 	}
-	return s.productRepo.CreateProduct(ctx, product)
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.CreateProduct(ctx, product); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventProductCreated, product.ID, product.PartnerID, newProductEventData(product))
+	})
 }
 
 func (s *ProductService) UpdateProduct(ctx context.Context, product *entities.Product) error {
@@ -65,15 +72,37 @@ func (s *ProductService) UpdateProduct(ctx context.Context, product *entities.Pr
 		return err
 	}
 
-	return s.productRepo.UpdateProduct(ctx, product)
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.UpdateProduct(ctx, product); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, entities.EventProductUpdated, product.ID, product.PartnerID, newProductEventData(product))
+	})
 }
 
 func (s *ProductService) DeleteProduct(ctx context.Context, id primitive.ObjectID) error {
-	return s.productRepo.DeleteProduct(ctx, id)
+	return s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		// The product is loaded first so the event can still name its partner once it is gone.
+		product, err := s.productRepo.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.productRepo.DeleteProduct(ctx, id); err != nil {
+			return err
+		}
+		if product == nil {
+			return nil
+		}
+		return s.events.Record(ctx, entities.EventProductDeleted, product.ID, product.PartnerID, newProductEventData(product))
+	})
 }
 
-func (s *ProductService) ListProducts(ctx context.Context, filter map[string]interface{}) ([]*entities.Product, error) {
-	return s.productRepo.ListProducts(ctx, filter)
+func (s *ProductService) ListProducts(ctx context.Context, query repositories.ProductQuery) ([]*entities.Product, repositories.PageInfo, error) {
+	if err := query.Validate(); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return s.productRepo.ListProducts(ctx, query)
 }
 
 var (
@@ -87,19 +87,19 @@ func buildPersonalLoanTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			PersonalLoan: &entities.PersonalLoanAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          2.3,
+					InterestRate:          valueobjects.MustParseRate("2.3"),
 					TermMonths:            36,
-					MinAmount:             1000,
-					MaxAmount:             50000,
-					ProcessingFee:         1.5,
+					MinAmount:             valueobjects.MustParseMoney("1000"),
+					MaxAmount:             valueobjects.MustParseMoney("50000"),
+					ProcessingFee:         valueobjects.MustParseRate("1.5"),
 					EarlyRepaymentAllowed: true,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentIDProof,
 						valueobjects.DocumentIncomeProof,
 					},
 					GracePeriodDays: 30,
-					IofRate:         0.38,
-					CETRate:         2.9,
+					IofRate:         valueobjects.MustParseRate("0.38"),
+					CETRate:         valueobjects.MustParseRate("2.9"),
 				},
 				CreditAnalysisRequired: true,
 				SalaryTransferRequired: false,
@@ -118,11 +118,11 @@ func buildPayrollLoanTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			PayrollLoan: &entities.PayrollLoanAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          1.9,
+					InterestRate:          valueobjects.MustParseRate("1.9"),
 					TermMonths:            60,
-					MinAmount:             500,
-					MaxAmount:             80000,
-					ProcessingFee:         0,
+					MinAmount:             valueobjects.MustParseMoney("500"),
+					MaxAmount:             valueobjects.MustParseMoney("80000"),
+					ProcessingFee:         valueobjects.MustParseRate("0"),
 					EarlyRepaymentAllowed: true,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentIDProof,
@@ -130,7 +130,7 @@ func buildPayrollLoanTemplate() ProductTemplate {
 					},
 				},
 				DiscountOnPayroll:     true,
-				MaximumInstallmentPct: 35,
+				MaximumInstallmentPct: valueobjects.MustParseRate("35"),
 				OnlyForPublicServants: false,
 				RetirementBenefit:     true,
 			},
@@ -145,12 +145,12 @@ func buildCreditCardTemplate() ProductTemplate {
 		Category:    "credit",
 		Attributes: entities.ProductAttributes{
 			CreditCard: &entities.CreditCardAttributes{
-				AnnualFee:           150,
-				CreditLimit:         10000,
+				AnnualFee:           valueobjects.MustParseMoney("150"),
+				CreditLimit:         valueobjects.MustParseMoney("10000"),
 				GracePeriod:         40,
-				RevolvingInterest:   12.5,
-				CashAdvanceInterest: 9.9,
-				CashAdvanceFee:      3.9,
+				RevolvingInterest:   valueobjects.MustParseRate("12.5"),
+				CashAdvanceInterest: valueobjects.MustParseRate("9.9"),
+				CashAdvanceFee:      valueobjects.MustParseMoney("3.9"),
 				InternationalUse:    true,
 				RewardsProgram:      true,
 				InsuranceIncluded:   false,
@@ -166,11 +166,11 @@ func buildOverdraftCreditTemplate() ProductTemplate {
 		Category:    "credit",
 		Attributes: entities.ProductAttributes{
 			OverdraftCredit: &entities.BaseProductAttributes{
-				InterestRate:          3.9,
+				InterestRate:          valueobjects.MustParseRate("3.9"),
 				TermMonths:            12,
-				MinAmount:             100,
-				MaxAmount:             20000,
-				ProcessingFee:         0,
+				MinAmount:             valueobjects.MustParseMoney("100"),
+				MaxAmount:             valueobjects.MustParseMoney("20000"),
+				ProcessingFee:         valueobjects.MustParseRate("0"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -189,11 +189,11 @@ func buildVehicleFinancingTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			VehicleFinancing: &entities.VehicleFinancingAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          1.6,
+					InterestRate:          valueobjects.MustParseRate("1.6"),
 					TermMonths:            48,
-					MinAmount:             5000,
-					MaxAmount:             150000,
-					ProcessingFee:         1.2,
+					MinAmount:             valueobjects.MustParseMoney("5000"),
+					MaxAmount:             valueobjects.MustParseMoney("150000"),
+					ProcessingFee:         valueobjects.MustParseRate("1.2"),
 					EarlyRepaymentAllowed: true,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentIDProof,
@@ -201,7 +201,7 @@ func buildVehicleFinancingTemplate() ProductTemplate {
 					},
 				},
 				DownPaymentRequired: true,
-				MinDownPaymentPct:   20,
+				MinDownPaymentPct:   valueobjects.MustParseRate("20"),
 				VehicleAgeLimit:     8,
 				InsuranceRequired:   true,
 				BalloonPayment:      false,
@@ -218,11 +218,11 @@ func buildMortgageLoanTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			MortgageLoan: &entities.MortgageLoanAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          1.4,
+					InterestRate:          valueobjects.MustParseRate("1.4"),
 					TermMonths:            240,
-					MinAmount:             50000,
-					MaxAmount:             1000000,
-					ProcessingFee:         0.9,
+					MinAmount:             valueobjects.MustParseMoney("50000"),
+					MaxAmount:             valueobjects.MustParseMoney("1000000"),
+					ProcessingFee:         valueobjects.MustParseRate("0.9"),
 					EarlyRepaymentAllowed: true,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentIDProof,
@@ -230,9 +230,9 @@ func buildMortgageLoanTemplate() ProductTemplate {
 						valueobjects.DocumentFinancialStatements,
 					},
 				},
-				PropertyValue:             300000,
-				MinDownPaymentPct:         20,
-				LtvRatio:                  80,
+				PropertyValue:             valueobjects.MustParseMoney("300000"),
+				MinDownPaymentPct:         valueobjects.MustParseRate("20"),
+				LtvRatio:                  valueobjects.MustParseRate("80"),
 				PropertyInsuranceRequired: true,
 				AppraisalRequired:         true,
 				FixedRatePeriod:           36,
@@ -248,11 +248,11 @@ func buildSecuredLoanTemplate() ProductTemplate {
 		Category:    "credit",
 		Attributes: entities.ProductAttributes{
 			SecuredLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.7,
+				InterestRate:          valueobjects.MustParseRate("1.7"),
 				TermMonths:            60,
-				MinAmount:             10000,
-				MaxAmount:             300000,
-				ProcessingFee:         0.5,
+				MinAmount:             valueobjects.MustParseMoney("10000"),
+				MaxAmount:             valueobjects.MustParseMoney("300000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.5"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -270,11 +270,11 @@ func buildMicrocreditLoanTemplate() ProductTemplate {
 		Category:    "credit",
 		Attributes: entities.ProductAttributes{
 			MicrocreditLoan: &entities.BaseProductAttributes{
-				InterestRate:          2.1,
+				InterestRate:          valueobjects.MustParseRate("2.1"),
 				TermMonths:            24,
-				MinAmount:             500,
-				MaxAmount:             15000,
-				ProcessingFee:         0.3,
+				MinAmount:             valueobjects.MustParseMoney("500"),
+				MaxAmount:             valueobjects.MustParseMoney("15000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.3"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -292,11 +292,11 @@ func buildFGTSLoanTemplate() ProductTemplate {
 		Category:    "credit",
 		Attributes: entities.ProductAttributes{
 			FGTSLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.2,
+				InterestRate:          valueobjects.MustParseRate("1.2"),
 				TermMonths:            24,
-				MinAmount:             500,
-				MaxAmount:             30000,
-				ProcessingFee:         0,
+				MinAmount:             valueobjects.MustParseMoney("500"),
+				MaxAmount:             valueobjects.MustParseMoney("30000"),
+				ProcessingFee:         valueobjects.MustParseRate("0"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -314,11 +314,11 @@ func buildIRPFLoanTemplate() ProductTemplate {
 		Category:    "credit",
 		Attributes: entities.ProductAttributes{
 			IRPFLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.4,
+				InterestRate:          valueobjects.MustParseRate("1.4"),
 				TermMonths:            12,
-				MinAmount:             500,
-				MaxAmount:             20000,
-				ProcessingFee:         0,
+				MinAmount:             valueobjects.MustParseMoney("500"),
+				MaxAmount:             valueobjects.MustParseMoney("20000"),
+				ProcessingFee:         valueobjects.MustParseRate("0"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -337,11 +337,11 @@ func buildWorkingCapitalTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			WorkingCapital: &entities.WorkingCapitalAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          1.8,
+					InterestRate:          valueobjects.MustParseRate("1.8"),
 					TermMonths:            24,
-					MinAmount:             10000,
-					MaxAmount:             500000,
-					ProcessingFee:         0.8,
+					MinAmount:             valueobjects.MustParseMoney("10000"),
+					MaxAmount:             valueobjects.MustParseMoney("500000"),
+					ProcessingFee:         valueobjects.MustParseRate("0.8"),
 					EarlyRepaymentAllowed: true,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentCNPJ,
@@ -349,7 +349,7 @@ func buildWorkingCapitalTemplate() ProductTemplate {
 					},
 				},
 				BusinessAgeRequirement:   12,
-				AnnualRevenueRequirement: 200000,
+				AnnualRevenueRequirement: valueobjects.MustParseMoney("200000"),
 				CollateralRequired:       false,
 				ReceivablesAsCollateral:  false,
 			},
@@ -364,11 +364,11 @@ func buildReceivablesAdvanceTemplate() ProductTemplate {
 		Category:    "business",
 		Attributes: entities.ProductAttributes{
 			ReceivablesAdvance: &entities.BaseProductAttributes{
-				InterestRate:          1.3,
+				InterestRate:          valueobjects.MustParseRate("1.3"),
 				TermMonths:            6,
-				MinAmount:             2000,
-				MaxAmount:             250000,
-				ProcessingFee:         0.4,
+				MinAmount:             valueobjects.MustParseMoney("2000"),
+				MaxAmount:             valueobjects.MustParseMoney("250000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.4"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentCNPJ,
@@ -387,11 +387,11 @@ func buildSecuredOverdraftTemplate() ProductTemplate {
 		Category:    "business",
 		Attributes: entities.ProductAttributes{
 			SecuredOverdraft: &entities.BaseProductAttributes{
-				InterestRate:          2.4,
+				InterestRate:          valueobjects.MustParseRate("2.4"),
 				TermMonths:            18,
-				MinAmount:             1000,
-				MaxAmount:             100000,
-				ProcessingFee:         0.2,
+				MinAmount:             valueobjects.MustParseMoney("1000"),
+				MaxAmount:             valueobjects.MustParseMoney("100000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.2"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -410,11 +410,11 @@ func buildInvestmentFinancingTemplate() ProductTemplate {
 		Category:    "business",
 		Attributes: entities.ProductAttributes{
 			InvestmentFinancing: &entities.BaseProductAttributes{
-				InterestRate:          1.6,
+				InterestRate:          valueobjects.MustParseRate("1.6"),
 				TermMonths:            84,
-				MinAmount:             50000,
-				MaxAmount:             800000,
-				ProcessingFee:         0.6,
+				MinAmount:             valueobjects.MustParseMoney("50000"),
+				MaxAmount:             valueobjects.MustParseMoney("800000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.6"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentCNPJ,
@@ -433,11 +433,11 @@ func buildBNDESLoanTemplate() ProductTemplate {
 		Category:    "business",
 		Attributes: entities.ProductAttributes{
 			BNDESLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.1,
+				InterestRate:          valueobjects.MustParseRate("1.1"),
 				TermMonths:            120,
-				MinAmount:             100000,
-				MaxAmount:             2000000,
-				ProcessingFee:         0.5,
+				MinAmount:             valueobjects.MustParseMoney("100000"),
+				MaxAmount:             valueobjects.MustParseMoney("2000000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.5"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentCNPJ,
@@ -456,11 +456,11 @@ func buildAgriculturalCreditTemplate() ProductTemplate {
 		Category:    "agribusiness",
 		Attributes: entities.ProductAttributes{
 			AgriculturalCredit: &entities.BaseProductAttributes{
-				InterestRate:          1.3,
+				InterestRate:          valueobjects.MustParseRate("1.3"),
 				TermMonths:            48,
-				MinAmount:             20000,
-				MaxAmount:             700000,
-				ProcessingFee:         0.3,
+				MinAmount:             valueobjects.MustParseMoney("20000"),
+				MaxAmount:             valueobjects.MustParseMoney("700000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.3"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentCNPJ,
@@ -479,11 +479,11 @@ func buildLeasingContractTemplate() ProductTemplate {
 		Category:    "business",
 		Attributes: entities.ProductAttributes{
 			LeasingContract: &entities.BaseProductAttributes{
-				InterestRate:          1.5,
+				InterestRate:          valueobjects.MustParseRate("1.5"),
 				TermMonths:            60,
-				MinAmount:             10000,
-				MaxAmount:             500000,
-				ProcessingFee:         0.7,
+				MinAmount:             valueobjects.MustParseMoney("10000"),
+				MaxAmount:             valueobjects.MustParseMoney("500000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.7"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -502,11 +502,11 @@ func buildStudentLoanTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			StudentLoan: &entities.StudentLoanAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          0.9,
+					InterestRate:          valueobjects.MustParseRate("0.9"),
 					TermMonths:            72,
-					MinAmount:             1000,
-					MaxAmount:             100000,
-					ProcessingFee:         0,
+					MinAmount:             valueobjects.MustParseMoney("1000"),
+					MaxAmount:             valueobjects.MustParseMoney("100000"),
+					ProcessingFee:         valueobjects.MustParseRate("0"),
 					EarlyRepaymentAllowed: false,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentStudentID,
@@ -531,19 +531,19 @@ func buildGreenLoanTemplate() ProductTemplate {
 		Attributes: entities.ProductAttributes{
 			GreenLoan: &entities.GreenLoanAttributes{
 				BaseProductAttributes: entities.BaseProductAttributes{
-					InterestRate:          1.2,
+					InterestRate:          valueobjects.MustParseRate("1.2"),
 					TermMonths:            60,
-					MinAmount:             500,
-					MaxAmount:             50000,
-					ProcessingFee:         0,
+					MinAmount:             valueobjects.MustParseMoney("500"),
+					MaxAmount:             valueobjects.MustParseMoney("50000"),
+					ProcessingFee:         valueobjects.MustParseRate("0"),
 					EarlyRepaymentAllowed: true,
 					RequiredDocuments: []valueobjects.RequiredDocument{
 						valueobjects.DocumentIDProof,
 						valueobjects.DocumentIncomeProof,
 					},
 					GracePeriodDays: 30,
-					IofRate:         0.38,
-					CETRate:         2.9,
+					IofRate:         valueobjects.MustParseRate("0.38"),
+					CETRate:         valueobjects.MustParseRate("2.9"),
 				},
 				EcoFriendlyCategory:   valueobjects.EcoFriendlyCategoryGreen,
 				CertificationRequired: valueobjects.CertificationRequiredTrue,
@@ -561,11 +561,11 @@ func buildSolarEnergyLoanTemplate() ProductTemplate {
 		Category:    "sustainability",
 		Attributes: entities.ProductAttributes{
 			SolarEnergyLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.1,
+				InterestRate:          valueobjects.MustParseRate("1.1"),
 				TermMonths:            72,
-				MinAmount:             5000,
-				MaxAmount:             200000,
-				ProcessingFee:         0.4,
+				MinAmount:             valueobjects.MustParseMoney("5000"),
+				MaxAmount:             valueobjects.MustParseMoney("200000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.4"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -584,11 +584,11 @@ func buildFintechLoanTemplate() ProductTemplate {
 		Category:    "innovation",
 		Attributes: entities.ProductAttributes{
 			FintechLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.7,
+				InterestRate:          valueobjects.MustParseRate("1.7"),
 				TermMonths:            36,
-				MinAmount:             1000,
-				MaxAmount:             100000,
-				ProcessingFee:         0.2,
+				MinAmount:             valueobjects.MustParseMoney("1000"),
+				MaxAmount:             valueobjects.MustParseMoney("100000"),
+				ProcessingFee:         valueobjects.MustParseRate("0.2"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
@@ -606,11 +606,11 @@ func buildMicrocreditSolidaryLoanTemplate() ProductTemplate {
 		Category:    "social",
 		Attributes: entities.ProductAttributes{
 			MicrocreditSolidaryLoan: &entities.BaseProductAttributes{
-				InterestRate:          1.5,
+				InterestRate:          valueobjects.MustParseRate("1.5"),
 				TermMonths:            18,
-				MinAmount:             300,
-				MaxAmount:             5000,
-				ProcessingFee:         0,
+				MinAmount:             valueobjects.MustParseMoney("300"),
+				MaxAmount:             valueobjects.MustParseMoney("5000"),
+				ProcessingFee:         valueobjects.MustParseRate("0"),
 				EarlyRepaymentAllowed: true,
 				RequiredDocuments: []valueobjects.RequiredDocument{
 					valueobjects.DocumentIDProof,
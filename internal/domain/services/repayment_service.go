@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInstallmentRepositoryUnavailable = errors.New("installment repository unavailable")
+	ErrPaymentRepositoryUnavailable     = errors.New("payment repository unavailable")
+	ErrContractNotFound                 = errors.New("contract not found")
+	ErrContractNotActive                = errors.New("contract is not active")
+	ErrContractWithoutInstallments      = errors.New("contract has no installments")
+	ErrInvalidPayment                   = errors.New("invalid payment")
+	ErrEarlyRepaymentNotAllowed         = errors.New("product does not allow early repayment")
+	ErrPaymentExceedsBalance            = errors.New("payment exceeds the contract balance")
+	ErrAgingInProgress                  = errors.New("installment aging is running in another process")
+)
+
+const (
+	// paymentAttempts bounds how often a payment is reapplied after losing a race for an
+	// installment inside a unit of work.
+	paymentAttempts = 3
+
+	agingLease    = "installment_aging"
+	agingLeaseTTL = 30 * time.Minute
+)
+
+// PaymentRequest posts money received for a contract. An early repayment without amount settles
+// the whole contract. PaidAt defaults to now.
+type PaymentRequest struct {
+	Type   entities.PaymentType
+	Amount valueobjects.Money
+	PaidAt time.Time
+}
+
+func (r PaymentRequest) Validate() error {
+	if err := r.Type.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+	}
+	if r.Amount.IsNegative() {
+		return fmt.Errorf("%w: amount cannot be negative", ErrInvalidPayment)
+	}
+	if r.Type == entities.PaymentTypeRegular && r.Amount.IsZero() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidPayment)
+	}
+	if r.PaidAt.After(time.Now()) {
+		return fmt.Errorf("%w: paid_at cannot be in the future", ErrInvalidPayment)
+	}
+	return nil
+}
+
+// AgingReport summarizes one run of the aging job. Skipped counts installments changed by a
+// payment while the job ran; the payment aged them itself.
+type AgingReport struct {
+	AsOf      time.Time
+	Checked   int
+	Updated   int
+	Skipped   int
+	Consumers int
+}
+
+// RepaymentService tracks the installments of contracts: payments, late charges and the
+// consumer's outstanding debt, which is kept equal to what is still owed on open installments.
+type RepaymentService struct {
+	installmentRepo repositories.InstallmentRepository
+	contractRepo    repositories.ContractRepository
+	paymentRepo     repositories.PaymentRepository
+	consumerRepo    repositories.ConsumerRepository
+	uow             repositories.UnitOfWork
+	lease           repositories.JobLease
+	policy          entities.LateChargePolicy
+}
+
+// NewRepaymentService builds the service. Payments run in uow when the store has one, and lease,
+// when set, keeps the aging job to one process at a time.
+func NewRepaymentService(
+	installmentRepo repositories.InstallmentRepository,
+	contractRepo repositories.ContractRepository,
+	paymentRepo repositories.PaymentRepository,
+	consumerRepo repositories.ConsumerRepository,
+	uow repositories.UnitOfWork,
+	lease repositories.JobLease,
+) *RepaymentService {
+	if installmentRepo == nil {
+		return nil
+	}
+
+	return &RepaymentService{
+		installmentRepo: installmentRepo,
+		contractRepo:    contractRepo,
+		paymentRepo:     paymentRepo,
+		consumerRepo:    consumerRepo,
+		uow:             uow,
+		lease:           lease,
+		policy:          entities.DefaultLateChargePolicy,
+	}
+}
+
+// ListInstallments returns the installments of one of the consumer's contracts.
+func (s *RepaymentService) ListInstallments(ctx context.Context, consumerID, contractID primitive.ObjectID) ([]*entities.Installment, error) {
+	if s == nil || s.installmentRepo == nil {
+		return nil, ErrInstallmentRepositoryUnavailable
+	}
+	if _, err := s.consumerContract(ctx, consumerID, contractID); err != nil {
+		return nil, err
+	}
+
+	return s.installmentRepo.ListInstallmentsByContract(ctx, contractID)
+}
+
+// ListPayments returns the payments posted to one of the consumer's contracts.
+func (s *RepaymentService) ListPayments(ctx context.Context, consumerID, contractID primitive.ObjectID) ([]*entities.Payment, error) {
+	if s == nil || s.installmentRepo == nil {
+		return nil, ErrInstallmentRepositoryUnavailable
+	}
+	if s.paymentRepo == nil {
+		return nil, ErrPaymentRepositoryUnavailable
+	}
+	if _, err := s.consumerContract(ctx, consumerID, contractID); err != nil {
+		return nil, err
+	}
+
+	return s.paymentRepo.ListPaymentsByContract(ctx, contractID)
+}
+
+// PostPayment applies a payment to the contract's installments. Late charges are brought up to
+// the payment date first. Regular payments settle installments in order and may be partial; early
+// repayments require a product that allows them.
+//
+// Installments are written only if unchanged since they were read. Inside a unit of work a
+// payment that lost such a race is rolled back and applied again to the fresh installments;
+// without one the conflict is returned as repositories.ErrConcurrentUpdate, and installments
+// written before it keep their update.
+func (s *RepaymentService) PostPayment(ctx context.Context, consumerID, contractID primitive.ObjectID, request PaymentRequest) (*entities.Payment, error) {
+	if s == nil || s.installmentRepo == nil {
+		return nil, ErrInstallmentRepositoryUnavailable
+	}
+	if s.paymentRepo == nil {
+		return nil, ErrPaymentRepositoryUnavailable
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		var payment *entities.Payment
+		err := runUnitOfWork(ctx, s.uow, func(ctx context.Context) error {
+			var err error
+			payment, err = s.applyPayment(ctx, consumerID, contractID, request)
+			return err
+		})
+		if err == nil {
+			return payment, nil
+		}
+		if s.uow == nil || attempt == paymentAttempts || !errors.Is(err, repositories.ErrConcurrentUpdate) {
+			return nil, err
+		}
+	}
+}
+
+func (s *RepaymentService) applyPayment(ctx context.Context, consumerID, contractID primitive.ObjectID, request PaymentRequest) (*entities.Payment, error) {
+	contract, err := s.consumerContract(ctx, consumerID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if !contract.IsActive() {
+		return nil, ErrContractNotActive
+	}
+	if request.Type == entities.PaymentTypeEarlyRepayment && !contract.EarlyRepaymentAllowed {
+		return nil, ErrEarlyRepaymentNotAllowed
+	}
+
+	installments, err := s.installmentRepo.ListInstallmentsByContract(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if len(installments) == 0 {
+		return nil, ErrContractWithoutInstallments
+	}
+
+	now := time.Now().UTC()
+	paidAt := request.PaidAt.UTC()
+	if request.PaidAt.IsZero() {
+		paidAt = now
+	}
+
+	changed := make(map[int]bool, len(installments))
+	for _, installment := range installments {
+		changed[installment.Number] = installment.Age(paidAt, s.policy)
+	}
+
+	balance := payableBalance(installments, request.Type, paidAt)
+	amount := request.Amount
+	if amount.IsZero() {
+		amount = balance
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: nothing is owed on the contract", ErrInvalidPayment)
+	}
+	if amount.GreaterThan(balance) {
+		return nil, fmt.Errorf("%w: at most %s can be paid", ErrPaymentExceedsBalance, balance)
+	}
+
+	payment := &entities.Payment{
+		ID:         primitive.NewObjectID(),
+		ContractID: contract.ID,
+		ConsumerID: contract.ConsumerID,
+		Type:       request.Type,
+		Amount:     amount,
+		PaidAt:     paidAt,
+		CreatedAt:  now,
+	}
+	payment.Allocations = allocatePayment(installments, request.Type, amount, paidAt)
+	for _, allocation := range payment.Allocations {
+		changed[allocation.InstallmentNumber] = true
+	}
+
+	for _, installment := range installments {
+		if !changed[installment.Number] {
+			continue
+		}
+		if err := s.installmentRepo.UpdateInstallment(ctx, installment); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.paymentRepo.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	if settled(installments) && s.contractRepo != nil {
+		contract.Settle(paidAt)
+		if err := s.contractRepo.UpdateContract(ctx, contract); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.refreshOutstandingDebt(ctx, contract.ConsumerID); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// AgeInstallments is the daily aging job: it marks installments due before asOf as overdue,
+// accrues their late charges and refreshes the outstanding debt of the affected consumers.
+// Running it twice for the same day changes nothing. While another process holds the aging
+// lease it returns ErrAgingInProgress.
+func (s *RepaymentService) AgeInstallments(ctx context.Context, asOf time.Time) (AgingReport, error) {
+	report := AgingReport{AsOf: asOf.UTC()}
+	if s == nil || s.installmentRepo == nil {
+		return report, ErrInstallmentRepositoryUnavailable
+	}
+
+	if s.lease != nil {
+		acquired, err := s.lease.Acquire(ctx, agingLease, agingLeaseTTL)
+		if err != nil {
+			return report, err
+		}
+		if !acquired {
+			return report, ErrAgingInProgress
+		}
+		defer func() { _ = s.lease.Release(context.WithoutCancel(ctx), agingLease) }()
+	}
+
+	day := time.Date(report.AsOf.Year(), report.AsOf.Month(), report.AsOf.Day(), 0, 0, 0, 0, time.UTC)
+	installments, err := s.installmentRepo.ListOpenInstallmentsDueBefore(ctx, day)
+	if err != nil {
+		return report, err
+	}
+
+	consumers := make(map[primitive.ObjectID]struct{})
+	for _, installment := range installments {
+		report.Checked++
+		if !installment.Age(report.AsOf, s.policy) {
+			continue
+		}
+		err := s.installmentRepo.UpdateInstallment(ctx, installment)
+		if errors.Is(err, repositories.ErrConcurrentUpdate) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Updated++
+		consumers[installment.ConsumerID] = struct{}{}
+	}
+
+	for consumerID := range consumers {
+		if err := s.refreshOutstandingDebt(ctx, consumerID); err != nil {
+			return report, err
+		}
+	}
+	report.Consumers = len(consumers)
+
+	return report, nil
+}
+
+func (s *RepaymentService) consumerContract(ctx context.Context, consumerID, contractID primitive.ObjectID) (*entities.Contract, error) {
+	if s.contractRepo == nil {
+		return nil, ErrContractRepositoryUnavailable
+	}
+	if consumerID.IsZero() || contractID.IsZero() {
+		return nil, errors.New("consumer id and contract id are required")
+	}
+
+	contract, err := s.contractRepo.GetContractByID(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil || contract.ConsumerID != consumerID {
+		return nil, ErrContractNotFound
+	}
+	return contract, nil
+}
+
+func (s *RepaymentService) refreshOutstandingDebt(ctx context.Context, consumerID primitive.ObjectID) error {
+	if s.consumerRepo == nil {
+		return nil
+	}
+
+	consumer, err := s.consumerRepo.GetConsumerByID(ctx, consumerID)
+	if err != nil || consumer == nil {
+		return err
+	}
+
+	debt, err := outstandingDebt(ctx, s.installmentRepo, consumerID)
+	if err != nil {
+		return err
+	}
+	if consumer.CreditProfile.OutstandingDebt == debt {
+		return nil
+	}
+
+	consumer.CreditProfile.OutstandingDebt = debt
+	consumer.UpdatedAt = time.Now().UTC()
+	return s.consumerRepo.UpdateConsumer(ctx, consumer)
+}
+
+// outstandingDebt sums what the consumer still owes on open installments, late charges included.
+func outstandingDebt(ctx context.Context, repo repositories.InstallmentRepository, consumerID primitive.ObjectID) (valueobjects.Money, error) {
+	installments, err := repo.ListInstallmentsByConsumer(ctx, consumerID)
+	if err != nil {
+		return valueobjects.Money{}, err
+	}
+
+	var debt valueobjects.Money
+	for _, installment := range installments {
+		debt = debt.Add(installment.Outstanding())
+	}
+	return debt, nil
+}
+
+// payableBalance is the most a payment of the given type may carry. Early repayments pay only
+// the principal of installments that are not due yet.
+func payableBalance(installments []*entities.Installment, paymentType entities.PaymentType, paidAt time.Time) valueobjects.Money {
+	var balance valueobjects.Money
+	for _, installment := range installments {
+		if paymentType == entities.PaymentTypeEarlyRepayment && !installment.IsDue(paidAt) {
+			balance = balance.Add(installment.PrepaymentValue())
+			continue
+		}
+		balance = balance.Add(installment.Outstanding())
+	}
+	return balance
+}
+
+// allocatePayment spreads the amount over the installments. Both payment types first settle, in
+// order, the installments already due; regular payments then continue in order, while early
+// repayments prepay the remaining installments from the last one backwards.
+func allocatePayment(installments []*entities.Installment, paymentType entities.PaymentType, amount valueobjects.Money, paidAt time.Time) []entities.PaymentAllocation {
+	var allocations []entities.PaymentAllocation
+	remaining := amount
+
+	apply := func(installment *entities.Installment, prepay bool) {
+		if !remaining.IsPositive() || !installment.IsOpen() {
+			return
+		}
+
+		discount := installment.Discount
+		var applied valueobjects.Money
+		if prepay {
+			applied = installment.Prepay(remaining, paidAt)
+		} else {
+			applied = installment.Pay(remaining, paidAt)
+		}
+		if !applied.IsPositive() {
+			return
+		}
+
+		remaining = remaining.Sub(applied)
+		allocations = append(allocations, entities.PaymentAllocation{
+			InstallmentNumber: installment.Number,
+			Amount:            applied,
+			InterestWaived:    installment.Discount.Sub(discount),
+		})
+	}
+
+	early := paymentType == entities.PaymentTypeEarlyRepayment
+	for _, installment := range installments {
+		if !early || installment.IsDue(paidAt) {
+			apply(installment, false)
+		}
+	}
+	if early {
+		for i := len(installments) - 1; i >= 0; i-- {
+			if !installments[i].IsDue(paidAt) {
+				apply(installments[i], true)
+			}
+		}
+	}
+
+	return allocations
+}
+
+func settled(installments []*entities.Installment) bool {
+	for _, installment := range installments {
+		if installment.IsOpen() {
+			return false
+		}
+	}
+	return true
+}
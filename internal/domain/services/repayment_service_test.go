@@ -0,0 +1,186 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+func testInstallments(t *testing.T) []*entities.Installment {
+	t.Helper()
+	installments := entities.NewInstallments(&entities.Contract{
+		Amount:       valueobjects.MustParseMoney("10000"),
+		TermMonths:   12,
+		InterestRate: valueobjects.MustParseRate("2"),
+		Installment:  valueobjects.MustParseMoney("945.60"),
+		FirstDueDate: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+	})
+	if len(installments) != 12 {
+		t.Fatalf("expected 12 installments, got %d", len(installments))
+	}
+	return installments
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPayableBalance(t *testing.T) {
+	tests := []struct {
+		name        string
+		paymentType entities.PaymentType
+		paidAt      time.Time
+		prepare     func([]*entities.Installment)
+		want        string
+	}{
+		{
+			name:        "regular payment may carry every installment",
+			paymentType: entities.PaymentTypeRegular,
+			paidAt:      day(2026, time.March, 1),
+			want:        "11347.15",
+		},
+		{
+			name:        "early repayment before the first due date carries only the principal",
+			paymentType: entities.PaymentTypeEarlyRepayment,
+			paidAt:      day(2026, time.March, 1),
+			want:        "10000.00",
+		},
+		{
+			name:        "early repayment pays installments due that day in full",
+			paymentType: entities.PaymentTypeEarlyRepayment,
+			paidAt:      day(2026, time.March, 10),
+			want:        "10200.00",
+		},
+		{
+			name:        "early repayment includes late charges of overdue installments",
+			paymentType: entities.PaymentTypeEarlyRepayment,
+			paidAt:      day(2026, time.April, 9),
+			prepare: func(installments []*entities.Installment) {
+				installments[0].Age(day(2026, time.April, 9), entities.DefaultLateChargePolicy)
+			},
+			want: "10228.37",
+		},
+		{
+			name:        "settled installments are left out",
+			paymentType: entities.PaymentTypeEarlyRepayment,
+			paidAt:      day(2026, time.March, 15),
+			prepare: func(installments []*entities.Installment) {
+				installments[0].Pay(installments[0].Amount, day(2026, time.March, 10))
+			},
+			want: "9254.40",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := testInstallments(t)
+			if tt.prepare != nil {
+				tt.prepare(installments)
+			}
+			if got := payableBalance(installments, tt.paymentType, tt.paidAt); got != valueobjects.MustParseMoney(tt.want) {
+				t.Fatalf("expected a payable balance of %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAllocatePayment(t *testing.T) {
+	allocation := func(number int, amount, waived string) entities.PaymentAllocation {
+		return entities.PaymentAllocation{
+			InstallmentNumber: number,
+			Amount:            valueobjects.MustParseMoney(amount),
+			InterestWaived:    valueobjects.MustParseMoney(waived),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		paymentType entities.PaymentType
+		amount      string
+		paidAt      time.Time
+		prepare     func([]*entities.Installment)
+		want        []entities.PaymentAllocation
+	}{
+		{
+			name:        "regular payment settles installments in order",
+			paymentType: entities.PaymentTypeRegular,
+			amount:      "1000",
+			paidAt:      day(2026, time.March, 5),
+			want:        []entities.PaymentAllocation{allocation(1, "945.60", "0"), allocation(2, "54.40", "0")},
+		},
+		{
+			name:        "regular payment settles late charges first",
+			paymentType: entities.PaymentTypeRegular,
+			amount:      "1000",
+			paidAt:      day(2026, time.April, 9),
+			prepare: func(installments []*entities.Installment) {
+				installments[0].Age(day(2026, time.April, 9), entities.DefaultLateChargePolicy)
+			},
+			want: []entities.PaymentAllocation{allocation(1, "973.97", "0"), allocation(2, "26.03", "0")},
+		},
+		{
+			name:        "regular payment continues a partial payment",
+			paymentType: entities.PaymentTypeRegular,
+			amount:      "200",
+			paidAt:      day(2026, time.March, 5),
+			prepare: func(installments []*entities.Installment) {
+				installments[0].Pay(valueobjects.MustParseMoney("845.60"), day(2026, time.March, 1))
+			},
+			want: []entities.PaymentAllocation{allocation(1, "100.00", "0"), allocation(2, "100.00", "0")},
+		},
+		{
+			name:        "early repayment settles what is due and prepays from the last installment",
+			paymentType: entities.PaymentTypeEarlyRepayment,
+			amount:      "1000",
+			paidAt:      day(2026, time.March, 10),
+			want:        []entities.PaymentAllocation{allocation(1, "945.60", "0"), allocation(12, "54.40", "18.54")},
+		},
+		{
+			name:        "early repayment moves to the previous installment once the last is settled",
+			paymentType: entities.PaymentTypeEarlyRepayment,
+			amount:      "1000",
+			paidAt:      day(2026, time.March, 1),
+			want:        []entities.PaymentAllocation{allocation(12, "927.01", "18.54"), allocation(11, "72.99", "36.72")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := testInstallments(t)
+			if tt.prepare != nil {
+				tt.prepare(installments)
+			}
+			got := allocatePayment(installments, tt.paymentType, valueobjects.MustParseMoney(tt.amount), tt.paidAt)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected allocations %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAllocatePayment_EarlyRepaymentSettlesContract(t *testing.T) {
+	installments := testInstallments(t)
+	paidAt := day(2026, time.March, 10)
+
+	balance := payableBalance(installments, entities.PaymentTypeEarlyRepayment, paidAt)
+	allocations := allocatePayment(installments, entities.PaymentTypeEarlyRepayment, balance, paidAt)
+
+	var paid, waived valueobjects.Money
+	for _, allocation := range allocations {
+		paid = paid.Add(allocation.Amount)
+		waived = waived.Add(allocation.InterestWaived)
+	}
+	if paid != balance || !settled(installments) {
+		t.Fatalf("expected %s to settle every installment, allocated %s", balance, paid)
+	}
+	// Only the interest of installments not yet due is waived; the first one is paid in full.
+	if waived != valueobjects.MustParseMoney("1147.15") {
+		t.Fatalf("expected 1147.15 of interest waived, got %s", waived)
+	}
+	if len(allocations) != 12 || allocations[0].InstallmentNumber != 1 || allocations[1].InstallmentNumber != 12 {
+		t.Fatalf("expected the due installment first and the rest from the last one, got %+v", allocations)
+	}
+}
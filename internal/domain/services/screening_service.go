@@ -0,0 +1,431 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrScreeningRepositoryUnavailable = errors.New("screening repository unavailable")
+	ErrReviewNotFound                 = errors.New("screening review not found")
+	ErrReviewSubjectChanged           = errors.New("reviewed record is no longer held for review")
+)
+
+// Screening rule names reported in flags.
+const (
+	ScreeningRuleSharedContact       = "shared_contact"
+	ScreeningRuleSharedAddress       = "shared_address"
+	ScreeningRuleRecentIncorporation = "recent_incorporation_high_limit"
+	ScreeningRuleApplicationVelocity = "application_velocity"
+
+	maxScreeningScore = 100
+)
+
+// ScreeningRules configures the fraud and AML rules. Each rule that fires adds its weight to the
+// risk score (capped at 100); a weight of zero disables the rule. Records scoring HoldScore or
+// more are held for manual review.
+type ScreeningRules struct {
+	HoldScore int
+
+	// SharedContactMaxConsumers is how many other consumers may use the same e-mail or phone.
+	SharedContactMaxConsumers int
+	SharedContactWeight       int
+
+	// SharedAddressMaxConsumers is how many other consumers may live at the same address.
+	SharedAddressMaxConsumers int
+	SharedAddressWeight       int
+
+	// Companies incorporated less than RecentIncorporationMonths ago asking for at least
+	// HighRequestedLimit are flagged.
+	RecentIncorporationMonths int
+	HighRequestedLimit        valueobjects.Money
+	RecentIncorporationWeight int
+
+	// VelocityMaxApplications is how many applications a document may file within
+	// VelocityWindow, the current one included.
+	VelocityMaxApplications int
+	VelocityWindow          time.Duration
+	VelocityWeight          int
+}
+
+func DefaultScreeningRules() ScreeningRules {
+	return ScreeningRules{
+		HoldScore:                 50,
+		SharedContactMaxConsumers: 3,
+		SharedContactWeight:       40,
+		SharedAddressMaxConsumers: 5,
+		SharedAddressWeight:       30,
+		RecentIncorporationMonths: 6,
+		HighRequestedLimit:        valueobjects.MustParseMoney("100000"),
+		RecentIncorporationWeight: 40,
+		VelocityMaxApplications:   3,
+		VelocityWindow:            24 * time.Hour,
+		VelocityWeight:            50,
+	}
+}
+
+// ScreeningResult is the outcome of running the rules over one application.
+type ScreeningResult struct {
+	Stage entities.ScreeningStage
+	Score int
+	Flags []entities.ScreeningFlag
+	Hold  bool
+
+	signals []*entities.ScreeningSignal
+}
+
+// Status is the review status the screened record starts with.
+func (r ScreeningResult) Status() entities.ReviewStatus {
+	if r.Hold {
+		return entities.ReviewStatusPending
+	}
+	return entities.ReviewStatusClear
+}
+
+// ScreeningService scores onboarding and contracting applications against the fraud and AML
+// rules and runs the manual review queue for the ones it holds.
+type ScreeningService struct {
+	reviewRepo      repositories.ScreeningReviewRepository
+	signalRepo      repositories.ScreeningSignalRepository
+	consumerRepo    repositories.ConsumerRepository
+	contractRepo    repositories.ContractRepository
+	installmentRepo repositories.InstallmentRepository
+	addressRepo     repositories.AddressRepository
+	events          *EventOutbox
+	rules           ScreeningRules
+	fingerprintKey  []byte
+}
+
+func NewScreeningService(
+	reviewRepo repositories.ScreeningReviewRepository,
+	signalRepo repositories.ScreeningSignalRepository,
+	consumerRepo repositories.ConsumerRepository,
+	contractRepo repositories.ContractRepository,
+	installmentRepo repositories.InstallmentRepository,
+	addressRepo repositories.AddressRepository,
+	events *EventOutbox,
+	rules ScreeningRules,
+	fingerprintKey string,
+) *ScreeningService {
+	if reviewRepo == nil || signalRepo == nil {
+		return nil
+	}
+
+	return &ScreeningService{
+		reviewRepo:      reviewRepo,
+		signalRepo:      signalRepo,
+		consumerRepo:    consumerRepo,
+		contractRepo:    contractRepo,
+		installmentRepo: installmentRepo,
+		addressRepo:     addressRepo,
+		events:          events,
+		rules:           rules,
+		fingerprintKey:  []byte(fingerprintKey),
+	}
+}
+
+// Screen runs the rules for a consumer being onboarded or, when contract is set, contracting.
+// Nothing is stored; call Record once the application has been persisted.
+func (s *ScreeningService) Screen(ctx context.Context, consumer *entities.Consumer, contract *entities.Contract) (ScreeningResult, error) {
+	if s == nil || s.signalRepo == nil {
+		return ScreeningResult{}, ErrScreeningRepositoryUnavailable
+	}
+	if consumer == nil {
+		return ScreeningResult{}, entities.ErrConsumerNil
+	}
+
+	now := time.Now().UTC()
+	result := ScreeningResult{Stage: entities.ScreeningStageOnboarding}
+	if contract != nil {
+		result.Stage = entities.ScreeningStageContracting
+	}
+
+	signals, err := s.signals(ctx, consumer, result.Stage, now)
+	if err != nil {
+		return ScreeningResult{}, err
+	}
+	result.signals = signals
+
+	var sharedContact, sharedAddress []string
+	for _, signal := range signals {
+		switch signal.Kind {
+		case entities.ScreeningSignalEmail, entities.ScreeningSignalPhone:
+			if s.rules.SharedContactWeight <= 0 {
+				continue
+			}
+			others, err := s.signalRepo.CountConsumers(ctx, signal.Kind, signal.Value, consumer.ID)
+			if err != nil {
+				return ScreeningResult{}, err
+			}
+			if others >= s.rules.SharedContactMaxConsumers {
+				sharedContact = append(sharedContact, fmt.Sprintf("%s used by %d other consumers", signal.Kind, others))
+			}
+		case entities.ScreeningSignalAddress:
+			if s.rules.SharedAddressWeight <= 0 {
+				continue
+			}
+			others, err := s.signalRepo.CountConsumers(ctx, signal.Kind, signal.Value, consumer.ID)
+			if err != nil {
+				return ScreeningResult{}, err
+			}
+			if others >= s.rules.SharedAddressMaxConsumers {
+				sharedAddress = append(sharedAddress, fmt.Sprintf("address shared with %d other consumers", others))
+			}
+		case entities.ScreeningSignalApplication:
+			if s.rules.VelocityWeight <= 0 || s.rules.VelocityWindow <= 0 {
+				continue
+			}
+			previous, err := s.signalRepo.CountSince(ctx, signal.Kind, signal.Value, now.Add(-s.rules.VelocityWindow))
+			if err != nil {
+				return ScreeningResult{}, err
+			}
+			if previous+1 > s.rules.VelocityMaxApplications {
+				result.flag(ScreeningRuleApplicationVelocity, s.rules.VelocityWeight,
+					fmt.Sprintf("%d applications for the document within %s", previous+1, s.rules.VelocityWindow))
+			}
+		}
+	}
+	if len(sharedContact) > 0 {
+		result.flag(ScreeningRuleSharedContact, s.rules.SharedContactWeight, strings.Join(sharedContact, "; "))
+	}
+	if len(sharedAddress) > 0 {
+		result.flag(ScreeningRuleSharedAddress, s.rules.SharedAddressWeight, strings.Join(sharedAddress, "; "))
+	}
+
+	if business := consumer.PersonalData.Business; business != nil && s.rules.RecentIncorporationWeight > 0 {
+		requested := consumer.CreditProfile.CreditLimitRequested
+		if contract != nil && contract.Amount.GreaterThan(requested) {
+			requested = contract.Amount
+		}
+		recent := business.IncorporationDate.After(now.AddDate(0, -s.rules.RecentIncorporationMonths, 0))
+		if recent && requested.IsPositive() && !requested.LessThan(s.rules.HighRequestedLimit) {
+			result.flag(ScreeningRuleRecentIncorporation, s.rules.RecentIncorporationWeight,
+				fmt.Sprintf("incorporated on %s and requesting %s", business.IncorporationDate.Format(time.DateOnly), requested))
+		}
+	}
+
+	result.Hold = s.rules.HoldScore > 0 && result.Score >= s.rules.HoldScore
+	return result, nil
+}
+
+// Record stores the signals of a screened application and, if it was held, opens its review.
+func (s *ScreeningService) Record(ctx context.Context, consumer *entities.Consumer, contract *entities.Contract, result ScreeningResult) error {
+	if s == nil || s.signalRepo == nil {
+		return ErrScreeningRepositoryUnavailable
+	}
+
+	for _, signal := range result.signals {
+		signal.ConsumerID = consumer.ID
+	}
+	if err := s.signalRepo.RecordSignals(ctx, result.signals); err != nil {
+		return err
+	}
+	if !result.Hold {
+		return nil
+	}
+
+	review := &entities.ScreeningReview{
+		ID:         primitive.NewObjectID(),
+		Stage:      result.Stage,
+		ConsumerID: consumer.ID,
+		Score:      result.Score,
+		Flags:      result.Flags,
+		Status:     entities.ReviewStatusPending,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if contract != nil {
+		review.ContractID = contract.ID
+	}
+	if err := review.Validate(); err != nil {
+		return err
+	}
+	return s.reviewRepo.CreateReview(ctx, review)
+}
+
+func (s *ScreeningService) ListReviews(ctx context.Context, query repositories.ScreeningReviewQuery) ([]*entities.ScreeningReview, repositories.PageInfo, error) {
+	if s == nil || s.reviewRepo == nil {
+		return nil, repositories.PageInfo{}, ErrScreeningRepositoryUnavailable
+	}
+	if err := query.Validate(); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return s.reviewRepo.ListReviews(ctx, query)
+}
+
+func (s *ScreeningService) GetReview(ctx context.Context, id primitive.ObjectID) (*entities.ScreeningReview, error) {
+	if s == nil || s.reviewRepo == nil {
+		return nil, ErrScreeningRepositoryUnavailable
+	}
+
+	review, err := s.reviewRepo.GetReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	return review, nil
+}
+
+// DecideReview approves or rejects a held record. Approved consumers may contract products and
+// approved contracts become active with their installments; rejected contracts are cancelled.
+// The decision is applied in one transaction when the store supports them; otherwise the review
+// is closed last, so a failure midway leaves it pending and the decision can be retried.
+func (s *ScreeningService) DecideReview(ctx context.Context, id primitive.ObjectID, approved bool, reviewerID primitive.ObjectID, notes string) (*entities.ScreeningReview, error) {
+	review, err := s.GetReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.consumerRepo == nil {
+		return nil, ErrConsumerRepositoryUnavailable
+	}
+
+	now := time.Now().UTC()
+	if err := review.Decide(approved, reviewerID, notes, now); err != nil {
+		return nil, err
+	}
+
+	err = s.events.WithinTransaction(ctx, func(ctx context.Context) error {
+		consumer, err := s.consumerRepo.GetConsumerByID(ctx, review.ConsumerID)
+		if err != nil {
+			return err
+		}
+		if consumer == nil {
+			return ErrConsumerNotFound
+		}
+
+		switch review.Stage {
+		case entities.ScreeningStageOnboarding:
+			consumer.Screening.Status = review.Status
+			consumer.UpdatedAt = now
+			if err := s.consumerRepo.UpdateConsumer(ctx, consumer); err != nil {
+				return err
+			}
+		case entities.ScreeningStageContracting:
+			if err := s.decideContract(ctx, consumer, review, now); err != nil {
+				return err
+			}
+		}
+
+		return s.reviewRepo.UpdateReview(ctx, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *ScreeningService) decideContract(ctx context.Context, consumer *entities.Consumer, review *entities.ScreeningReview, now time.Time) error {
+	if s.contractRepo == nil {
+		return ErrContractRepositoryUnavailable
+	}
+
+	contract, err := s.contractRepo.GetContractByID(ctx, review.ContractID)
+	if err != nil {
+		return err
+	}
+	if contract == nil || !contract.IsPendingReview() {
+		return ErrReviewSubjectChanged
+	}
+
+	if review.Status == entities.ReviewStatusApproved {
+		contract.Activate(now)
+		if err := s.contractRepo.UpdateContract(ctx, contract); err != nil {
+			return err
+		}
+		if s.installmentRepo != nil {
+			if installments := entities.NewInstallments(contract); len(installments) > 0 {
+				if err := s.installmentRepo.CreateInstallments(ctx, installments); err != nil {
+					return err
+				}
+			}
+			debt, err := outstandingDebt(ctx, s.installmentRepo, consumer.ID)
+			if err != nil {
+				return err
+			}
+			consumer.CreditProfile.OutstandingDebt = debt
+		}
+		if err := s.events.Record(ctx, entities.EventProductContracted, contract.ID, contract.PartnerID, newContractEventData(contract)); err != nil {
+			return err
+		}
+	} else {
+		contract.Cancel(now)
+		if err := s.contractRepo.UpdateContract(ctx, contract); err != nil {
+			return err
+		}
+		if err := consumer.RemoveContractedProduct(contract.ProductID); err != nil && !errors.Is(err, entities.ErrConsumerProductNotLinked) {
+			return err
+		}
+	}
+
+	consumer.UpdatedAt = now
+	return s.consumerRepo.UpdateConsumer(ctx, consumer)
+}
+
+func (s *ScreeningService) signals(ctx context.Context, consumer *entities.Consumer, stage entities.ScreeningStage, now time.Time) ([]*entities.ScreeningSignal, error) {
+	var signals []*entities.ScreeningSignal
+	add := func(kind, value string) {
+		if strings.TrimSpace(value) == "" {
+			return
+		}
+		signals = append(signals, &entities.ScreeningSignal{
+			ID:         primitive.NewObjectID(),
+			Kind:       kind,
+			Value:      entities.ScreeningFingerprint(s.fingerprintKey, value),
+			ConsumerID: consumer.ID,
+			Stage:      stage,
+			RecordedAt: now,
+		})
+	}
+
+	add(entities.ScreeningSignalApplication, digitsOnly(consumerDocument(consumer)))
+	if stage != entities.ScreeningStageOnboarding {
+		return signals, nil
+	}
+
+	add(entities.ScreeningSignalEmail, consumer.Contact.Email)
+	add(entities.ScreeningSignalPhone, digitsOnly(consumer.Contact.Phone))
+	add(entities.ScreeningSignalPhone, digitsOnly(consumer.Contact.SecondaryPhone))
+
+	if s.addressRepo != nil && !consumer.PrimaryAddressID.IsZero() {
+		address, err := s.addressRepo.GetAddressByID(ctx, consumer.PrimaryAddressID)
+		if err != nil {
+			return nil, err
+		}
+		if address != nil {
+			add(entities.ScreeningSignalAddress, strings.Join([]string{
+				digitsOnly(address.PostalCode), address.Street, address.Number, address.Complement,
+			}, "|"))
+		}
+	}
+
+	return signals, nil
+}
+
+func (r *ScreeningResult) flag(rule string, weight int, detail string) {
+	r.Flags = append(r.Flags, entities.ScreeningFlag{Rule: rule, Score: weight, Detail: detail})
+	r.Score += weight
+	if r.Score > maxScreeningScore {
+		r.Score = maxScreeningScore
+	}
+}
+
+func digitsOnly(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,569 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/persistence/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// screeningSignals keeps recorded signals in memory.
+type screeningSignals struct {
+	signals []*entities.ScreeningSignal
+}
+
+func (r *screeningSignals) RecordSignals(_ context.Context, signals []*entities.ScreeningSignal) error {
+	r.signals = append(r.signals, signals...)
+	return nil
+}
+
+func (r *screeningSignals) CountConsumers(_ context.Context, kind, value string, excludeConsumerID primitive.ObjectID) (int, error) {
+	consumers := map[primitive.ObjectID]struct{}{}
+	for _, signal := range r.signals {
+		if signal.Kind == kind && signal.Value == value && signal.ConsumerID != excludeConsumerID {
+			consumers[signal.ConsumerID] = struct{}{}
+		}
+	}
+	return len(consumers), nil
+}
+
+func (r *screeningSignals) CountSince(_ context.Context, kind, value string, since time.Time) (int, error) {
+	count := 0
+	for _, signal := range r.signals {
+		if signal.Kind == kind && signal.Value == value && !signal.RecordedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *screeningSignals) DeleteConsumerSignals(_ context.Context, consumerID primitive.ObjectID) (int64, error) {
+	kept := r.signals[:0]
+	for _, signal := range r.signals {
+		if signal.ConsumerID != consumerID {
+			kept = append(kept, signal)
+		}
+	}
+	deleted := int64(len(r.signals) - len(kept))
+	r.signals = kept
+	return deleted, nil
+}
+
+// screeningReviews keeps copies of the reviews, so a decision only counts once it is saved.
+type screeningReviews struct {
+	reviews map[primitive.ObjectID]entities.ScreeningReview
+}
+
+func newScreeningReviews() *screeningReviews {
+	return &screeningReviews{reviews: map[primitive.ObjectID]entities.ScreeningReview{}}
+}
+
+func (r *screeningReviews) CreateReview(_ context.Context, review *entities.ScreeningReview) error {
+	r.reviews[review.ID] = *review
+	return nil
+}
+
+func (r *screeningReviews) GetReviewByID(_ context.Context, id primitive.ObjectID) (*entities.ScreeningReview, error) {
+	review, ok := r.reviews[id]
+	if !ok {
+		return nil, nil
+	}
+	return &review, nil
+}
+
+func (r *screeningReviews) UpdateReview(_ context.Context, review *entities.ScreeningReview) error {
+	r.reviews[review.ID] = *review
+	return nil
+}
+
+func (r *screeningReviews) ListReviews(context.Context, repositories.ScreeningReviewQuery) ([]*entities.ScreeningReview, repositories.PageInfo, error) {
+	var reviews []*entities.ScreeningReview
+	for _, review := range r.reviews {
+		review := review
+		reviews = append(reviews, &review)
+	}
+	return reviews, repositories.PageInfo{}, nil
+}
+
+// screeningContracts keeps copies of the contracts.
+type screeningContracts struct {
+	contracts map[primitive.ObjectID]entities.Contract
+}
+
+func (r *screeningContracts) GetContractByID(_ context.Context, id primitive.ObjectID) (*entities.Contract, error) {
+	contract, ok := r.contracts[id]
+	if !ok {
+		return nil, nil
+	}
+	return &contract, nil
+}
+
+func (r *screeningContracts) CreateContract(_ context.Context, contract *entities.Contract) error {
+	r.contracts[contract.ID] = *contract
+	return nil
+}
+
+func (r *screeningContracts) UpdateContract(_ context.Context, contract *entities.Contract) error {
+	r.contracts[contract.ID] = *contract
+	return nil
+}
+
+func (r *screeningContracts) ListContractsByConsumer(context.Context, primitive.ObjectID) ([]*entities.Contract, error) {
+	return nil, nil
+}
+
+type screeningFixture struct {
+	signals   *screeningSignals
+	reviews   *screeningReviews
+	contracts *screeningContracts
+	consumers repositories.ConsumerRepository
+	addresses repositories.AddressRepository
+	addressID primitive.ObjectID
+}
+
+func newScreeningFixture(t *testing.T) *screeningFixture {
+	t.Helper()
+	fixture := &screeningFixture{
+		signals:   &screeningSignals{},
+		reviews:   newScreeningReviews(),
+		contracts: &screeningContracts{contracts: map[primitive.ObjectID]entities.Contract{}},
+		consumers: memory.NewConsumerRepository(),
+		addresses: memory.NewAddressRepository(),
+		addressID: primitive.NewObjectID(),
+	}
+	address := &entities.Address{
+		ID:         fixture.addressID,
+		Country:    "BR",
+		State:      "SP",
+		City:       "São Paulo",
+		Street:     "Rua Augusta",
+		Number:     "100",
+		PostalCode: "01305-000",
+	}
+	if err := fixture.addresses.CreateAddress(context.Background(), address); err != nil {
+		t.Fatalf("CreateAddress returned error: %v", err)
+	}
+	return fixture
+}
+
+func (f *screeningFixture) service(rules ScreeningRules) *ScreeningService {
+	return NewScreeningService(f.reviews, f.signals, f.consumers, f.contracts, nil, f.addresses, nil, rules, "fingerprint-key")
+}
+
+// applicant returns an individual that shares its contact and address with every other
+// applicant of the fixture.
+func (f *screeningFixture) applicant(document string) *entities.Consumer {
+	return &entities.Consumer{
+		ID:   primitive.NewObjectID(),
+		Type: valueobjects.ConsumerTypeIndividual,
+		PersonalData: entities.ConsumerPersonalData{Individual: &entities.ConsumerIndividualData{
+			FullName:       "Maria Silva",
+			DocumentNumber: document,
+		}},
+		Contact:          entities.ConsumerContactInformation{Email: "maria@example.com", Phone: "+55 11 98888-7777"},
+		PrimaryAddressID: f.addressID,
+	}
+}
+
+func (f *screeningFixture) businessApplicant(document string, incorporated time.Time, requested string) *entities.Consumer {
+	return &entities.Consumer{
+		ID:   primitive.NewObjectID(),
+		Type: valueobjects.ConsumerTypeBusiness,
+		PersonalData: entities.ConsumerPersonalData{Business: &entities.ConsumerBusinessData{
+			CorporateName:     "Padaria Central Ltda",
+			DocumentNumber:    document,
+			IncorporationDate: incorporated,
+		}},
+		CreditProfile: entities.ConsumerCreditProfile{CreditLimitRequested: valueobjects.MustParseMoney(requested)},
+	}
+}
+
+// apply screens the application and records it, as the onboarding and contracting flows do.
+func (f *screeningFixture) apply(t *testing.T, service *ScreeningService, consumer *entities.Consumer, contract *entities.Contract) ScreeningResult {
+	t.Helper()
+	ctx := context.Background()
+	result, err := service.Screen(ctx, consumer, contract)
+	if err != nil {
+		t.Fatalf("Screen returned error: %v", err)
+	}
+	if err := service.Record(ctx, consumer, contract, result); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	return result
+}
+
+func TestScreeningService_Screen(t *testing.T) {
+	const document = "52998224725"
+	monthsAgo := func(months int) time.Time { return time.Now().UTC().AddDate(0, -months, 0) }
+
+	tests := []struct {
+		name string
+		// others is how many other consumers applied first with the same contact and address.
+		others int
+		// repeats is how many times the applicant applied before.
+		repeats   int
+		rules     ScreeningRules
+		applicant func(*screeningFixture) *entities.Consumer
+		contract  *entities.Contract
+		wantRules []string
+		wantScore int
+		wantHold  bool
+	}{
+		{
+			name:  "first application passes the default rules",
+			rules: DefaultScreeningRules(),
+		},
+		{
+			name:      "shared contact",
+			others:    2,
+			rules:     ScreeningRules{HoldScore: 50, SharedContactMaxConsumers: 2, SharedContactWeight: 40},
+			wantRules: []string{ScreeningRuleSharedContact},
+			wantScore: 40,
+		},
+		{
+			name:   "shared contact under the limit",
+			others: 1,
+			rules:  ScreeningRules{HoldScore: 50, SharedContactMaxConsumers: 2, SharedContactWeight: 40},
+		},
+		{
+			name:   "zero weight disables shared contact",
+			others: 5,
+			rules:  ScreeningRules{HoldScore: 50, SharedContactMaxConsumers: 2},
+		},
+		{
+			name:      "shared address",
+			others:    2,
+			rules:     ScreeningRules{HoldScore: 50, SharedAddressMaxConsumers: 2, SharedAddressWeight: 30},
+			wantRules: []string{ScreeningRuleSharedAddress},
+			wantScore: 30,
+		},
+		{
+			name:   "zero weight disables shared address",
+			others: 5,
+			rules:  ScreeningRules{HoldScore: 50, SharedAddressMaxConsumers: 2},
+		},
+		{
+			name:      "application velocity",
+			repeats:   2,
+			rules:     ScreeningRules{HoldScore: 50, VelocityMaxApplications: 2, VelocityWindow: 24 * time.Hour, VelocityWeight: 50},
+			wantRules: []string{ScreeningRuleApplicationVelocity},
+			wantScore: 50,
+			wantHold:  true,
+		},
+		{
+			name:    "application velocity within the limit",
+			repeats: 1,
+			rules:   ScreeningRules{HoldScore: 50, VelocityMaxApplications: 2, VelocityWindow: 24 * time.Hour, VelocityWeight: 50},
+		},
+		{
+			name:    "zero weight disables application velocity",
+			repeats: 5,
+			rules:   ScreeningRules{HoldScore: 50, VelocityMaxApplications: 2, VelocityWindow: 24 * time.Hour},
+		},
+		{
+			name:    "zero window disables application velocity",
+			repeats: 5,
+			rules:   ScreeningRules{HoldScore: 50, VelocityMaxApplications: 2, VelocityWeight: 50},
+		},
+		{
+			name:  "recent company asking for a high limit",
+			rules: ScreeningRules{HoldScore: 50, RecentIncorporationMonths: 6, HighRequestedLimit: valueobjects.MustParseMoney("100000"), RecentIncorporationWeight: 40},
+			applicant: func(f *screeningFixture) *entities.Consumer {
+				return f.businessApplicant("11222333000181", monthsAgo(2), "150000")
+			},
+			wantRules: []string{ScreeningRuleRecentIncorporation},
+			wantScore: 40,
+		},
+		{
+			name:  "recent company contracting a high amount",
+			rules: ScreeningRules{HoldScore: 50, RecentIncorporationMonths: 6, HighRequestedLimit: valueobjects.MustParseMoney("100000"), RecentIncorporationWeight: 40},
+			applicant: func(f *screeningFixture) *entities.Consumer {
+				return f.businessApplicant("11222333000181", monthsAgo(2), "0")
+			},
+			contract:  &entities.Contract{ID: primitive.NewObjectID(), Amount: valueobjects.MustParseMoney("120000")},
+			wantRules: []string{ScreeningRuleRecentIncorporation},
+			wantScore: 40,
+		},
+		{
+			name:  "established company asking for a high limit",
+			rules: ScreeningRules{HoldScore: 50, RecentIncorporationMonths: 6, HighRequestedLimit: valueobjects.MustParseMoney("100000"), RecentIncorporationWeight: 40},
+			applicant: func(f *screeningFixture) *entities.Consumer {
+				return f.businessApplicant("11222333000181", monthsAgo(24), "150000")
+			},
+		},
+		{
+			name:  "zero weight disables recent incorporation",
+			rules: ScreeningRules{HoldScore: 50, RecentIncorporationMonths: 6, HighRequestedLimit: valueobjects.MustParseMoney("100000")},
+			applicant: func(f *screeningFixture) *entities.Consumer {
+				return f.businessApplicant("11222333000181", monthsAgo(2), "150000")
+			},
+		},
+		{
+			name:      "score is capped at 100",
+			others:    5,
+			repeats:   3,
+			rules:     DefaultScreeningRules(),
+			wantRules: []string{ScreeningRuleApplicationVelocity, ScreeningRuleSharedContact, ScreeningRuleSharedAddress},
+			wantScore: 100,
+			wantHold:  true,
+		},
+		{
+			name:      "score at the hold score is held",
+			others:    2,
+			rules:     ScreeningRules{HoldScore: 40, SharedContactMaxConsumers: 2, SharedContactWeight: 40},
+			wantRules: []string{ScreeningRuleSharedContact},
+			wantScore: 40,
+			wantHold:  true,
+		},
+		{
+			name:      "zero hold score never holds",
+			others:    2,
+			rules:     ScreeningRules{SharedContactMaxConsumers: 2, SharedContactWeight: 90},
+			wantRules: []string{ScreeningRuleSharedContact},
+			wantScore: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newScreeningFixture(t)
+			service := fixture.service(tt.rules)
+
+			for i := 0; i < tt.others; i++ {
+				fixture.apply(t, service, fixture.applicant(fmt.Sprintf("3905334470%d", i)), nil)
+			}
+			applicant := fixture.applicant(document)
+			if tt.applicant != nil {
+				applicant = tt.applicant(fixture)
+			}
+			for i := 0; i < tt.repeats; i++ {
+				fixture.apply(t, service, applicant, nil)
+			}
+
+			result, err := service.Screen(context.Background(), applicant, tt.contract)
+			if err != nil {
+				t.Fatalf("Screen returned error: %v", err)
+			}
+
+			var rules []string
+			for _, flag := range result.Flags {
+				rules = append(rules, flag.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Fatalf("expected rules %v to fire, got %+v", tt.wantRules, result.Flags)
+			}
+			if result.Score != tt.wantScore || result.Hold != tt.wantHold {
+				t.Fatalf("expected score %d hold %t, got score %d hold %t", tt.wantScore, tt.wantHold, result.Score, result.Hold)
+			}
+		})
+	}
+}
+
+func TestScreeningService_Record(t *testing.T) {
+	tests := []struct {
+		name       string
+		others     int
+		wantReview bool
+	}{
+		{name: "clear application only stores its signals"},
+		{name: "held application opens a review", others: 1, wantReview: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newScreeningFixture(t)
+			service := fixture.service(ScreeningRules{HoldScore: 40, SharedContactMaxConsumers: 1, SharedContactWeight: 40})
+			for i := 0; i < tt.others; i++ {
+				fixture.apply(t, service, fixture.applicant(fmt.Sprintf("3905334470%d", i)), nil)
+			}
+
+			applicant := fixture.applicant("52998224725")
+			result := fixture.apply(t, service, applicant, nil)
+
+			recorded := 0
+			for _, signal := range fixture.signals.signals {
+				if signal.ConsumerID == applicant.ID {
+					recorded++
+				}
+			}
+			// Application, e-mail, phone and address.
+			if recorded != 4 {
+				t.Fatalf("expected 4 signals recorded for the applicant, got %d", recorded)
+			}
+
+			reviews, _, _ := fixture.reviews.ListReviews(context.Background(), repositories.ScreeningReviewQuery{})
+			var review *entities.ScreeningReview
+			for _, candidate := range reviews {
+				if candidate.ConsumerID == applicant.ID {
+					review = candidate
+				}
+			}
+			if (review != nil) != tt.wantReview {
+				t.Fatalf("expected a review opened=%t, got %+v", tt.wantReview, review)
+			}
+			if review != nil && (review.Status != entities.ReviewStatusPending || review.Score != result.Score || len(review.Flags) != len(result.Flags)) {
+				t.Fatalf("expected a pending review with the screening outcome %+v, got %+v", result, review)
+			}
+		})
+	}
+}
+
+func TestScreeningService_DecideReview(t *testing.T) {
+	tests := []struct {
+		name     string
+		stage    entities.ScreeningStage
+		approved bool
+		// contractStatus is the status the contract has when the review is decided.
+		contractStatus entities.ContractStatus
+		wantErr        error
+		wantReview     entities.ReviewStatus
+		wantContract   entities.ContractStatus
+		wantConsumer   entities.ReviewStatus
+		wantProducts   int
+	}{
+		{
+			name:         "approved onboarding clears the consumer",
+			stage:        entities.ScreeningStageOnboarding,
+			approved:     true,
+			wantReview:   entities.ReviewStatusApproved,
+			wantConsumer: entities.ReviewStatusApproved,
+			wantProducts: 1,
+		},
+		{
+			name:         "rejected onboarding blocks the consumer",
+			stage:        entities.ScreeningStageOnboarding,
+			wantReview:   entities.ReviewStatusRejected,
+			wantConsumer: entities.ReviewStatusRejected,
+			wantProducts: 1,
+		},
+		{
+			name:           "approved contract becomes active",
+			stage:          entities.ScreeningStageContracting,
+			approved:       true,
+			contractStatus: entities.ContractStatusPendingReview,
+			wantReview:     entities.ReviewStatusApproved,
+			wantContract:   entities.ContractStatusActive,
+			wantConsumer:   entities.ReviewStatusPending,
+			wantProducts:   1,
+		},
+		{
+			name:           "rejected contract is cancelled and unlinked",
+			stage:          entities.ScreeningStageContracting,
+			contractStatus: entities.ContractStatusPendingReview,
+			wantReview:     entities.ReviewStatusRejected,
+			wantContract:   entities.ContractStatusCancelled,
+			wantConsumer:   entities.ReviewStatusPending,
+		},
+		{
+			name:           "contract no longer held keeps the review pending",
+			stage:          entities.ScreeningStageContracting,
+			approved:       true,
+			contractStatus: entities.ContractStatusCancelled,
+			wantErr:        ErrReviewSubjectChanged,
+			wantReview:     entities.ReviewStatusPending,
+			wantContract:   entities.ContractStatusCancelled,
+			wantConsumer:   entities.ReviewStatusPending,
+			wantProducts:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fixture := newScreeningFixture(t)
+			service := fixture.service(DefaultScreeningRules())
+
+			contract := &entities.Contract{
+				ID:        primitive.NewObjectID(),
+				ProductID: primitive.NewObjectID(),
+				Status:    tt.contractStatus,
+				CreatedAt: time.Now().UTC(),
+			}
+			consumer := fixture.applicant("52998224725")
+			consumer.ContractedProducts = []primitive.ObjectID{contract.ProductID}
+			consumer.Screening.Status = entities.ReviewStatusPending
+			contract.ConsumerID = consumer.ID
+			if err := fixture.consumers.CreateConsumer(ctx, consumer); err != nil {
+				t.Fatalf("CreateConsumer returned error: %v", err)
+			}
+
+			review := &entities.ScreeningReview{
+				ID:         primitive.NewObjectID(),
+				Stage:      tt.stage,
+				ConsumerID: consumer.ID,
+				Status:     entities.ReviewStatusPending,
+			}
+			if tt.stage == entities.ScreeningStageContracting {
+				review.ContractID = contract.ID
+				if err := fixture.contracts.CreateContract(ctx, contract); err != nil {
+					t.Fatalf("CreateContract returned error: %v", err)
+				}
+			}
+			if err := fixture.reviews.CreateReview(ctx, review); err != nil {
+				t.Fatalf("CreateReview returned error: %v", err)
+			}
+
+			_, err := service.DecideReview(ctx, review.ID, tt.approved, primitive.NewObjectID(), "checked")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			stored, _ := fixture.reviews.GetReviewByID(ctx, review.ID)
+			if stored.Status != tt.wantReview {
+				t.Fatalf("expected review status %s, got %s", tt.wantReview, stored.Status)
+			}
+			if tt.wantContract != "" {
+				decided, _ := fixture.contracts.GetContractByID(ctx, contract.ID)
+				if decided.Status != tt.wantContract {
+					t.Fatalf("expected contract status %s, got %s", tt.wantContract, decided.Status)
+				}
+			}
+			updated, err := fixture.consumers.GetConsumerByID(ctx, consumer.ID)
+			if err != nil {
+				t.Fatalf("GetConsumerByID returned error: %v", err)
+			}
+			if updated.Screening.Status != tt.wantConsumer || len(updated.ContractedProducts) != tt.wantProducts {
+				t.Fatalf("expected consumer screening %s with %d products, got %s with %v", tt.wantConsumer, tt.wantProducts, updated.Screening.Status, updated.ContractedProducts)
+			}
+		})
+	}
+}
+
+func TestScreeningService_DecideReviewRejectsUnknownOrDecided(t *testing.T) {
+	ctx := context.Background()
+	fixture := newScreeningFixture(t)
+	service := fixture.service(DefaultScreeningRules())
+
+	if _, err := service.DecideReview(ctx, primitive.NewObjectID(), true, primitive.NewObjectID(), ""); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected ErrReviewNotFound, got %v", err)
+	}
+
+	consumer := fixture.applicant("52998224725")
+	if err := fixture.consumers.CreateConsumer(ctx, consumer); err != nil {
+		t.Fatalf("CreateConsumer returned error: %v", err)
+	}
+	review := &entities.ScreeningReview{
+		ID:         primitive.NewObjectID(),
+		Stage:      entities.ScreeningStageOnboarding,
+		ConsumerID: consumer.ID,
+		Status:     entities.ReviewStatusPending,
+	}
+	if err := fixture.reviews.CreateReview(ctx, review); err != nil {
+		t.Fatalf("CreateReview returned error: %v", err)
+	}
+	if _, err := service.DecideReview(ctx, review.ID, true, primitive.NewObjectID(), ""); err != nil {
+		t.Fatalf("DecideReview returned error: %v", err)
+	}
+	if _, err := service.DecideReview(ctx, review.ID, false, primitive.NewObjectID(), ""); !errors.Is(err, entities.ErrReviewAlreadyDecided) {
+		t.Fatalf("expected ErrReviewAlreadyDecided on a second decision, got %v", err)
+	}
+}
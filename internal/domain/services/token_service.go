@@ -12,6 +12,13 @@ import (
 // ErrTokenStoreUnavailable indicates the token store dependency was not configured.
 var ErrTokenStoreUnavailable = errors.New("token store unavailable")
 
+// TokenTTL is how long issued access tokens stay valid.
+const TokenTTL = 24 * time.Hour
+
+// subjectRevocationPrefix marks subject revocations in the token store. JWTs never contain a
+// colon, so they cannot collide with them.
+const subjectRevocationPrefix = "subject:"
+
 // TokenService encapsulates token revocation operations.
 type TokenService struct {
 	store security.TokenStore
@@ -39,6 +46,36 @@ func (s *TokenService) RevokeToken(ctx context.Context, token string, expiresAt
 	return s.store.Revoke(ctx, token, expiresAt)
 }
 
+// RevokeSubject revokes every token of the subject, for as long as any of them may be valid.
+// Tokens issued in that window are refused too, so it suits subjects that will not sign in
+// again, such as deleted users.
+func (s *TokenService) RevokeSubject(ctx context.Context, subject string) error {
+	if s == nil || s.store == nil {
+		return ErrTokenStoreUnavailable
+	}
+
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return nil
+	}
+
+	return s.store.Revoke(ctx, subjectRevocationPrefix+subject, time.Now().Add(TokenTTL))
+}
+
+// IsSubjectRevoked returns true when every token of the subject has been revoked.
+func (s *TokenService) IsSubjectRevoked(ctx context.Context, subject string) (bool, error) {
+	if s == nil || s.store == nil {
+		return false, ErrTokenStoreUnavailable
+	}
+
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return false, nil
+	}
+
+	return s.store.IsRevoked(ctx, subjectRevocationPrefix+subject)
+}
+
 // IsTokenRevoked returns true when the token has been revoked.
 func (s *TokenService) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
 	if s == nil || s.store == nil {
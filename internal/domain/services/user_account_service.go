@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewUserAccount describes a user to provision. Partner managers and consumers must reference
+// the partner or consumer their profile is linked to.
+type NewUserAccount struct {
+	Email       string
+	Password    string
+	Active      bool
+	Role        entities.Role
+	Permissions []string
+	ProfileType entities.UserProfileType
+	ProfileID   primitive.ObjectID
+}
+
+// UserAccountService creates and deletes users together with the link to their partner or
+// consumer profile. Each operation runs in one unit of work, so a failed link leaves no user
+// behind and a failed deletion leaves the profile linked. Stores without units of work get the
+// same outcome by undoing the first write when the second one fails.
+type UserAccountService struct {
+	auth      *AuthService
+	partners  *PartnerService
+	consumers *ConsumerService
+	uow       repositories.UnitOfWork
+}
+
+func NewUserAccountService(auth *AuthService, partners *PartnerService, consumers *ConsumerService, uow repositories.UnitOfWork) *UserAccountService {
+	if auth == nil {
+		return nil
+	}
+
+	return &UserAccountService{
+		auth:      auth,
+		partners:  partners,
+		consumers: consumers,
+		uow:       uow,
+	}
+}
+
+// CreateUser provisions the user and links it to its profile. The profile is checked before the
+// unit of work starts, so the usual failures are reported without writing anything.
+func (s *UserAccountService) CreateUser(ctx context.Context, account NewUserAccount) (*entities.User, error) {
+	if s == nil || s.auth == nil {
+		return nil, ErrInvalidUserData
+	}
+	if err := s.checkProfile(ctx, account.ProfileType, account.ProfileID); err != nil {
+		return nil, err
+	}
+
+	var user *entities.User
+	err := runUnitOfWork(ctx, s.uow, func(ctx context.Context) error {
+		created, err := s.auth.CreateUser(ctx, account.Email, account.Password, account.Active, account.Role, account.Permissions, account.ProfileType, account.ProfileID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.linkProfile(ctx, created); err != nil {
+			if s.uow == nil {
+				return undone(err, s.auth.DeleteUser(context.WithoutCancel(ctx), created.ID))
+			}
+			return err
+		}
+
+		user = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteUser unlinks the user from its profile and deletes it. A partner must keep at least one
+// manager, so deleting its last one fails with ErrPartnerManagerRequired.
+func (s *UserAccountService) DeleteUser(ctx context.Context, id primitive.ObjectID) error {
+	if s == nil || s.auth == nil {
+		return ErrInvalidUserData
+	}
+
+	return runUnitOfWork(ctx, s.uow, func(ctx context.Context) error {
+		user, err := s.auth.GetUserByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.unlinkProfile(ctx, user); err != nil {
+			return err
+		}
+
+		if err := s.auth.DeleteUser(ctx, user.ID); err != nil {
+			if s.uow == nil {
+				return undone(err, s.linkProfile(context.WithoutCancel(ctx), user))
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *UserAccountService) linkProfile(ctx context.Context, user *entities.User) error {
+	switch user.ProfileType {
+	case entities.ProfileTypePartnerManager:
+		return s.partners.AssignManagerProfile(ctx, user.ProfileID, user.ID)
+	case entities.ProfileTypeConsumer:
+		return s.consumers.AttachUserProfile(ctx, user.ProfileID, user.ID)
+	}
+	return nil
+}
+
+func (s *UserAccountService) unlinkProfile(ctx context.Context, user *entities.User) error {
+	if user.ProfileID.IsZero() {
+		return nil
+	}
+
+	switch user.ProfileType {
+	case entities.ProfileTypePartnerManager:
+		if s.partners == nil {
+			return ErrPartnerRepositoryUnavailable
+		}
+		return s.partners.RemoveManagerProfile(ctx, user.ProfileID, user.ID)
+	case entities.ProfileTypeConsumer:
+		if s.consumers == nil {
+			return ErrConsumerRepositoryUnavailable
+		}
+		return s.consumers.DetachUserProfile(ctx, user.ProfileID)
+	}
+	return nil
+}
+
+// undone returns err once the write made before it was reverted by undo, and both errors when
+// reverting failed too, leaving the change half applied.
+func undone(err, undoErr error) error {
+	if undoErr == nil {
+		return err
+	}
+	return errors.Join(err, fmt.Errorf("reverting the previous write: %w", undoErr))
+}
+
+func (s *UserAccountService) checkProfile(ctx context.Context, profileType entities.UserProfileType, profileID primitive.ObjectID) error {
+	switch profileType {
+	case entities.ProfileTypePartnerManager:
+		if s.partners == nil || s.partners.partnerRepo == nil {
+			return ErrPartnerRepositoryUnavailable
+		}
+		partner, err := s.partners.GetPartnerByID(ctx, profileID)
+		if err != nil {
+			return err
+		}
+		if partner == nil {
+			return ErrPartnerNotFound
+		}
+	case entities.ProfileTypeConsumer:
+		if s.consumers == nil {
+			return ErrConsumerRepositoryUnavailable
+		}
+		consumer, err := s.consumers.GetConsumerByID(ctx, profileID)
+		if err != nil {
+			return err
+		}
+		if consumer == nil {
+			return ErrConsumerNotFound
+		}
+		if consumer.HasLinkedUser() {
+			return ErrConsumerUserAlreadyLinked
+		}
+	}
+	return nil
+}
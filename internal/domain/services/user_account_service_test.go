@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/persistence/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var errStoreDown = errors.New("store unavailable")
+
+// failingPartners fails every partner update, like a store that went away mid-request.
+type failingPartners struct {
+	repositories.PartnerRepository
+}
+
+func (failingPartners) UpdatePartner(context.Context, *entities.Partner) error {
+	return errStoreDown
+}
+
+// failingUsers fails every user deletion.
+type failingUsers struct {
+	repositories.UserRepository
+}
+
+func (failingUsers) DeleteUser(context.Context, primitive.ObjectID) error {
+	return errStoreDown
+}
+
+func newTestPartner(t *testing.T, repo repositories.PartnerRepository, managers ...primitive.ObjectID) *entities.Partner {
+	t.Helper()
+	partner := &entities.Partner{
+		ID:                primitive.NewObjectID(),
+		Name:              "Banco A",
+		Type:              valueobjects.PartnerTypeBank,
+		Attributes:        entities.PartnerAttributes{Bank: &entities.BankPartnerAttributes{BankCode: "001"}},
+		ManagerProfileIDs: managers,
+	}
+	if err := repo.CreatePartner(context.Background(), partner); err != nil {
+		t.Fatalf("CreatePartner returned error: %v", err)
+	}
+	return partner
+}
+
+func TestUserAccountService_CreateUserRemovesUserWhenLinkFails(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepository()
+	partners := memory.NewPartnerRepository()
+	partner := newTestPartner(t, partners, primitive.NewObjectID())
+
+	// No unit of work, as on a standalone Mongo server.
+	service := NewUserAccountService(NewAuthService(users, nil), NewPartnerService(failingPartners{partners}, nil), nil, nil)
+
+	_, err := service.CreateUser(ctx, NewUserAccount{
+		Email:       "manager@banco-a.example",
+		Password:    "s3cret-password",
+		Active:      true,
+		ProfileType: entities.ProfileTypePartnerManager,
+		ProfileID:   partner.ID,
+	})
+	if !errors.Is(err, errStoreDown) {
+		t.Fatalf("expected the link failure, got %v", err)
+	}
+	if user, _ := users.FindByEmail(ctx, "manager@banco-a.example"); user != nil {
+		t.Fatalf("expected the user to be removed after the failed link, found %s", user.ID.Hex())
+	}
+}
+
+func TestUserAccountService_DeleteUserRelinksWhenDeleteFails(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepository()
+	partners := memory.NewPartnerRepository()
+	auth := NewAuthService(users, nil)
+
+	partner := newTestPartner(t, partners, primitive.NewObjectID())
+	user, err := auth.CreateUser(ctx, "manager@banco-a.example", "s3cret-password", true, entities.RoleUser, nil, entities.ProfileTypePartnerManager, partner.ID)
+	if err != nil {
+		t.Fatalf("CreateUser returned error: %v", err)
+	}
+	partnerService := NewPartnerService(partners, nil)
+	if err := partnerService.AssignManagerProfile(ctx, partner.ID, user.ID); err != nil {
+		t.Fatalf("AssignManagerProfile returned error: %v", err)
+	}
+
+	service := NewUserAccountService(NewAuthService(failingUsers{users}, nil), partnerService, nil, nil)
+	if err := service.DeleteUser(ctx, user.ID); !errors.Is(err, errStoreDown) {
+		t.Fatalf("expected the delete failure, got %v", err)
+	}
+
+	stored, err := partners.GetPartnerByID(ctx, partner.ID)
+	if err != nil {
+		t.Fatalf("GetPartnerByID returned error: %v", err)
+	}
+	if !stored.HasManagerProfile(user.ID) {
+		t.Fatalf("expected the user to be linked to the partner again, managers %v", stored.ManagerProfileIDs)
+	}
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrWebhookRepositoryUnavailable = errors.New("webhook repository unavailable")
+	ErrWebhookSubscriptionNotFound  = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrWebhookSenderUnavailable     = errors.New("webhook sender not configured")
+)
+
+// WebhookSender posts a delivery to the subscriber, signed with the subscription secret. It
+// returns the HTTP status received, if any, and an error unless the subscriber accepted it.
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (int, error)
+}
+
+// WebhookRetryPolicy controls redelivery of failed webhooks. The wait doubles after each
+// failed attempt, starting at InitialBackoff and capped at MaxBackoff; after MaxAttempts the
+// delivery moves to the dead-letter list.
+type WebhookRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Lease is how long a claimed delivery stays hidden from other workers while it is sent.
+	Lease     time.Duration
+	BatchSize int
+}
+
+func DefaultWebhookRetryPolicy() WebhookRetryPolicy {
+	return WebhookRetryPolicy{
+		MaxAttempts:    8,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     6 * time.Hour,
+		Lease:          time.Minute,
+		BatchSize:      100,
+	}
+}
+
+func (p WebhookRetryPolicy) backoff(attempts int) time.Duration {
+	return exponentialBackoff(p.InitialBackoff, p.MaxBackoff, attempts)
+}
+
+// webhookEnvelope is the body posted to subscribers. ID is the domain event ID, shared by every
+// delivery of the event, so receivers can drop duplicates.
+type webhookEnvelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookDeliveryReport summarizes one pass over the due deliveries.
+type WebhookDeliveryReport struct {
+	Attempted  int
+	Delivered  int
+	Failed     int
+	DeadLetter int
+}
+
+// WebhookService manages partner webhook subscriptions, queues deliveries for the events they
+// selected and sends the due ones with retries.
+type WebhookService struct {
+	subscriptionRepo repositories.WebhookSubscriptionRepository
+	deliveryRepo     repositories.WebhookDeliveryRepository
+	partnerRepo      repositories.PartnerRepository
+	sender           WebhookSender
+	policy           WebhookRetryPolicy
+}
+
+var _ EventSink = (*WebhookService)(nil)
+
+func NewWebhookService(
+	subscriptionRepo repositories.WebhookSubscriptionRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	partnerRepo repositories.PartnerRepository,
+	sender WebhookSender,
+	policy WebhookRetryPolicy,
+) *WebhookService {
+	if subscriptionRepo == nil {
+		return nil
+	}
+
+	return &WebhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		partnerRepo:      partnerRepo,
+		sender:           sender,
+		policy:           policy,
+	}
+}
+
+// CreateSubscription registers a subscription for the partner. A signing secret is generated
+// when none is given; it is only returned here, on creation.
+func (s *WebhookService) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	if s == nil || s.subscriptionRepo == nil {
+		return ErrWebhookRepositoryUnavailable
+	}
+	if subscription == nil {
+		return entities.ErrWebhookSubscriptionNil
+	}
+
+	if strings.TrimSpace(subscription.Secret) == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return err
+		}
+		subscription.Secret = secret
+	}
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+	if s.partnerRepo != nil {
+		partner, err := s.partnerRepo.GetPartnerByID(ctx, subscription.PartnerID)
+		if err != nil {
+			return err
+		}
+		if partner == nil {
+			return ErrPartnerNotFound
+		}
+	}
+
+	now := time.Now().UTC()
+	subscription.ID = primitive.NewObjectID()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	return s.subscriptionRepo.CreateSubscription(ctx, subscription)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, partnerID, id primitive.ObjectID) (*entities.WebhookSubscription, error) {
+	if s == nil || s.subscriptionRepo == nil {
+		return nil, ErrWebhookRepositoryUnavailable
+	}
+
+	subscription, err := s.subscriptionRepo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil || subscription.PartnerID != partnerID {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription replaces the URL, event types and active flag. The secret is kept unless
+// a new one is given.
+func (s *WebhookService) UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	if subscription == nil {
+		return entities.ErrWebhookSubscriptionNil
+	}
+
+	existing, err := s.GetSubscription(ctx, subscription.PartnerID, subscription.ID)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(subscription.Secret) == "" {
+		subscription.Secret = existing.Secret
+	}
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+	subscription.CreatedAt = existing.CreatedAt
+	subscription.UpdatedAt = time.Now().UTC()
+
+	return s.subscriptionRepo.UpdateSubscription(ctx, subscription)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, partnerID, id primitive.ObjectID) error {
+	if _, err := s.GetSubscription(ctx, partnerID, id); err != nil {
+		return err
+	}
+
+	return s.subscriptionRepo.DeleteSubscription(ctx, id)
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context, partnerID primitive.ObjectID) ([]*entities.WebhookSubscription, error) {
+	if s == nil || s.subscriptionRepo == nil {
+		return nil, ErrWebhookRepositoryUnavailable
+	}
+
+	return s.subscriptionRepo.ListSubscriptionsByPartner(ctx, partnerID)
+}
+
+// Name identifies the webhook queue as an event sink.
+func (s *WebhookService) Name() string {
+	return "webhooks"
+}
+
+// Publish queues a delivery of a webhook event for every active subscription of the partner it
+// concerns that selected it. Other domain events are ignored, as are all events when webhooks
+// are not configured.
+func (s *WebhookService) Publish(ctx context.Context, event *entities.DomainEvent) error {
+	if s == nil || s.subscriptionRepo == nil || s.deliveryRepo == nil || event == nil {
+		return nil
+	}
+	if event.PartnerID.IsZero() || !entities.IsWebhookEventType(event.Type) {
+		return nil
+	}
+
+	subscriptions, err := s.subscriptionRepo.ListSubscriptionsByPartner(ctx, event.PartnerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var payload []byte
+	var deliveries []*entities.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(event.Type) {
+			continue
+		}
+		if payload == nil {
+			envelope := webhookEnvelope{ID: event.ID.Hex(), Type: event.Type, CreatedAt: event.OccurredAt, Data: json.RawMessage(event.Data)}
+			if payload, err = json.Marshal(envelope); err != nil {
+				return fmt.Errorf("encoding webhook payload: %w", err)
+			}
+		}
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			ID:             primitive.NewObjectID(),
+			SubscriptionID: subscription.ID,
+			PartnerID:      event.PartnerID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return s.deliveryRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue sends the deliveries due at now. Failures are rescheduled with backoff; deliveries
+// whose subscription was removed or deactivated go straight to the dead-letter list.
+func (s *WebhookService) DeliverDue(ctx context.Context, now time.Time) (WebhookDeliveryReport, error) {
+	var report WebhookDeliveryReport
+	if s == nil || s.subscriptionRepo == nil || s.deliveryRepo == nil {
+		return report, ErrWebhookRepositoryUnavailable
+	}
+	if s.sender == nil {
+		return report, ErrWebhookSenderUnavailable
+	}
+
+	now = now.UTC()
+	deliveries, err := s.deliveryRepo.ClaimDueDeliveries(ctx, now, s.policy.Lease, s.policy.BatchSize)
+	if err != nil {
+		return report, err
+	}
+
+	subscriptions := make(map[primitive.ObjectID]*entities.WebhookSubscription)
+	for _, delivery := range deliveries {
+		subscription, cached := subscriptions[delivery.SubscriptionID]
+		if !cached {
+			if subscription, err = s.subscriptionRepo.GetSubscriptionByID(ctx, delivery.SubscriptionID); err != nil {
+				return report, err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		report.Attempted++
+		switch {
+		case subscription == nil || !subscription.Active:
+			delivery.RecordFailure(0, errors.New("subscription removed or inactive"), now, 0, 0)
+		default:
+			status, sendErr := s.sender.Send(ctx, subscription, delivery)
+			if sendErr == nil {
+				delivery.RecordSuccess(status, time.Now().UTC())
+			} else {
+				delivery.RecordFailure(status, sendErr, time.Now().UTC(), s.policy.MaxAttempts, s.policy.backoff(delivery.Attempts+1))
+			}
+		}
+
+		switch delivery.Status {
+		case entities.WebhookDeliveryDelivered:
+			report.Delivered++
+		case entities.WebhookDeliveryDeadLetter:
+			report.DeadLetter++
+		default:
+			report.Failed++
+		}
+
+		if err := s.deliveryRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// ListDeliveries returns the partner's deliveries; filter by the dead_letter status for the
+// dead-letter list.
+func (s *WebhookService) ListDeliveries(ctx context.Context, query repositories.WebhookDeliveryQuery) ([]*entities.WebhookDelivery, repositories.PageInfo, error) {
+	if s == nil || s.deliveryRepo == nil {
+		return nil, repositories.PageInfo{}, ErrWebhookRepositoryUnavailable
+	}
+	if err := query.Validate(); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return s.deliveryRepo.ListDeliveries(ctx, query)
+}
+
+// Redeliver queues a delivery of the partner again, whatever its status, with a fresh set of
+// attempts. It is sent on the next pass of the delivery worker.
+func (s *WebhookService) Redeliver(ctx context.Context, partnerID, id primitive.ObjectID) (*entities.WebhookDelivery, error) {
+	if s == nil || s.deliveryRepo == nil {
+		return nil, ErrWebhookRepositoryUnavailable
+	}
+
+	delivery, err := s.deliveryRepo.GetDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil || delivery.PartnerID != partnerID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	delivery.Redeliver(time.Now().UTC())
+	if err := s.deliveryRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generating webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
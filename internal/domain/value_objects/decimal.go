@@ -0,0 +1,163 @@
+package valueobjects
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+var errDecimalOverflow = errors.New("decimal value out of range")
+
+// parseDecimalText reads a plain decimal number. A single comma is accepted as the decimal
+// separator when the text has no dot.
+func parseDecimalText(text string) (*big.Rat, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("empty decimal value")
+	}
+	if !strings.Contains(text, ".") && strings.Count(text, ",") == 1 {
+		text = strings.Replace(text, ",", ".", 1)
+	}
+	if strings.ContainsAny(text, "/_") {
+		return nil, fmt.Errorf("invalid decimal value %q", text)
+	}
+
+	value, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal value %q", text)
+	}
+	return value, nil
+}
+
+// ratToUnits scales the value by 10^decimals and rounds half away from zero. exact reports
+// whether the value already fit the scale.
+func ratToUnits(value *big.Rat, decimals int) (units int64, exact bool, err error) {
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(pow10(decimals)))
+	if scaled.IsInt() {
+		if !scaled.Num().IsInt64() {
+			return 0, false, errDecimalOverflow
+		}
+		return scaled.Num().Int64(), true, nil
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		if scaled.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return 0, false, errDecimalOverflow
+	}
+	return quotient.Int64(), false, nil
+}
+
+// divRound divides and rounds half away from zero.
+func divRound(numerator, denominator *big.Int) int64 {
+	units, _, _ := ratToUnits(new(big.Rat).SetFrac(numerator, denominator), 0)
+	return units
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// formatUnits renders units/10^decimals keeping at least minDecimals fractional digits.
+func formatUnits(units int64, decimals, minDecimals int) string {
+	sign := ""
+	magnitude := new(big.Int).SetInt64(units)
+	if units < 0 {
+		sign = "-"
+		magnitude.Neg(magnitude)
+	}
+
+	digits := magnitude.String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integer := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if len(fraction) < minDecimals {
+		fraction += strings.Repeat("0", minDecimals-len(fraction))
+	}
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+func unitsToDecimal128(units int64, decimals int) (primitive.Decimal128, error) {
+	value, ok := primitive.ParseDecimal128FromBigInt(big.NewInt(units), -decimals)
+	if !ok {
+		return primitive.Decimal128{}, errDecimalOverflow
+	}
+	return value, nil
+}
+
+func decimal128ToRat(value primitive.Decimal128) (*big.Rat, error) {
+	coefficient, exponent, err := value.BigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Rat).SetInt(coefficient)
+	if exponent > 0 {
+		result.Mul(result, new(big.Rat).SetInt(pow10(exponent)))
+	} else if exponent < 0 {
+		result.Quo(result, new(big.Rat).SetInt(pow10(-exponent)))
+	}
+	return result, nil
+}
+
+// unmarshalDecimalJSON accepts a JSON string or number, read from its literal text so no binary
+// floating point is involved. null yields a nil value.
+func unmarshalDecimalJSON(data []byte) (*big.Rat, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		text, err := strconv.Unquote(string(data))
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(text) == "" {
+			return nil, nil
+		}
+		return parseDecimalText(text)
+	}
+	return parseDecimalText(string(data))
+}
+
+// unmarshalDecimalBSON reads Decimal128 and, for documents written before the decimal types,
+// doubles, integers and numeric strings. Null yields a nil value.
+func unmarshalDecimalBSON(kind bsontype.Type, data []byte) (*big.Rat, error) {
+	value := bsoncore.Value{Type: kind, Data: data}
+
+	switch kind {
+	case bsontype.Decimal128:
+		return decimal128ToRat(value.Decimal128())
+	case bsontype.Double:
+		return new(big.Rat).SetFloat64(value.Double()), nil
+	case bsontype.Int32:
+		return new(big.Rat).SetInt64(int64(value.Int32())), nil
+	case bsontype.Int64:
+		return new(big.Rat).SetInt64(value.Int64()), nil
+	case bsontype.String:
+		return parseDecimalText(value.StringValue())
+	case bsontype.Null, bsontype.Undefined:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("cannot decode %s into a decimal value", kind)
+	}
+}
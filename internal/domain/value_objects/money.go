@@ -0,0 +1,280 @@
+package valueobjects
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Currency is an ISO 4217 code.
+type Currency string
+
+const (
+	CurrencyBRL     Currency = "BRL"
+	DefaultCurrency          = CurrencyBRL
+
+	moneyDecimals = 2
+)
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money is an exact amount in minor units (cents). The zero value is zero reais. Arithmetic
+// between amounts assumes a single currency; the platform only operates in BRL today.
+//
+// Money is written to JSON as a decimal string ("1234.56", or "1234.56 USD" outside the default
+// currency) and to Mongo as Decimal128. Both decoders also accept the plain numbers stored
+// before the type existed.
+type Money struct {
+	cents int64
+	// currency is empty for DefaultCurrency so that equal amounts compare equal with ==.
+	currency Currency
+}
+
+// NewMoney builds an amount from minor units.
+func NewMoney(cents int64, currency Currency) Money {
+	return Money{cents: cents, currency: normalizeCurrency(currency)}
+}
+
+// Cents builds an amount in the default currency from minor units.
+func Cents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// ParseMoney reads a decimal amount with at most two fractional digits, optionally followed by
+// a currency code, e.g. "1234.56", "1234,56" or "10 USD".
+func ParseMoney(text string) (Money, error) {
+	amount, currency := splitCurrency(text)
+
+	value, err := parseDecimalText(amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	cents, exact, err := ratToUnits(value, moneyDecimals)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	if !exact {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidMoney, amount, moneyDecimals)
+	}
+	return NewMoney(cents, currency), nil
+}
+
+// MustParseMoney is ParseMoney for constants known to be valid; it panics otherwise.
+func MustParseMoney(text string) Money {
+	money, err := ParseMoney(text)
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
+// MoneyFromFloat rounds a binary float to cents. It exists for legacy values and for results of
+// floating-point formulas (e.g. compounding) that must be brought back to exact cents.
+func MoneyFromFloat(value float64) Money {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Money{}
+	}
+	cents, _, err := ratToUnits(new(big.Rat).SetFloat64(value), moneyDecimals)
+	if err != nil {
+		return Money{}
+	}
+	return Money{cents: cents}
+}
+
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.cents > 0
+}
+
+// Cmp returns -1, 0 or +1 comparing m to other.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) LessThan(other Money) bool {
+	return m.cents < other.cents
+}
+
+func (m Money) GreaterThan(other Money) bool {
+	return m.cents > other.cents
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{cents: m.cents + other.cents, currency: m.currency}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{cents: m.cents - other.cents, currency: m.currency}
+}
+
+func (m Money) Mul(factor int64) Money {
+	return Money{cents: m.cents * factor, currency: m.currency}
+}
+
+// Div splits the amount, rounding the quotient half away from zero.
+func (m Money) Div(divisor int64) Money {
+	if divisor == 0 {
+		return Money{currency: m.currency}
+	}
+	return Money{cents: divRound(big.NewInt(m.cents), big.NewInt(divisor)), currency: m.currency}
+}
+
+// Percent returns rate percent of the amount rounded half away from zero, e.g. 2.5% of 100.00.
+func (m Money) Percent(rate Rate) Money {
+	numerator := new(big.Int).Mul(big.NewInt(m.cents), big.NewInt(rate.units))
+	denominator := new(big.Int).Mul(big.NewInt(100), pow10(rateDecimals))
+	return Money{cents: divRound(numerator, denominator), currency: m.currency}
+}
+
+// Float64 returns the amount in major units for ratios and floating-point formulas.
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// Amount renders the amount without currency, always with two decimals.
+func (m Money) Amount() string {
+	return formatUnits(m.cents, moneyDecimals, moneyDecimals)
+}
+
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Amount()
+	}
+	return m.Amount() + " " + string(m.currency)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		text, err := strconv.Unquote(trimmed)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+		}
+		if strings.TrimSpace(text) == "" {
+			*m = Money{}
+			return nil
+		}
+		parsed, err := ParseMoney(text)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	value, err := unmarshalDecimalJSON(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	return m.setRat(value, "", true)
+}
+
+type moneyDocument struct {
+	Amount   primitive.Decimal128 `bson:"amount"`
+	Currency Currency             `bson:"currency"`
+}
+
+// MarshalBSONValue stores the default currency as a bare Decimal128 and other currencies as an
+// {amount, currency} document.
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	amount, err := unitsToDecimal128(m.cents, moneyDecimals)
+	if err != nil {
+		return 0, nil, err
+	}
+	if m.currency == "" {
+		return bsontype.Decimal128, bsoncore.AppendDecimal128(nil, amount), nil
+	}
+
+	data, err := bson.Marshal(moneyDocument{Amount: amount, Currency: m.currency})
+	return bsontype.EmbeddedDocument, data, err
+}
+
+func (m *Money) UnmarshalBSONValue(kind bsontype.Type, data []byte) error {
+	if kind == bsontype.EmbeddedDocument {
+		var doc moneyDocument
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		value, err := decimal128ToRat(doc.Amount)
+		if err != nil {
+			return err
+		}
+		return m.setRat(value, normalizeCurrency(doc.Currency), false)
+	}
+
+	value, err := unmarshalDecimalBSON(kind, data)
+	if err != nil {
+		return err
+	}
+	// Legacy doubles are rounded to cents rather than rejected.
+	return m.setRat(value, "", false)
+}
+
+func (m *Money) setRat(value *big.Rat, currency Currency, strict bool) error {
+	if value == nil {
+		*m = Money{}
+		return nil
+	}
+	cents, exact, err := ratToUnits(value, moneyDecimals)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	if strict && !exact {
+		return fmt.Errorf("%w: more than %d decimal places", ErrInvalidMoney, moneyDecimals)
+	}
+	*m = Money{cents: cents, currency: currency}
+	return nil
+}
+
+func splitCurrency(text string) (string, Currency) {
+	fields := strings.Fields(strings.TrimSpace(text))
+	if len(fields) == 2 && len(fields[1]) == 3 {
+		return fields[0], Currency(strings.ToUpper(fields[1]))
+	}
+	return strings.TrimSpace(text), ""
+}
+
+func normalizeCurrency(currency Currency) Currency {
+	currency = Currency(strings.ToUpper(strings.TrimSpace(string(currency))))
+	if currency == DefaultCurrency {
+		return ""
+	}
+	return currency
+}
@@ -0,0 +1,74 @@
+package valueobjects
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMoney_ExactArithmeticAndRounding(t *testing.T) {
+	amount, err := ParseMoney("1000,10")
+	if err != nil {
+		t.Fatalf("ParseMoney returned error: %v", err)
+	}
+	if amount.Cents() != 100010 {
+		t.Fatalf("expected 100010 cents, got %d", amount.Cents())
+	}
+	if _, err := ParseMoney("10.005"); err == nil {
+		t.Fatalf("expected amounts below one cent to be rejected")
+	}
+
+	if fee := Cents(100000).Percent(MustParseRate("0.38")); fee.String() != "3.80" {
+		t.Fatalf("expected 0.38%% of 1000.00 to be 3.80, got %s", fee)
+	}
+	if third := MustParseMoney("0.50").Div(3); third.String() != "0.17" {
+		t.Fatalf("expected 0.50/3 to round half up to 0.17, got %s", third)
+	}
+	if sum := MustParseMoney("0.10").Add(MustParseMoney("0.20")); sum != MustParseMoney("0.30") {
+		t.Fatalf("expected 0.10+0.20 to be exactly 0.30, got %s", sum)
+	}
+}
+
+func TestMoney_EncodesAsDecimalAndReadsLegacyNumbers(t *testing.T) {
+	type document struct {
+		Amount Money `bson:"amount" json:"amount"`
+		Rate   Rate  `bson:"rate" json:"rate"`
+	}
+
+	legacy, err := bson.Marshal(bson.M{"amount": 1234.567, "rate": 2.3})
+	if err != nil {
+		t.Fatalf("bson.Marshal returned error: %v", err)
+	}
+	var decoded document
+	if err := bson.Unmarshal(legacy, &decoded); err != nil {
+		t.Fatalf("expected legacy doubles to decode, got %v", err)
+	}
+	if decoded.Amount.String() != "1234.57" || decoded.Rate.String() != "2.3" {
+		t.Fatalf("expected legacy values rounded to 1234.57 and 2.3, got %s and %s", decoded.Amount, decoded.Rate)
+	}
+
+	encoded, err := bson.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("bson.Marshal returned error: %v", err)
+	}
+	if kind := bson.Raw(encoded).Lookup("amount").Type; kind != bson.TypeDecimal128 {
+		t.Fatalf("expected amount stored as Decimal128, got %s", kind)
+	}
+
+	body, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if string(body) != `{"amount":"1234.57","rate":"2.3"}` {
+		t.Fatalf("expected decimal strings in JSON, got %s", body)
+	}
+
+	var request document
+	if err := json.Unmarshal([]byte(`{"amount": 10.5, "rate": "1,25"}`), &request); err != nil {
+		t.Fatalf("expected JSON numbers and decimal commas to be accepted, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 10.555}`), &request); err == nil {
+		t.Fatalf("expected JSON amounts below one cent to be rejected")
+	}
+}
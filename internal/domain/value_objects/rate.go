@@ -0,0 +1,170 @@
+package valueobjects
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+const rateDecimals = 6
+
+var ErrInvalidRate = errors.New("invalid rate")
+
+// Rate is a percentage with fixed precision of six decimal places, e.g. 2.3 means 2.3%. Like
+// Money it is written to JSON as a decimal string and to Mongo as Decimal128, and reads the
+// plain numbers stored before the type existed.
+type Rate struct {
+	units int64
+}
+
+// ParseRate reads a percentage with at most six fractional digits.
+func ParseRate(text string) (Rate, error) {
+	value, err := parseDecimalText(text)
+	if err != nil {
+		return Rate{}, fmt.Errorf("%w: %v", ErrInvalidRate, err)
+	}
+	units, exact, err := ratToUnits(value, rateDecimals)
+	if err != nil {
+		return Rate{}, fmt.Errorf("%w: %v", ErrInvalidRate, err)
+	}
+	if !exact {
+		return Rate{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidRate, text, rateDecimals)
+	}
+	return Rate{units: units}, nil
+}
+
+// MustParseRate is ParseRate for constants known to be valid; it panics otherwise.
+func MustParseRate(text string) Rate {
+	rate, err := ParseRate(text)
+	if err != nil {
+		panic(err)
+	}
+	return rate
+}
+
+// RateFromFloat rounds a floating-point percentage to the rate precision. It is meant for the
+// results of compounding formulas, which cannot be computed exactly.
+func RateFromFloat(percent float64) Rate {
+	if math.IsNaN(percent) || math.IsInf(percent, 0) {
+		return Rate{}
+	}
+	units, _, err := ratToUnits(new(big.Rat).SetFloat64(percent), rateDecimals)
+	if err != nil {
+		return Rate{}
+	}
+	return Rate{units: units}
+}
+
+func (r Rate) IsZero() bool {
+	return r.units == 0
+}
+
+func (r Rate) IsNegative() bool {
+	return r.units < 0
+}
+
+func (r Rate) IsPositive() bool {
+	return r.units > 0
+}
+
+// Cmp returns -1, 0 or +1 comparing r to other.
+func (r Rate) Cmp(other Rate) int {
+	switch {
+	case r.units < other.units:
+		return -1
+	case r.units > other.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r Rate) LessThan(other Rate) bool {
+	return r.units < other.units
+}
+
+func (r Rate) GreaterThan(other Rate) bool {
+	return r.units > other.units
+}
+
+func (r Rate) Add(other Rate) Rate {
+	return Rate{units: r.units + other.units}
+}
+
+func (r Rate) Sub(other Rate) Rate {
+	return Rate{units: r.units - other.units}
+}
+
+// Round keeps the given number of decimal places, rounding half away from zero.
+func (r Rate) Round(decimals int) Rate {
+	if decimals >= rateDecimals || decimals < 0 {
+		return r
+	}
+	step := pow10(rateDecimals - decimals)
+	rounded := divRound(big.NewInt(r.units), step)
+	return Rate{units: rounded * step.Int64()}
+}
+
+// Percent returns the percentage as a float, e.g. 2.3 for 2.3%.
+func (r Rate) Percent() float64 {
+	return float64(r.units) / math.Pow10(rateDecimals)
+}
+
+// Fraction returns the rate as a fraction of one, e.g. 0.023 for 2.3%, for compounding formulas.
+func (r Rate) Fraction() float64 {
+	return r.Percent() / 100
+}
+
+func (r Rate) String() string {
+	return formatUnits(r.units, rateDecimals, 0)
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalDecimalJSON(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRate, err)
+	}
+	return r.setRat(value, true)
+}
+
+func (r Rate) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	value, err := unitsToDecimal128(r.units, rateDecimals)
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsontype.Decimal128, bsoncore.AppendDecimal128(nil, value), nil
+}
+
+func (r *Rate) UnmarshalBSONValue(kind bsontype.Type, data []byte) error {
+	value, err := unmarshalDecimalBSON(kind, data)
+	if err != nil {
+		return err
+	}
+	// Legacy doubles are rounded to the rate precision rather than rejected.
+	return r.setRat(value, false)
+}
+
+func (r *Rate) setRat(value *big.Rat, strict bool) error {
+	if value == nil {
+		*r = Rate{}
+		return nil
+	}
+	units, exact, err := ratToUnits(value, rateDecimals)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRate, err)
+	}
+	if strict && !exact {
+		return fmt.Errorf("%w: more than %d decimal places", ErrInvalidRate, rateDecimals)
+	}
+	*r = Rate{units: units}
+	return nil
+}
@@ -0,0 +1,44 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+// RateIndex is a Brazilian reference rate used to price floating-rate products.
+type RateIndex string
+
+const (
+	RateIndexCDI   RateIndex = "cdi"
+	RateIndexSELIC RateIndex = "selic"
+	RateIndexIPCA  RateIndex = "ipca"
+)
+
+// RateIndexes lists every supported index.
+var RateIndexes = []RateIndex{RateIndexCDI, RateIndexSELIC, RateIndexIPCA}
+
+var ErrInvalidRateIndex = errors.New("invalid rate index")
+
+// NewRateIndex creates a validated RateIndex, accepting any letter case.
+func NewRateIndex(value string) (RateIndex, error) {
+	index := RateIndex(strings.ToLower(strings.TrimSpace(value)))
+	if err := index.Validate(); err != nil {
+		return "", err
+	}
+	return index, nil
+}
+
+// Validate checks if the RateIndex is supported
+func (ri RateIndex) Validate() error {
+	for _, index := range RateIndexes {
+		if ri == index {
+			return nil
+		}
+	}
+	return ErrInvalidRateIndex
+}
+
+// String returns the string representation
+func (ri RateIndex) String() string {
+	return string(ri)
+}
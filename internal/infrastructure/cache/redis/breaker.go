@@ -0,0 +1,164 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ErrCircuitOpen is returned instead of calling Redis while the breaker is open.
+var ErrCircuitOpen = errors.New("redis circuit breaker is open")
+
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half_open"
+)
+
+// Breaker is a go-redis hook that stops calling Redis after consecutive failures. Once the
+// cooldown has passed a single command probes Redis: success closes the breaker, failure opens
+// it for another cooldown. Only transport failures count; replies such as a missing key or a
+// WRONGTYPE error mean Redis is answering.
+type Breaker struct {
+	failures int
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	state       string
+	consecutive int
+	openedAt    time.Time
+	probing     bool
+}
+
+// NewBreaker returns nil when failures is not positive, leaving the client unguarded.
+func NewBreaker(failures int, cooldown time.Duration) *Breaker {
+	if failures <= 0 {
+		return nil
+	}
+	if cooldown <= 0 {
+		cooldown = 10 * time.Second
+	}
+
+	return &Breaker{
+		failures: failures,
+		cooldown: cooldown,
+		now:      time.Now,
+		state:    StateClosed,
+	}
+}
+
+// State reports closed, open or half_open; a nil breaker is always closed.
+func (b *Breaker) State() string {
+	if b == nil {
+		return StateClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && !b.now().Before(b.openedAt.Add(b.cooldown)) {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) DialHook(next goredis.DialHook) goredis.DialHook {
+	return next
+}
+
+// guardedKey marks the context of a command the breaker already let through. Commands go-redis
+// issues on its behalf, such as the handshake of a new connection, carry it and are left to the
+// outcome of the outer command.
+type guardedKey struct{}
+
+func (b *Breaker) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		if ctx.Value(guardedKey{}) != nil {
+			return next(ctx, cmd)
+		}
+		if err := b.allow(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+
+		err := next(context.WithValue(ctx, guardedKey{}, true), cmd)
+		b.report(err)
+		return err
+	}
+}
+
+func (b *Breaker) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		if ctx.Value(guardedKey{}) != nil {
+			return next(ctx, cmds)
+		}
+		if err := b.allow(); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+
+		err := next(context.WithValue(ctx, guardedKey{}, true), cmds)
+		b.report(err)
+		return err
+	}
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return ErrCircuitOpen
+		}
+		b.state, b.probing = StateHalfOpen, true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) report(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isTransportFailure(err) {
+		if b.state != StateClosed {
+			log.Printf("redis: circuit breaker closed")
+		}
+		b.state, b.consecutive, b.probing = StateClosed, 0, false
+		return
+	}
+
+	b.consecutive++
+	if b.state == StateHalfOpen || b.consecutive >= b.failures {
+		if b.state != StateOpen {
+			log.Printf("redis: circuit breaker open failures=%d cooldown=%s error=%v", b.consecutive, b.cooldown, err)
+		}
+		b.state, b.openedAt, b.probing = StateOpen, b.now(), false
+	}
+}
+
+// isTransportFailure tells Redis being unreachable or slow apart from Redis answering.
+func isTransportFailure(err error) bool {
+	if err == nil || errors.Is(err, goredis.Nil) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var reply goredis.Error
+	return !errors.As(err, &reply)
+}
@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestBreaker_OpensOnTransportFailuresAndRecovers(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	breaker := NewBreaker(2, 50*time.Millisecond)
+
+	client, err := NewClient(Config{Addresses: []string{server.Addr()}, Timeout: 100 * time.Millisecond, Breaker: breaker})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Missing keys are answers, not failures.
+	for i := 0; i < 3; i++ {
+		if err := client.Get(ctx, "absent").Err(); !errors.Is(err, goredis.Nil) {
+			t.Fatalf("expected redis.Nil, got %v", err)
+		}
+	}
+	if state := breaker.State(); state != StateClosed {
+		t.Fatalf("expected the breaker to stay closed, got %s", state)
+	}
+
+	server.Close()
+	for i := 0; i < 2; i++ {
+		if err := client.Get(ctx, "key").Err(); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected a transport error, got %v", err)
+		}
+	}
+	if err := client.Get(ctx, "key").Err(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the breaker to reject calls, got %v", err)
+	}
+	if state := breaker.State(); state != StateOpen {
+		t.Fatalf("expected the breaker to be open, got %s", state)
+	}
+
+	if err := server.Restart(); err != nil {
+		t.Fatalf("restarting server: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if state := breaker.State(); state != StateHalfOpen {
+		t.Fatalf("expected the breaker to be half open after the cooldown, got %s", state)
+	}
+	if err := client.Set(ctx, "key", "value", 0).Err(); err != nil {
+		t.Fatalf("expected the probe to reach Redis, got %v", err)
+	}
+	if state := breaker.State(); state != StateClosed {
+		t.Fatalf("expected a successful probe to close the breaker, got %s", state)
+	}
+}
@@ -2,32 +2,190 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	goredis "github.com/redis/go-redis/v9"
 )
 
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
 type Config struct {
-	Address  string
+	// Mode selects a single server, a Sentinel-managed master or a cluster; empty means standalone.
+	Mode string
+	// Addresses are the server, the Sentinels or the cluster seed nodes, depending on Mode.
+	Addresses []string
+	// MasterName is the master monitored by the Sentinels.
+	MasterName string
+	// Username and Password authenticate with an ACL user, or the default user when Username is empty.
+	Username string
 	Password string
-	DB       int
+	// SentinelUsername and SentinelPassword authenticate with the Sentinels themselves.
+	SentinelUsername string
+	SentinelPassword string
+	// DB is not supported by clusters, which only have database 0.
+	DB  int
+	TLS TLSConfig
+	// DialTimeout and Timeout bound connecting and each read or write, so a slow Redis fails
+	// fast enough for callers to fall back. Zero keeps the go-redis defaults.
+	DialTimeout time.Duration
+	Timeout     time.Duration
+	// PoolSize, MinIdleConns and PoolTimeout size the connection pool of each node; zero keeps
+	// the go-redis defaults.
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
+	// Breaker, when set, stops calling Redis while it keeps failing.
+	Breaker *Breaker
+}
+
+// TLSConfig enables TLS towards every node. CAFile replaces the system roots, and CertFile and
+// KeyFile present a client certificate. ServerName defaults to the host of each address.
+type TLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
 }
 
-func NewClient(cfg Config) (*goredis.Client, error) {
-	options := &goredis.Options{
-		Addr:     cfg.Address,
-		Password: cfg.Password,
-		DB:       cfg.DB,
+func NewClient(cfg Config) (goredis.UniversalClient, error) {
+	options, err := universalOptions(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	client := goredis.NewClient(options)
+	var client goredis.UniversalClient
+	switch options.mode {
+	case ModeSentinel:
+		client = goredis.NewFailoverClient(options.Failover())
+	case ModeCluster:
+		client = goredis.NewClusterClient(options.Cluster())
+	default:
+		client = goredis.NewClient(options.Simple())
+	}
+	if cfg.Breaker != nil {
+		client.AddHook(cfg.Breaker)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
 		return nil, err
 	}
 
 	return client, nil
 }
+
+type modeOptions struct {
+	goredis.UniversalOptions
+	mode string
+}
+
+func universalOptions(cfg Config) (*modeOptions, error) {
+	mode := strings.ToLower(strings.TrimSpace(cfg.Mode))
+	if mode == "" {
+		mode = ModeStandalone
+	}
+
+	addresses := make([]string, 0, len(cfg.Addresses))
+	for _, address := range cfg.Addresses {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, errors.New("redis: no address configured")
+	}
+
+	switch mode {
+	case ModeStandalone:
+		if len(addresses) > 1 {
+			return nil, fmt.Errorf("redis: %d addresses given in standalone mode", len(addresses))
+		}
+	case ModeSentinel:
+		if strings.TrimSpace(cfg.MasterName) == "" {
+			return nil, errors.New("redis: sentinel mode needs a master name")
+		}
+	case ModeCluster:
+		if cfg.DB != 0 {
+			return nil, errors.New("redis: cluster mode only supports database 0")
+		}
+	default:
+		return nil, fmt.Errorf("redis: unknown mode %q", cfg.Mode)
+	}
+
+	tlsConfig, err := cfg.TLS.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &modeOptions{
+		mode: mode,
+		UniversalOptions: goredis.UniversalOptions{
+			Addrs:            addresses,
+			MasterName:       strings.TrimSpace(cfg.MasterName),
+			Username:         cfg.Username,
+			Password:         cfg.Password,
+			SentinelUsername: cfg.SentinelUsername,
+			SentinelPassword: cfg.SentinelPassword,
+			DB:               cfg.DB,
+			TLSConfig:        tlsConfig,
+			DialTimeout:      cfg.DialTimeout,
+			ReadTimeout:      cfg.Timeout,
+			WriteTimeout:     cfg.Timeout,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+			PoolTimeout:      cfg.PoolTimeout,
+		},
+	}, nil
+}
+
+func (c TLSConfig) build() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         strings.TrimSpace(c.ServerName),
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis: reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("redis: no certificate found in %s", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, errors.New("redis: a client certificate needs both the certificate and the key file")
+		}
+		certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis: loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUniversalOptions_ValidatesMode(t *testing.T) {
+	cases := map[string]Config{
+		"no address":              {},
+		"unknown mode":            {Mode: "ring", Addresses: []string{"localhost:6379"}},
+		"standalone with seeds":   {Addresses: []string{"a:6379", "b:6379"}},
+		"sentinel without name":   {Mode: ModeSentinel, Addresses: []string{"a:26379"}},
+		"cluster with database":   {Mode: ModeCluster, Addresses: []string{"a:6379"}, DB: 2},
+		"certificate without key": {Addresses: []string{"a:6379"}, TLS: TLSConfig{Enabled: true, CertFile: "client.pem"}},
+	}
+	for name, cfg := range cases {
+		if _, err := universalOptions(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	options, err := universalOptions(Config{Mode: "Sentinel", Addresses: []string{" a:26379 ", "b:26379"}, MasterName: "katseye", Username: "app"})
+	if err != nil {
+		t.Fatalf("universalOptions returned error: %v", err)
+	}
+	if options.mode != ModeSentinel || len(options.Addrs) != 2 || options.Addrs[0] != "a:26379" || options.Username != "app" {
+		t.Fatalf("unexpected options %+v", options)
+	}
+}
+
+func TestTLSConfig_LoadsCertificateAuthority(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("writing CA file: %v", err)
+	}
+
+	if _, err := (TLSConfig{Enabled: true, CAFile: caFile}).build(); err == nil {
+		t.Fatal("expected a CA file without certificates to be rejected")
+	}
+
+	config, err := (TLSConfig{Enabled: true, ServerName: "redis.internal"}).build()
+	if err != nil || config == nil || config.ServerName != "redis.internal" {
+		t.Fatalf("expected a TLS config, got %+v (%v)", config, err)
+	}
+	if config, _ := (TLSConfig{}).build(); config != nil {
+		t.Fatal("expected TLS to stay off unless enabled")
+	}
+}
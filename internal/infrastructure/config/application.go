@@ -14,10 +14,12 @@ type Application struct {
 	server       *webrouter.Server
 	mongo        *MongoResources
 	cache        *RedisResources
+	encryption   *EncryptionResources
 	repositories RepositorySet
 	services     ServiceSet
 	handlers     HandlerSet
 	middlewares  MiddlewareSet
+	jobs         *JobRunner
 }
 
 func Initialize() (*Application, error) {
@@ -29,35 +31,112 @@ func Initialize() (*Application, error) {
 	}
 
 	log.Printf(
-		"config: environment=%s gin_mode=%s port=%s mongo_uri=%s mongo_database=%s jwt_secret_configured=%t",
+		"config: environment=%s gin_mode=%s port=%s storage=%s mongo_uri=%s mongo_database=%s jwt_secret_configured=%t privacy_signing_key_configured=%t",
 		settings.Environment,
 		settings.HTTP.GinMode,
 		settings.HTTP.Port,
+		settings.Storage.Backend,
 		settings.Mongo.URI,
 		settings.Mongo.Database,
 		strings.TrimSpace(settings.Auth.JWTSecret) != "",
+		strings.TrimSpace(settings.Privacy.ReportSigningKey) != "",
 	)
 
-	mongoResources, err := newMongoResources(settings.Mongo)
+	mongoResources, err := newStorage(ctx, settings.Storage, settings.Mongo)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to mongo: %w", err)
+		return nil, err
 	}
 
-	log.Printf("mongo: client initialized database=%s", settings.Mongo.Database)
+	if mongoResources != nil {
+		log.Printf("mongo: client initialized database=%s transactions=%t", settings.Mongo.Database, mongoResources.Transactions)
+		if !mongoResources.Transactions {
+			log.Printf("mongo: server is standalone, changes and their outbox events are written without a transaction")
+		}
+	} else {
+		log.Printf("storage: in-memory repositories, data is lost on restart")
+		if settings.Encryption.Enabled {
+			return nil, fmt.Errorf("field encryption requires the %s storage backend", StorageBackendMongo)
+		}
+	}
 
-	redisResources, err := newRedisResources(settings.Cache)
+	redisResources, err := newRedisResources(settings.Cache, settings.Auth)
 	if err != nil {
 		return nil, fmt.Errorf("connecting to redis: %w", err)
 	}
 
 	if redisResources != nil {
-		log.Printf("redis: cache enabled addr=%s db=%d ttl=%s", settings.Cache.Redis.Address, settings.Cache.Redis.DB, redisResources.TTL)
+		log.Printf("redis: cache enabled mode=%s addr=%s db=%d tls=%t ttl=%s list_ttl=%s codec=%s negative_ttl=%s lock_ttl=%s refresh_beta=%g", settings.Cache.Redis.Mode, strings.Join(settings.Cache.Redis.Addresses, ","), settings.Cache.Redis.DB, settings.Cache.Redis.TLS.Enabled, redisResources.Options.TTL, redisResources.Options.ListTTL, redisResources.Options.Codec.Name(), redisResources.Options.NegativeTTL, redisResources.Options.LockTTL, redisResources.Options.RefreshBeta)
+		if local := settings.Cache.Redis.Local; local.Enabled {
+			log.Printf("redis: local cache enabled size=%d ttl=%s overrides=%d", local.Defaults.Size, local.Defaults.TTL, len(local.Resources))
+		}
+		log.Printf("redis: timeout=%s breaker_failures=%d breaker_cooldown=%s revocation_fail_open=%t revocation_replica=%t", settings.Cache.Redis.Timeout, settings.Cache.Redis.BreakerFailures, settings.Cache.Redis.BreakerCooldown, settings.Auth.RevocationFailOpen, settings.Auth.RevocationReplica)
 	} else {
 		log.Printf("redis: cache disabled")
 	}
 
-	repositories := buildRepositories(mongoResources, redisResources)
-	services := buildServices(repositories)
+	encryptionResources, err := newEncryptionResources(ctx, settings.Encryption, mongoResources)
+	if err != nil {
+		return nil, fmt.Errorf("configuring field encryption: %w", err)
+	}
+
+	if encryptionResources != nil {
+		log.Printf("encryption: field encryption enabled active_key=%s fields=%s key_reload=%s", encryptionResources.KeyRing.ActiveKeyID(), strings.Join(encryptionResources.Policy.Fields(), ","), settings.Encryption.KeyReloadInterval)
+	} else {
+		log.Printf("encryption: field encryption disabled")
+	}
+
+	searchIndex, err := newProductSearchIndex(ctx, mongoResources)
+	if err != nil {
+		return nil, fmt.Errorf("configuring product search: %w", err)
+	}
+
+	bureau, err := newCreditBureau(settings.Bureau, redisResources, encryptionResources)
+	if err != nil {
+		return nil, fmt.Errorf("configuring credit bureau: %w", err)
+	}
+
+	if bureau != nil {
+		log.Printf("credit_bureau: provider=%s ttl=%s", bureau.Name(), settings.Bureau.TTL)
+	} else {
+		log.Printf("credit_bureau: disabled")
+	}
+
+	eventStream, err := newEventStream(settings.Events, redisResources)
+	if err != nil {
+		return nil, fmt.Errorf("configuring event stream: %w", err)
+	}
+
+	if eventStream != nil {
+		log.Printf("events: redis stream enabled stream=%s max_len=%d", settings.Events.StreamName, settings.Events.StreamMaxLen)
+	}
+
+	if settings.Screening.Enabled {
+		if mongoResources != nil && settings.Screening.FingerprintKey == "" {
+			return nil, fmt.Errorf("screening requires %s, or %s=false to run without it", screeningFingerprintKeyEnvKey, screeningEnabledEnvKey)
+		}
+		log.Printf("screening: enabled hold_score=%d", settings.Screening.Rules.HoldScore)
+	} else {
+		log.Printf("screening: disabled")
+	}
+
+	var repositories RepositorySet
+	if mongoResources != nil {
+		repositories = buildRepositories(mongoResources, redisResources, encryptionResources, searchIndex)
+	} else {
+		repositories = buildMemoryRepositories(redisResources)
+	}
+	if redisResources == nil && repositories.Token != nil {
+		log.Printf("auth: token revocations kept in process memory, they are not shared between instances")
+	}
+	services := buildServices(repositories, settings.Privacy, settings.Screening, settings.Webhooks, settings.Events, eventStream, bureau)
+	admin, err := seedMemoryAdmin(ctx, settings.Storage, services.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if admin != nil {
+		log.Printf("storage: seeded in-memory admin id=%s", admin.ID.Hex())
+	}
+
 	handlers := buildHandlers(services, settings.Auth)
 	middlewares, err := buildMiddlewares(settings.HTTP, settings.Auth, services.Token)
 	if err != nil {
@@ -72,10 +151,12 @@ func Initialize() (*Application, error) {
 		server:       server,
 		mongo:        mongoResources,
 		cache:        redisResources,
+		encryption:   encryptionResources,
 		repositories: repositories,
 		services:     services,
 		handlers:     handlers,
 		middlewares:  middlewares,
+		jobs:         startJobs(settings.Jobs, services),
 	}
 
 	defer func() {
@@ -101,6 +182,9 @@ func (a *Application) Close(ctx context.Context) error {
 
 	var firstErr error
 
+	a.jobs.Stop()
+	a.encryption.Close()
+
 	if a.mongo != nil {
 		if err := a.mongo.Close(ctx); err != nil {
 			firstErr = retainFirstError(firstErr, err)
@@ -8,6 +8,11 @@ import (
 	"sync"
 	"time"
 
+	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
+	redisclient "katseye/internal/infrastructure/cache/redis"
+	"katseye/internal/infrastructure/persistence/rediscache"
+
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
@@ -22,33 +27,144 @@ const (
 	defaultRedisAddr   = "localhost:6379"
 	defaultRedisDB     = 0
 	defaultRedisTTL    = 5 * time.Minute
+	defaultNegativeTTL = 30 * time.Second
+	defaultRefreshBeta = 1.0
+	defaultL1Size      = 1000
+	defaultL1TTL       = 30 * time.Second
+	defaultRedisDial   = 2 * time.Second
+	defaultRedisIO     = 250 * time.Millisecond
+	defaultBreakerFail = 5
+	defaultBreakerWait = 10 * time.Second
 	defaultCORSOrigins = "*"
 	defaultCORSMethods = "GET,POST,PUT,PATCH,DELETE,OPTIONS"
 	defaultCORSHeaders = "Authorization,Content-Type,Accept,Origin"
 
-	redisEnabledEnvKey = "REDIS_ENABLED"
-	redisAddrEnvKey    = "REDIS_ADDR"
-	redisPasswordKey   = "REDIS_PASSWORD"
-	redisDBEnvKey      = "REDIS_DB"
-	redisTTLEnvKey     = "REDIS_CACHE_TTL"
+	redisEnabledEnvKey     = "REDIS_ENABLED"
+	redisAddrEnvKey        = "REDIS_ADDR"
+	redisPasswordKey       = "REDIS_PASSWORD"
+	redisDBEnvKey          = "REDIS_DB"
+	redisTTLEnvKey         = "REDIS_CACHE_TTL"
+	redisListTTLEnvKey     = "REDIS_CACHE_LIST_TTL"
+	redisCodecEnvKey       = "REDIS_CACHE_CODEC"
+	redisNegativeTTLEnvKey = "REDIS_NEGATIVE_TTL"
+	redisLockTTLEnvKey     = "REDIS_LOCK_TTL"
+	redisRefreshBetaEnvKey = "REDIS_EARLY_REFRESH_BETA"
+	redisL1EnabledEnvKey   = "REDIS_L1_ENABLED"
+	redisL1SizeEnvKey      = "REDIS_L1_SIZE"
+	redisL1TTLEnvKey       = "REDIS_L1_TTL"
+	redisModeEnvKey        = "REDIS_MODE"
+	redisMasterNameEnvKey  = "REDIS_MASTER_NAME"
+	redisUsernameEnvKey    = "REDIS_USERNAME"
+	redisSentinelUserKey   = "REDIS_SENTINEL_USERNAME"
+	redisSentinelPassKey   = "REDIS_SENTINEL_PASSWORD"
+	redisTLSEnabledEnvKey  = "REDIS_TLS_ENABLED"
+	redisTLSCAFileEnvKey   = "REDIS_TLS_CA_FILE"
+	redisTLSCertFileEnvKey = "REDIS_TLS_CERT_FILE"
+	redisTLSKeyFileEnvKey  = "REDIS_TLS_KEY_FILE"
+	redisTLSServerEnvKey   = "REDIS_TLS_SERVER_NAME"
+	redisTLSInsecureEnvKey = "REDIS_TLS_INSECURE_SKIP_VERIFY"
+	redisPoolSizeEnvKey    = "REDIS_POOL_SIZE"
+	redisMinIdleEnvKey     = "REDIS_MIN_IDLE_CONNS"
+	redisPoolTimeoutEnvKey = "REDIS_POOL_TIMEOUT"
+	redisDialTimeoutEnvKey = "REDIS_DIAL_TIMEOUT"
+	redisTimeoutEnvKey     = "REDIS_TIMEOUT"
+	redisBreakerEnvKey     = "REDIS_BREAKER_FAILURES"
+	redisBreakerWaitEnvKey = "REDIS_BREAKER_COOLDOWN"
 
 	appEnvKey                  = "APP_ENV"
 	ginModeEnvKey              = "GIN_MODE"
 	jwtSecretEnvKey            = "JWT_SECRET"
+	revocationFailOpenEnvKey   = "AUTH_REVOCATION_FAIL_OPEN"
+	revocationReplicaEnvKey    = "AUTH_REVOCATION_REPLICA"
 	productionEnvFile          = ".env"
 	developmentEnvFile         = ".env.example"
 	corsAllowedOriginsEnvKey   = "CORS_ALLOWED_ORIGINS"
 	corsAllowedMethodsEnvKey   = "CORS_ALLOWED_METHODS"
 	corsAllowedHeadersEnvKey   = "CORS_ALLOWED_HEADERS"
 	corsAllowCredentialsEnvKey = "CORS_ALLOW_CREDENTIALS"
+	privacySigningKeyEnvKey    = "PRIVACY_REPORT_SIGNING_KEY"
+
+	defaultEncryptedFields     = "document_number,birth_date,monthly_income,annual_revenue,estimated_income,email,phone,secondary_phone"
+	defaultDeterministicFields = "document_number"
+
+	encryptionEnabledEnvKey             = "FIELD_ENCRYPTION_ENABLED"
+	encryptionMasterKeyEnvKey           = "FIELD_ENCRYPTION_MASTER_KEY"
+	encryptionMasterKeyFileEnvKey       = "FIELD_ENCRYPTION_MASTER_KEY_FILE"
+	encryptionFieldsEnvKey              = "FIELD_ENCRYPTION_FIELDS"
+	encryptionDeterministicFieldsEnvKey = "FIELD_ENCRYPTION_DETERMINISTIC_FIELDS"
+	encryptionKeyReloadEnvKey           = "FIELD_ENCRYPTION_KEY_RELOAD_INTERVAL"
+	defaultKeyReloadInterval            = time.Minute
+
+	defaultAgingInterval        = 24 * time.Hour
+	installmentAgingEnvKey      = "INSTALLMENT_AGING_ENABLED"
+	installmentAgingEveryEnvKey = "INSTALLMENT_AGING_INTERVAL"
+
+	defaultWebhookDeliveryInterval = 10 * time.Second
+	defaultWebhookTimeout          = 10 * time.Second
+	webhookDeliveryEnvKey          = "WEBHOOK_DELIVERY_ENABLED"
+	webhookDeliveryEveryEnvKey     = "WEBHOOK_DELIVERY_INTERVAL"
+	webhookTimeoutEnvKey           = "WEBHOOK_TIMEOUT"
+	webhookMaxAttemptsEnvKey       = "WEBHOOK_MAX_ATTEMPTS"
+	webhookInitialBackoffEnvKey    = "WEBHOOK_INITIAL_BACKOFF"
+	webhookMaxBackoffEnvKey        = "WEBHOOK_MAX_BACKOFF"
+
+	defaultOutboxRelayInterval = 2 * time.Second
+	defaultEventStreamName     = "katseye:events"
+	defaultEventStreamMaxLen   = 100000
+	outboxRelayEnvKey          = "OUTBOX_RELAY_ENABLED"
+	outboxRelayEveryEnvKey     = "OUTBOX_RELAY_INTERVAL"
+	outboxMaxAttemptsEnvKey    = "OUTBOX_MAX_ATTEMPTS"
+	outboxInitialBackoffEnvKey = "OUTBOX_INITIAL_BACKOFF"
+	outboxMaxBackoffEnvKey     = "OUTBOX_MAX_BACKOFF"
+	outboxRetentionEnvKey      = "OUTBOX_RETENTION"
+	defaultOutboxRetention     = 7 * 24 * time.Hour
+	eventStreamEnabledEnvKey   = "EVENT_STREAM_ENABLED"
+	eventStreamNameEnvKey      = "EVENT_STREAM_NAME"
+	eventStreamMaxLenEnvKey    = "EVENT_STREAM_MAX_LEN"
+
+	StorageBackendMongo  = "mongo"
+	StorageBackendMemory = "memory"
+	storageBackendEnvKey = "STORAGE_BACKEND"
+	memoryAdminEmailKey  = "MEMORY_ADMIN_EMAIL"
+	memoryAdminPassKey   = "MEMORY_ADMIN_PASSWORD"
+
+	CreditBureauProviderStub       = "stub"
+	defaultCreditBureauTTL         = 24 * time.Hour
+	creditBureauProviderEnvKey     = "CREDIT_BUREAU_PROVIDER"
+	creditBureauTTLEnvKey          = "CREDIT_BUREAU_TTL"
+	creditBureauStubSeedEnvKey     = "CREDIT_BUREAU_STUB_SEED"
+	creditBureauStubProfilesEnvKey = "CREDIT_BUREAU_STUB_PROFILES_FILE"
+	creditBureauCacheSecretEnvKey  = "CREDIT_BUREAU_CACHE_KEY_SECRET"
+
+	screeningEnabledEnvKey             = "SCREENING_ENABLED"
+	screeningFingerprintKeyEnvKey      = "SCREENING_FINGERPRINT_KEY"
+	screeningHoldScoreEnvKey           = "SCREENING_HOLD_SCORE"
+	screeningSharedContactMaxEnvKey    = "SCREENING_SHARED_CONTACT_MAX_CONSUMERS"
+	screeningSharedContactWeightEnvKey = "SCREENING_SHARED_CONTACT_WEIGHT"
+	screeningSharedAddressMaxEnvKey    = "SCREENING_SHARED_ADDRESS_MAX_CONSUMERS"
+	screeningSharedAddressWeightEnvKey = "SCREENING_SHARED_ADDRESS_WEIGHT"
+	screeningIncorporationMonthsEnvKey = "SCREENING_RECENT_INCORPORATION_MONTHS"
+	screeningHighLimitEnvKey           = "SCREENING_HIGH_REQUESTED_LIMIT"
+	screeningIncorporationWeightEnvKey = "SCREENING_RECENT_INCORPORATION_WEIGHT"
+	screeningVelocityMaxEnvKey         = "SCREENING_VELOCITY_MAX_APPLICATIONS"
+	screeningVelocityWindowEnvKey      = "SCREENING_VELOCITY_WINDOW"
+	screeningVelocityWeightEnvKey      = "SCREENING_VELOCITY_WEIGHT"
 )
 
 type Config struct {
 	Environment string
 	HTTP        HTTPConfig
+	Storage     StorageConfig
 	Mongo       MongoConfig
 	Auth        AuthConfig
 	Cache       CacheConfig
+	Privacy     PrivacyConfig
+	Encryption  EncryptionConfig
+	Jobs        JobsConfig
+	Bureau      CreditBureauConfig
+	Screening   ScreeningConfig
+	Webhooks    WebhookConfig
+	Events      EventsConfig
 }
 
 type HTTPConfig struct {
@@ -60,13 +176,94 @@ type HTTPConfig struct {
 	AllowCredentials bool
 }
 
+// StorageConfig selects where the repositories keep their data. The memory backend needs no
+// external service but loses everything on restart and only backs the catalog, consumer and
+// user repositories; it starts with the admin described by AdminEmail and AdminPassword, if set.
+type StorageConfig struct {
+	Backend       string
+	AdminEmail    string
+	AdminPassword string
+}
+
 type MongoConfig struct {
 	URI      string
 	Database string
+	// OutboxRetention is how long published outbox events are kept; zero keeps them forever.
+	OutboxRetention time.Duration
 }
 
 type AuthConfig struct {
 	JWTSecret string
+	// RevocationFailOpen accepts tokens whose revocation cannot be checked because Redis is
+	// unreachable; by default those requests are refused with 503.
+	RevocationFailOpen bool
+	// RevocationReplica keeps the revocations of every instance in memory, so logouts are still
+	// honoured while Redis is unreachable, whichever way the check fails.
+	RevocationReplica bool
+}
+
+type PrivacyConfig struct {
+	ReportSigningKey string
+}
+
+type EncryptionConfig struct {
+	Enabled             bool
+	MasterKey           string
+	MasterKeyFile       string
+	Fields              []string
+	DeterministicFields []string
+	// KeyReloadInterval is how often the API reloads the data keys, so a rotation done by
+	// cmd/rotate_field_keys reaches it without a restart; zero disables it.
+	KeyReloadInterval time.Duration
+}
+
+// JobsConfig controls the background jobs run by the API process. Installment aging takes a lease
+// in Mongo before each run, so it is safe to enable on every replica or to run it from cron too.
+type JobsConfig struct {
+	InstallmentAgingEnabled  bool
+	InstallmentAgingInterval time.Duration
+	// Webhook delivery claims due deliveries with a lease, so replicas do not send twice.
+	WebhookDeliveryEnabled  bool
+	WebhookDeliveryInterval time.Duration
+	// The outbox relay claims events with a lease as well.
+	OutboxRelayEnabled  bool
+	OutboxRelayInterval time.Duration
+}
+
+// WebhookConfig sets the HTTP timeout of webhook deliveries and how failures are retried.
+type WebhookConfig struct {
+	Timeout time.Duration
+	Retry   services.WebhookRetryPolicy
+}
+
+// EventsConfig sets how the outbox relay retries events and whether they are also appended to
+// a Redis stream, which requires Redis to be enabled.
+type EventsConfig struct {
+	Relay         services.EventRelayPolicy
+	StreamEnabled bool
+	StreamName    string
+	StreamMaxLen  int
+}
+
+// CreditBureauConfig selects the credit bureau integration. An empty provider disables credit
+// profile refreshes. TTL is how long the stub lets its answers be cached; answers are cached in
+// Redis only when CacheKeySecret is set, since it keys the documents in their cache keys.
+type CreditBureauConfig struct {
+	Provider         string
+	TTL              time.Duration
+	StubSeed         string
+	StubProfilesFile string
+	CacheKeySecret   string
+}
+
+// ScreeningConfig controls the fraud and AML screening of onboarding and contracting. It is
+// enabled unless SCREENING_ENABLED is set to a false value.
+type ScreeningConfig struct {
+	Enabled bool
+	Rules   services.ScreeningRules
+	// FingerprintKey keys the fingerprints stored in screening signals. Changing it stops new
+	// signals from matching the ones recorded before.
+	FingerprintKey string
 }
 
 type CacheConfig struct {
@@ -75,10 +272,52 @@ type CacheConfig struct {
 }
 
 type RedisConfig struct {
-	Address  string
-	Password string
-	DB       int
-	TTL      time.Duration
+	// Mode is standalone, sentinel or cluster. Addresses holds the server, the Sentinels or the
+	// cluster seed nodes accordingly, and MasterName the master the Sentinels monitor.
+	Mode       string
+	Addresses  []string
+	MasterName string
+	// Username selects an ACL user; empty authenticates as the default user.
+	Username         string
+	Password         string
+	SentinelUsername string
+	SentinelPassword string
+	DB               int
+	TLS              redisclient.TLSConfig
+	// PoolSize, MinIdleConns and PoolTimeout size the pool of each node; zero keeps the defaults.
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
+	// TTL is how long entities stay cached and ListTTL list pages, unless Resources overrides
+	// them for a resource.
+	TTL       time.Duration
+	ListTTL   time.Duration
+	Resources map[string]rediscache.ResourceTTL
+	// Codec is json or bson; entries written by another codec are recomputed.
+	Codec string
+	// NegativeTTL is how long lookups that found nothing are cached; zero disables it.
+	NegativeTTL time.Duration
+	// LockTTL enables a Redis lock so only one instance recomputes a missing key; zero disables it.
+	LockTTL time.Duration
+	// RefreshBeta controls how early hot entries are recomputed before expiring; zero disables it.
+	RefreshBeta float64
+	Local       LocalCacheConfig
+	// DialTimeout and Timeout bound connecting to Redis and each command, so reads fall back to
+	// the database quickly when Redis is slow.
+	DialTimeout time.Duration
+	Timeout     time.Duration
+	// BreakerFailures consecutive failures stop calling Redis for BreakerCooldown; zero disables
+	// the breaker.
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// LocalCacheConfig controls the in-process tier kept in front of Redis. Every process writing
+// through the cache must enable it alike, or its writes never reach the other replicas' tiers.
+type LocalCacheConfig struct {
+	Enabled   bool
+	Defaults  rediscache.LocalLimits
+	Resources map[string]rediscache.LocalLimits
 }
 
 var cachedConfig struct {
@@ -105,14 +344,51 @@ func Load() (Config, error) {
 				AllowedHeaders:   parseCSV(lookupEnv(corsAllowedHeadersEnvKey, ""), defaultCORSHeaders),
 				AllowCredentials: parseBool(lookupEnv(corsAllowCredentialsEnvKey, "")),
 			},
+			Storage: StorageConfig{
+				Backend:       strings.ToLower(lookupEnv(storageBackendEnvKey, StorageBackendMongo)),
+				AdminEmail:    lookupEnv(memoryAdminEmailKey, ""),
+				AdminPassword: lookupEnv(memoryAdminPassKey, ""),
+			},
 			Mongo: MongoConfig{
-				URI:      lookupEnv("MONGO_URI", defaultMongoURI),
-				Database: lookupEnv("MONGO_DATABASE", defaultMongoDB),
+				URI:             lookupEnv("MONGO_URI", defaultMongoURI),
+				Database:        lookupEnv("MONGO_DATABASE", defaultMongoDB),
+				OutboxRetention: parseDuration(lookupEnv(outboxRetentionEnvKey, ""), defaultOutboxRetention),
 			},
 			Auth: AuthConfig{
-				JWTSecret: lookupEnv(jwtSecretEnvKey, ""),
+				JWTSecret:          lookupEnv(jwtSecretEnvKey, ""),
+				RevocationFailOpen: parseBool(lookupEnv(revocationFailOpenEnvKey, "")),
+				RevocationReplica:  parseBool(lookupEnv(revocationReplicaEnvKey, "")),
 			},
 			Cache: loadCacheConfig(),
+			Privacy: PrivacyConfig{
+				ReportSigningKey: lookupEnv(privacySigningKeyEnvKey, ""),
+			},
+			Encryption: EncryptionConfig{
+				Enabled:             parseBool(lookupEnv(encryptionEnabledEnvKey, "")),
+				MasterKey:           lookupEnv(encryptionMasterKeyEnvKey, ""),
+				MasterKeyFile:       lookupEnv(encryptionMasterKeyFileEnvKey, ""),
+				Fields:              parseCSV(lookupEnv(encryptionFieldsEnvKey, ""), defaultEncryptedFields),
+				DeterministicFields: parseCSV(lookupEnv(encryptionDeterministicFieldsEnvKey, ""), defaultDeterministicFields),
+				KeyReloadInterval:   parseDuration(lookupEnv(encryptionKeyReloadEnvKey, ""), defaultKeyReloadInterval),
+			},
+			Jobs: JobsConfig{
+				InstallmentAgingEnabled:  parseBool(lookupEnv(installmentAgingEnvKey, "")),
+				InstallmentAgingInterval: parseDuration(lookupEnv(installmentAgingEveryEnvKey, ""), defaultAgingInterval),
+				WebhookDeliveryEnabled:   parseBool(lookupEnv(webhookDeliveryEnvKey, "")),
+				WebhookDeliveryInterval:  parseDuration(lookupEnv(webhookDeliveryEveryEnvKey, ""), defaultWebhookDeliveryInterval),
+				OutboxRelayEnabled:       parseBool(lookupEnv(outboxRelayEnvKey, "")),
+				OutboxRelayInterval:      parseDuration(lookupEnv(outboxRelayEveryEnvKey, ""), defaultOutboxRelayInterval),
+			},
+			Bureau: CreditBureauConfig{
+				Provider:         strings.ToLower(lookupEnv(creditBureauProviderEnvKey, "")),
+				TTL:              parseDuration(lookupEnv(creditBureauTTLEnvKey, ""), defaultCreditBureauTTL),
+				StubSeed:         lookupEnv(creditBureauStubSeedEnvKey, ""),
+				StubProfilesFile: lookupEnv(creditBureauStubProfilesEnvKey, ""),
+				CacheKeySecret:   lookupEnv(creditBureauCacheSecretEnvKey, ""),
+			},
+			Screening: loadScreeningConfig(),
+			Webhooks:  loadWebhookConfig(),
+			Events:    loadEventsConfig(),
 		}
 	})
 
@@ -210,10 +486,39 @@ func loadCacheConfig() CacheConfig {
 	cacheCfg.Enabled = parseBool(enabledValue)
 
 	redisCfg := RedisConfig{
-		Address:  lookupEnv(redisAddrEnvKey, defaultRedisAddr),
-		Password: lookupEnv(redisPasswordKey, ""),
-		DB:       parseInt(lookupEnv(redisDBEnvKey, ""), defaultRedisDB),
-		TTL:      parseDuration(lookupEnv(redisTTLEnvKey, ""), defaultRedisTTL),
+		Mode:             strings.ToLower(lookupEnv(redisModeEnvKey, redisclient.ModeStandalone)),
+		Addresses:        parseCSV(lookupEnv(redisAddrEnvKey, ""), defaultRedisAddr),
+		MasterName:       lookupEnv(redisMasterNameEnvKey, ""),
+		Username:         lookupEnv(redisUsernameEnvKey, ""),
+		Password:         lookupEnv(redisPasswordKey, ""),
+		SentinelUsername: lookupEnv(redisSentinelUserKey, ""),
+		SentinelPassword: lookupEnv(redisSentinelPassKey, ""),
+		DB:               parseInt(lookupEnv(redisDBEnvKey, ""), defaultRedisDB),
+		TLS: redisclient.TLSConfig{
+			Enabled:            parseBool(lookupEnv(redisTLSEnabledEnvKey, "")),
+			CAFile:             lookupEnv(redisTLSCAFileEnvKey, ""),
+			CertFile:           lookupEnv(redisTLSCertFileEnvKey, ""),
+			KeyFile:            lookupEnv(redisTLSKeyFileEnvKey, ""),
+			ServerName:         lookupEnv(redisTLSServerEnvKey, ""),
+			InsecureSkipVerify: parseBool(lookupEnv(redisTLSInsecureEnvKey, "")),
+		},
+		PoolSize:     parseInt(lookupEnv(redisPoolSizeEnvKey, ""), 0),
+		MinIdleConns: parseInt(lookupEnv(redisMinIdleEnvKey, ""), 0),
+		PoolTimeout:  parseDuration(lookupEnv(redisPoolTimeoutEnvKey, ""), 0),
+		TTL:          parseDuration(lookupEnv(redisTTLEnvKey, ""), defaultRedisTTL),
+		ListTTL:      parseDuration(lookupEnv(redisListTTLEnvKey, ""), 0),
+		Resources:    loadResourceTTLs(),
+		Codec:        lookupEnv(redisCodecEnvKey, rediscache.CodecJSON),
+
+		NegativeTTL: parseDuration(lookupEnv(redisNegativeTTLEnvKey, ""), defaultNegativeTTL),
+		LockTTL:     parseDuration(lookupEnv(redisLockTTLEnvKey, ""), 0),
+		RefreshBeta: parseFloat(lookupEnv(redisRefreshBetaEnvKey, ""), defaultRefreshBeta),
+		Local:       loadLocalCacheConfig(),
+
+		DialTimeout:     parseDuration(lookupEnv(redisDialTimeoutEnvKey, ""), defaultRedisDial),
+		Timeout:         parseDuration(lookupEnv(redisTimeoutEnvKey, ""), defaultRedisIO),
+		BreakerFailures: parseInt(lookupEnv(redisBreakerEnvKey, ""), defaultBreakerFail),
+		BreakerCooldown: parseDuration(lookupEnv(redisBreakerWaitEnvKey, ""), defaultBreakerWait),
 	}
 
 	cacheCfg.Redis = redisCfg
@@ -221,6 +526,107 @@ func loadCacheConfig() CacheConfig {
 	return cacheCfg
 }
 
+// cachedResources are the resources with a caching decorator, as named in their keys.
+var cachedResources = []string{"products", "partners", "addresses", "consumers", "users"}
+
+// loadResourceTTLs reads REDIS_CACHE_<RESOURCE>_TTL, which overrides both TTLs of a resource, and
+// REDIS_CACHE_<RESOURCE>_LIST_TTL, which overrides its list pages only.
+func loadResourceTTLs() map[string]rediscache.ResourceTTL {
+	ttls := make(map[string]rediscache.ResourceTTL)
+
+	for _, resource := range cachedResources {
+		prefix := "REDIS_CACHE_" + strings.ToUpper(resource)
+		ttl := parseDuration(lookupEnv(prefix+"_TTL", ""), 0)
+		list := parseDuration(lookupEnv(prefix+"_LIST_TTL", ""), ttl)
+		if ttl <= 0 && list <= 0 {
+			continue
+		}
+		ttls[resource] = rediscache.ResourceTTL{Get: ttl, List: list}
+	}
+
+	return ttls
+}
+
+// loadLocalCacheConfig reads REDIS_L1_SIZE and REDIS_L1_TTL as defaults, overridden per resource
+// by REDIS_L1_<RESOURCE>_SIZE and REDIS_L1_<RESOURCE>_TTL. A size of zero disables a resource.
+func loadLocalCacheConfig() LocalCacheConfig {
+	cfg := LocalCacheConfig{
+		Enabled: parseBool(lookupEnv(redisL1EnabledEnvKey, "")),
+		Defaults: rediscache.LocalLimits{
+			Size: parseInt(lookupEnv(redisL1SizeEnvKey, ""), defaultL1Size),
+			TTL:  parseDuration(lookupEnv(redisL1TTLEnvKey, ""), defaultL1TTL),
+		},
+		Resources: make(map[string]rediscache.LocalLimits),
+	}
+
+	for _, resource := range cachedResources {
+		prefix := "REDIS_L1_" + strings.ToUpper(resource)
+		size, ttl := lookupEnv(prefix+"_SIZE", ""), lookupEnv(prefix+"_TTL", "")
+		if size == "" && ttl == "" {
+			continue
+		}
+		cfg.Resources[resource] = rediscache.LocalLimits{
+			Size: parseInt(size, cfg.Defaults.Size),
+			TTL:  parseDuration(ttl, cfg.Defaults.TTL),
+		}
+	}
+
+	return cfg
+}
+
+func loadScreeningConfig() ScreeningConfig {
+	rules := services.DefaultScreeningRules()
+
+	rules.HoldScore = parseInt(lookupEnv(screeningHoldScoreEnvKey, ""), rules.HoldScore)
+	rules.SharedContactMaxConsumers = parseInt(lookupEnv(screeningSharedContactMaxEnvKey, ""), rules.SharedContactMaxConsumers)
+	rules.SharedContactWeight = parseInt(lookupEnv(screeningSharedContactWeightEnvKey, ""), rules.SharedContactWeight)
+	rules.SharedAddressMaxConsumers = parseInt(lookupEnv(screeningSharedAddressMaxEnvKey, ""), rules.SharedAddressMaxConsumers)
+	rules.SharedAddressWeight = parseInt(lookupEnv(screeningSharedAddressWeightEnvKey, ""), rules.SharedAddressWeight)
+	rules.RecentIncorporationMonths = parseInt(lookupEnv(screeningIncorporationMonthsEnvKey, ""), rules.RecentIncorporationMonths)
+	rules.RecentIncorporationWeight = parseInt(lookupEnv(screeningIncorporationWeightEnvKey, ""), rules.RecentIncorporationWeight)
+	rules.VelocityMaxApplications = parseInt(lookupEnv(screeningVelocityMaxEnvKey, ""), rules.VelocityMaxApplications)
+	rules.VelocityWindow = parseDuration(lookupEnv(screeningVelocityWindowEnvKey, ""), rules.VelocityWindow)
+	rules.VelocityWeight = parseInt(lookupEnv(screeningVelocityWeightEnvKey, ""), rules.VelocityWeight)
+	if limit, err := valueobjects.ParseMoney(lookupEnv(screeningHighLimitEnvKey, "")); err == nil && limit.IsPositive() {
+		rules.HighRequestedLimit = limit
+	}
+
+	return ScreeningConfig{
+		Enabled:        parseBool(lookupEnv(screeningEnabledEnvKey, "true")),
+		Rules:          rules,
+		FingerprintKey: strings.TrimSpace(lookupEnv(screeningFingerprintKeyEnvKey, "")),
+	}
+}
+
+func loadWebhookConfig() WebhookConfig {
+	retry := services.DefaultWebhookRetryPolicy()
+	retry.MaxAttempts = parseInt(lookupEnv(webhookMaxAttemptsEnvKey, ""), retry.MaxAttempts)
+	retry.InitialBackoff = parseDuration(lookupEnv(webhookInitialBackoffEnvKey, ""), retry.InitialBackoff)
+	retry.MaxBackoff = parseDuration(lookupEnv(webhookMaxBackoffEnvKey, ""), retry.MaxBackoff)
+
+	timeout := parseDuration(lookupEnv(webhookTimeoutEnvKey, ""), defaultWebhookTimeout)
+	// The lease must outlast a send, or another worker could claim the delivery mid-flight.
+	if retry.Lease < 2*timeout {
+		retry.Lease = 2 * timeout
+	}
+
+	return WebhookConfig{Timeout: timeout, Retry: retry}
+}
+
+func loadEventsConfig() EventsConfig {
+	relay := services.DefaultEventRelayPolicy()
+	relay.MaxAttempts = parseInt(lookupEnv(outboxMaxAttemptsEnvKey, ""), relay.MaxAttempts)
+	relay.InitialBackoff = parseDuration(lookupEnv(outboxInitialBackoffEnvKey, ""), relay.InitialBackoff)
+	relay.MaxBackoff = parseDuration(lookupEnv(outboxMaxBackoffEnvKey, ""), relay.MaxBackoff)
+
+	return EventsConfig{
+		Relay:         relay,
+		StreamEnabled: parseBool(lookupEnv(eventStreamEnabledEnvKey, "")),
+		StreamName:    lookupEnv(eventStreamNameEnvKey, defaultEventStreamName),
+		StreamMaxLen:  parseInt(lookupEnv(eventStreamMaxLenEnvKey, ""), defaultEventStreamMaxLen),
+	}
+}
+
 func parseBool(value string) bool {
 	if value == "" {
 		return false
@@ -244,6 +650,16 @@ func parseInt(value string, fallback int) int {
 	return fallback
 }
 
+func parseFloat(value string, fallback float64) float64 {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+		return v
+	}
+	return fallback
+}
+
 func parseDuration(value string, fallback time.Duration) time.Duration {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"log"
+
+	"katseye/internal/domain/creditbureau"
+	"katseye/internal/domain/security"
+	bureauproviders "katseye/internal/infrastructure/creditbureau"
+	rediscache "katseye/internal/infrastructure/persistence/rediscache"
+)
+
+func newCreditBureau(cfg CreditBureauConfig, cache *RedisResources, encryption *EncryptionResources) (creditbureau.Provider, error) {
+	var provider creditbureau.Provider
+
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case CreditBureauProviderStub:
+		stub := bureauproviders.StubConfig{Seed: cfg.StubSeed, TTL: cfg.TTL}
+		if cfg.StubProfilesFile != "" {
+			profiles, err := bureauproviders.LoadStubProfiles(cfg.StubProfilesFile)
+			if err != nil {
+				return nil, err
+			}
+			stub.Profiles = profiles
+		}
+		provider = bureauproviders.NewStubProvider(stub)
+	default:
+		return nil, fmt.Errorf("unknown credit bureau provider %q", cfg.Provider)
+	}
+
+	if cache != nil {
+		// Bureau answers are personal data, so they are only cached sealed with the field cipher.
+		var cipher security.FieldCipher
+		switch {
+		case cfg.CacheKeySecret == "":
+			log.Printf("credit_bureau: %s is not set, bureau answers are not cached", creditBureauCacheSecretEnvKey)
+		case encryption == nil:
+			log.Printf("credit_bureau: field encryption is disabled, bureau answers are not cached")
+		default:
+			cipher = encryption.KeyRing
+		}
+		provider = rediscache.NewCreditBureauProvider(cache.Client, provider, []byte(cfg.CacheKeySecret), cipher)
+	}
+
+	return provider, nil
+}
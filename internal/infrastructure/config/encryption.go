@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"katseye/internal/infrastructure/crypto/envelope"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
+)
+
+type EncryptionResources struct {
+	KeyRing *envelope.KeyRing
+	Policy  fieldencryption.Policy
+
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+func newEncryptionResources(ctx context.Context, cfg EncryptionConfig, mongo *MongoResources) (*EncryptionResources, error) {
+	if !cfg.Enabled || mongo == nil {
+		return nil, nil
+	}
+
+	policy, err := fieldencryption.NewPolicy(cfg.Fields, cfg.DeterministicFields)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := envelope.LoadMasterKey(cfg.MasterKey, cfg.MasterKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	keyRing, err := envelope.NewKeyRing(ctx, masterKey, mongorepositories.NewDataKeyStoreMongo(mongo.Collections.EncryptionKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	resources := &EncryptionResources{
+		KeyRing: keyRing,
+		Policy:  policy,
+	}
+	if cfg.KeyReloadInterval > 0 {
+		reloadCtx, stop := context.WithCancel(context.Background())
+		resources.stop, resources.done = stop, make(chan struct{})
+		go resources.reloadKeys(reloadCtx, cfg.KeyReloadInterval)
+	}
+
+	return resources, nil
+}
+
+// reloadKeys follows rotations made by other processes. Decrypt already loads unknown keys on
+// demand; the periodic reload also moves new ciphertexts and document lookups to the new key.
+func (r *EncryptionResources) reloadKeys(ctx context.Context, interval time.Duration) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			previous := r.KeyRing.ActiveKeyID()
+			if err := r.KeyRing.Reload(ctx); err != nil {
+				log.Printf("encryption: data keys not reloaded error=%v", err)
+				continue
+			}
+			if active := r.KeyRing.ActiveKeyID(); active != previous {
+				log.Printf("encryption: active key changed from=%s to=%s", previous, active)
+			}
+		}
+	}
+}
+
+// Close stops reloading the data keys.
+func (r *EncryptionResources) Close() {
+	if r == nil || r.stop == nil {
+		return
+	}
+
+	r.stop()
+	<-r.done
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"errors"
+
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/eventstream"
+)
+
+// newEventStream returns the Redis stream sink, or nil when the stream is disabled.
+func newEventStream(cfg EventsConfig, cache *RedisResources) (services.EventSink, error) {
+	if !cfg.StreamEnabled {
+		return nil, nil
+	}
+	if cache == nil || cache.Client == nil {
+		return nil, errors.New("the event stream requires Redis to be enabled")
+	}
+
+	return eventstream.NewRedisStreamSink(cache.Client, cfg.StreamName, int64(cfg.StreamMaxLen)), nil
+}
@@ -6,18 +6,27 @@ import (
 )
 
 type HandlerSet struct {
-	Product  *handlers.ProductHandler
-	Partner  *handlers.PartnerHandler
-	Address  *handlers.AddressHandler
-	Consumer *handlers.ConsumerHandler
-	Auth     *handlers.AuthHandler
+	Product       *handlers.ProductHandler
+	Partner       *handlers.PartnerHandler
+	Address       *handlers.AddressHandler
+	Consumer      *handlers.ConsumerHandler
+	Auth          *handlers.AuthHandler
+	Privacy       *handlers.PrivacyHandler
+	Offer         *handlers.OfferHandler
+	IndexSeries   *handlers.IndexSeriesHandler
+	Repayment     *handlers.RepaymentHandler
+	CreditProfile *handlers.CreditProfileHandler
+	Screening     *handlers.ScreeningHandler
+	Webhook       *handlers.WebhookHandler
+	Cache         *handlers.CacheHandler
+	Health        *handlers.HealthHandler
 }
 
 func buildHandlers(services ServiceSet, authCfg AuthConfig) HandlerSet {
 	handlerSet := HandlerSet{}
 
 	if services.Product != nil {
-		handlerSet.Product = handlers.NewProductHandler(services.Product, services.ProductTemplates)
+		handlerSet.Product = handlers.NewProductHandler(services.Product, services.ProductTemplates, services.ProductSearch)
 	}
 
 	if services.Partner != nil {
@@ -33,7 +42,43 @@ func buildHandlers(services ServiceSet, authCfg AuthConfig) HandlerSet {
 	}
 
 	if services.Auth != nil {
-		handlerSet.Auth = handlers.NewAuthHandler(services.Auth, services.Token, services.Partner, services.Consumer, authCfg.JWTSecret)
+		handlerSet.Auth = handlers.NewAuthHandler(services.Auth, services.Token, services.UserAccount, authCfg.JWTSecret)
+	}
+
+	if services.Privacy != nil {
+		handlerSet.Privacy = handlers.NewPrivacyHandler(services.Privacy)
+	}
+
+	if services.Offer != nil {
+		handlerSet.Offer = handlers.NewOfferHandler(services.Offer)
+	}
+
+	if services.IndexSeries != nil {
+		handlerSet.IndexSeries = handlers.NewIndexSeriesHandler(services.IndexSeries)
+	}
+
+	if services.Repayment != nil {
+		handlerSet.Repayment = handlers.NewRepaymentHandler(services.Repayment)
+	}
+
+	if services.CreditProfile != nil {
+		handlerSet.CreditProfile = handlers.NewCreditProfileHandler(services.CreditProfile)
+	}
+
+	if services.Screening != nil {
+		handlerSet.Screening = handlers.NewScreeningHandler(services.Screening)
+	}
+
+	if services.Webhook != nil {
+		handlerSet.Webhook = handlers.NewWebhookHandler(services.Webhook)
+	}
+
+	if services.Cache != nil {
+		handlerSet.Cache = handlers.NewCacheHandler(services.Cache)
+	}
+
+	if services.Health != nil {
+		handlerSet.Health = handlers.NewHealthHandler(services.Health)
 	}
 
 	return handlerSet
@@ -41,10 +86,19 @@ func buildHandlers(services ServiceSet, authCfg AuthConfig) HandlerSet {
 
 func (h HandlerSet) toRouterHandlers() webrouter.Handlers {
 	return webrouter.Handlers{
-		Product:  h.Product,
-		Partner:  h.Partner,
-		Address:  h.Address,
-		Consumer: h.Consumer,
-		Auth:     h.Auth,
+		Product:       h.Product,
+		Partner:       h.Partner,
+		Address:       h.Address,
+		Consumer:      h.Consumer,
+		Auth:          h.Auth,
+		Privacy:       h.Privacy,
+		Offer:         h.Offer,
+		IndexSeries:   h.IndexSeries,
+		Repayment:     h.Repayment,
+		CreditProfile: h.CreditProfile,
+		Screening:     h.Screening,
+		Webhook:       h.Webhook,
+		Cache:         h.Cache,
+		Health:        h.Health,
 	}
 }
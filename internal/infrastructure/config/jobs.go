@@ -0,0 +1,146 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"katseye/internal/domain/services"
+)
+
+// JobRunner runs the periodic jobs of the API process until it is stopped.
+type JobRunner struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func startJobs(cfg JobsConfig, services ServiceSet) *JobRunner {
+	aging := cfg.InstallmentAgingEnabled && services.Repayment != nil
+	delivery := cfg.WebhookDeliveryEnabled && services.Webhook != nil
+	relay := cfg.OutboxRelayEnabled && services.EventRelay != nil
+	if !aging && !delivery && !relay {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := &JobRunner{cancel: cancel}
+
+	if aging {
+		interval := cfg.InstallmentAgingInterval
+		if interval <= 0 {
+			interval = defaultAgingInterval
+		}
+		log.Printf("jobs: installment aging enabled interval=%s", interval)
+		runner.run(func() { runInstallmentAging(ctx, services.Repayment, interval) })
+	}
+
+	if delivery {
+		interval := cfg.WebhookDeliveryInterval
+		if interval <= 0 {
+			interval = defaultWebhookDeliveryInterval
+		}
+		log.Printf("jobs: webhook delivery enabled interval=%s", interval)
+		runner.run(func() { runWebhookDelivery(ctx, services.Webhook, interval) })
+	}
+
+	if relay {
+		interval := cfg.OutboxRelayInterval
+		if interval <= 0 {
+			interval = defaultOutboxRelayInterval
+		}
+		log.Printf("jobs: outbox relay enabled interval=%s sinks=%s", interval, strings.Join(services.EventRelay.Sinks(), ","))
+		runner.run(func() { runOutboxRelay(ctx, services.EventRelay, interval) })
+	}
+
+	return runner
+}
+
+func (r *JobRunner) run(job func()) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		job()
+	}()
+}
+
+// Stop cancels the jobs and waits for a run in progress to finish.
+func (r *JobRunner) Stop() {
+	if r == nil {
+		return
+	}
+
+	r.cancel()
+	r.wg.Wait()
+}
+
+// runInstallmentAging ages installments at startup, catching up on days the process was down,
+// and then once per interval.
+func runInstallmentAging(ctx context.Context, service *services.RepaymentService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := service.AgeInstallments(ctx, time.Now())
+		switch {
+		case errors.Is(err, services.ErrAgingInProgress):
+			log.Printf("jobs: installment aging skipped, another replica is running it")
+		case err != nil:
+			log.Printf("jobs: installment aging failed: %v", err)
+		default:
+			log.Printf("jobs: installment aging as_of=%s checked=%d updated=%d skipped=%d consumers=%d", report.AsOf.Format(time.DateOnly), report.Checked, report.Updated, report.Skipped, report.Consumers)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWebhookDelivery sends the due webhook deliveries once per interval. Passes that found
+// nothing to send are not logged.
+func runWebhookDelivery(ctx context.Context, service *services.WebhookService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := service.DeliverDue(ctx, time.Now())
+		if err != nil {
+			log.Printf("jobs: webhook delivery failed: %v", err)
+		} else if report.Attempted > 0 {
+			log.Printf("jobs: webhook delivery attempted=%d delivered=%d failed=%d dead_letter=%d", report.Attempted, report.Delivered, report.Failed, report.DeadLetter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOutboxRelay publishes the pending outbox events once per interval. Only passes in which a
+// sink failed are logged.
+func runOutboxRelay(ctx context.Context, relay *services.EventRelay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := relay.Relay(ctx, time.Now())
+		if err != nil {
+			log.Printf("jobs: outbox relay failed: %v", err)
+		} else if report.Retried > 0 || report.Failed > 0 {
+			log.Printf("jobs: outbox relay claimed=%d published=%d retried=%d failed=%d", report.Claimed, report.Published, report.Retried, report.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -29,7 +29,7 @@ func buildMiddlewares(httpCfg HTTPConfig, cfg AuthConfig, tokenService *services
 		return set, fmt.Errorf("jwt secret is not configured")
 	}
 
-	options := []webmiddleware.JWTOption{webmiddleware.WithPublicPaths("/auth/login")}
+	options := []webmiddleware.JWTOption{webmiddleware.WithPublicPaths("/auth/login", "/metrics", "/health")}
 	if tokenService != nil {
 		options = append(options, webmiddleware.WithTokenRevocationChecker(tokenService))
 	}
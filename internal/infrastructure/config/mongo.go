@@ -2,42 +2,97 @@ package config
 
 import (
 	"context"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"katseye/internal/infrastructure/persistence/mongodb"
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
 )
 
 type MongoResources struct {
 	Client      *mongo.Client
 	Database    *mongo.Database
 	Collections MongoCollections
+	// Transactions reports whether the deployment supports multi-document transactions.
+	Transactions bool
 }
 
 type MongoCollections struct {
-	Products  *mongo.Collection
-	Partners  *mongo.Collection
-	Addresses *mongo.Collection
-	Users     *mongo.Collection
-	Consumers *mongo.Collection
+	Products       *mongo.Collection
+	Partners       *mongo.Collection
+	Addresses      *mongo.Collection
+	Users          *mongo.Collection
+	Consumers      *mongo.Collection
+	AuditEvents    *mongo.Collection
+	EncryptionKeys *mongo.Collection
+	ProductSearch  *mongo.Collection
+	Contracts      *mongo.Collection
+	IndexSeries    *mongo.Collection
+	Installments   *mongo.Collection
+	Payments       *mongo.Collection
+	Signals        *mongo.Collection
+	Reviews        *mongo.Collection
+	Webhooks       *mongo.Collection
+	Deliveries     *mongo.Collection
+	Outbox         *mongo.Collection
+	Leases         *mongo.Collection
 }
 
-func newMongoResources(cfg MongoConfig) (*MongoResources, error) {
+func newMongoResources(ctx context.Context, cfg MongoConfig) (*MongoResources, error) {
 	client, err := mongodb.NewMongoClient(cfg.URI)
 	if err != nil {
 		return nil, err
 	}
 
+	transactions, err := mongorepositories.SupportsTransactions(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
 	database := client.Database(cfg.Database)
 
+	if err := mongorepositories.NewUserRepositoryMongo(database.Collection("users")).EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("creating user indexes: %w", err)
+	}
+	if err := mongorepositories.NewOutboxRepositoryMongo(database.Collection("outbox_events")).EnsureIndexes(ctx, cfg.OutboxRetention); err != nil {
+		return nil, fmt.Errorf("creating outbox indexes: %w", err)
+	}
+	if err := mongorepositories.NewWebhookDeliveryRepositoryMongo(database.Collection("webhook_deliveries")).EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("creating webhook delivery indexes: %w", err)
+	}
+	if err := mongorepositories.NewIndexSeriesRepositoryMongo(database.Collection("index_series")).EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("creating index series indexes: %w", err)
+	}
+	if err := mongorepositories.NewInstallmentRepositoryMongo(database.Collection("installments")).EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("creating installment indexes: %w", err)
+	}
+	if err := mongorepositories.NewScreeningSignalRepositoryMongo(database.Collection("screening_signals")).EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("creating screening signal indexes: %w", err)
+	}
+
 	return &MongoResources{
-		Client:   client,
-		Database: database,
+		Client:       client,
+		Database:     database,
+		Transactions: transactions,
 		Collections: MongoCollections{
-			Products:  database.Collection("products"),
-			Partners:  database.Collection("partners"),
-			Addresses: database.Collection("addresses"),
-			Users:     database.Collection("users"),
-			Consumers: database.Collection("consumers"),
+			Products:       database.Collection("products"),
+			Partners:       database.Collection("partners"),
+			Addresses:      database.Collection("addresses"),
+			Users:          database.Collection("users"),
+			Consumers:      database.Collection("consumers"),
+			AuditEvents:    database.Collection("audit_events"),
+			EncryptionKeys: database.Collection("encryption_keys"),
+			ProductSearch:  database.Collection("product_search"),
+			Contracts:      database.Collection("contracts"),
+			IndexSeries:    database.Collection("index_series"),
+			Installments:   database.Collection("installments"),
+			Payments:       database.Collection("payments"),
+			Signals:        database.Collection("screening_signals"),
+			Reviews:        database.Collection("screening_reviews"),
+			Webhooks:       database.Collection("webhook_subscriptions"),
+			Deliveries:     database.Collection("webhook_deliveries"),
+			Outbox:         database.Collection("outbox_events"),
+			Leases:         database.Collection("job_leases"),
 		},
 	}, nil
 }
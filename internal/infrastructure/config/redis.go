@@ -2,46 +2,117 @@ package config
 
 import (
 	"context"
-	"time"
 
 	goredis "github.com/redis/go-redis/v9"
-	rediscache "katseye/internal/infrastructure/cache/redis"
+	redisclient "katseye/internal/infrastructure/cache/redis"
+	"katseye/internal/infrastructure/persistence/rediscache"
 )
 
 type RedisResources struct {
-	Client *goredis.Client
-	TTL    time.Duration
+	Client  goredis.UniversalClient
+	Options rediscache.Options
+	Admin   *rediscache.Admin
+	Breaker *redisclient.Breaker
+	Tokens  *rediscache.TokenStore
+	Health  *rediscache.HealthCheck
 }
 
-func newRedisResources(cfg CacheConfig) (*RedisResources, error) {
+func newRedisResources(cfg CacheConfig, auth AuthConfig) (*RedisResources, error) {
 	if !cfg.Enabled {
 		return nil, nil
 	}
 
-	client, err := rediscache.NewClient(rediscache.Config{
-		Address:  cfg.Redis.Address,
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
+	clientCfg := cfg.Redis.ClientConfig()
+	client, err := redisclient.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
 
-	ttl := cfg.Redis.TTL
-	if ttl <= 0 {
-		ttl = defaultRedisTTL
+	options, err := cfg.Redis.CacheOptions()
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	if options.TTL <= 0 {
+		options.TTL = defaultRedisTTL
 	}
+	options.Local = cfg.Redis.NewLocalCache(client)
+	options.Metrics = rediscache.NewMetrics()
+
+	revocation := auth.RevocationOptions()
 
 	return &RedisResources{
-		Client: client,
-		TTL:    ttl,
+		Client:  client,
+		Options: options,
+		Admin:   rediscache.NewAdmin(client, options),
+		Breaker: clientCfg.Breaker,
+		Tokens:  rediscache.NewTokenStore(client, revocation),
+		Health:  rediscache.NewHealthCheck(client, clientCfg.Breaker, revocation),
 	}, nil
 }
 
+// ClientConfig returns the connection settings, each call with a breaker of its own.
+func (c RedisConfig) ClientConfig() redisclient.Config {
+	return redisclient.Config{
+		Mode:             c.Mode,
+		Addresses:        c.Addresses,
+		MasterName:       c.MasterName,
+		Username:         c.Username,
+		Password:         c.Password,
+		SentinelUsername: c.SentinelUsername,
+		SentinelPassword: c.SentinelPassword,
+		DB:               c.DB,
+		TLS:              c.TLS,
+		DialTimeout:      c.DialTimeout,
+		Timeout:          c.Timeout,
+		PoolSize:         c.PoolSize,
+		MinIdleConns:     c.MinIdleConns,
+		PoolTimeout:      c.PoolTimeout,
+		Breaker:          redisclient.NewBreaker(c.BreakerFailures, c.BreakerCooldown),
+	}
+}
+
+// RevocationOptions returns how the Redis token store behaves while Redis is unreachable.
+func (c AuthConfig) RevocationOptions() rediscache.TokenStoreOptions {
+	return rediscache.TokenStoreOptions{
+		FailOpen: c.RevocationFailOpen,
+		Replica:  c.RevocationReplica,
+	}
+}
+
+// CacheOptions returns the settings of the Redis caching decorators, failing on an unknown codec.
+func (c RedisConfig) CacheOptions() (rediscache.Options, error) {
+	codec, err := rediscache.NewCodec(c.Codec)
+	if err != nil {
+		return rediscache.Options{}, err
+	}
+
+	return rediscache.Options{
+		TTL:         c.TTL,
+		ListTTL:     c.ListTTL,
+		Resources:   c.Resources,
+		Codec:       codec,
+		NegativeTTL: c.NegativeTTL,
+		LockTTL:     c.LockTTL,
+		RefreshBeta: c.RefreshBeta,
+	}, nil
+}
+
+// NewLocalCache returns the in-process tier, or nil when it is disabled.
+func (c RedisConfig) NewLocalCache(client goredis.UniversalClient) *rediscache.LocalCache {
+	if !c.Local.Enabled {
+		return nil
+	}
+	return rediscache.NewLocalCache(client, c.Local.Defaults, c.Local.Resources)
+}
+
 func (r *RedisResources) Close(ctx context.Context) error {
 	if r == nil || r.Client == nil {
 		return nil
 	}
 
+	r.Options.Local.Close()
+	r.Tokens.Close()
+
 	return r.Client.Close()
 }
@@ -3,20 +3,38 @@ package config
 import (
 	"katseye/internal/domain/repositories"
 	"katseye/internal/domain/security"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	"katseye/internal/infrastructure/persistence/memory"
 	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
 	rediscache "katseye/internal/infrastructure/persistence/rediscache"
+	"katseye/internal/infrastructure/persistence/searchindex"
 )
 
 type RepositorySet struct {
-	Product  repositories.ProductRepository
-	Partner  repositories.PartnerRepository
-	Address  repositories.AddressRepository
-	Consumer repositories.ConsumerRepository
-	User     repositories.UserRepository
-	Token    security.TokenStore
+	Product       repositories.ProductRepository
+	Partner       repositories.PartnerRepository
+	Address       repositories.AddressRepository
+	Consumer      repositories.ConsumerRepository
+	User          repositories.UserRepository
+	Audit         repositories.AuditRepository
+	Contract      repositories.ContractRepository
+	IndexSeries   repositories.IndexSeriesRepository
+	Installment   repositories.InstallmentRepository
+	Payment       repositories.PaymentRepository
+	Signal        repositories.ScreeningSignalRepository
+	Review        repositories.ScreeningReviewRepository
+	Webhook       repositories.WebhookSubscriptionRepository
+	Delivery      repositories.WebhookDeliveryRepository
+	Outbox        repositories.OutboxRepository
+	UnitOfWork    repositories.UnitOfWork
+	JobLease      repositories.JobLease
+	ProductSearch repositories.ProductSearchIndex
+	Token         security.TokenStore
+	CacheAdmin    repositories.CacheAdmin
+	Health        []repositories.HealthCheck
 }
 
-func buildRepositories(resources *MongoResources, cache *RedisResources) RepositorySet {
+func buildRepositories(resources *MongoResources, cache *RedisResources, encryption *EncryptionResources, search *mongorepositories.ProductSearchMongo) RepositorySet {
 	if resources == nil {
 		return RepositorySet{}
 	}
@@ -26,22 +44,103 @@ func buildRepositories(resources *MongoResources, cache *RedisResources) Reposit
 	var addressRepo repositories.AddressRepository = mongorepositories.NewAddressRepositoryMongo(resources.Collections.Addresses)
 	var consumerRepo repositories.ConsumerRepository = mongorepositories.NewConsumerRepositoryMongo(resources.Collections.Consumers)
 	var userRepo repositories.UserRepository = mongorepositories.NewUserRepositoryMongo(resources.Collections.Users)
+	var auditRepo repositories.AuditRepository = mongorepositories.NewAuditRepositoryMongo(resources.Collections.AuditEvents)
+	var contractRepo repositories.ContractRepository = mongorepositories.NewContractRepositoryMongo(resources.Collections.Contracts)
+	var indexSeriesRepo repositories.IndexSeriesRepository = mongorepositories.NewIndexSeriesRepositoryMongo(resources.Collections.IndexSeries)
+	var installmentRepo repositories.InstallmentRepository = mongorepositories.NewInstallmentRepositoryMongo(resources.Collections.Installments)
+	var paymentRepo repositories.PaymentRepository = mongorepositories.NewPaymentRepositoryMongo(resources.Collections.Payments)
+	var signalRepo repositories.ScreeningSignalRepository = mongorepositories.NewScreeningSignalRepositoryMongo(resources.Collections.Signals)
+	var reviewRepo repositories.ScreeningReviewRepository = mongorepositories.NewScreeningReviewRepositoryMongo(resources.Collections.Reviews)
+	var webhookRepo repositories.WebhookSubscriptionRepository = mongorepositories.NewWebhookSubscriptionRepositoryMongo(resources.Collections.Webhooks)
+	var deliveryRepo repositories.WebhookDeliveryRepository = mongorepositories.NewWebhookDeliveryRepositoryMongo(resources.Collections.Deliveries)
+	var outboxRepo repositories.OutboxRepository = mongorepositories.NewOutboxRepositoryMongo(resources.Collections.Outbox)
 	var tokenStore security.TokenStore
+	var cacheAdmin repositories.CacheAdmin
+	health := []repositories.HealthCheck{mongorepositories.NewHealthCheckMongo(resources.Client)}
 
+	// Without transactions the services still write their events, right after their changes.
+	var unitOfWork repositories.UnitOfWork
+	if resources.Transactions {
+		unitOfWork = mongorepositories.NewUnitOfWorkMongo(resources.Client)
+	}
+
+	if cache != nil && cache.Client != nil {
+		productRepo = rediscache.NewProductRepository(cache.Client, cache.Options, productRepo)
+		partnerRepo = rediscache.NewPartnerRepository(cache.Client, cache.Options, partnerRepo)
+		addressRepo = rediscache.NewAddressRepository(cache.Client, cache.Options, addressRepo)
+		userRepo = rediscache.NewUserRepository(cache.Client, cache.Options, userRepo)
+		tokenStore = cache.Tokens
+		cache.Admin.Register(productRepo, partnerRepo, addressRepo)
+		cacheAdmin = cache.Admin
+		health = append(health, cache.Health)
+	}
+
+	// Without Redis, revocations are at least honoured by the instance that received the logout.
+	if tokenStore == nil {
+		tokenStore = memory.NewTokenStore()
+	}
+
+	// Encryption wraps the cache decorators so Redis and Mongo only ever receive ciphertext.
+	// Consumers are cached only once sealed, so without encryption they skip Redis entirely.
+	if encryption != nil {
+		if cache != nil && cache.Client != nil {
+			consumerRepo = rediscache.NewConsumerRepository(cache.Client, cache.Options, consumerRepo)
+			cache.Admin.Register(consumerRepo)
+		}
+		consumerRepo = fieldencryption.NewConsumerRepository(encryption.KeyRing, encryption.Policy, consumerRepo)
+	}
+
+	// The search index is fed after the write reached Mongo and the cache was refreshed.
+	var searchIndex repositories.ProductSearchIndex
+	if search != nil {
+		searchIndex = search
+		productRepo = searchindex.NewProductRepository(searchIndex, partnerRepo, productRepo)
+		partnerRepo = searchindex.NewPartnerRepository(searchIndex, partnerRepo)
+	}
+
+	return RepositorySet{
+		Product:       productRepo,
+		Partner:       partnerRepo,
+		Address:       addressRepo,
+		Consumer:      consumerRepo,
+		User:          userRepo,
+		Audit:         auditRepo,
+		Contract:      contractRepo,
+		IndexSeries:   indexSeriesRepo,
+		Installment:   installmentRepo,
+		Payment:       paymentRepo,
+		Signal:        signalRepo,
+		Review:        reviewRepo,
+		Webhook:       webhookRepo,
+		Delivery:      deliveryRepo,
+		Outbox:        outboxRepo,
+		UnitOfWork:    unitOfWork,
+		JobLease:      mongorepositories.NewJobLeaseMongo(resources.Collections.Leases),
+		ProductSearch: searchIndex,
+		Token:         tokenStore,
+		CacheAdmin:    cacheAdmin,
+		Health:        health,
+	}
+}
+
+// buildMemoryRepositories backs the catalog, consumer and user repositories with in-process
+// stores, leaving the others unset so the features depending on them stay disabled. Revoked
+// tokens go to Redis when it is enabled. The in-process stores are not cached.
+func buildMemoryRepositories(cache *RedisResources) RepositorySet {
+	var tokenStore security.TokenStore = memory.NewTokenStore()
+	var health []repositories.HealthCheck
 	if cache != nil && cache.Client != nil {
-		productRepo = rediscache.NewProductRepository(cache.Client, cache.TTL, productRepo)
-		partnerRepo = rediscache.NewPartnerRepository(cache.Client, cache.TTL, partnerRepo)
-		addressRepo = rediscache.NewAddressRepository(cache.Client, cache.TTL, addressRepo)
-		userRepo = rediscache.NewUserRepository(cache.Client, cache.TTL, userRepo)
-		tokenStore = rediscache.NewTokenStore(cache.Client)
+		tokenStore = cache.Tokens
+		health = append(health, cache.Health)
 	}
 
 	return RepositorySet{
-		Product:  productRepo,
-		Partner:  partnerRepo,
-		Address:  addressRepo,
-		Consumer: consumerRepo,
-		User:     userRepo,
+		Product:  memory.NewProductRepository(),
+		Partner:  memory.NewPartnerRepository(),
+		Address:  memory.NewAddressRepository(),
+		Consumer: memory.NewConsumerRepository(),
+		User:     memory.NewUserRepository(),
 		Token:    tokenStore,
+		Health:   health,
 	}
 }
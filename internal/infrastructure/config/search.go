@@ -0,0 +1,22 @@
+package config
+
+import (
+	"context"
+
+	mongorepositories "katseye/internal/infrastructure/persistence/mongodb/repositories"
+)
+
+// newProductSearchIndex builds the Mongo-backed catalog search index, creating its text index
+// on startup so /products/search works against a fresh database.
+func newProductSearchIndex(ctx context.Context, resources *MongoResources) (*mongorepositories.ProductSearchMongo, error) {
+	if resources == nil || resources.Collections.ProductSearch == nil {
+		return nil, nil
+	}
+
+	index := mongorepositories.NewProductSearchMongo(resources.Collections.ProductSearch)
+	if err := index.EnsureIndexes(ctx); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
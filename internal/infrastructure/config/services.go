@@ -1,6 +1,10 @@
 package config
 
-import "katseye/internal/domain/services"
+import (
+	"katseye/internal/domain/creditbureau"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/webhooks"
+)
 
 type ServiceSet struct {
 	Product          *services.ProductService
@@ -8,18 +12,67 @@ type ServiceSet struct {
 	Address          *services.AddressService
 	Consumer         *services.ConsumerService
 	Auth             *services.AuthService
+	UserAccount      *services.UserAccountService
 	Token            *services.TokenService
 	ProductTemplates *services.ProductTemplateService
+	Privacy          *services.PrivacyService
+	ProductSearch    *services.ProductSearchService
+	Offer            *services.OfferService
+	IndexSeries      *services.IndexSeriesService
+	Repayment        *services.RepaymentService
+	CreditProfile    *services.CreditProfileService
+	Screening        *services.ScreeningService
+	Webhook          *services.WebhookService
+	Cache            *services.CacheService
+	Health           *services.HealthService
+	EventBus         *services.EventBus
+	EventRelay       *services.EventRelay
 }
 
-func buildServices(repos RepositorySet) ServiceSet {
+func buildServices(repos RepositorySet, privacyCfg PrivacyConfig, screeningCfg ScreeningConfig, webhookCfg WebhookConfig, eventsCfg EventsConfig, stream services.EventSink, bureau creditbureau.Provider) ServiceSet {
+	events := services.NewEventOutbox(repos.UnitOfWork, repos.Outbox)
+	webhookService := services.NewWebhookService(repos.Webhook, repos.Delivery, repos.Partner, webhooks.NewHTTPSender(webhookCfg.Timeout), webhookCfg.Retry)
+
+	eventBus := services.NewEventBus()
+	sinks := []services.EventSink{eventBus}
+	if stream != nil {
+		sinks = append(sinks, stream)
+	}
+	if webhookService != nil {
+		sinks = append(sinks, webhookService)
+	}
+
+	var screening *services.ScreeningService
+	if screeningCfg.Enabled {
+		screening = services.NewScreeningService(repos.Review, repos.Signal, repos.Consumer, repos.Contract, repos.Installment, repos.Address, events, screeningCfg.Rules, screeningCfg.FingerprintKey)
+	}
+
+	partner := services.NewPartnerService(repos.Partner, events)
+	consumer := services.NewConsumerService(repos.Consumer, repos.Product, repos.Partner, repos.Contract, repos.IndexSeries, repos.Installment, screening, events)
+	auth := services.NewAuthService(repos.User, events)
+
+	token := services.NewTokenService(repos.Token)
+
 	return ServiceSet{
-		Product:          services.NewProductService(repos.Product, repos.Partner),
-		Partner:          services.NewPartnerService(repos.Partner),
+		Product:          services.NewProductService(repos.Product, repos.Partner, events),
+		Partner:          partner,
 		Address:          services.NewAddressService(repos.Address),
-		Consumer:         services.NewConsumerService(repos.Consumer, repos.Product),
-		Auth:             services.NewAuthService(repos.User),
-		Token:            services.NewTokenService(repos.Token),
+		Consumer:         consumer,
+		Auth:             auth,
+		UserAccount:      services.NewUserAccountService(auth, partner, consumer, repos.UnitOfWork),
+		Token:            token,
 		ProductTemplates: services.NewProductTemplateService(),
+		Privacy:          services.NewPrivacyService(repos.Consumer, repos.Address, repos.User, repos.Product, repos.Contract, repos.Audit, repos.Signal, token, repos.UnitOfWork, privacyCfg.ReportSigningKey),
+		ProductSearch:    services.NewProductSearchService(repos.ProductSearch),
+		Cache:            services.NewCacheService(repos.CacheAdmin),
+		Health:           services.NewHealthService(repos.Health...),
+		IndexSeries:      services.NewIndexSeriesService(repos.IndexSeries),
+		Offer:            services.NewOfferService(repos.Consumer, repos.Partner, repos.Product, repos.IndexSeries),
+		Repayment:        services.NewRepaymentService(repos.Installment, repos.Contract, repos.Payment, repos.Consumer, repos.UnitOfWork, repos.JobLease),
+		CreditProfile:    services.NewCreditProfileService(repos.Consumer, bureau),
+		Screening:        screening,
+		Webhook:          webhookService,
+		EventBus:         eventBus,
+		EventRelay:       services.NewEventRelay(repos.Outbox, eventsCfg.Relay, sinks...),
 	}
 }
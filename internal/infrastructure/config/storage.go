@@ -0,0 +1,42 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newStorage connects to Mongo for the mongo backend. The memory backend returns no resources,
+// and the repositories are then built in process.
+func newStorage(ctx context.Context, cfg StorageConfig, mongoCfg MongoConfig) (*MongoResources, error) {
+	switch cfg.Backend {
+	case StorageBackendMongo:
+		resources, err := newMongoResources(ctx, mongoCfg)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to mongo: %w", err)
+		}
+		return resources, nil
+	case StorageBackendMemory:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// seedMemoryAdmin creates the configured admin, without which nobody could sign in to an
+// empty in-memory store.
+func seedMemoryAdmin(ctx context.Context, cfg StorageConfig, auth *services.AuthService) (*entities.User, error) {
+	if cfg.Backend != StorageBackendMemory || cfg.AdminEmail == "" || cfg.AdminPassword == "" {
+		return nil, nil
+	}
+
+	admin, err := auth.CreateUser(ctx, cfg.AdminEmail, cfg.AdminPassword, true, entities.RoleAdmin, nil, entities.ProfileTypeServiceAccount, primitive.NilObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("seeding in-memory admin: %w", err)
+	}
+	return admin, nil
+}
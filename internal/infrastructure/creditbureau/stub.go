@@ -0,0 +1,192 @@
+package creditbureau
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"katseye/internal/domain/creditbureau"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+const (
+	defaultStubName = "stub"
+	defaultStubTTL  = 24 * time.Hour
+)
+
+var (
+	stubCreditors = []string{"Banco Exemplo S.A.", "Varejo Modelo Ltda.", "Telefonia Teste S.A.", "Cartório de Protesto"}
+	stubKinds     = []string{"unpaid_debt", "protest", "bounced_check"}
+)
+
+// StubProfile fixes the answers for one document instead of deriving them from its digits.
+type StubProfile struct {
+	NotFound               bool                          `json:"not_found"`
+	Score                  int                           `json:"score"`
+	DelinquencyProbability float64                       `json:"delinquency_probability"`
+	NegativeRecords        []creditbureau.NegativeRecord `json:"negative_records"`
+	MonthlyIncome          valueobjects.Money            `json:"monthly_income"`
+}
+
+// StubConfig configures the stub bureau. Documents without a profile get values derived from a
+// hash of Seed and the document, so the same document always gets the same answer.
+type StubConfig struct {
+	Name     string
+	Seed     string
+	TTL      time.Duration
+	Profiles map[string]StubProfile
+}
+
+type stubProvider struct {
+	name     string
+	seed     string
+	ttl      time.Duration
+	profiles map[string]StubProfile
+	now      func() time.Time
+}
+
+// NewStubProvider returns a deterministic bureau for development and tests; it never leaves
+// the process.
+func NewStubProvider(cfg StubConfig) creditbureau.Provider {
+	name := cfg.Name
+	if name == "" {
+		name = defaultStubName
+	}
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultStubTTL
+	}
+
+	profiles := make(map[string]StubProfile, len(cfg.Profiles))
+	for document, profile := range cfg.Profiles {
+		if normalized, err := creditbureau.NormalizeDocument(document); err == nil {
+			profiles[normalized] = profile
+		}
+	}
+
+	return &stubProvider{
+		name:     name,
+		seed:     cfg.Seed,
+		ttl:      ttl,
+		profiles: profiles,
+		now:      time.Now,
+	}
+}
+
+// LoadStubProfiles reads a JSON object of profiles keyed by CPF/CNPJ.
+func LoadStubProfiles(path string) (map[string]StubProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles map[string]StubProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return profiles, nil
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) Score(_ context.Context, document string) (creditbureau.ScoreReport, error) {
+	profile, err := p.profile(document)
+	if err != nil {
+		return creditbureau.ScoreReport{}, err
+	}
+
+	return creditbureau.ScoreReport{
+		Attribution:            p.attribution(),
+		Score:                  profile.Score,
+		DelinquencyProbability: profile.DelinquencyProbability,
+	}, nil
+}
+
+func (p *stubProvider) NegativeRecords(_ context.Context, document string) (creditbureau.NegativeRecordsReport, error) {
+	profile, err := p.profile(document)
+	if err != nil {
+		return creditbureau.NegativeRecordsReport{}, err
+	}
+
+	return creditbureau.NegativeRecordsReport{
+		Attribution: p.attribution(),
+		Records:     append([]creditbureau.NegativeRecord(nil), profile.NegativeRecords...),
+	}, nil
+}
+
+func (p *stubProvider) EstimateIncome(_ context.Context, document string) (creditbureau.IncomeEstimate, error) {
+	profile, err := p.profile(document)
+	if err != nil {
+		return creditbureau.IncomeEstimate{}, err
+	}
+
+	return creditbureau.IncomeEstimate{
+		Attribution:   p.attribution(),
+		MonthlyIncome: profile.MonthlyIncome,
+	}, nil
+}
+
+func (p *stubProvider) attribution() creditbureau.Attribution {
+	return creditbureau.Attribution{
+		Source:      p.name,
+		RetrievedAt: p.now().UTC(),
+		TTL:         p.ttl,
+	}
+}
+
+func (p *stubProvider) profile(document string) (StubProfile, error) {
+	normalized, err := creditbureau.NormalizeDocument(document)
+	if err != nil {
+		return StubProfile{}, err
+	}
+
+	profile, configured := p.profiles[normalized]
+	if !configured {
+		profile = p.derive(normalized)
+	}
+	if profile.NotFound {
+		return StubProfile{}, creditbureau.ErrDocumentNotFound
+	}
+	return profile, nil
+}
+
+// derive builds a plausible profile from the hash of the document: scores spread over 0-1000,
+// about one document in five has negative records, and companies earn more than individuals.
+func (p *stubProvider) derive(document string) StubProfile {
+	sum := sha256.Sum256([]byte(p.seed + ":" + document))
+
+	score := int(binary.BigEndian.Uint16(sum[0:2]) % 1001)
+	profile := StubProfile{
+		Score:                  score,
+		DelinquencyProbability: math.Round(float64(1000-score)*0.3) / 1000,
+	}
+
+	if sum[2]%5 == 0 {
+		count := 1 + int(sum[3]%2)
+		for i := 0; i < count; i++ {
+			cents := 10000 + int64(binary.BigEndian.Uint16(sum[4+2*i:6+2*i]))*10
+			profile.NegativeRecords = append(profile.NegativeRecords, creditbureau.NegativeRecord{
+				Creditor:     stubCreditors[int(sum[8+i])%len(stubCreditors)],
+				Kind:         stubKinds[int(sum[10+i])%len(stubKinds)],
+				Amount:       valueobjects.Cents(cents),
+				RegisteredAt: time.Date(2020+int(sum[12+i]%5), time.Month(1+sum[14+i]%12), 1+int(sum[16+i]%28), 0, 0, 0, 0, time.UTC),
+			})
+		}
+	}
+
+	income := int64(binary.BigEndian.Uint32(sum[18:22]))
+	if len(document) == 14 {
+		profile.MonthlyIncome = valueobjects.Cents((20_000 + income%480_000) * 100)
+	} else {
+		profile.MonthlyIncome = valueobjects.Cents((1_500 + income%18_500) * 100)
+	}
+
+	return profile
+}
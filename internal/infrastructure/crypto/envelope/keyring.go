@@ -0,0 +1,568 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"katseye/internal/domain/security"
+)
+
+const (
+	KeyStatusActive  = "active"
+	KeyStatusRetired = "retired"
+
+	ciphertextPrefix  = "enc:v1:"
+	modeRandomized    = "r"
+	modeDeterministic = "d"
+
+	keySize   = 32
+	nonceSize = 12
+
+	encryptionKeyLabel = "katseye/field-encryption/aes-gcm"
+	nonceKeyLabel      = "katseye/field-encryption/deterministic-nonce"
+
+	// initialKeyID names the first data key, so instances booting together on an empty store
+	// race to insert the same key and all but the winner adopt it.
+	initialKeyID = "0000000000000000"
+
+	// minReloadInterval bounds how often ciphertexts under unknown keys send the ring back to
+	// its store.
+	minReloadInterval = time.Second
+	reloadTimeout     = 5 * time.Second
+)
+
+var (
+	ErrMasterKeyUnavailable = errors.New("field encryption master key not configured")
+	ErrInvalidMasterKey     = errors.New("field encryption master key must be 32 bytes encoded in base64")
+	ErrKeyStoreUnavailable  = errors.New("field encryption key store unavailable")
+	ErrNoActiveKey          = errors.New("no active field encryption key")
+	ErrUnknownKey           = errors.New("unknown field encryption key")
+	ErrKeyExists            = errors.New("field encryption key already exists")
+	ErrMalformedCiphertext  = errors.New("malformed field ciphertext")
+)
+
+// WrappedKey is a data key encrypted under the master key, as persisted by a KeyStore.
+type WrappedKey struct {
+	ID         string
+	Ciphertext []byte
+	Status     string
+	CreatedAt  time.Time
+	RetiredAt  time.Time
+}
+
+// KeyStore persists wrapped data keys.
+type KeyStore interface {
+	LoadKeys(ctx context.Context) ([]WrappedKey, error)
+	SaveKey(ctx context.Context, key WrappedKey) error
+	// InsertKey stores a new key, failing with ErrKeyExists when its ID is taken.
+	InsertKey(ctx context.Context, key WrappedKey) error
+}
+
+type dataKey struct {
+	wrapped  WrappedKey
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// KeyRing implements envelope encryption: values are sealed with AES-GCM data keys which are
+// themselves wrapped by a master key that never leaves the process.
+type KeyRing struct {
+	mu       sync.RWMutex
+	master   []byte
+	store    KeyStore
+	keys     map[string]*dataKey
+	order    []string
+	activeID string
+
+	// reloadMu lets a single caller reload the ring for an unknown key while the others wait;
+	// missedAt is when such a reload last failed to find its key.
+	reloadMu sync.Mutex
+	missedAt time.Time
+}
+
+var _ security.FieldCipher = (*KeyRing)(nil)
+
+// LoadMasterKey reads the base64 encoded master key from a file, falling back to the raw value.
+func LoadMasterKey(value, path string) ([]byte, error) {
+	encoded := strings.TrimSpace(value)
+	if path = strings.TrimSpace(path); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading master key file: %w", err)
+		}
+		encoded = strings.TrimSpace(string(content))
+	}
+
+	if encoded == "" {
+		return nil, ErrMasterKeyUnavailable
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != keySize {
+		return nil, ErrInvalidMasterKey
+	}
+
+	return key, nil
+}
+
+// NewKeyRing unwraps every stored data key and creates the first one when the store is empty.
+// Keys created later by other processes are picked up by Reload, which Decrypt also calls on
+// meeting a ciphertext under an unknown key.
+func NewKeyRing(ctx context.Context, master []byte, store KeyStore) (*KeyRing, error) {
+	if len(master) != keySize {
+		return nil, ErrInvalidMasterKey
+	}
+	if store == nil {
+		return nil, ErrKeyStoreUnavailable
+	}
+
+	ring := &KeyRing{
+		master: append([]byte(nil), master...),
+		store:  store,
+		keys:   make(map[string]*dataKey),
+	}
+
+	if err := ring.Reload(ctx); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case ring.activeID != "":
+	case len(ring.order) == 0:
+		if err := ring.bootstrap(ctx); err != nil {
+			return nil, err
+		}
+	default:
+		// Every stored key was retired by hand; start a new one.
+		if _, err := ring.Rotate(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return ring, nil
+}
+
+// Reload adds the keys other processes stored since the ring was loaded and follows their
+// rotations, so this instance reads values re-encrypted under a new key and seals with it.
+func (r *KeyRing) Reload(ctx context.Context) error {
+	if r == nil {
+		return ErrNoActiveKey
+	}
+
+	wrappedKeys, err := r.store.LoadKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("loading data keys: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, wrapped := range wrappedKeys {
+		if known, ok := r.keys[wrapped.ID]; ok {
+			known.wrapped.Status, known.wrapped.RetiredAt = wrapped.Status, wrapped.RetiredAt
+			continue
+		}
+		if err := r.add(wrapped); err != nil {
+			return err
+		}
+	}
+
+	// The newest active key wins, as it does for a rotation that crashed before retiring the
+	// previous one.
+	r.activeID = ""
+	for _, id := range r.order {
+		if r.keys[id].wrapped.Status == KeyStatusActive {
+			r.activeID = id
+			break
+		}
+	}
+
+	return nil
+}
+
+// bootstrap creates the first data key. When another instance inserted it first, that key is
+// loaded instead, so a fresh store never ends up with two active keys.
+func (r *KeyRing) bootstrap(ctx context.Context) error {
+	wrapped, material, err := r.generate(initialKeyID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	err = r.store.InsertKey(ctx, wrapped)
+	if errors.Is(err, ErrKeyExists) {
+		if err := r.Reload(ctx); err != nil {
+			return err
+		}
+		if r.ActiveKeyID() == "" {
+			return ErrNoActiveKey
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("saving data key: %w", err)
+	}
+
+	key, err := newDataKey(wrapped, material)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[wrapped.ID] = key
+	r.order = append([]string{wrapped.ID}, r.order...)
+	r.activeID = wrapped.ID
+	return nil
+}
+
+// generate creates data key material and wraps it under the master key.
+func (r *KeyRing) generate(id string, now time.Time) (WrappedKey, []byte, error) {
+	material := make([]byte, keySize)
+	if _, err := rand.Read(material); err != nil {
+		return WrappedKey{}, nil, fmt.Errorf("generating data key: %w", err)
+	}
+
+	r.mu.RLock()
+	ciphertext, err := wrapKey(r.master, id, material)
+	r.mu.RUnlock()
+	if err != nil {
+		return WrappedKey{}, nil, err
+	}
+
+	return WrappedKey{ID: id, Ciphertext: ciphertext, Status: KeyStatusActive, CreatedAt: now}, material, nil
+}
+
+// ActiveKeyID returns the identifier of the key used for new ciphertexts.
+func (r *KeyRing) ActiveKeyID() string {
+	if r == nil {
+		return ""
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.activeID
+}
+
+// Rotate generates a new active data key and retires the previous one. Retired keys stay
+// available for decryption until every value has been re-encrypted.
+func (r *KeyRing) Rotate(ctx context.Context) (string, error) {
+	if r == nil {
+		return "", ErrNoActiveKey
+	}
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", fmt.Errorf("generating data key id: %w", err)
+	}
+
+	now := time.Now().UTC()
+	id := hex.EncodeToString(idBytes)
+
+	wrapped, material, err := r.generate(id, now)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.store.SaveKey(ctx, wrapped); err != nil {
+		return "", fmt.Errorf("saving data key: %w", err)
+	}
+
+	if previous, ok := r.keys[r.activeID]; ok {
+		previous.wrapped.Status = KeyStatusRetired
+		previous.wrapped.RetiredAt = now
+		if err := r.store.SaveKey(ctx, previous.wrapped); err != nil {
+			return "", fmt.Errorf("retiring data key %s: %w", previous.wrapped.ID, err)
+		}
+	}
+
+	key, err := newDataKey(wrapped, material)
+	if err != nil {
+		return "", err
+	}
+
+	r.keys[id] = key
+	r.order = append([]string{id}, r.order...)
+	r.activeID = id
+
+	return id, nil
+}
+
+// Rewrap encrypts every data key under a new master key. Field ciphertexts are unaffected.
+func (r *KeyRing) Rewrap(ctx context.Context, newMaster []byte) error {
+	if r == nil {
+		return ErrNoActiveKey
+	}
+	if len(newMaster) != keySize {
+		return ErrInvalidMasterKey
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.order {
+		key := r.keys[id]
+
+		material, err := unwrapKey(r.master, id, key.wrapped.Ciphertext)
+		if err != nil {
+			return err
+		}
+
+		ciphertext, err := wrapKey(newMaster, id, material)
+		if err != nil {
+			return err
+		}
+
+		key.wrapped.Ciphertext = ciphertext
+		if err := r.store.SaveKey(ctx, key.wrapped); err != nil {
+			return fmt.Errorf("saving rewrapped data key %s: %w", id, err)
+		}
+	}
+
+	r.master = append([]byte(nil), newMaster...)
+	return nil
+}
+
+// Encrypt seals the plaintext with the active data key, using the field name as associated data.
+func (r *KeyRing) Encrypt(field string, plaintext []byte, deterministic bool) (string, error) {
+	if r == nil {
+		return "", ErrNoActiveKey
+	}
+
+	r.mu.RLock()
+	key, ok := r.keys[r.activeID]
+	r.mu.RUnlock()
+	if !ok {
+		return "", ErrNoActiveKey
+	}
+
+	return key.seal(field, plaintext, deterministic)
+}
+
+// Decrypt opens a ciphertext produced by any key known to the ring.
+func (r *KeyRing) Decrypt(field string, ciphertext string) ([]byte, error) {
+	if r == nil {
+		return nil, ErrNoActiveKey
+	}
+
+	if !strings.HasPrefix(ciphertext, ciphertextPrefix) {
+		return nil, ErrMalformedCiphertext
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(ciphertext, ciphertextPrefix), ":", 3)
+	if len(parts) != 3 || (parts[0] != modeRandomized && parts[0] != modeDeterministic) {
+		return nil, ErrMalformedCiphertext
+	}
+
+	key, ok := r.lookup(parts[1])
+	if !ok {
+		key, ok = r.reloadFor(parts[1])
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, parts[1])
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sealed) < nonceSize {
+		return nil, ErrMalformedCiphertext
+	}
+
+	plaintext, err := key.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(field))
+	if err != nil {
+		return nil, fmt.Errorf("opening field %s: %w", field, err)
+	}
+
+	return plaintext, nil
+}
+
+// SearchTokens returns the deterministic ciphertext of plaintext under each key, active first.
+func (r *KeyRing) SearchTokens(field string, plaintext []byte) ([]string, error) {
+	if r == nil {
+		return nil, ErrNoActiveKey
+	}
+
+	r.mu.RLock()
+	keys := make([]*dataKey, 0, len(r.order))
+	for _, id := range r.order {
+		keys = append(keys, r.keys[id])
+	}
+	r.mu.RUnlock()
+
+	tokens := make([]string, 0, len(keys))
+	for _, key := range keys {
+		token, err := key.seal(field, plaintext, true)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (r *KeyRing) lookup(id string) (*dataKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[id]
+	return key, ok
+}
+
+// reloadFor reloads the ring to find a key created by another process since the last load. After
+// a reload that did not find its key, unknown IDs wait minReloadInterval before the next one.
+func (r *KeyRing) reloadFor(id string) (*dataKey, bool) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	if key, ok := r.lookup(id); ok {
+		return key, true
+	}
+
+	if time.Since(r.missedAt) < minReloadInterval {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reloadTimeout)
+	defer cancel()
+	if err := r.Reload(ctx); err != nil {
+		r.missedAt = time.Now()
+		return nil, false
+	}
+
+	key, ok := r.lookup(id)
+	if !ok {
+		r.missedAt = time.Now()
+	}
+	return key, ok
+}
+
+// IsCiphertext reports whether the value carries the envelope ciphertext prefix.
+func IsCiphertext(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+func (r *KeyRing) add(wrapped WrappedKey) error {
+	material, err := unwrapKey(r.master, wrapped.ID, wrapped.Ciphertext)
+	if err != nil {
+		return err
+	}
+
+	key, err := newDataKey(wrapped, material)
+	if err != nil {
+		return err
+	}
+
+	r.keys[wrapped.ID] = key
+
+	// Keep keys ordered from newest to oldest so lookups try the most likely key first.
+	position := len(r.order)
+	for i, id := range r.order {
+		if wrapped.CreatedAt.After(r.keys[id].wrapped.CreatedAt) {
+			position = i
+			break
+		}
+	}
+	r.order = append(r.order, "")
+	copy(r.order[position+1:], r.order[position:])
+	r.order[position] = wrapped.ID
+
+	return nil
+}
+
+func newDataKey(wrapped WrappedKey, material []byte) (*dataKey, error) {
+	block, err := aes.NewCipher(deriveKey(material, encryptionKeyLabel))
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataKey{
+		wrapped:  wrapped,
+		aead:     aead,
+		nonceKey: deriveKey(material, nonceKeyLabel),
+	}, nil
+}
+
+func (k *dataKey) seal(field string, plaintext []byte, deterministic bool) (string, error) {
+	mode := modeRandomized
+	nonce := make([]byte, nonceSize)
+
+	if deterministic {
+		// Synthetic nonce: identical plaintexts of the same field map to identical ciphertexts.
+		mode = modeDeterministic
+		mac := hmac.New(sha256.New, k.nonceKey)
+		mac.Write([]byte(field))
+		mac.Write([]byte{0})
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+
+	sealed := k.aead.Seal(nonce, nonce, plaintext, []byte(field))
+
+	return ciphertextPrefix + mode + ":" + k.wrapped.ID + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func deriveKey(material []byte, label string) []byte {
+	mac := hmac.New(sha256.New, material)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
+func wrapKey(master []byte, id string, material []byte) ([]byte, error) {
+	aead, err := masterAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, material, []byte(id)), nil
+}
+
+func unwrapKey(master []byte, id string, ciphertext []byte) ([]byte, error) {
+	aead, err := masterAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("unwrapping data key %s: %w", id, ErrMalformedCiphertext)
+	}
+
+	material, err := aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key %s: %w", id, err)
+	}
+
+	return material, nil
+}
+
+func masterAEAD(master []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(master)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,167 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+)
+
+type memoryKeyStore struct {
+	keys map[string]WrappedKey
+}
+
+func (s *memoryKeyStore) LoadKeys(context.Context) ([]WrappedKey, error) {
+	keys := make([]WrappedKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *memoryKeyStore) SaveKey(_ context.Context, key WrappedKey) error {
+	if s.keys == nil {
+		s.keys = make(map[string]WrappedKey)
+	}
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *memoryKeyStore) InsertKey(ctx context.Context, key WrappedKey) error {
+	if _, ok := s.keys[key.ID]; ok {
+		return ErrKeyExists
+	}
+	return s.SaveKey(ctx, key)
+}
+
+func newMasterKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("generating master key: %v", err)
+	}
+	return key
+}
+
+func TestKeyRing_EncryptDecryptAcrossRotation(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryKeyStore{}
+	master := newMasterKey(t)
+
+	ring, err := NewKeyRing(ctx, master, store)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned error: %v", err)
+	}
+
+	randomized, err := ring.Encrypt("email", []byte("ana@example.com"), false)
+	if err != nil {
+		t.Fatalf("Encrypt returned error: %v", err)
+	}
+	again, _ := ring.Encrypt("email", []byte("ana@example.com"), false)
+	if randomized == again {
+		t.Fatalf("expected randomized ciphertexts to differ")
+	}
+
+	first, _ := ring.Encrypt("document_number", []byte("12345678900"), true)
+	second, _ := ring.Encrypt("document_number", []byte("12345678900"), true)
+	if first != second {
+		t.Fatalf("expected deterministic ciphertexts to match")
+	}
+
+	if _, err := ring.Decrypt("phone", randomized); err == nil {
+		t.Fatalf("expected ciphertext to be bound to its field")
+	}
+
+	if _, err := ring.Rotate(ctx); err != nil {
+		t.Fatalf("Rotate returned error: %v", err)
+	}
+
+	reloaded, err := NewKeyRing(ctx, master, store)
+	if err != nil {
+		t.Fatalf("reloading key ring returned error: %v", err)
+	}
+	if reloaded.ActiveKeyID() != ring.ActiveKeyID() {
+		t.Fatalf("expected active key %s, got %s", ring.ActiveKeyID(), reloaded.ActiveKeyID())
+	}
+
+	plaintext, err := reloaded.Decrypt("email", randomized)
+	if err != nil {
+		t.Fatalf("Decrypt with retired key returned error: %v", err)
+	}
+	if !bytes.Equal(plaintext, []byte("ana@example.com")) {
+		t.Fatalf("unexpected plaintext %q", plaintext)
+	}
+
+	tokens, err := reloaded.SearchTokens("document_number", []byte("12345678900"))
+	if err != nil {
+		t.Fatalf("SearchTokens returned error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[1] != first {
+		t.Fatalf("expected retired key token to be searched last, got %v", tokens)
+	}
+}
+
+func TestKeyRing_RewrapKeepsCiphertextsReadable(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryKeyStore{}
+
+	ring, err := NewKeyRing(ctx, newMasterKey(t), store)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned error: %v", err)
+	}
+
+	ciphertext, err := ring.Encrypt("birth_date", []byte("1990-01-01"), false)
+	if err != nil {
+		t.Fatalf("Encrypt returned error: %v", err)
+	}
+
+	replacement := newMasterKey(t)
+	if err := ring.Rewrap(ctx, replacement); err != nil {
+		t.Fatalf("Rewrap returned error: %v", err)
+	}
+
+	reloaded, err := NewKeyRing(ctx, replacement, store)
+	if err != nil {
+		t.Fatalf("reloading with new master key returned error: %v", err)
+	}
+
+	if _, err := reloaded.Decrypt("birth_date", ciphertext); err != nil {
+		t.Fatalf("Decrypt after rewrap returned error: %v", err)
+	}
+}
+
+func TestKeyRing_FollowsKeysCreatedElsewhere(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryKeyStore{}
+	master := newMasterKey(t)
+
+	// Two instances booting on an empty store settle on a single active key.
+	api, err := NewKeyRing(ctx, master, store)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned error: %v", err)
+	}
+	if err := (&KeyRing{master: master, store: store, keys: make(map[string]*dataKey)}).bootstrap(ctx); err != nil {
+		t.Fatalf("bootstrap after another instance returned error: %v", err)
+	}
+	if len(store.keys) != 1 {
+		t.Fatalf("expected a single data key, got %d", len(store.keys))
+	}
+
+	// A rotation tool re-encrypts a value under a key the API has never loaded.
+	tool, err := NewKeyRing(ctx, master, store)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned error: %v", err)
+	}
+	if _, err := tool.Rotate(ctx); err != nil {
+		t.Fatalf("Rotate returned error: %v", err)
+	}
+	ciphertext, _ := tool.Encrypt("email", []byte("ana@example.com"), false)
+
+	plaintext, err := api.Decrypt("email", ciphertext)
+	if err != nil || string(plaintext) != "ana@example.com" {
+		t.Fatalf("expected the new key to be loaded on demand, got %q (%v)", plaintext, err)
+	}
+	if api.ActiveKeyID() != tool.ActiveKeyID() {
+		t.Fatalf("expected the rotated key to become active, got %s", api.ActiveKeyID())
+	}
+}
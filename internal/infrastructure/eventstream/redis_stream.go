@@ -0,0 +1,56 @@
+package eventstream
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+)
+
+// RedisStreamSink appends domain events to a Redis stream. Each entry carries the event fields
+// as strings, data being the JSON payload; consumers read it with XREAD or a consumer group and
+// deduplicate by event_id, since the relay may publish an event more than once.
+type RedisStreamSink struct {
+	client goredis.UniversalClient
+	stream string
+	maxLen int64
+}
+
+var _ services.EventSink = (*RedisStreamSink)(nil)
+
+// NewRedisStreamSink writes to the stream, trimming it to about maxLen entries; zero keeps
+// every entry.
+func NewRedisStreamSink(client goredis.UniversalClient, stream string, maxLen int64) *RedisStreamSink {
+	if client == nil || stream == "" {
+		return nil
+	}
+
+	return &RedisStreamSink{client: client, stream: stream, maxLen: maxLen}
+}
+
+func (s *RedisStreamSink) Name() string {
+	return "redis_stream"
+}
+
+func (s *RedisStreamSink) Publish(ctx context.Context, event *entities.DomainEvent) error {
+	values := map[string]interface{}{
+		"event_id":     event.ID.Hex(),
+		"type":         event.Type,
+		"aggregate_id": event.AggregateID.Hex(),
+		"occurred_at":  event.OccurredAt.UTC().Format(time.RFC3339Nano),
+		"data":         string(event.Data),
+	}
+	if !event.PartnerID.IsZero() {
+		values["partner_id"] = event.PartnerID.Hex()
+	}
+
+	return s.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: s.maxLen > 0,
+		Values: values,
+	}).Err()
+}
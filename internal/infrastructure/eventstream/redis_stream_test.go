@@ -0,0 +1,47 @@
+package eventstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRedisStreamSink_AppendsEvents(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+
+	partnerID := primitive.NewObjectID()
+	event, err := entities.NewDomainEvent(entities.EventProductCreated, primitive.NewObjectID(), partnerID, map[string]string{"name": "Crédito"}, time.Now())
+	if err != nil {
+		t.Fatalf("NewDomainEvent returned error: %v", err)
+	}
+
+	sink := NewRedisStreamSink(client, "katseye:events", 1000)
+	if err := sink.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+
+	entries, err := client.XRange(ctx, "katseye:events", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("XRange returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one stream entry, got %d", len(entries))
+	}
+
+	values := entries[0].Values
+	if values["event_id"] != event.ID.Hex() || values["type"] != entities.EventProductCreated || values["partner_id"] != partnerID.Hex() {
+		t.Fatalf("unexpected stream entry %v", values)
+	}
+	if values["data"] != string(event.Data) {
+		t.Fatalf("expected data %s, got %v", event.Data, values["data"])
+	}
+}
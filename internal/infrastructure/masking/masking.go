@@ -0,0 +1,223 @@
+// Package masking hides personally identifiable information in API payloads and log lines.
+//
+// Rules are declared on struct fields with the `mask` tag, e.g. `mask:"document"`, and applied
+// with Struct. The same rules are exposed as functions for use in log statements.
+package masking
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	TagName = "mask"
+
+	// RuleDocument keeps the middle block of a CPF/CNPJ, e.g. ***.456.789-**.
+	RuleDocument = "document"
+	// RulePhone keeps only the last four digits.
+	RulePhone = "phone"
+	// RuleEmail keeps the first character of the local part and the domain.
+	RuleEmail = "email"
+	// RuleRedact removes the value entirely (zero value, nil pointer or fixed placeholder).
+	RuleRedact = "redact"
+
+	placeholder = "***"
+)
+
+// sensitiveQueryParams maps query string parameters to the rule applied when they are logged.
+var sensitiveQueryParams = map[string]string{
+	"document_number": RuleDocument,
+	"email":           RuleEmail,
+	"phone":           RulePhone,
+}
+
+// Value applies the named rule to a string value.
+func Value(rule, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch rule {
+	case RuleDocument:
+		return Document(value)
+	case RulePhone:
+		return Phone(value)
+	case RuleEmail:
+		return Email(value)
+	default:
+		return placeholder
+	}
+}
+
+// Document masks a CPF or CNPJ preserving its formatting. Values that are not 11 or 14 digits
+// long only keep their last two characters.
+func Document(value string) string {
+	digits := 0
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	var visibleFrom, visibleTo int
+	switch digits {
+	case 11: // CPF: 123.456.789-00 -> ***.456.789-**
+		visibleFrom, visibleTo = 3, 9
+	case 14: // CNPJ: 12.345.678/0001-90 -> **.345.678/****-**
+		visibleFrom, visibleTo = 2, 8
+	default:
+		return maskAllButLast(value, 2)
+	}
+
+	var builder strings.Builder
+	position := 0
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			builder.WriteRune(r)
+			continue
+		}
+		if position >= visibleFrom && position < visibleTo {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteByte('*')
+		}
+		position++
+	}
+
+	return builder.String()
+}
+
+// Phone masks every digit except the last four, preserving formatting characters.
+func Phone(value string) string {
+	digits := 0
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	var builder strings.Builder
+	position := 0
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			builder.WriteRune(r)
+			continue
+		}
+		if position >= digits-4 {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteByte('*')
+		}
+		position++
+	}
+
+	return builder.String()
+}
+
+// Email masks the local part of an address, e.g. a***@example.com.
+func Email(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return placeholder
+	}
+
+	// The first character may take more than one byte, as in an internationalized address.
+	_, size := utf8.DecodeRuneInString(value)
+	return value[:size] + placeholder + value[at:]
+}
+
+// Query masks the values of sensitive parameters in a raw query string.
+func Query(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return placeholder
+	}
+
+	changed := false
+	for name, entries := range values {
+		rule, sensitive := sensitiveQueryParams[strings.ToLower(name)]
+		if !sensitive {
+			continue
+		}
+		for i, entry := range entries {
+			entries[i] = Value(rule, entry)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return rawQuery
+	}
+
+	// Keep the mask characters readable instead of percent-encoded.
+	return strings.ReplaceAll(values.Encode(), "%2A", "*")
+}
+
+// Struct walks the value (usually a pointer to a DTO) and applies the rule declared in the
+// `mask` tag of each field. Untagged structs, pointers, slices and maps are traversed.
+func Struct(target interface{}) {
+	if target == nil {
+		return
+	}
+	apply(reflect.ValueOf(target))
+}
+
+func apply(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			apply(value.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			apply(value.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			entry := value.MapIndex(key)
+			if entry.Kind() == reflect.Ptr || entry.Kind() == reflect.Interface {
+				apply(entry)
+			}
+		}
+	case reflect.Struct:
+		valueType := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+
+			rule, tagged := valueType.Field(i).Tag.Lookup(TagName)
+			if !tagged {
+				apply(field)
+				continue
+			}
+
+			if field.Kind() == reflect.String {
+				field.SetString(Value(rule, field.String()))
+				continue
+			}
+
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
+
+func maskAllButLast(value string, visible int) string {
+	runes := []rune(value)
+	if len(runes) <= visible {
+		return strings.Repeat("*", len(runes))
+	}
+
+	for i := 0; i < len(runes)-visible; i++ {
+		runes[i] = '*'
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,73 @@
+package masking
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValue_AppliesRules(t *testing.T) {
+	cases := []struct {
+		rule  string
+		value string
+		want  string
+	}{
+		{RuleDocument, "123.456.789-00", "***.456.789-**"},
+		{RuleDocument, "12345678900", "***456789**"},
+		{RuleDocument, "12.345.678/0001-90", "**.345.678/****-**"},
+		{RulePhone, "(11) 98765-4321", "(**) *****-4321"},
+		{RuleEmail, "ana@example.com", "a***@example.com"},
+		{RuleEmail, "élise@example.com", "é***@example.com"},
+		{RuleRedact, "anything", "***"},
+		{RuleDocument, "", ""},
+	}
+
+	for _, tc := range cases {
+		if got := Value(tc.rule, tc.value); got != tc.want {
+			t.Errorf("Value(%q, %q) = %q, want %q", tc.rule, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestStruct_MasksTaggedFields(t *testing.T) {
+	type contact struct {
+		Phone string `mask:"phone"`
+	}
+	type payload struct {
+		Name      string
+		Document  string     `mask:"document"`
+		BirthDate *time.Time `mask:"redact"`
+		Income    float64    `mask:"redact"`
+		Contacts  []contact
+	}
+
+	birthDate := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	value := payload{
+		Name:      "Ana",
+		Document:  "123.456.789-00",
+		BirthDate: &birthDate,
+		Income:    5000,
+		Contacts:  []contact{{Phone: "11987654321"}},
+	}
+
+	Struct(&value)
+
+	if value.Name != "Ana" {
+		t.Fatalf("expected untagged field to be preserved, got %q", value.Name)
+	}
+	if value.Document != "***.456.789-**" {
+		t.Fatalf("unexpected masked document %q", value.Document)
+	}
+	if value.BirthDate != nil || value.Income != 0 {
+		t.Fatalf("expected redacted fields to be cleared, got %v and %v", value.BirthDate, value.Income)
+	}
+	if value.Contacts[0].Phone != "*******4321" {
+		t.Fatalf("expected nested slices to be masked, got %q", value.Contacts[0].Phone)
+	}
+}
+
+func TestQuery_MasksSensitiveParameters(t *testing.T) {
+	got := Query("document_number=12345678900&limit=10")
+	if got != "document_number=***456789**&limit=10" {
+		t.Fatalf("unexpected masked query %q", got)
+	}
+}
@@ -0,0 +1,381 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/security"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+var ErrFieldNotSearchable = errors.New("field is encrypted without deterministic mode and cannot be searched")
+
+type consumerRepository struct {
+	repo   repositories.ConsumerRepository
+	cipher security.FieldCipher
+	policy Policy
+}
+
+// NewConsumerRepository seals the sensitive consumer fields selected by the policy before they
+// reach the wrapped repository, so every layer below it (cache and Mongo) only sees ciphertext.
+func NewConsumerRepository(cipher security.FieldCipher, policy Policy, repo repositories.ConsumerRepository) repositories.ConsumerRepository {
+	if cipher == nil || repo == nil || policy.IsEmpty() {
+		return repo
+	}
+
+	return &consumerRepository{
+		repo:   repo,
+		cipher: cipher,
+		policy: policy,
+	}
+}
+
+func (r *consumerRepository) GetConsumerByID(ctx context.Context, id primitive.ObjectID) (*entities.Consumer, error) {
+	consumer, err := r.repo.GetConsumerByID(ctx, id)
+	if err != nil || consumer == nil {
+		return consumer, err
+	}
+
+	return consumer, r.open(consumer)
+}
+
+func (r *consumerRepository) GetConsumerByDocument(ctx context.Context, documentNumber string) (*entities.Consumer, error) {
+	deterministic, sealed := r.policy.Mode(FieldDocumentNumber)
+	if !sealed {
+		consumer, err := r.repo.GetConsumerByDocument(ctx, documentNumber)
+		if err != nil || consumer == nil {
+			return consumer, err
+		}
+		return consumer, r.open(consumer)
+	}
+	if !deterministic {
+		return nil, ErrFieldNotSearchable
+	}
+
+	tokens, err := r.cipher.SearchTokens(FieldDocumentNumber, []byte(documentNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	// Plaintext is tried last so records written before encryption was enabled stay reachable
+	// until the rotation command re-encrypts them.
+	for _, candidate := range append(tokens, documentNumber) {
+		consumer, err := r.repo.GetConsumerByDocument(ctx, candidate)
+		if err != nil {
+			return nil, err
+		}
+		if consumer != nil {
+			return consumer, r.open(consumer)
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *consumerRepository) CreateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	sealed, err := r.seal(consumer)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.CreateConsumer(ctx, sealed); err != nil {
+		return err
+	}
+
+	copyPersistenceMetadata(consumer, sealed)
+	return nil
+}
+
+func (r *consumerRepository) UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	sealed, err := r.seal(consumer)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.UpdateConsumer(ctx, sealed); err != nil {
+		return err
+	}
+
+	copyPersistenceMetadata(consumer, sealed)
+	return nil
+}
+
+func (r *consumerRepository) DeleteConsumer(ctx context.Context, id primitive.ObjectID) error {
+	return r.repo.DeleteConsumer(ctx, id)
+}
+
+func (r *consumerRepository) ListConsumers(ctx context.Context, query repositories.ConsumerQuery) ([]*entities.Consumer, repositories.PageInfo, error) {
+	consumers, page, err := r.repo.ListConsumers(ctx, query)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	for _, consumer := range consumers {
+		if consumer == nil {
+			continue
+		}
+		if err := r.open(consumer); err != nil {
+			return nil, repositories.PageInfo{}, err
+		}
+	}
+
+	return consumers, page, nil
+}
+
+// seal returns a copy of the consumer with every configured field replaced by its ciphertext.
+func (r *consumerRepository) seal(consumer *entities.Consumer) (*entities.Consumer, error) {
+	if consumer == nil {
+		return nil, entities.ErrConsumerNil
+	}
+
+	sealed := cloneConsumer(consumer)
+	sealed.SealedFields = nil
+
+	for _, name := range r.policy.Fields() {
+		field := consumerFields[name]
+
+		plaintext, present := field.read(sealed)
+		if !present {
+			continue
+		}
+
+		deterministic, _ := r.policy.Mode(name)
+		ciphertext, err := r.cipher.Encrypt(name, plaintext, deterministic)
+		if err != nil {
+			return nil, fmt.Errorf("encrypting consumer field %s: %w", name, err)
+		}
+
+		if sealed.SealedFields == nil {
+			sealed.SealedFields = make(map[string]string)
+		}
+		sealed.SealedFields[name] = ciphertext
+		field.clear(sealed)
+	}
+
+	return sealed, nil
+}
+
+// open decrypts every sealed field in place, regardless of the current policy, so values
+// written under an older configuration are still readable.
+func (r *consumerRepository) open(consumer *entities.Consumer) error {
+	for name, ciphertext := range consumer.SealedFields {
+		field, ok := consumerFields[name]
+		if !ok {
+			return fmt.Errorf("decrypting consumer field %s: %w", name, ErrUnknownField)
+		}
+
+		plaintext, err := r.cipher.Decrypt(name, ciphertext)
+		if err != nil {
+			return fmt.Errorf("decrypting consumer field %s: %w", name, err)
+		}
+
+		if err := field.write(consumer, plaintext); err != nil {
+			return fmt.Errorf("decoding consumer field %s: %w", name, err)
+		}
+	}
+
+	consumer.SealedFields = nil
+	return nil
+}
+
+// HasPlaintext reports whether any field this package can seal still holds a readable value,
+// meaning the consumer must not be copied to storage that is only trusted with ciphertext.
+func HasPlaintext(consumer *entities.Consumer) bool {
+	if consumer == nil {
+		return false
+	}
+
+	for _, field := range consumerFields {
+		if _, present := field.read(consumer); present {
+			return true
+		}
+	}
+	return false
+}
+
+type consumerField struct {
+	read  func(*entities.Consumer) ([]byte, bool)
+	write func(*entities.Consumer, []byte) error
+	clear func(*entities.Consumer)
+}
+
+var consumerFields = map[string]consumerField{
+	FieldDocumentNumber: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			switch {
+			case c.PersonalData.Individual != nil:
+				return stringValue(c.PersonalData.Individual.DocumentNumber)
+			case c.PersonalData.Business != nil:
+				return stringValue(c.PersonalData.Business.DocumentNumber)
+			}
+			return nil, false
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			switch {
+			case c.PersonalData.Individual != nil:
+				c.PersonalData.Individual.DocumentNumber = string(value)
+			case c.PersonalData.Business != nil:
+				c.PersonalData.Business.DocumentNumber = string(value)
+			}
+			return nil
+		},
+		clear: func(c *entities.Consumer) {
+			switch {
+			case c.PersonalData.Individual != nil:
+				c.PersonalData.Individual.DocumentNumber = ""
+			case c.PersonalData.Business != nil:
+				c.PersonalData.Business.DocumentNumber = ""
+			}
+		},
+	},
+	FieldBirthDate: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			if c.PersonalData.Individual == nil || c.PersonalData.Individual.BirthDate.IsZero() {
+				return nil, false
+			}
+			return []byte(c.PersonalData.Individual.BirthDate.UTC().Format(time.RFC3339Nano)), true
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			if c.PersonalData.Individual == nil {
+				return nil
+			}
+			birthDate, err := time.Parse(time.RFC3339Nano, string(value))
+			if err != nil {
+				return err
+			}
+			c.PersonalData.Individual.BirthDate = birthDate
+			return nil
+		},
+		clear: func(c *entities.Consumer) {
+			if c.PersonalData.Individual != nil {
+				c.PersonalData.Individual.BirthDate = time.Time{}
+			}
+		},
+	},
+	FieldMonthlyIncome: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			return moneyValue(c.CreditProfile.MonthlyIncome)
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			return parseMoneyInto(&c.CreditProfile.MonthlyIncome, value)
+		},
+		clear: func(c *entities.Consumer) {
+			c.CreditProfile.MonthlyIncome = valueobjects.Money{}
+		},
+	},
+	FieldAnnualRevenue: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			return moneyValue(c.CreditProfile.AnnualRevenue)
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			return parseMoneyInto(&c.CreditProfile.AnnualRevenue, value)
+		},
+		clear: func(c *entities.Consumer) {
+			c.CreditProfile.AnnualRevenue = valueobjects.Money{}
+		},
+	},
+	FieldEstimatedIncome: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			return moneyValue(c.CreditProfile.Bureau.EstimatedIncome)
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			return parseMoneyInto(&c.CreditProfile.Bureau.EstimatedIncome, value)
+		},
+		clear: func(c *entities.Consumer) {
+			c.CreditProfile.Bureau.EstimatedIncome = valueobjects.Money{}
+		},
+	},
+	FieldEmail: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			return stringValue(c.Contact.Email)
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			c.Contact.Email = string(value)
+			return nil
+		},
+		clear: func(c *entities.Consumer) {
+			c.Contact.Email = ""
+		},
+	},
+	FieldPhone: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			return stringValue(c.Contact.Phone)
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			c.Contact.Phone = string(value)
+			return nil
+		},
+		clear: func(c *entities.Consumer) {
+			c.Contact.Phone = ""
+		},
+	},
+	FieldSecondaryPhone: {
+		read: func(c *entities.Consumer) ([]byte, bool) {
+			return stringValue(c.Contact.SecondaryPhone)
+		},
+		write: func(c *entities.Consumer, value []byte) error {
+			c.Contact.SecondaryPhone = string(value)
+			return nil
+		},
+		clear: func(c *entities.Consumer) {
+			c.Contact.SecondaryPhone = ""
+		},
+	},
+}
+
+func stringValue(value string) ([]byte, bool) {
+	if value == "" {
+		return nil, false
+	}
+	return []byte(value), true
+}
+
+func moneyValue(value valueobjects.Money) ([]byte, bool) {
+	if value.IsZero() {
+		return nil, false
+	}
+	return []byte(value.String()), true
+}
+
+// parseMoneyInto also reads the float text written before amounts were exact, rounding it to cents.
+func parseMoneyInto(target *valueobjects.Money, value []byte) error {
+	parsed, err := valueobjects.ParseMoney(string(value))
+	if err == nil {
+		*target = parsed
+		return nil
+	}
+
+	legacy, legacyErr := strconv.ParseFloat(string(value), 64)
+	if legacyErr != nil {
+		return err
+	}
+	*target = valueobjects.MoneyFromFloat(legacy)
+	return nil
+}
+
+func cloneConsumer(consumer *entities.Consumer) *entities.Consumer {
+	clone := *consumer
+
+	if consumer.PersonalData.Individual != nil {
+		individual := *consumer.PersonalData.Individual
+		clone.PersonalData.Individual = &individual
+	}
+	if consumer.PersonalData.Business != nil {
+		business := *consumer.PersonalData.Business
+		clone.PersonalData.Business = &business
+	}
+
+	return &clone
+}
+
+func copyPersistenceMetadata(target, source *entities.Consumer) {
+	target.ID = source.ID
+	target.CreatedAt = source.CreatedAt
+	target.UpdatedAt = source.UpdatedAt
+}
@@ -0,0 +1,78 @@
+package encryption
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	FieldDocumentNumber  = "document_number"
+	FieldBirthDate       = "birth_date"
+	FieldMonthlyIncome   = "monthly_income"
+	FieldAnnualRevenue   = "annual_revenue"
+	FieldEstimatedIncome = "estimated_income"
+	FieldEmail           = "email"
+	FieldPhone           = "phone"
+	FieldSecondaryPhone  = "secondary_phone"
+)
+
+var ErrUnknownField = errors.New("unknown encrypted field")
+
+// Policy lists which consumer fields are encrypted and which of them use deterministic mode.
+type Policy struct {
+	fields map[string]bool
+}
+
+// NewPolicy validates the configured field names. Deterministic fields are implicitly encrypted.
+func NewPolicy(fields, deterministic []string) (Policy, error) {
+	policy := Policy{fields: make(map[string]bool)}
+
+	for _, name := range fields {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := consumerFields[name]; !ok {
+			return Policy{}, fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+		if _, exists := policy.fields[name]; !exists {
+			policy.fields[name] = false
+		}
+	}
+
+	for _, name := range deterministic {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := consumerFields[name]; !ok {
+			return Policy{}, fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+		policy.fields[name] = true
+	}
+
+	return policy, nil
+}
+
+// IsEmpty reports whether no field is selected for encryption.
+func (p Policy) IsEmpty() bool {
+	return len(p.fields) == 0
+}
+
+// Mode reports whether the field is deterministic and whether it is encrypted at all.
+func (p Policy) Mode(field string) (deterministic bool, encrypted bool) {
+	deterministic, encrypted = p.fields[field]
+	return deterministic, encrypted
+}
+
+// Fields returns the encrypted field names in a stable order.
+func (p Policy) Fields() []string {
+	names := make([]string, 0, len(p.fields))
+	for name := range p.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type addressRepository struct {
+	addresses *collection[entities.Address]
+}
+
+func NewAddressRepository() repositories.AddressRepository {
+	return &addressRepository{addresses: newCollection(addressID)}
+}
+
+func (r *addressRepository) GetAddressByID(ctx context.Context, id primitive.ObjectID) (*entities.Address, error) {
+	return r.addresses.get(id)
+}
+
+func (r *addressRepository) CreateAddress(ctx context.Context, address *entities.Address) error {
+	if address == nil {
+		return errNilEntity
+	}
+	return r.addresses.insert(address, nil)
+}
+
+func (r *addressRepository) UpdateAddress(ctx context.Context, address *entities.Address) error {
+	if address == nil {
+		return errNilEntity
+	}
+	return r.addresses.replace(address)
+}
+
+func (r *addressRepository) DeleteAddress(ctx context.Context, id primitive.ObjectID) error {
+	r.addresses.remove(id)
+	return nil
+}
+
+var addressSortFields = map[string]sortField[entities.Address]{
+	"city":        {value: func(a *entities.Address) interface{} { return a.City }},
+	"state":       {value: func(a *entities.Address) interface{} { return a.State }},
+	"postal_code": {value: func(a *entities.Address) interface{} { return a.PostalCode }},
+}
+
+func (r *addressRepository) ListAddresses(ctx context.Context, query repositories.AddressQuery) ([]*entities.Address, repositories.PageInfo, error) {
+	addresses, err := r.addresses.find(func(address *entities.Address) bool {
+		if query.City != "" && address.City != query.City {
+			return false
+		}
+		if query.State != "" && address.State != query.State {
+			return false
+		}
+		if query.PostalCode != "" && address.PostalCode != query.PostalCode {
+			return false
+		}
+		if query.Type != "" && address.Type != query.Type {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return paginate(addresses, query.Page, addressSortFields, addressID)
+}
+
+func addressID(address *entities.Address) primitive.ObjectID {
+	return address.ID
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	errDuplicateKey = errors.New("memory: duplicate key")
+	errNilEntity    = errors.New("memory: entity must not be nil")
+)
+
+// collection holds entities keyed by ID behind a read-write lock. Entities are copied on the way
+// in and out, so callers never share memory with the store or with each other.
+type collection[T any] struct {
+	mu    sync.RWMutex
+	items map[primitive.ObjectID]*T
+	id    func(*T) primitive.ObjectID
+}
+
+func newCollection[T any](id func(*T) primitive.ObjectID) *collection[T] {
+	return &collection[T]{
+		items: make(map[primitive.ObjectID]*T),
+		id:    id,
+	}
+}
+
+// get returns a copy of the entity, or nil when it does not exist.
+func (c *collection[T]) get(id primitive.ObjectID) (*T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	item, ok := c.items[id]
+	if !ok {
+		return nil, nil
+	}
+	return clone(item)
+}
+
+// first returns a copy of the first entity accepted by match, or nil when there is none.
+func (c *collection[T]) first(match func(*T) bool) (*T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, item := range c.items {
+		if match(item) {
+			return clone(item)
+		}
+	}
+	return nil, nil
+}
+
+// find returns copies of every entity accepted by match, in no particular order.
+func (c *collection[T]) find(match func(*T) bool) ([]*T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	found := make([]*T, 0)
+	for _, item := range c.items {
+		if match != nil && !match(item) {
+			continue
+		}
+		copied, err := clone(item)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, copied)
+	}
+	return found, nil
+}
+
+// insert stores a new entity. It fails with errDuplicateKey when the ID is taken or when
+// conflicts accepts a stored entity, which is how unique indexes are emulated.
+func (c *collection[T]) insert(item *T, conflicts func(stored *T) bool) error {
+	copied, err := clone(item)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.id(copied)
+	if _, ok := c.items[id]; ok {
+		return errDuplicateKey
+	}
+	if conflicts != nil {
+		for _, stored := range c.items {
+			if conflicts(stored) {
+				return errDuplicateKey
+			}
+		}
+	}
+
+	c.items[id] = copied
+	return nil
+}
+
+// replace overwrites a stored entity. Like an update matching no document, it does nothing when
+// the entity does not exist.
+func (c *collection[T]) replace(item *T) error {
+	copied, err := clone(item)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.id(copied)
+	if _, ok := c.items[id]; ok {
+		c.items[id] = copied
+	}
+	return nil
+}
+
+// remove deletes the entity and reports whether it existed.
+func (c *collection[T]) remove(id primitive.ObjectID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	return true
+}
+
+// clone deep-copies an entity through its JSON form, the same encoding the Redis cache stores.
+func clone[T any](item *T) (*T, error) {
+	if item == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied T
+	if err := json.Unmarshal(payload, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
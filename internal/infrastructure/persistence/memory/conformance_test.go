@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"testing"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/repositories/repositorytest"
+	"katseye/internal/domain/security"
+)
+
+func TestProductRepositoryConformance(t *testing.T) {
+	repositorytest.ProductRepository(t, func(t *testing.T) repositories.ProductRepository {
+		return NewProductRepository()
+	})
+}
+
+func TestPartnerRepositoryConformance(t *testing.T) {
+	repositorytest.PartnerRepository(t, func(t *testing.T) repositories.PartnerRepository {
+		return NewPartnerRepository()
+	})
+}
+
+func TestAddressRepositoryConformance(t *testing.T) {
+	repositorytest.AddressRepository(t, func(t *testing.T) repositories.AddressRepository {
+		return NewAddressRepository()
+	})
+}
+
+func TestConsumerRepositoryConformance(t *testing.T) {
+	repositorytest.ConsumerRepository(t, func(t *testing.T) repositories.ConsumerRepository {
+		return NewConsumerRepository()
+	})
+}
+
+func TestUserRepositoryConformance(t *testing.T) {
+	repositorytest.UserRepository(t, func(t *testing.T) repositories.UserRepository {
+		return NewUserRepository()
+	})
+}
+
+func TestTokenStoreConformance(t *testing.T) {
+	repositorytest.TokenStore(t, func(t *testing.T) security.TokenStore {
+		return NewTokenStore()
+	})
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentNumberField is the sealed field holding the deterministic document number token.
+const documentNumberField = "document_number"
+
+type consumerRepository struct {
+	consumers *collection[entities.Consumer]
+}
+
+func NewConsumerRepository() repositories.ConsumerRepository {
+	return &consumerRepository{consumers: newCollection(consumerID)}
+}
+
+func (r *consumerRepository) GetConsumerByID(ctx context.Context, id primitive.ObjectID) (*entities.Consumer, error) {
+	return r.consumers.get(id)
+}
+
+// GetConsumerByDocument matches the plaintext document number of either kind of consumer, or
+// its sealed token when the consumer went through field encryption.
+func (r *consumerRepository) GetConsumerByDocument(ctx context.Context, documentNumber string) (*entities.Consumer, error) {
+	return r.consumers.first(func(consumer *entities.Consumer) bool {
+		personal := consumer.PersonalData
+		switch {
+		case personal.Individual != nil && personal.Individual.DocumentNumber == documentNumber:
+			return true
+		case personal.Business != nil && personal.Business.DocumentNumber == documentNumber:
+			return true
+		default:
+			sealed, ok := consumer.SealedFields[documentNumberField]
+			return ok && sealed == documentNumber
+		}
+	})
+}
+
+func (r *consumerRepository) CreateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	if consumer == nil {
+		return errNilEntity
+	}
+	return r.consumers.insert(consumer, nil)
+}
+
+func (r *consumerRepository) UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	if consumer == nil {
+		return errNilEntity
+	}
+	return r.consumers.replace(consumer)
+}
+
+func (r *consumerRepository) DeleteConsumer(ctx context.Context, id primitive.ObjectID) error {
+	r.consumers.remove(id)
+	return nil
+}
+
+var consumerSortFields = map[string]sortField[entities.Consumer]{
+	"created_at": {value: func(c *entities.Consumer) interface{} { return c.CreatedAt }, kind: sortKindTime},
+	"updated_at": {value: func(c *entities.Consumer) interface{} { return c.UpdatedAt }, kind: sortKindTime},
+}
+
+func (r *consumerRepository) ListConsumers(ctx context.Context, query repositories.ConsumerQuery) ([]*entities.Consumer, repositories.PageInfo, error) {
+	consumers, err := r.consumers.find(func(consumer *entities.Consumer) bool {
+		return query.Type == "" || consumer.Type == query.Type
+	})
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return paginate(consumers, query.Page, consumerSortFields, consumerID)
+}
+
+func consumerID(consumer *entities.Consumer) primitive.ObjectID {
+	return consumer.ID
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type sortKind int
+
+const (
+	sortKindString sortKind = iota
+	sortKindTime
+	sortKindInt
+)
+
+// sortField reads the value of a whitelisted API sort field from an entity.
+type sortField[T any] struct {
+	value func(*T) interface{}
+	kind  sortKind
+}
+
+// paginate applies the keyset pagination of the Mongo repositories to items: they are ordered
+// by the sort field and then by ID, and the cursor resumes strictly after the last returned item.
+func paginate[T any](items []*T, page repositories.Pagination, fields map[string]sortField[T], id func(*T) primitive.ObjectID) ([]*T, repositories.PageInfo, error) {
+	page = page.Normalize()
+
+	var field *sortField[T]
+	if page.Sort.Field != repositories.SortByID {
+		selected, ok := fields[page.Sort.Field]
+		if !ok {
+			return nil, repositories.PageInfo{}, fmt.Errorf("%w: %s", repositories.ErrInvalidSortField, page.Sort.Field)
+		}
+		field = &selected
+	}
+
+	compare := func(a, b *T) int {
+		if field != nil {
+			if order := compareValues(field.value(a), field.value(b)); order != 0 {
+				return order
+			}
+		}
+		ida, idb := id(a), id(b)
+		return bytes.Compare(ida[:], idb[:])
+	}
+	if page.Sort.Descending {
+		ascending := compare
+		compare = func(a, b *T) int { return ascending(b, a) }
+	}
+
+	sort.Slice(items, func(i, j int) bool { return compare(items[i], items[j]) < 0 })
+
+	if page.Cursor != "" {
+		cursor, err := repositories.DecodeCursor(page.Cursor, page.Sort)
+		if err != nil {
+			return nil, repositories.PageInfo{}, err
+		}
+
+		var cursorValue interface{}
+		if field != nil {
+			cursorValue, err = field.parse(cursor.Value)
+			if err != nil {
+				return nil, repositories.PageInfo{}, repositories.ErrInvalidCursor
+			}
+		}
+
+		start := sort.Search(len(items), func(i int) bool {
+			order := 0
+			if field != nil {
+				order = compareValues(field.value(items[i]), cursorValue)
+			}
+			if order == 0 {
+				itemID := id(items[i])
+				order = bytes.Compare(itemID[:], cursor.ID[:])
+			}
+			if page.Sort.Descending {
+				order = -order
+			}
+			return order > 0
+		})
+		items = items[start:]
+	}
+
+	info := repositories.PageInfo{Limit: page.Limit}
+	if len(items) <= page.Limit {
+		return items, info, nil
+	}
+
+	items = items[:page.Limit]
+	last := items[len(items)-1]
+	cursor := repositories.Cursor{Sort: page.Sort.String(), ID: id(last)}
+	if field != nil {
+		cursor.Value = field.format(field.value(last))
+	}
+	info.HasMore = true
+	info.NextCursor = repositories.EncodeCursor(cursor)
+
+	return items, info, nil
+}
+
+func compareValues(a, b interface{}) int {
+	switch left := a.(type) {
+	case time.Time:
+		right, _ := b.(time.Time)
+		return left.Compare(right)
+	case int:
+		right, _ := b.(int)
+		switch {
+		case left < right:
+			return -1
+		case left > right:
+			return 1
+		}
+		return 0
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func (f sortField[T]) parse(value string) (interface{}, error) {
+	switch f.kind {
+	case sortKindTime:
+		return time.Parse(time.RFC3339Nano, value)
+	case sortKindInt:
+		return strconv.Atoi(value)
+	default:
+		return value, nil
+	}
+}
+
+func (f sortField[T]) format(value interface{}) string {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano)
+	case string:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type partnerRepository struct {
+	partners *collection[entities.Partner]
+}
+
+func NewPartnerRepository() repositories.PartnerRepository {
+	return &partnerRepository{partners: newCollection(partnerID)}
+}
+
+func (r *partnerRepository) GetPartnerByID(ctx context.Context, id primitive.ObjectID) (*entities.Partner, error) {
+	return r.partners.get(id)
+}
+
+func (r *partnerRepository) CreatePartner(ctx context.Context, partner *entities.Partner) error {
+	if partner == nil {
+		return errNilEntity
+	}
+	return r.partners.insert(partner, nil)
+}
+
+func (r *partnerRepository) UpdatePartner(ctx context.Context, partner *entities.Partner) error {
+	if partner == nil {
+		return errNilEntity
+	}
+	return r.partners.replace(partner)
+}
+
+func (r *partnerRepository) DeletePartner(ctx context.Context, id primitive.ObjectID) error {
+	r.partners.remove(id)
+	return nil
+}
+
+var partnerSortFields = map[string]sortField[entities.Partner]{
+	"name": {value: func(p *entities.Partner) interface{} { return p.Name }},
+	"type": {value: func(p *entities.Partner) interface{} { return p.Type.String() }},
+}
+
+func (r *partnerRepository) ListPartners(ctx context.Context, query repositories.PartnerQuery) ([]*entities.Partner, repositories.PageInfo, error) {
+	partners, err := r.partners.find(func(partner *entities.Partner) bool {
+		if query.Type != "" && partner.Type != query.Type {
+			return false
+		}
+		if query.AcceptedType != "" && !acceptsType(partner, query.AcceptedType) {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return paginate(partners, query.Page, partnerSortFields, partnerID)
+}
+
+func acceptsType(partner *entities.Partner, productType valueobjects.ProductType) bool {
+	for _, accepted := range partner.AcceptedTypes {
+		if accepted == productType {
+			return true
+		}
+	}
+	return false
+}
+
+func partnerID(partner *entities.Partner) primitive.ObjectID {
+	return partner.ID
+}
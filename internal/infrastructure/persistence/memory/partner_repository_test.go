@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPartnerRepository_ListPagesBySortField(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPartnerRepository()
+
+	for _, name := range []string{"Banco B", "Banco A", "Banco C", "Banco A"} {
+		partner := &entities.Partner{ID: primitive.NewObjectID(), Name: name, Type: valueobjects.PartnerTypeBank}
+		if err := repo.CreatePartner(ctx, partner); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+	}
+
+	query := repositories.PartnerQuery{Page: repositories.Pagination{Limit: 3, Sort: repositories.ParseSort("-name")}}
+	first, info, err := repo.ListPartners(ctx, query)
+	if err != nil {
+		t.Fatalf("ListPartners returned error: %v", err)
+	}
+	if got := partnerNames(first); got != "Banco C,Banco B,Banco A" || !info.HasMore {
+		t.Fatalf("unexpected first page %q has_more=%t", got, info.HasMore)
+	}
+
+	query.Page.Cursor = info.NextCursor
+	second, info, err := repo.ListPartners(ctx, query)
+	if err != nil {
+		t.Fatalf("ListPartners returned error: %v", err)
+	}
+	if len(second) != 1 || second[0].Name != "Banco A" || second[0].ID == first[2].ID || info.HasMore {
+		t.Fatalf("unexpected second page %q has_more=%t", partnerNames(second), info.HasMore)
+	}
+}
+
+func TestPartnerRepository_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPartnerRepository()
+
+	partner := &entities.Partner{ID: primitive.NewObjectID(), Name: "Banco A", Type: valueobjects.PartnerTypeBank}
+	if err := repo.CreatePartner(ctx, partner); err != nil {
+		t.Fatalf("CreatePartner returned error: %v", err)
+	}
+	partner.Name = "changed after create"
+
+	stored, err := repo.GetPartnerByID(ctx, partner.ID)
+	if err != nil {
+		t.Fatalf("GetPartnerByID returned error: %v", err)
+	}
+	stored.Name = "changed after read"
+
+	if again, _ := repo.GetPartnerByID(ctx, partner.ID); again.Name != "Banco A" {
+		t.Fatalf("expected stored partner to be isolated from callers, got %q", again.Name)
+	}
+}
+
+func partnerNames(partners []*entities.Partner) string {
+	names := ""
+	for i, partner := range partners {
+		if i > 0 {
+			names += ","
+		}
+		names += partner.Name
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type productRepository struct {
+	products *collection[entities.Product]
+}
+
+func NewProductRepository() repositories.ProductRepository {
+	return &productRepository{products: newCollection(productID)}
+}
+
+func (r *productRepository) GetProductByID(ctx context.Context, id primitive.ObjectID) (*entities.Product, error) {
+	return r.products.get(id)
+}
+
+func (r *productRepository) CreateProduct(ctx context.Context, product *entities.Product) error {
+	if product == nil {
+		return errNilEntity
+	}
+	return r.products.insert(product, nil)
+}
+
+func (r *productRepository) UpdateProduct(ctx context.Context, product *entities.Product) error {
+	if product == nil {
+		return errNilEntity
+	}
+	return r.products.replace(product)
+}
+
+func (r *productRepository) DeleteProduct(ctx context.Context, id primitive.ObjectID) error {
+	r.products.remove(id)
+	return nil
+}
+
+var productSortFields = map[string]sortField[entities.Product]{
+	"name":         {value: func(p *entities.Product) interface{} { return p.Name }},
+	"product_type": {value: func(p *entities.Product) interface{} { return p.ProductType.String() }},
+	"category":     {value: func(p *entities.Product) interface{} { return string(p.Category) }},
+}
+
+func (r *productRepository) ListProducts(ctx context.Context, query repositories.ProductQuery) ([]*entities.Product, repositories.PageInfo, error) {
+	products, err := r.products.find(func(product *entities.Product) bool {
+		if !query.PartnerID.IsZero() && product.PartnerID != query.PartnerID {
+			return false
+		}
+		if query.ProductType != "" && product.ProductType != query.ProductType {
+			return false
+		}
+		if query.Category != "" && product.Category != query.Category {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	return paginate(products, query.Page, productSortFields, productID)
+}
+
+func productID(product *entities.Product) primitive.ObjectID {
+	return product.ID
+}
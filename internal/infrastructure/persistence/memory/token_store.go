@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+
+	"katseye/internal/domain/security"
+)
+
+const minimumRevocationTTL = time.Minute
+
+var _ security.TokenStore = (*TokenStore)(nil)
+
+// TokenStore keeps revoked token hashes in process memory until the tokens expire. Revocations
+// are not shared between instances, so it only fits single-instance deployments.
+type TokenStore struct {
+	mu      sync.Mutex
+	revoked map[[sha256.Size]byte]time.Time
+	now     func() time.Time
+}
+
+// NewTokenStore creates an empty TokenStore.
+func NewTokenStore() *TokenStore {
+	return &TokenStore{
+		revoked: make(map[[sha256.Size]byte]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Revoke records the token hash until the supplied expiration time, dropping expired entries.
+func (s *TokenStore) Revoke(ctx context.Context, token string, expiresAt time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for hash, until := range s.revoked {
+		if !until.After(now) {
+			delete(s.revoked, hash)
+		}
+	}
+
+	// As in the Redis store, a token that already expired stays revoked for a short while.
+	if !expiresAt.After(now) {
+		expiresAt = now.Add(minimumRevocationTTL)
+	}
+	s.revoked[sha256.Sum256([]byte(token))] = expiresAt
+
+	return nil
+}
+
+// IsRevoked reports whether the token hash was revoked and has not expired yet.
+func (s *TokenStore) IsRevoked(ctx context.Context, token string) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.revoked[sha256.Sum256([]byte(token))]
+	return ok && until.After(s.now()), nil
+}
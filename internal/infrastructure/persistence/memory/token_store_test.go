@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenStore_RevocationExpiresWithToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewTokenStore()
+	store.now = func() time.Time { return now }
+
+	const token = "sample.jwt.token"
+
+	if err := store.Revoke(ctx, token, now.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+
+	if revoked, err := store.IsRevoked(ctx, token); err != nil {
+		t.Fatalf("IsRevoked returned error: %v", err)
+	} else if !revoked {
+		t.Fatalf("expected token to be marked revoked")
+	}
+
+	now = now.Add(time.Hour)
+	if revoked, _ := store.IsRevoked(ctx, token); revoked {
+		t.Fatalf("expected revocation to end with the token lifetime")
+	}
+
+	if err := store.Revoke(ctx, "other.jwt.token", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+	if len(store.revoked) != 1 {
+		t.Fatalf("expected expired revocations to be dropped, got %d entries", len(store.revoked))
+	}
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userRepository struct {
+	users *collection[entities.User]
+}
+
+func NewUserRepository() repositories.UserRepository {
+	return &userRepository{users: newCollection(userID)}
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	email = normalizeEmail(email)
+	if email == "" {
+		return nil, nil
+	}
+
+	return r.users.first(func(user *entities.User) bool {
+		return user.Email == email
+	})
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*entities.User, error) {
+	if id.IsZero() {
+		return nil, nil
+	}
+	return r.users.get(id)
+}
+
+// CreateUser stores the normalized user and, like the users collection index, keeps emails unique.
+func (r *userRepository) CreateUser(ctx context.Context, user *entities.User) error {
+	if user == nil {
+		return errors.New("user payload must not be nil")
+	}
+
+	normalized := *user
+	normalized.Normalize()
+
+	err := r.users.insert(&normalized, func(stored *entities.User) bool {
+		return stored.Email == normalized.Email
+	})
+	if errors.Is(err, errDuplicateKey) {
+		return repositories.ErrUserAlreadyExists
+	}
+	return err
+}
+
+func (r *userRepository) DeleteUser(ctx context.Context, id primitive.ObjectID) error {
+	if id.IsZero() || !r.users.remove(id) {
+		return repositories.ErrUserNotFound
+	}
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.TrimSpace(strings.ToLower(email))
+}
+
+func userID(user *entities.User) primitive.ObjectID {
+	return user.ID
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditEventDocument descreve como eventos de auditoria são persistidos no MongoDB.
+type AuditEventDocument struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	SubjectType string             `bson:"subject_type"`
+	SubjectID   primitive.ObjectID `bson:"subject_id"`
+	Action      string             `bson:"action"`
+	ActorID     primitive.ObjectID `bson:"actor_id,omitempty"`
+	Details     map[string]string  `bson:"details,omitempty"`
+	OccurredAt  time.Time          `bson:"occurred_at"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc AuditEventDocument) ToEntity() *entities.AuditEvent {
+	return &entities.AuditEvent{
+		ID:          doc.ID,
+		SubjectType: doc.SubjectType,
+		SubjectID:   doc.SubjectID,
+		Action:      doc.Action,
+		ActorID:     doc.ActorID,
+		Details:     copyStringMap(doc.Details),
+		OccurredAt:  doc.OccurredAt,
+	}
+}
+
+// NewAuditEventDocument cria o documento persistido a partir da entidade.
+func NewAuditEventDocument(event *entities.AuditEvent) AuditEventDocument {
+	if event == nil {
+		return AuditEventDocument{}
+	}
+
+	return AuditEventDocument{
+		ID:          event.ID,
+		SubjectType: event.SubjectType,
+		SubjectID:   event.SubjectID,
+		Action:      event.Action,
+		ActorID:     event.ActorID,
+		Details:     copyStringMap(event.Details),
+		OccurredAt:  event.OccurredAt,
+	}
+}
+
+func copyStringMap(details map[string]string) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(details))
+	for key, value := range details {
+		copied[key] = value
+	}
+	return copied
+}
@@ -19,8 +19,11 @@ type ConsumerDocument struct {
 	AdditionalAddressIDs []primitive.ObjectID          `bson:"additional_address_ids,omitempty"`
 	ContractedProducts   []primitive.ObjectID          `bson:"contracted_products,omitempty"`
 	UserID               primitive.ObjectID            `bson:"user_id,omitempty"`
+	Screening            ConsumerScreeningDocument     `bson:"screening,omitempty"`
 	CreatedAt            time.Time                     `bson:"created_at"`
 	UpdatedAt            time.Time                     `bson:"updated_at"`
+	ErasedAt             time.Time                     `bson:"erased_at,omitempty"`
+	SealedFields         map[string]string             `bson:"sealed_fields,omitempty"`
 }
 
 type ConsumerPersonalDataDocument struct {
@@ -55,18 +58,48 @@ type ConsumerContactDocument struct {
 }
 
 type ConsumerCreditProfileDocument struct {
-	CreditScore             int     `bson:"credit_score"`
-	MonthlyIncome           float64 `bson:"monthly_income"`
-	AnnualRevenue           float64 `bson:"annual_revenue"`
-	CreditLimitRequested    float64 `bson:"credit_limit_requested"`
-	CreditLimitApproved     float64 `bson:"credit_limit_approved"`
-	OutstandingDebt         float64 `bson:"outstanding_debt"`
-	RiskLevel               string  `bson:"risk_level,omitempty"`
-	DelinquencyProbability  float64 `bson:"delinquency_probability"`
-	EmploymentStatus        string  `bson:"employment_status,omitempty"`
-	YearsInCurrentJob       int     `bson:"years_in_current_job"`
-	YearsInBusiness         int     `bson:"years_in_business"`
-	BankingRelationshipRank string  `bson:"banking_relationship_rank,omitempty"`
+	CreditScore             int                  `bson:"credit_score"`
+	MonthlyIncome           valueobjects.Money   `bson:"monthly_income"`
+	AnnualRevenue           valueobjects.Money   `bson:"annual_revenue"`
+	CreditLimitRequested    valueobjects.Money   `bson:"credit_limit_requested"`
+	CreditLimitApproved     valueobjects.Money   `bson:"credit_limit_approved"`
+	OutstandingDebt         valueobjects.Money   `bson:"outstanding_debt"`
+	RiskLevel               string               `bson:"risk_level,omitempty"`
+	DelinquencyProbability  float64              `bson:"delinquency_probability"`
+	EmploymentStatus        string               `bson:"employment_status,omitempty"`
+	YearsInCurrentJob       int                  `bson:"years_in_current_job"`
+	YearsInBusiness         int                  `bson:"years_in_business"`
+	BankingRelationshipRank string               `bson:"banking_relationship_rank,omitempty"`
+	Bureau                  CreditBureauDocument `bson:"bureau,omitempty"`
+}
+
+// CreditBureauDocument guarda o último retorno do bureau de crédito com a origem e as datas.
+type CreditBureauDocument struct {
+	Source                     string             `bson:"source"`
+	RefreshedAt                time.Time          `bson:"refreshed_at"`
+	ScoreRetrievedAt           time.Time          `bson:"score_retrieved_at"`
+	NegativeRecords            int                `bson:"negative_records"`
+	NegativeRecordsAmount      valueobjects.Money `bson:"negative_records_amount"`
+	NegativeRecordsRetrievedAt time.Time          `bson:"negative_records_retrieved_at"`
+	EstimatedIncome            valueobjects.Money `bson:"estimated_income"`
+	IncomeRetrievedAt          time.Time          `bson:"income_retrieved_at"`
+}
+
+// IsZero permite omitir o documento de consumidores que nunca consultaram o bureau.
+func (doc CreditBureauDocument) IsZero() bool {
+	return doc == CreditBureauDocument{}
+}
+
+// ConsumerScreeningDocument guarda o resultado da triagem de fraude feita no cadastro.
+type ConsumerScreeningDocument struct {
+	Score      int                   `bson:"score"`
+	Status     entities.ReviewStatus `bson:"status"`
+	ScreenedAt time.Time             `bson:"screened_at"`
+}
+
+// IsZero permite omitir o documento de consumidores cadastrados sem triagem.
+func (doc ConsumerScreeningDocument) IsZero() bool {
+	return doc == ConsumerScreeningDocument{}
 }
 
 func NewConsumerDocument(consumer *entities.Consumer) ConsumerDocument {
@@ -84,8 +117,11 @@ func NewConsumerDocument(consumer *entities.Consumer) ConsumerDocument {
 		AdditionalAddressIDs: append([]primitive.ObjectID(nil), consumer.AdditionalAddressIDs...),
 		ContractedProducts:   append([]primitive.ObjectID(nil), consumer.ContractedProducts...),
 		UserID:               consumer.UserID,
+		Screening:            ConsumerScreeningDocument(consumer.Screening),
 		CreatedAt:            consumer.CreatedAt,
 		UpdatedAt:            consumer.UpdatedAt,
+		ErasedAt:             consumer.ErasedAt,
+		SealedFields:         copyStringMap(consumer.SealedFields),
 	}
 
 	return doc
@@ -102,8 +138,11 @@ func (doc ConsumerDocument) ToEntity() *entities.Consumer {
 		AdditionalAddressIDs: append([]primitive.ObjectID(nil), doc.AdditionalAddressIDs...),
 		ContractedProducts:   append([]primitive.ObjectID(nil), doc.ContractedProducts...),
 		UserID:               doc.UserID,
+		Screening:            entities.ConsumerScreening(doc.Screening),
 		CreatedAt:            doc.CreatedAt,
 		UpdatedAt:            doc.UpdatedAt,
+		ErasedAt:             doc.ErasedAt,
+		SealedFields:         copyStringMap(doc.SealedFields),
 	}
 
 	return consumer
@@ -203,6 +242,7 @@ func newCreditProfileDocument(profile entities.ConsumerCreditProfile) ConsumerCr
 		YearsInCurrentJob:       profile.YearsInCurrentJob,
 		YearsInBusiness:         profile.YearsInBusiness,
 		BankingRelationshipRank: profile.BankingRelationshipRank,
+		Bureau:                  CreditBureauDocument(profile.Bureau),
 	}
 }
 
@@ -220,5 +260,6 @@ func (doc ConsumerCreditProfileDocument) toEntity() entities.ConsumerCreditProfi
 		YearsInCurrentJob:       doc.YearsInCurrentJob,
 		YearsInBusiness:         doc.YearsInBusiness,
 		BankingRelationshipRank: doc.BankingRelationshipRank,
+		Bureau:                  entities.CreditBureauData(doc.Bureau),
 	}
 }
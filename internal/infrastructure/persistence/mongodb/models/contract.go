@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ContractDocument representa um contrato persistido, com o snapshot da faixa de preço aplicada.
+type ContractDocument struct {
+	ID                    primitive.ObjectID       `bson:"_id,omitempty"`
+	ConsumerID            primitive.ObjectID       `bson:"consumer_id"`
+	ProductID             primitive.ObjectID       `bson:"product_id"`
+	PartnerID             primitive.ObjectID       `bson:"partner_id,omitempty"`
+	ProductType           valueobjects.ProductType `bson:"product_type"`
+	Status                entities.ContractStatus  `bson:"status"`
+	Amount                valueobjects.Money       `bson:"amount,omitempty"`
+	TermMonths            int                      `bson:"term_months,omitempty"`
+	InterestRate          valueobjects.Rate        `bson:"interest_rate"`
+	Installment           valueobjects.Money       `bson:"installment,omitempty"`
+	CETMonthly            valueobjects.Rate        `bson:"cet_monthly,omitempty"`
+	PricingTier           *entities.PricingTier    `bson:"pricing_tier,omitempty"`
+	IndexedRate           *entities.IndexedRate    `bson:"indexed_rate,omitempty"`
+	IndexValue            *IndexValueDocument      `bson:"index_value,omitempty"`
+	FirstDueDate          time.Time                `bson:"first_due_date,omitempty"`
+	EarlyRepaymentAllowed bool                     `bson:"early_repayment_allowed,omitempty"`
+	CreatedAt             time.Time                `bson:"created_at"`
+	UpdatedAt             time.Time                `bson:"updated_at"`
+	CancelledAt           time.Time                `bson:"cancelled_at,omitempty"`
+	SettledAt             time.Time                `bson:"settled_at,omitempty"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc ContractDocument) ToEntity() *entities.Contract {
+	var indexValue *entities.IndexValue
+	if doc.IndexValue != nil {
+		indexValue = doc.IndexValue.ToEntity()
+	}
+
+	return &entities.Contract{
+		ID:                    doc.ID,
+		ConsumerID:            doc.ConsumerID,
+		ProductID:             doc.ProductID,
+		PartnerID:             doc.PartnerID,
+		ProductType:           doc.ProductType,
+		Status:                doc.Status,
+		Amount:                doc.Amount,
+		TermMonths:            doc.TermMonths,
+		InterestRate:          doc.InterestRate,
+		Installment:           doc.Installment,
+		CETMonthly:            doc.CETMonthly,
+		PricingTier:           doc.PricingTier,
+		IndexedRate:           doc.IndexedRate,
+		IndexValue:            indexValue,
+		FirstDueDate:          doc.FirstDueDate,
+		EarlyRepaymentAllowed: doc.EarlyRepaymentAllowed,
+		CreatedAt:             doc.CreatedAt,
+		UpdatedAt:             doc.UpdatedAt,
+		CancelledAt:           doc.CancelledAt,
+		SettledAt:             doc.SettledAt,
+	}
+}
+
+// NewContractDocument cria o documento persistido a partir da entidade.
+func NewContractDocument(contract *entities.Contract) ContractDocument {
+	if contract == nil {
+		return ContractDocument{}
+	}
+
+	var indexValue *IndexValueDocument
+	if contract.IndexValue != nil {
+		doc := NewIndexValueDocument(contract.IndexValue)
+		indexValue = &doc
+	}
+
+	return ContractDocument{
+		ID:                    contract.ID,
+		ConsumerID:            contract.ConsumerID,
+		ProductID:             contract.ProductID,
+		PartnerID:             contract.PartnerID,
+		ProductType:           contract.ProductType,
+		Status:                contract.Status,
+		Amount:                contract.Amount,
+		TermMonths:            contract.TermMonths,
+		InterestRate:          contract.InterestRate,
+		Installment:           contract.Installment,
+		CETMonthly:            contract.CETMonthly,
+		PricingTier:           contract.PricingTier,
+		IndexedRate:           contract.IndexedRate,
+		IndexValue:            indexValue,
+		FirstDueDate:          contract.FirstDueDate,
+		EarlyRepaymentAllowed: contract.EarlyRepaymentAllowed,
+		CreatedAt:             contract.CreatedAt,
+		UpdatedAt:             contract.UpdatedAt,
+		CancelledAt:           contract.CancelledAt,
+		SettledAt:             contract.SettledAt,
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/infrastructure/crypto/envelope"
+)
+
+// DataKeyDocument armazena uma chave de dados cifrada pela chave mestra.
+type DataKeyDocument struct {
+	ID         string    `bson:"_id"`
+	WrappedKey []byte    `bson:"wrapped_key"`
+	Status     string    `bson:"status"`
+	CreatedAt  time.Time `bson:"created_at"`
+	RetiredAt  time.Time `bson:"retired_at,omitempty"`
+}
+
+// ToWrappedKey converte o documento na representação usada pelo keyring.
+func (doc DataKeyDocument) ToWrappedKey() envelope.WrappedKey {
+	return envelope.WrappedKey{
+		ID:         doc.ID,
+		Ciphertext: append([]byte(nil), doc.WrappedKey...),
+		Status:     doc.Status,
+		CreatedAt:  doc.CreatedAt,
+		RetiredAt:  doc.RetiredAt,
+	}
+}
+
+// NewDataKeyDocument cria o documento persistido a partir da chave cifrada.
+func NewDataKeyDocument(key envelope.WrappedKey) DataKeyDocument {
+	return DataKeyDocument{
+		ID:         key.ID,
+		WrappedKey: append([]byte(nil), key.Ciphertext...),
+		Status:     key.Status,
+		CreatedAt:  key.CreatedAt,
+		RetiredAt:  key.RetiredAt,
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// IndexValueDocument representa uma observação da série de um indexador (CDI, SELIC, IPCA).
+type IndexValueDocument struct {
+	Index      valueobjects.RateIndex `bson:"index"`
+	Date       time.Time              `bson:"date"`
+	Rate       valueobjects.Rate      `bson:"rate"`
+	Source     string                 `bson:"source,omitempty"`
+	RecordedAt time.Time              `bson:"recorded_at"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc IndexValueDocument) ToEntity() *entities.IndexValue {
+	return &entities.IndexValue{
+		Index:      doc.Index,
+		Date:       doc.Date,
+		Rate:       doc.Rate,
+		Source:     doc.Source,
+		RecordedAt: doc.RecordedAt,
+	}
+}
+
+// NewIndexValueDocument cria o documento persistido a partir da entidade.
+func NewIndexValueDocument(value *entities.IndexValue) IndexValueDocument {
+	if value == nil {
+		return IndexValueDocument{}
+	}
+
+	return IndexValueDocument{
+		Index:      value.Index,
+		Date:       value.Date.UTC(),
+		Rate:       value.Rate,
+		Source:     value.Source,
+		RecordedAt: value.RecordedAt,
+	}
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InstallmentDocument representa uma parcela de contrato com os pagamentos e encargos de atraso.
+type InstallmentDocument struct {
+	ID           primitive.ObjectID         `bson:"_id,omitempty"`
+	ContractID   primitive.ObjectID         `bson:"contract_id"`
+	ConsumerID   primitive.ObjectID         `bson:"consumer_id"`
+	Number       int                        `bson:"number"`
+	DueDate      time.Time                  `bson:"due_date"`
+	Amount       valueobjects.Money         `bson:"amount"`
+	Principal    valueobjects.Money         `bson:"principal"`
+	Interest     valueobjects.Money         `bson:"interest"`
+	Discount     valueobjects.Money         `bson:"discount,omitempty"`
+	Paid         valueobjects.Money         `bson:"paid"`
+	Fine         valueobjects.Money         `bson:"fine,omitempty"`
+	LateInterest valueobjects.Money         `bson:"late_interest,omitempty"`
+	ChargesPaid  valueobjects.Money         `bson:"charges_paid,omitempty"`
+	Status       entities.InstallmentStatus `bson:"status"`
+	DaysOverdue  int                        `bson:"days_overdue,omitempty"`
+	AgedThrough  time.Time                  `bson:"aged_through,omitempty"`
+	PaidAt       time.Time                  `bson:"paid_at,omitempty"`
+	CreatedAt    time.Time                  `bson:"created_at"`
+	UpdatedAt    time.Time                  `bson:"updated_at"`
+	Version      int64                      `bson:"version"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc InstallmentDocument) ToEntity() *entities.Installment {
+	return &entities.Installment{
+		ID:           doc.ID,
+		ContractID:   doc.ContractID,
+		ConsumerID:   doc.ConsumerID,
+		Number:       doc.Number,
+		DueDate:      doc.DueDate,
+		Amount:       doc.Amount,
+		Principal:    doc.Principal,
+		Interest:     doc.Interest,
+		Discount:     doc.Discount,
+		Paid:         doc.Paid,
+		Fine:         doc.Fine,
+		LateInterest: doc.LateInterest,
+		ChargesPaid:  doc.ChargesPaid,
+		Status:       doc.Status,
+		DaysOverdue:  doc.DaysOverdue,
+		AgedThrough:  doc.AgedThrough,
+		PaidAt:       doc.PaidAt,
+		CreatedAt:    doc.CreatedAt,
+		UpdatedAt:    doc.UpdatedAt,
+		Version:      doc.Version,
+	}
+}
+
+// NewInstallmentDocument cria o documento persistido a partir da entidade.
+func NewInstallmentDocument(installment *entities.Installment) InstallmentDocument {
+	if installment == nil {
+		return InstallmentDocument{}
+	}
+
+	return InstallmentDocument{
+		ID:           installment.ID,
+		ContractID:   installment.ContractID,
+		ConsumerID:   installment.ConsumerID,
+		Number:       installment.Number,
+		DueDate:      installment.DueDate,
+		Amount:       installment.Amount,
+		Principal:    installment.Principal,
+		Interest:     installment.Interest,
+		Discount:     installment.Discount,
+		Paid:         installment.Paid,
+		Fine:         installment.Fine,
+		LateInterest: installment.LateInterest,
+		ChargesPaid:  installment.ChargesPaid,
+		Status:       installment.Status,
+		DaysOverdue:  installment.DaysOverdue,
+		AgedThrough:  installment.AgedThrough,
+		PaidAt:       installment.PaidAt,
+		CreatedAt:    installment.CreatedAt,
+		UpdatedAt:    installment.UpdatedAt,
+		Version:      installment.Version,
+	}
+}
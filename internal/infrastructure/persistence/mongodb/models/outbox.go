@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEventDocument representa um evento de domínio na caixa de saída, com os destinos que
+// já o receberam e o controle de novas tentativas.
+type OutboxEventDocument struct {
+	ID            primitive.ObjectID    `bson:"_id"`
+	Type          string                `bson:"type"`
+	AggregateID   primitive.ObjectID    `bson:"aggregate_id"`
+	PartnerID     primitive.ObjectID    `bson:"partner_id,omitempty"`
+	Data          []byte                `bson:"data"`
+	OccurredAt    time.Time             `bson:"occurred_at"`
+	Status        entities.OutboxStatus `bson:"status"`
+	Attempts      int                   `bson:"attempts"`
+	NextAttemptAt time.Time             `bson:"next_attempt_at,omitempty"`
+	PublishedTo   []string              `bson:"published_to,omitempty"`
+	LastError     string                `bson:"last_error,omitempty"`
+	PublishedAt   time.Time             `bson:"published_at,omitempty"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc OutboxEventDocument) ToEntity() *entities.OutboxEvent {
+	return &entities.OutboxEvent{
+		Event: entities.DomainEvent{
+			ID:          doc.ID,
+			Type:        doc.Type,
+			AggregateID: doc.AggregateID,
+			PartnerID:   doc.PartnerID,
+			Data:        doc.Data,
+			OccurredAt:  doc.OccurredAt,
+		},
+		Status:        doc.Status,
+		Attempts:      doc.Attempts,
+		NextAttemptAt: doc.NextAttemptAt,
+		PublishedTo:   append([]string(nil), doc.PublishedTo...),
+		LastError:     doc.LastError,
+		PublishedAt:   doc.PublishedAt,
+	}
+}
+
+// NewOutboxEventDocument cria o documento persistido a partir da entidade.
+func NewOutboxEventDocument(event *entities.OutboxEvent) OutboxEventDocument {
+	if event == nil {
+		return OutboxEventDocument{}
+	}
+
+	return OutboxEventDocument{
+		ID:            event.Event.ID,
+		Type:          event.Event.Type,
+		AggregateID:   event.Event.AggregateID,
+		PartnerID:     event.Event.PartnerID,
+		Data:          event.Event.Data,
+		OccurredAt:    event.Event.OccurredAt,
+		Status:        event.Status,
+		Attempts:      event.Attempts,
+		NextAttemptAt: event.NextAttemptAt,
+		PublishedTo:   append([]string(nil), event.PublishedTo...),
+		LastError:     event.LastError,
+		PublishedAt:   event.PublishedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PaymentDocument representa um pagamento recebido e sua distribuição entre as parcelas.
+type PaymentDocument struct {
+	ID          primitive.ObjectID          `bson:"_id,omitempty"`
+	ContractID  primitive.ObjectID          `bson:"contract_id"`
+	ConsumerID  primitive.ObjectID          `bson:"consumer_id"`
+	Type        entities.PaymentType        `bson:"type"`
+	Amount      valueobjects.Money          `bson:"amount"`
+	Allocations []PaymentAllocationDocument `bson:"allocations"`
+	PaidAt      time.Time                   `bson:"paid_at"`
+	CreatedAt   time.Time                   `bson:"created_at"`
+}
+
+type PaymentAllocationDocument struct {
+	InstallmentNumber int                `bson:"installment_number"`
+	Amount            valueobjects.Money `bson:"amount"`
+	InterestWaived    valueobjects.Money `bson:"interest_waived,omitempty"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc PaymentDocument) ToEntity() *entities.Payment {
+	allocations := make([]entities.PaymentAllocation, 0, len(doc.Allocations))
+	for _, allocation := range doc.Allocations {
+		allocations = append(allocations, entities.PaymentAllocation{
+			InstallmentNumber: allocation.InstallmentNumber,
+			Amount:            allocation.Amount,
+			InterestWaived:    allocation.InterestWaived,
+		})
+	}
+
+	return &entities.Payment{
+		ID:          doc.ID,
+		ContractID:  doc.ContractID,
+		ConsumerID:  doc.ConsumerID,
+		Type:        doc.Type,
+		Amount:      doc.Amount,
+		Allocations: allocations,
+		PaidAt:      doc.PaidAt,
+		CreatedAt:   doc.CreatedAt,
+	}
+}
+
+// NewPaymentDocument cria o documento persistido a partir da entidade.
+func NewPaymentDocument(payment *entities.Payment) PaymentDocument {
+	if payment == nil {
+		return PaymentDocument{}
+	}
+
+	allocations := make([]PaymentAllocationDocument, 0, len(payment.Allocations))
+	for _, allocation := range payment.Allocations {
+		allocations = append(allocations, PaymentAllocationDocument{
+			InstallmentNumber: allocation.InstallmentNumber,
+			Amount:            allocation.Amount,
+			InterestWaived:    allocation.InterestWaived,
+		})
+	}
+
+	return PaymentDocument{
+		ID:          payment.ID,
+		ContractID:  payment.ContractID,
+		ConsumerID:  payment.ConsumerID,
+		Type:        payment.Type,
+		Amount:      payment.Amount,
+		Allocations: allocations,
+		PaidAt:      payment.PaidAt,
+		CreatedAt:   payment.CreatedAt,
+	}
+}
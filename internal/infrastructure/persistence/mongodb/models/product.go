@@ -15,6 +15,7 @@ type ProductDocument struct {
 	Attributes    entities.ProductAttributes   `bson:"product_attributes"`
 	PartnerID     primitive.ObjectID           `bson:"partner_id"`
 	ProductType   valueobjects.ProductType     `bson:"product_type"`
+	PricingTiers  []entities.PricingTier       `bson:"pricing_tiers,omitempty"`
 	LegacyPartner *legacyPartnerDocument       `bson:"product_partner,omitempty"`
 }
 
@@ -26,12 +27,13 @@ func (doc ProductDocument) ToEntity() *entities.Product {
 	}
 
 	return &entities.Product{
-		ID:          doc.ID,
-		Name:        doc.Name,
-		Category:    doc.Category,
-		Attributes:  doc.Attributes,
-		PartnerID:   partnerID,
-		ProductType: doc.ProductType,
+		ID:           doc.ID,
+		Name:         doc.Name,
+		Category:     doc.Category,
+		Attributes:   doc.Attributes,
+		PartnerID:    partnerID,
+		ProductType:  doc.ProductType,
+		PricingTiers: doc.PricingTiers,
 	}
 }
 
@@ -42,12 +44,13 @@ func NewProductDocument(product *entities.Product) ProductDocument {
 	}
 
 	return ProductDocument{
-		ID:          product.ID,
-		Name:        product.Name,
-		Category:    product.Category,
-		Attributes:  product.Attributes,
-		PartnerID:   product.PartnerID,
-		ProductType: product.ProductType,
+		ID:           product.ID,
+		Name:         product.Name,
+		Category:     product.Category,
+		Attributes:   product.Attributes,
+		PartnerID:    product.PartnerID,
+		ProductType:  product.ProductType,
+		PricingTiers: product.PricingTiers,
 	}
 }
 
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProductSearchDocument é a projeção desnormalizada usada pela busca do catálogo. Os atributos
+// de preço ficam no nível raiz para permitir filtros por faixa independentemente do tipo do produto.
+type ProductSearchDocument struct {
+	ID           primitive.ObjectID           `bson:"_id"`
+	Name         string                       `bson:"name"`
+	ProductType  valueobjects.ProductType     `bson:"product_type"`
+	Category     valueobjects.ProductCategory `bson:"category"`
+	RiskLevel    valueobjects.RiskLevel       `bson:"risk_level"`
+	PartnerID    primitive.ObjectID           `bson:"partner_id"`
+	PartnerName  string                       `bson:"partner_name,omitempty"`
+	PartnerType  valueobjects.PartnerType     `bson:"partner_type,omitempty"`
+	InterestRate *valueobjects.Rate           `bson:"interest_rate,omitempty"`
+	MinAmount    *valueobjects.Money          `bson:"min_amount,omitempty"`
+	MaxAmount    *valueobjects.Money          `bson:"max_amount,omitempty"`
+	TermMonths   *int                         `bson:"term_months,omitempty"`
+	Product      ProductDocument              `bson:"product"`
+}
+
+// NewProductSearchDocument monta a projeção a partir do produto e do parceiro responsável.
+func NewProductSearchDocument(product *entities.Product, partner *entities.Partner) ProductSearchDocument {
+	if product == nil {
+		return ProductSearchDocument{}
+	}
+
+	doc := ProductSearchDocument{
+		ID:          product.ID,
+		Name:        product.Name,
+		ProductType: product.ProductType,
+		Category:    product.Category,
+		RiskLevel:   product.ProductType.GetRiskLevel(),
+		PartnerID:   product.PartnerID,
+		Product:     NewProductDocument(product),
+	}
+
+	if partner != nil {
+		doc.PartnerName = partner.Name
+		doc.PartnerType = partner.Type
+	}
+
+	if base, ok := product.Attributes.BaseAttributes(product.ProductType); ok {
+		if base.InterestRate.IsPositive() {
+			doc.InterestRate = &base.InterestRate
+		}
+		if base.MinAmount.IsPositive() {
+			doc.MinAmount = &base.MinAmount
+		}
+		if base.MaxAmount.IsPositive() {
+			doc.MaxAmount = &base.MaxAmount
+		}
+		if base.TermMonths > 0 {
+			termMonths := base.TermMonths
+			doc.TermMonths = &termMonths
+		}
+	}
+
+	return doc
+}
+
+// ToEntity devolve o produto armazenado na projeção.
+func (doc ProductSearchDocument) ToEntity() *entities.Product {
+	return doc.Product.ToEntity()
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ScreeningSignalDocument representa o uso de um dado (e-mail, telefone, endereço ou documento)
+// por um consumidor. O valor é sempre a impressão digital, nunca o dado em claro.
+type ScreeningSignalDocument struct {
+	ID         primitive.ObjectID      `bson:"_id,omitempty"`
+	Kind       string                  `bson:"kind"`
+	Value      string                  `bson:"value"`
+	ConsumerID primitive.ObjectID      `bson:"consumer_id"`
+	Stage      entities.ScreeningStage `bson:"stage"`
+	RecordedAt time.Time               `bson:"recorded_at"`
+}
+
+// NewScreeningSignalDocument cria o documento persistido a partir da entidade.
+func NewScreeningSignalDocument(signal *entities.ScreeningSignal) ScreeningSignalDocument {
+	if signal == nil {
+		return ScreeningSignalDocument{}
+	}
+
+	return ScreeningSignalDocument{
+		ID:         signal.ID,
+		Kind:       signal.Kind,
+		Value:      signal.Value,
+		ConsumerID: signal.ConsumerID,
+		Stage:      signal.Stage,
+		RecordedAt: signal.RecordedAt,
+	}
+}
+
+// ScreeningReviewDocument representa um item da fila de revisão manual.
+type ScreeningReviewDocument struct {
+	ID         primitive.ObjectID      `bson:"_id,omitempty"`
+	Stage      entities.ScreeningStage `bson:"stage"`
+	ConsumerID primitive.ObjectID      `bson:"consumer_id"`
+	ContractID primitive.ObjectID      `bson:"contract_id,omitempty"`
+	Score      int                     `bson:"score"`
+	Flags      []ScreeningFlagDocument `bson:"flags"`
+	Status     entities.ReviewStatus   `bson:"status"`
+	ReviewerID primitive.ObjectID      `bson:"reviewer_id,omitempty"`
+	Notes      string                  `bson:"notes,omitempty"`
+	CreatedAt  time.Time               `bson:"created_at"`
+	ReviewedAt time.Time               `bson:"reviewed_at,omitempty"`
+}
+
+// ScreeningFlagDocument representa uma regra disparada e a pontuação que ela somou.
+type ScreeningFlagDocument struct {
+	Rule   string `bson:"rule"`
+	Score  int    `bson:"score"`
+	Detail string `bson:"detail,omitempty"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc ScreeningReviewDocument) ToEntity() *entities.ScreeningReview {
+	flags := make([]entities.ScreeningFlag, 0, len(doc.Flags))
+	for _, flag := range doc.Flags {
+		flags = append(flags, entities.ScreeningFlag(flag))
+	}
+
+	return &entities.ScreeningReview{
+		ID:         doc.ID,
+		Stage:      doc.Stage,
+		ConsumerID: doc.ConsumerID,
+		ContractID: doc.ContractID,
+		Score:      doc.Score,
+		Flags:      flags,
+		Status:     doc.Status,
+		ReviewerID: doc.ReviewerID,
+		Notes:      doc.Notes,
+		CreatedAt:  doc.CreatedAt,
+		ReviewedAt: doc.ReviewedAt,
+	}
+}
+
+// NewScreeningReviewDocument cria o documento persistido a partir da entidade.
+func NewScreeningReviewDocument(review *entities.ScreeningReview) ScreeningReviewDocument {
+	if review == nil {
+		return ScreeningReviewDocument{}
+	}
+
+	flags := make([]ScreeningFlagDocument, 0, len(review.Flags))
+	for _, flag := range review.Flags {
+		flags = append(flags, ScreeningFlagDocument(flag))
+	}
+
+	return ScreeningReviewDocument{
+		ID:         review.ID,
+		Stage:      review.Stage,
+		ConsumerID: review.ConsumerID,
+		ContractID: review.ContractID,
+		Score:      review.Score,
+		Flags:      flags,
+		Status:     review.Status,
+		ReviewerID: review.ReviewerID,
+		Notes:      review.Notes,
+		CreatedAt:  review.CreatedAt,
+		ReviewedAt: review.ReviewedAt,
+	}
+}
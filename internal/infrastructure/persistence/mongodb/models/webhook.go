@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookSubscriptionDocument representa o endpoint de um parceiro inscrito em eventos.
+type WebhookSubscriptionDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	PartnerID  primitive.ObjectID `bson:"partner_id"`
+	URL        string             `bson:"url"`
+	EventTypes []string           `bson:"event_types"`
+	Secret     string             `bson:"secret"`
+	Active     bool               `bson:"active"`
+	CreatedAt  time.Time          `bson:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc WebhookSubscriptionDocument) ToEntity() *entities.WebhookSubscription {
+	return &entities.WebhookSubscription{
+		ID:         doc.ID,
+		PartnerID:  doc.PartnerID,
+		URL:        doc.URL,
+		EventTypes: append([]string(nil), doc.EventTypes...),
+		Secret:     doc.Secret,
+		Active:     doc.Active,
+		CreatedAt:  doc.CreatedAt,
+		UpdatedAt:  doc.UpdatedAt,
+	}
+}
+
+// NewWebhookSubscriptionDocument cria o documento persistido a partir da entidade.
+func NewWebhookSubscriptionDocument(subscription *entities.WebhookSubscription) WebhookSubscriptionDocument {
+	if subscription == nil {
+		return WebhookSubscriptionDocument{}
+	}
+
+	return WebhookSubscriptionDocument{
+		ID:         subscription.ID,
+		PartnerID:  subscription.PartnerID,
+		URL:        subscription.URL,
+		EventTypes: append([]string(nil), subscription.EventTypes...),
+		Secret:     subscription.Secret,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryDocument representa uma entrega de evento para uma inscrição, com o corpo
+// exato que é assinado e o histórico de tentativas.
+type WebhookDeliveryDocument struct {
+	ID             primitive.ObjectID             `bson:"_id,omitempty"`
+	SubscriptionID primitive.ObjectID             `bson:"subscription_id"`
+	PartnerID      primitive.ObjectID             `bson:"partner_id"`
+	EventID        primitive.ObjectID             `bson:"event_id"`
+	EventType      string                         `bson:"event_type"`
+	Payload        []byte                         `bson:"payload"`
+	Status         entities.WebhookDeliveryStatus `bson:"status"`
+	Attempts       int                            `bson:"attempts"`
+	NextAttemptAt  time.Time                      `bson:"next_attempt_at,omitempty"`
+	LastAttemptAt  time.Time                      `bson:"last_attempt_at,omitempty"`
+	LastStatusCode int                            `bson:"last_status_code,omitempty"`
+	LastError      string                         `bson:"last_error,omitempty"`
+	DeliveredAt    time.Time                      `bson:"delivered_at,omitempty"`
+	CreatedAt      time.Time                      `bson:"created_at"`
+}
+
+// ToEntity converte o documento em entidade de domínio.
+func (doc WebhookDeliveryDocument) ToEntity() *entities.WebhookDelivery {
+	delivery := entities.WebhookDelivery(doc)
+	return &delivery
+}
+
+// NewWebhookDeliveryDocument cria o documento persistido a partir da entidade.
+func NewWebhookDeliveryDocument(delivery *entities.WebhookDelivery) WebhookDeliveryDocument {
+	if delivery == nil {
+		return WebhookDeliveryDocument{}
+	}
+	return WebhookDeliveryDocument(*delivery)
+}
@@ -49,25 +49,63 @@ func (r *AddressRepositoryMongo) DeleteAddress(ctx context.Context, id primitive
 	return err
 }
 
-func (r *AddressRepositoryMongo) ListAddresses(ctx context.Context, filter map[string]interface{}) ([]*entities.Address, error) {
-	var addresses []*entities.Address
-	cursor, err := r.collection.Find(ctx, filter)
+var addressSortFields = map[string]sortField{
+	"city":        {path: "city"},
+	"state":       {path: "state"},
+	"postal_code": {path: "postal_code"},
+}
+
+func (r *AddressRepositoryMongo) ListAddresses(ctx context.Context, query repositories.AddressQuery) ([]*entities.Address, repositories.PageInfo, error) {
+	filter := bson.M{}
+	if query.City != "" {
+		filter["city"] = query.City
+	}
+	if query.State != "" {
+		filter["state"] = query.State
+	}
+	if query.PostalCode != "" {
+		filter["postal_code"] = query.PostalCode
+	}
+	if query.Type != "" {
+		filter["type"] = query.Type
+	}
+
+	page, err := newPageQuery(filter, query.Page, addressSortFields)
 	if err != nil {
-		return nil, err
+		return nil, repositories.PageInfo{}, err
+	}
+
+	cursor, err := r.collection.Find(ctx, page.filter, page.options)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
 	}
 	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var doc models.AddressDocument
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, err
-		}
+	var docs []models.AddressDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	count, hasMore := page.limit(len(docs))
+	addresses := make([]*entities.Address, 0, count)
+	for _, doc := range docs[:count] {
 		addresses = append(addresses, doc.ToEntity())
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, err
+	var lastValue interface{}
+	var lastID primitive.ObjectID
+	if count > 0 {
+		last := docs[count-1]
+		lastID = last.ID
+		switch page.page.Sort.Field {
+		case "city":
+			lastValue = last.City
+		case "state":
+			lastValue = last.State
+		case "postal_code":
+			lastValue = last.PostalCode
+		}
 	}
 
-	return addresses, nil
+	return addresses, page.info(hasMore, lastValue, lastID), nil
 }
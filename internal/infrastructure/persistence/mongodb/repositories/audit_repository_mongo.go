@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewAuditRepositoryMongo(collection *mongo.Collection) repositories.AuditRepository {
+	return &AuditRepositoryMongo{collection: collection}
+}
+
+func (r *AuditRepositoryMongo) RecordEvent(ctx context.Context, event *entities.AuditEvent) error {
+	_, err := r.collection.InsertOne(ctx, models.NewAuditEventDocument(event))
+	return err
+}
+
+func (r *AuditRepositoryMongo) ListEventsBySubject(ctx context.Context, subjectType string, subjectID primitive.ObjectID) ([]*entities.AuditEvent, error) {
+	var events []*entities.AuditEvent
+
+	filter := bson.M{"subject_type": subjectType, "subject_id": subjectID}
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc models.AuditEventDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		events = append(events, doc.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
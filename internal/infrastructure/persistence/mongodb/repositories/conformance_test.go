@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/repositories/repositorytest"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The conformance suites run against MONGO_TEST_URI, or a local mongod, and are skipped when
+// neither answers.
+const defaultTestURI = "mongodb://localhost:27017"
+
+var (
+	probeOnce   sync.Once
+	probeClient *mongo.Client
+	probeErr    error
+)
+
+func testClient(t *testing.T) *mongo.Client {
+	t.Helper()
+
+	probeOnce.Do(func() {
+		uri := os.Getenv("MONGO_TEST_URI")
+		if uri == "" {
+			uri = defaultTestURI
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(2*time.Second))
+		if err != nil {
+			probeErr = err
+			return
+		}
+		if err := client.Ping(ctx, nil); err != nil {
+			_ = client.Disconnect(context.Background())
+			probeErr = err
+			return
+		}
+		probeClient = client
+	})
+
+	if probeErr != nil {
+		t.Skipf("mongo not available: %v", probeErr)
+	}
+	return probeClient
+}
+
+// testCollection returns an empty collection in a throwaway database dropped when the test ends.
+func testCollection(t *testing.T, name string) *mongo.Collection {
+	t.Helper()
+
+	database := testClient(t).Database("katseye_conformance_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = database.Drop(ctx)
+	})
+	return database.Collection(name)
+}
+
+func TestProductRepositoryConformance(t *testing.T) {
+	testClient(t)
+	repositorytest.ProductRepository(t, func(t *testing.T) repositories.ProductRepository {
+		return NewProductRepositoryMongo(testCollection(t, "products"))
+	})
+}
+
+func TestPartnerRepositoryConformance(t *testing.T) {
+	testClient(t)
+	repositorytest.PartnerRepository(t, func(t *testing.T) repositories.PartnerRepository {
+		return NewPartnerRepositoryMongo(testCollection(t, "partners"))
+	})
+}
+
+func TestAddressRepositoryConformance(t *testing.T) {
+	testClient(t)
+	repositorytest.AddressRepository(t, func(t *testing.T) repositories.AddressRepository {
+		return NewAddressRepositoryMongo(testCollection(t, "addresses"))
+	})
+}
+
+func TestConsumerRepositoryConformance(t *testing.T) {
+	testClient(t)
+	repositorytest.ConsumerRepository(t, func(t *testing.T) repositories.ConsumerRepository {
+		return NewConsumerRepositoryMongo(testCollection(t, "consumers"))
+	})
+}
+
+func TestUserRepositoryConformance(t *testing.T) {
+	testClient(t)
+	repositorytest.UserRepository(t, func(t *testing.T) repositories.UserRepository {
+		repository := NewUserRepositoryMongo(testCollection(t, "users"))
+		if err := repository.EnsureIndexes(context.Background()); err != nil {
+			t.Fatalf("EnsureIndexes: %v", err)
+		}
+		return repository
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
 	"katseye/internal/infrastructure/persistence/mongodb/models"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -32,17 +33,54 @@ func (r *consumerRepositoryMongo) GetConsumerByID(ctx context.Context, id primit
 	return doc.ToEntity(), nil
 }
 
+func (r *consumerRepositoryMongo) GetConsumerByDocument(ctx context.Context, documentNumber string) (*entities.Consumer, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"personal_data.individual.document_number": documentNumber},
+		bson.M{"personal_data.business.document_number": documentNumber},
+		bson.M{"sealed_fields.document_number": documentNumber},
+	}}
+
+	var doc models.ConsumerDocument
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return doc.ToEntity(), nil
+}
+
 func (r *consumerRepositoryMongo) CreateConsumer(ctx context.Context, consumer *entities.Consumer) error {
 	_, err := r.collection.InsertOne(ctx, models.NewConsumerDocument(consumer))
 	return err
 }
 
 func (r *consumerRepositoryMongo) UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error {
-	_, err := r.collection.UpdateOne(
-		ctx,
-		bson.M{"_id": consumer.ID},
-		bson.M{"$set": models.NewConsumerDocument(consumer)},
-	)
+	// Fields omitted from $set when empty keep their stored value, so cleared ones are removed
+	// explicitly: a detached user link, emptied address and product lists, and ciphertext left
+	// over once no sealed field is present.
+	unset := bson.M{}
+	if consumer.UserID.IsZero() {
+		unset["user_id"] = ""
+	}
+	if len(consumer.AdditionalAddressIDs) == 0 {
+		unset["additional_address_ids"] = ""
+	}
+	if len(consumer.ContractedProducts) == 0 {
+		unset["contracted_products"] = ""
+	}
+	if len(consumer.SealedFields) == 0 {
+		unset["sealed_fields"] = ""
+	}
+
+	update := bson.M{"$set": models.NewConsumerDocument(consumer)}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": consumer.ID}, update)
 	return err
 }
 
@@ -51,35 +89,51 @@ func (r *consumerRepositoryMongo) DeleteConsumer(ctx context.Context, id primiti
 	return err
 }
 
-func (r *consumerRepositoryMongo) ListConsumers(ctx context.Context, filter map[string]interface{}) ([]*entities.Consumer, error) {
-	var consumers []*entities.Consumer
+var consumerSortFields = map[string]sortField{
+	"created_at": {path: "created_at", kind: sortKindTime},
+	"updated_at": {path: "updated_at", kind: sortKindTime},
+}
 
-	var bsonFilter interface{} = bson.D{}
-	if len(filter) > 0 {
-		m := bson.M{}
-		for k, v := range filter {
-			m[k] = v
-		}
-		bsonFilter = m
+func (r *consumerRepositoryMongo) ListConsumers(ctx context.Context, query repositories.ConsumerQuery) ([]*entities.Consumer, repositories.PageInfo, error) {
+	filter := bson.M{}
+	if query.Type != "" {
+		filter["type"] = query.Type
 	}
 
-	cursor, err := r.collection.Find(ctx, bsonFilter)
+	page, err := newPageQuery(filter, query.Page, consumerSortFields)
 	if err != nil {
-		return nil, err
+		return nil, repositories.PageInfo{}, err
+	}
+
+	cursor, err := r.collection.Find(ctx, page.filter, page.options)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
 	}
 	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var doc models.ConsumerDocument
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, err
-		}
+	var docs []models.ConsumerDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	count, hasMore := page.limit(len(docs))
+	consumers := make([]*entities.Consumer, 0, count)
+	for _, doc := range docs[:count] {
 		consumers = append(consumers, doc.ToEntity())
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, err
+	var lastValue interface{}
+	var lastID primitive.ObjectID
+	if count > 0 {
+		last := docs[count-1]
+		lastID = last.ID
+		switch page.page.Sort.Field {
+		case "created_at":
+			lastValue = last.CreatedAt
+		case "updated_at":
+			lastValue = last.UpdatedAt
+		}
 	}
 
-	return consumers, nil
+	return consumers, page.info(hasMore, lastValue, lastID), nil
 }
@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ContractRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewContractRepositoryMongo(collection *mongo.Collection) repositories.ContractRepository {
+	return &ContractRepositoryMongo{collection: collection}
+}
+
+func (r *ContractRepositoryMongo) GetContractByID(ctx context.Context, id primitive.ObjectID) (*entities.Contract, error) {
+	var doc models.ContractDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+func (r *ContractRepositoryMongo) CreateContract(ctx context.Context, contract *entities.Contract) error {
+	if contract.ID.IsZero() {
+		contract.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, models.NewContractDocument(contract))
+	return err
+}
+
+func (r *ContractRepositoryMongo) UpdateContract(ctx context.Context, contract *entities.Contract) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": contract.ID}, bson.M{"$set": models.NewContractDocument(contract)})
+	return err
+}
+
+func (r *ContractRepositoryMongo) ListContractsByConsumer(ctx context.Context, consumerID primitive.ObjectID) ([]*entities.Contract, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"consumer_id": consumerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.ContractDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	contracts := make([]*entities.Contract, 0, len(docs))
+	for _, doc := range docs {
+		contracts = append(contracts, doc.ToEntity())
+	}
+	return contracts, nil
+}
@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"context"
+
+	"katseye/internal/infrastructure/crypto/envelope"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DataKeyStoreMongo struct {
+	collection *mongo.Collection
+}
+
+func NewDataKeyStoreMongo(collection *mongo.Collection) envelope.KeyStore {
+	return &DataKeyStoreMongo{collection: collection}
+}
+
+func (s *DataKeyStoreMongo) LoadKeys(ctx context.Context) ([]envelope.WrappedKey, error) {
+	var keys []envelope.WrappedKey
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc models.DataKeyDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		keys = append(keys, doc.ToWrappedKey())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *DataKeyStoreMongo) SaveKey(ctx context.Context, key envelope.WrappedKey) error {
+	_, err := s.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": key.ID},
+		models.NewDataKeyDocument(key),
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *DataKeyStoreMongo) InsertKey(ctx context.Context, key envelope.WrappedKey) error {
+	_, err := s.collection.InsertOne(ctx, models.NewDataKeyDocument(key))
+	if mongo.IsDuplicateKeyError(err) {
+		return envelope.ErrKeyExists
+	}
+	return err
+}
@@ -0,0 +1,28 @@
+package mongodb
+
+import (
+	"context"
+
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// HealthCheckMongo pings the primary. Every request depends on it, so a failure means down.
+type HealthCheckMongo struct {
+	client *mongo.Client
+}
+
+func NewHealthCheckMongo(client *mongo.Client) repositories.HealthCheck {
+	return &HealthCheckMongo{client: client}
+}
+
+func (h *HealthCheckMongo) Check(ctx context.Context) repositories.HealthComponent {
+	component := repositories.HealthComponent{Name: "mongo", Status: repositories.HealthStatusUp}
+	if err := h.client.Ping(ctx, nil); err != nil {
+		component.Status = repositories.HealthStatusDown
+		component.Details = map[string]string{"error": err.Error()}
+	}
+
+	return component
+}
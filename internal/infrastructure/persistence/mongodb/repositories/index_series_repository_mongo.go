@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IndexSeriesRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewIndexSeriesRepositoryMongo(collection *mongo.Collection) *IndexSeriesRepositoryMongo {
+	return &IndexSeriesRepositoryMongo{collection: collection}
+}
+
+// EnsureIndexes creates the unique index and date index SaveIndexValues upserts on, so two
+// concurrent imports of the same day cannot store it twice.
+func (r *IndexSeriesRepositoryMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "index", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("index_series_index_date_unique").SetUnique(true),
+	})
+	return err
+}
+
+// SaveIndexValues upserts every value keyed by index and date in a single bulk write.
+func (r *IndexSeriesRepositoryMongo) SaveIndexValues(ctx context.Context, values []*entities.IndexValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(values))
+	for _, value := range values {
+		doc := models.NewIndexValueDocument(value)
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"index": doc.Index, "date": doc.Date}).
+			SetReplacement(doc).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *IndexSeriesRepositoryMongo) GetLatestIndexValue(ctx context.Context, index valueobjects.RateIndex) (*entities.IndexValue, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+
+	var doc models.IndexValueDocument
+	err := r.collection.FindOne(ctx, bson.M{"index": index}, opts).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+// ListIndexValues returns the values of the index between from and to, inclusive and oldest
+// first. Zero bounds are open.
+func (r *IndexSeriesRepositoryMongo) ListIndexValues(ctx context.Context, index valueobjects.RateIndex, from, to time.Time) ([]*entities.IndexValue, error) {
+	filter := bson.M{"index": index}
+	dates := bson.M{}
+	if !from.IsZero() {
+		dates["$gte"] = from.UTC()
+	}
+	if !to.IsZero() {
+		dates["$lte"] = to.UTC()
+	}
+	if len(dates) > 0 {
+		filter["date"] = dates
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.IndexValueDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	values := make([]*entities.IndexValue, 0, len(docs))
+	for _, doc := range docs {
+		values = append(values, doc.ToEntity())
+	}
+	return values, nil
+}
@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type InstallmentRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewInstallmentRepositoryMongo(collection *mongo.Collection) *InstallmentRepositoryMongo {
+	return &InstallmentRepositoryMongo{collection: collection}
+}
+
+// EnsureIndexes creates the indexes behind the contract and consumer listings and the aging
+// job's scan for open installments past their due date.
+func (r *InstallmentRepositoryMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "contract_id", Value: 1}, {Key: "number", Value: 1}},
+			Options: options.Index().SetName("installments_contract"),
+		},
+		{
+			Keys:    bson.D{{Key: "consumer_id", Value: 1}, {Key: "due_date", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("installments_consumer"),
+		},
+		{
+			Keys:    bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("installments_due_date"),
+		},
+	})
+	return err
+}
+
+func (r *InstallmentRepositoryMongo) CreateInstallments(ctx context.Context, installments []*entities.Installment) error {
+	if len(installments) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(installments))
+	for _, installment := range installments {
+		if installment.ID.IsZero() {
+			installment.ID = primitive.NewObjectID()
+		}
+		docs = append(docs, models.NewInstallmentDocument(installment))
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *InstallmentRepositoryMongo) UpdateInstallment(ctx context.Context, installment *entities.Installment) error {
+	filter := bson.M{"_id": installment.ID, "version": installment.Version}
+	if installment.Version == 0 {
+		// Installments stored before versioning have no version field.
+		filter["version"] = bson.M{"$in": bson.A{int64(0), nil}}
+	}
+
+	doc := models.NewInstallmentDocument(installment)
+	doc.Version = installment.Version + 1
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": doc})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrConcurrentUpdate
+	}
+
+	installment.Version = doc.Version
+	return nil
+}
+
+func (r *InstallmentRepositoryMongo) ListInstallmentsByContract(ctx context.Context, contractID primitive.ObjectID) ([]*entities.Installment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "number", Value: 1}})
+	return r.find(ctx, bson.M{"contract_id": contractID}, opts)
+}
+
+func (r *InstallmentRepositoryMongo) ListInstallmentsByConsumer(ctx context.Context, consumerID primitive.ObjectID) ([]*entities.Installment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, bson.M{"consumer_id": consumerID}, opts)
+}
+
+func (r *InstallmentRepositoryMongo) ListOpenInstallmentsDueBefore(ctx context.Context, asOf time.Time) ([]*entities.Installment, error) {
+	filter := bson.M{
+		"due_date": bson.M{"$lt": asOf},
+		"status":   bson.M{"$nin": []entities.InstallmentStatus{entities.InstallmentStatusPaid, entities.InstallmentStatusCancelled}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, filter, opts)
+}
+
+func (r *InstallmentRepositoryMongo) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*entities.Installment, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.InstallmentDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	installments := make([]*entities.Installment, 0, len(docs))
+	for _, doc := range docs {
+		installments = append(installments, doc.ToEntity())
+	}
+	return installments, nil
+}
@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobLeaseMongo keeps one document per lease, named after the job. A lease that expired is taken
+// over by the next process asking for it, so a holder that died blocks the job for ttl at most.
+type JobLeaseMongo struct {
+	collection *mongo.Collection
+	holder     string
+}
+
+func NewJobLeaseMongo(collection *mongo.Collection) repositories.JobLease {
+	host, _ := os.Hostname()
+	return &JobLeaseMongo{
+		collection: collection,
+		holder:     fmt.Sprintf("%s:%d:%s", host, os.Getpid(), primitive.NewObjectID().Hex()),
+	}
+}
+
+func (l *JobLeaseMongo) Acquire(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"holder": l.holder},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"holder": l.holder, "expires_at": now.Add(ttl)}}
+
+	// While another process holds the lease the filter misses, and the upsert collides with its
+	// document.
+	_, err := l.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (l *JobLeaseMongo) Release(ctx context.Context, name string) error {
+	_, err := l.collection.DeleteOne(ctx, bson.M{"_id": name, "holder": l.holder})
+	return err
+}
@@ -0,0 +1,135 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepositoryMongo(collection *mongo.Collection) *OutboxRepositoryMongo {
+	return &OutboxRepositoryMongo{collection: collection}
+}
+
+const outboxPublishedTTLIndex = "outbox_published_ttl"
+
+// EnsureIndexes creates the index ClaimPendingEvents walks and, when retention is positive, a
+// TTL index that removes published events once they are that old. Pending and failed events
+// have no published_at, so they are never removed.
+func (r *OutboxRepositoryMongo) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("outbox_claim"),
+	})
+	if err != nil {
+		return err
+	}
+	return r.ensurePublishedTTL(ctx, retention)
+}
+
+func (r *OutboxRepositoryMongo) ensurePublishedTTL(ctx context.Context, retention time.Duration) error {
+	if retention <= 0 {
+		_, err := r.collection.Indexes().DropOne(ctx, outboxPublishedTTLIndex)
+		if hasErrorCode(err, errCodeNamespaceNotFound, errCodeIndexNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	seconds := int32(retention / time.Second)
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "published_at", Value: 1}},
+		Options: options.Index().SetName(outboxPublishedTTLIndex).SetExpireAfterSeconds(seconds),
+	})
+	if !hasErrorCode(err, errCodeIndexOptionsConflict) {
+		return err
+	}
+
+	// The index exists with another retention, which collMod changes in place.
+	return r.collection.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: r.collection.Name()},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: outboxPublishedTTLIndex},
+			{Key: "expireAfterSeconds", Value: seconds},
+		}},
+	}).Err()
+}
+
+func (r *OutboxRepositoryMongo) AppendEvents(ctx context.Context, events []*entities.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		if err := event.Validate(); err != nil {
+			return err
+		}
+		docs = append(docs, models.NewOutboxEventDocument(entities.NewOutboxEvent(*event)))
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *OutboxRepositoryMongo) ClaimPendingEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.OutboxEvent, error) {
+	filter := bson.M{
+		"status":          entities.OutboxPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var events []*entities.OutboxEvent
+	for len(events) < limit {
+		var doc models.OutboxEventDocument
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, doc.ToEntity())
+	}
+	return events, nil
+}
+
+// UpdateOutboxEvent replaces the whole document, as UpdateDelivery does, so cleared fields do
+// not linger.
+func (r *OutboxRepositoryMongo) UpdateOutboxEvent(ctx context.Context, event *entities.OutboxEvent) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": event.Event.ID}, models.NewOutboxEventDocument(event))
+	return err
+}
+
+// Server error codes EnsureIndexes tolerates.
+const (
+	errCodeNamespaceNotFound    = 26
+	errCodeIndexNotFound        = 27
+	errCodeIndexOptionsConflict = 85
+)
+
+func hasErrorCode(err error, codes ...int32) bool {
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) {
+		return false
+	}
+	for _, code := range codes {
+		if commandErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
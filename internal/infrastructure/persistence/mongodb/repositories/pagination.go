@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sortKind int
+
+const (
+	sortKindString sortKind = iota
+	sortKindTime
+	sortKindInt
+)
+
+// sortField maps a whitelisted API sort field to its document path.
+type sortField struct {
+	path string
+	kind sortKind
+}
+
+var idSortField = sortField{path: "_id"}
+
+// pageQuery translates repository pagination into a keyset query: results are ordered by the
+// sort field and then by _id, and the cursor resumes strictly after the last returned item.
+type pageQuery struct {
+	filter  bson.M
+	options *options.FindOptions
+	page    repositories.Pagination
+	field   sortField
+}
+
+func newPageQuery(filter bson.M, page repositories.Pagination, fields map[string]sortField) (pageQuery, error) {
+	page = page.Normalize()
+
+	field, ok := fields[page.Sort.Field]
+	if page.Sort.Field == repositories.SortByID {
+		field, ok = idSortField, true
+	}
+	if !ok {
+		return pageQuery{}, fmt.Errorf("%w: %s", repositories.ErrInvalidSortField, page.Sort.Field)
+	}
+
+	direction, operator := 1, "$gt"
+	if page.Sort.Descending {
+		direction, operator = -1, "$lt"
+	}
+
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	if page.Cursor != "" {
+		cursor, err := repositories.DecodeCursor(page.Cursor, page.Sort)
+		if err != nil {
+			return pageQuery{}, err
+		}
+
+		keyset := bson.M{"_id": bson.M{operator: cursor.ID}}
+		if field != idSortField {
+			value, err := field.parse(cursor.Value)
+			if err != nil {
+				return pageQuery{}, repositories.ErrInvalidCursor
+			}
+			keyset = bson.M{"$or": bson.A{
+				bson.M{field.path: bson.M{operator: value}},
+				bson.M{field.path: value, "_id": bson.M{operator: cursor.ID}},
+			}}
+		}
+
+		if len(filter) == 0 {
+			filter = keyset
+		} else {
+			filter = bson.M{"$and": bson.A{filter, keyset}}
+		}
+	}
+
+	sort := bson.D{{Key: field.path, Value: direction}}
+	if field != idSortField {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+
+	// One extra document is fetched to find out whether another page exists.
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit + 1))
+
+	return pageQuery{filter: filter, options: opts, page: page, field: field}, nil
+}
+
+// limit returns how many of the fetched documents belong to the page and whether more exist.
+func (q pageQuery) limit(fetched int) (int, bool) {
+	if fetched > q.page.Limit {
+		return q.page.Limit, true
+	}
+	return fetched, false
+}
+
+// info builds the page metadata from the last item returned to the caller.
+func (q pageQuery) info(hasMore bool, lastValue interface{}, lastID primitive.ObjectID) repositories.PageInfo {
+	info := repositories.PageInfo{Limit: q.page.Limit, HasMore: hasMore}
+	if !hasMore {
+		return info
+	}
+
+	cursor := repositories.Cursor{Sort: q.page.Sort.String(), ID: lastID}
+	if q.field != idSortField {
+		cursor.Value = q.field.format(lastValue)
+	}
+	info.NextCursor = repositories.EncodeCursor(cursor)
+
+	return info
+}
+
+func (f sortField) parse(value string) (interface{}, error) {
+	switch f.kind {
+	case sortKindTime:
+		return time.Parse(time.RFC3339Nano, value)
+	case sortKindInt:
+		return strconv.Atoi(value)
+	default:
+		return value, nil
+	}
+}
+
+func (f sortField) format(value interface{}) string {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano)
+	case string:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -49,25 +49,54 @@ func (r *PartnerRepositoryMongo) DeletePartner(ctx context.Context, id primitive
 	return err
 }
 
-func (r *PartnerRepositoryMongo) ListPartners(ctx context.Context, filter map[string]interface{}) ([]*entities.Partner, error) {
-	var partners []*entities.Partner
-	cursor, err := r.collection.Find(ctx, filter)
+var partnerSortFields = map[string]sortField{
+	"name": {path: "partner_name"},
+	"type": {path: "partner_type"},
+}
+
+func (r *PartnerRepositoryMongo) ListPartners(ctx context.Context, query repositories.PartnerQuery) ([]*entities.Partner, repositories.PageInfo, error) {
+	filter := bson.M{}
+	if query.Type != "" {
+		filter["partner_type"] = query.Type.String()
+	}
+	if query.AcceptedType != "" {
+		filter["accepted_types"] = query.AcceptedType.String()
+	}
+
+	page, err := newPageQuery(filter, query.Page, partnerSortFields)
 	if err != nil {
-		return nil, err
+		return nil, repositories.PageInfo{}, err
+	}
+
+	cursor, err := r.collection.Find(ctx, page.filter, page.options)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
 	}
 	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var doc models.PartnerDocument
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, err
-		}
+	var docs []models.PartnerDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	count, hasMore := page.limit(len(docs))
+	partners := make([]*entities.Partner, 0, count)
+	for _, doc := range docs[:count] {
 		partners = append(partners, doc.ToEntity())
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, err
+	var lastValue interface{}
+	var lastID primitive.ObjectID
+	if count > 0 {
+		last := docs[count-1]
+		lastID = last.ID
+		switch page.page.Sort.Field {
+		case "name":
+			lastValue = last.Name
+		case "type":
+			lastValue = last.Type
+		}
 	}
 
-	return partners, nil
+	return partners, page.info(hasMore, lastValue, lastID), nil
 }
@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"context"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewPaymentRepositoryMongo(collection *mongo.Collection) repositories.PaymentRepository {
+	return &PaymentRepositoryMongo{collection: collection}
+}
+
+func (r *PaymentRepositoryMongo) CreatePayment(ctx context.Context, payment *entities.Payment) error {
+	if payment.ID.IsZero() {
+		payment.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, models.NewPaymentDocument(payment))
+	return err
+}
+
+func (r *PaymentRepositoryMongo) ListPaymentsByContract(ctx context.Context, contractID primitive.ObjectID) ([]*entities.Payment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "paid_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"contract_id": contractID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.PaymentDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	payments := make([]*entities.Payment, 0, len(docs))
+	for _, doc := range docs {
+		payments = append(payments, doc.ToEntity())
+	}
+	return payments, nil
+}
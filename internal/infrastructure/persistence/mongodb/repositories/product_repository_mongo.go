@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
 	"katseye/internal/infrastructure/persistence/mongodb/models"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -47,27 +48,65 @@ func (r *productRepositoryMongo) DeleteProduct(ctx context.Context, id primitive
 	return err
 }
 
-func (r *productRepositoryMongo) ListProducts(ctx context.Context, filter map[string]interface{}) ([]*entities.Product, error) {
-	var products []*entities.Product
-	cursor, err := r.collection.Find(ctx, filter)
+var productSortFields = map[string]sortField{
+	"name":         {path: "product_name"},
+	"product_type": {path: "product_type"},
+	"category":     {path: "product_category"},
+}
+
+func (r *productRepositoryMongo) ListProducts(ctx context.Context, query repositories.ProductQuery) ([]*entities.Product, repositories.PageInfo, error) {
+	filter := bson.M{}
+	if !query.PartnerID.IsZero() {
+		filter["$or"] = bson.A{
+			bson.M{"partner_id": query.PartnerID},
+			bson.M{"product_partner._id": query.PartnerID},
+		}
+	}
+	if query.ProductType != "" {
+		filter["product_type"] = query.ProductType
+	}
+	if query.Category != "" {
+		filter["product_category"] = query.Category
+	}
+
+	page, err := newPageQuery(filter, query.Page, productSortFields)
 	if err != nil {
-		return nil, err
+		return nil, repositories.PageInfo{}, err
+	}
+
+	cursor, err := r.collection.Find(ctx, page.filter, page.options)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
 	}
 	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var doc models.ProductDocument
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, err
-		}
+	var docs []models.ProductDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	count, hasMore := page.limit(len(docs))
+	products := make([]*entities.Product, 0, count)
+	for _, doc := range docs[:count] {
 		products = append(products, doc.ToEntity())
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, err
+	var lastValue interface{}
+	var lastID primitive.ObjectID
+	if count > 0 {
+		last := docs[count-1]
+		lastID = last.ID
+		switch page.page.Sort.Field {
+		case "name":
+			lastValue = last.Name
+		case "product_type":
+			lastValue = last.ProductType.String()
+		case "category":
+			lastValue = string(last.Category)
+		}
 	}
 
-	return products, nil
+	return products, page.info(hasMore, lastValue, lastID), nil
 }
 
 func (r *productRepositoryMongo) Save(ctx context.Context, product *entities.Product) error {
@@ -89,6 +128,6 @@ func (r *productRepositoryMongo) Delete(ctx context.Context, id primitive.Object
 	return r.DeleteProduct(ctx, id)
 }
 
-func (r *productRepositoryMongo) List(ctx context.Context, filter map[string]interface{}) ([]*entities.Product, error) {
-	return r.ListProducts(ctx, filter)
+func (r *productRepositoryMongo) List(ctx context.Context, query repositories.ProductQuery) ([]*entities.Product, repositories.PageInfo, error) {
+	return r.ListProducts(ctx, query)
 }
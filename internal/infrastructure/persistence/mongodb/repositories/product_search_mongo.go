@@ -0,0 +1,289 @@
+package mongodb
+
+import (
+	"context"
+	"strings"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// productSearchSortPaths maps search sort fields to projection paths.
+var productSearchSortPaths = map[string]string{
+	repositories.SortByID: "_id",
+	"name":                "name",
+	"interest_rate":       "interest_rate",
+	"term_months":         "term_months",
+}
+
+type ProductSearchMongo struct {
+	collection *mongo.Collection
+}
+
+func NewProductSearchMongo(collection *mongo.Collection) *ProductSearchMongo {
+	return &ProductSearchMongo{collection: collection}
+}
+
+// EnsureIndexes creates the text index used for free-text queries and the indexes backing the
+// exact filters. Creating an index that already exists is a no-op.
+func (r *ProductSearchMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "partner_name", Value: "text"},
+				{Key: "product_type", Value: "text"},
+				{Key: "category", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("product_search_text").
+				SetDefaultLanguage("portuguese").
+				SetWeights(bson.D{
+					{Key: "name", Value: 10},
+					{Key: "partner_name", Value: 5},
+					{Key: "product_type", Value: 2},
+					{Key: "category", Value: 1},
+				}),
+		},
+		{Keys: bson.D{{Key: "partner_id", Value: 1}}},
+		{Keys: bson.D{{Key: "product_type", Value: 1}, {Key: "category", Value: 1}}},
+	})
+	return err
+}
+
+func (r *ProductSearchMongo) IndexProduct(ctx context.Context, product *entities.Product, partner *entities.Partner) error {
+	if product == nil || product.ID.IsZero() {
+		return nil
+	}
+
+	doc := models.NewProductSearchDocument(product, partner)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *ProductSearchMongo) RemoveProduct(ctx context.Context, productID primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": productID})
+	return err
+}
+
+func (r *ProductSearchMongo) UpdatePartner(ctx context.Context, partner *entities.Partner) error {
+	if partner == nil || partner.ID.IsZero() {
+		return nil
+	}
+
+	update := bson.M{"$set": bson.M{
+		"partner_name": partner.Name,
+		"partner_type": partner.Type,
+	}}
+	_, err := r.collection.UpdateMany(ctx, bson.M{"partner_id": partner.ID}, update)
+	return err
+}
+
+func (r *ProductSearchMongo) RemovePartner(ctx context.Context, partnerID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"partner_id": partnerID})
+	return err
+}
+
+func (r *ProductSearchMongo) SearchProducts(ctx context.Context, query repositories.ProductSearchQuery) (repositories.ProductSearchResult, error) {
+	sort := query.Sort()
+	page := query.Page.Normalize()
+
+	offset := 0
+	if page.Cursor != "" {
+		decoded, err := repositories.DecodeOffsetCursor(page.Cursor, sort)
+		if err != nil {
+			return repositories.ProductSearchResult{}, err
+		}
+		offset = decoded
+	}
+
+	text := strings.TrimSpace(query.Text)
+	match := productSearchFilter(query, text)
+
+	items := bson.A{
+		bson.M{"$sort": productSearchSort(sort, text != "")},
+		bson.M{"$skip": offset},
+		bson.M{"$limit": page.Limit + 1},
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.M{
+			"items":         items,
+			"total":         bson.A{bson.M{"$count": "count"}},
+			"product_types": facetStage("$product_type"),
+			"categories":    facetStage("$category"),
+			"partner_types": facetStage("$partner_type"),
+			"risk_levels":   facetStage("$risk_level"),
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return repositories.ProductSearchResult{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []productSearchFacets
+	if err := cursor.All(ctx, &facets); err != nil {
+		return repositories.ProductSearchResult{}, err
+	}
+
+	if len(facets) == 0 {
+		return repositories.ProductSearchResult{Page: repositories.PageInfo{Limit: page.Limit}}, nil
+	}
+	return facets[0].result(sort, offset, page.Limit), nil
+}
+
+type productSearchFacets struct {
+	Items []models.ProductSearchDocument `bson:"items"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	ProductTypes []facetBucket `bson:"product_types"`
+	Categories   []facetBucket `bson:"categories"`
+	PartnerTypes []facetBucket `bson:"partner_types"`
+	RiskLevels   []facetBucket `bson:"risk_levels"`
+}
+
+type facetBucket struct {
+	Value interface{} `bson:"_id"`
+	Count int64       `bson:"count"`
+}
+
+// result maps the $facet output to a page of the search: the extra item fetched past the limit
+// only signals that another page exists.
+func (f productSearchFacets) result(sort repositories.Sort, offset, limit int) repositories.ProductSearchResult {
+	result := repositories.ProductSearchResult{Page: repositories.PageInfo{Limit: limit}}
+
+	count := len(f.Items)
+	if count > limit {
+		count = limit
+		result.Page.HasMore = true
+		result.Page.NextCursor = repositories.EncodeOffsetCursor(sort, offset+limit)
+	}
+
+	result.Products = make([]*entities.Product, 0, count)
+	for _, doc := range f.Items[:count] {
+		result.Products = append(result.Products, doc.ToEntity())
+	}
+
+	if len(f.Total) > 0 {
+		result.Total = f.Total[0].Count
+	}
+
+	result.Facets = repositories.ProductFacets{
+		ProductTypes: toFacetCounts(f.ProductTypes),
+		Categories:   toFacetCounts(f.Categories),
+		PartnerTypes: toFacetCounts(f.PartnerTypes),
+		RiskLevels:   toFacetCounts(f.RiskLevels),
+	}
+
+	return result
+}
+
+func facetStage(path string) bson.A {
+	return bson.A{
+		bson.M{"$match": bson.M{path[1:]: bson.M{"$nin": bson.A{nil, ""}}}},
+		bson.M{"$sortByCount": path},
+	}
+}
+
+func toFacetCounts(buckets []facetBucket) []repositories.FacetCount {
+	counts := make([]repositories.FacetCount, 0, len(buckets))
+	for _, bucket := range buckets {
+		value, ok := bucket.Value.(string)
+		if !ok {
+			continue
+		}
+		counts = append(counts, repositories.FacetCount{Value: value, Count: bucket.Count})
+	}
+	return counts
+}
+
+func productSearchFilter(query repositories.ProductSearchQuery, text string) bson.M {
+	filter := bson.M{}
+
+	if text != "" {
+		filter["$text"] = bson.M{"$search": text}
+	}
+	if !query.PartnerID.IsZero() {
+		filter["partner_id"] = query.PartnerID
+	}
+	if len(query.ProductTypes) > 0 {
+		filter["product_type"] = bson.M{"$in": query.ProductTypes}
+	}
+	if len(query.Categories) > 0 {
+		filter["category"] = bson.M{"$in": query.Categories}
+	}
+	if len(query.PartnerTypes) > 0 {
+		filter["partner_type"] = bson.M{"$in": query.PartnerTypes}
+	}
+	if len(query.RiskLevels) > 0 {
+		filter["risk_level"] = bson.M{"$in": query.RiskLevels}
+	}
+
+	if !query.InterestRate.IsZero() {
+		filter["interest_rate"] = floatBounds(query.InterestRate.Min, query.InterestRate.Max)
+	}
+
+	// Amount ranges match products whose accepted interval overlaps the requested one; a missing
+	// bound on the product means it is unbounded on that side.
+	if query.Amount.Max != nil {
+		filter["min_amount"] = bson.M{"$not": bson.M{"$gt": *query.Amount.Max}}
+	}
+	if query.Amount.Min != nil {
+		filter["max_amount"] = bson.M{"$not": bson.M{"$lt": *query.Amount.Min}}
+	}
+
+	if !query.TermMonths.IsZero() {
+		bounds := bson.M{}
+		if query.TermMonths.Min != nil {
+			bounds["$gte"] = *query.TermMonths.Min
+		}
+		if query.TermMonths.Max != nil {
+			bounds["$lte"] = *query.TermMonths.Max
+		}
+		filter["term_months"] = bounds
+	}
+
+	return filter
+}
+
+func floatBounds(min, max *float64) bson.M {
+	bounds := bson.M{}
+	if min != nil {
+		bounds["$gte"] = *min
+	}
+	if max != nil {
+		bounds["$lte"] = *max
+	}
+	return bounds
+}
+
+func productSearchSort(sort repositories.Sort, hasText bool) bson.D {
+	direction := 1
+	if sort.Descending {
+		direction = -1
+	}
+
+	if sort.Field == repositories.SortByRelevance {
+		if !hasText {
+			return bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}
+		}
+		return bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}
+	}
+
+	path, ok := productSearchSortPaths[sort.Field]
+	if !ok || path == "_id" {
+		return bson.D{{Key: "_id", Value: direction}}
+	}
+
+	return bson.D{{Key: path, Value: direction}, {Key: "_id", Value: direction}}
+}
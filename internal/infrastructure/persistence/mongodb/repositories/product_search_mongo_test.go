@@ -0,0 +1,207 @@
+package mongodb
+
+import (
+	"reflect"
+	"testing"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProductSearchFilter(t *testing.T) {
+	partnerID := primitive.NewObjectID()
+	low, high := 1.5, 3.0
+	minAmount, maxAmount := 1000.0, 50000.0
+	minTerm, maxTerm := 6, 48
+	productTypes := []valueobjects.ProductType{valueobjects.ProductTypePersonalLoan, valueobjects.ProductTypePayrollLoan}
+	partnerTypes := []valueobjects.PartnerType{valueobjects.PartnerTypeBank}
+
+	tests := []struct {
+		name  string
+		query repositories.ProductSearchQuery
+		text  string
+		want  bson.M
+	}{
+		{name: "no filters match everything", want: bson.M{}},
+		{
+			name: "text search",
+			text: "consignado",
+			want: bson.M{"$text": bson.M{"$search": "consignado"}},
+		},
+		{
+			name:  "exact filters",
+			query: repositories.ProductSearchQuery{PartnerID: partnerID, ProductTypes: productTypes, PartnerTypes: partnerTypes},
+			want: bson.M{
+				"partner_id":   partnerID,
+				"product_type": bson.M{"$in": productTypes},
+				"partner_type": bson.M{"$in": partnerTypes},
+			},
+		},
+		{
+			name:  "interest rate range",
+			query: repositories.ProductSearchQuery{InterestRate: repositories.FloatRange{Min: &low, Max: &high}},
+			want:  bson.M{"interest_rate": bson.M{"$gte": low, "$lte": high}},
+		},
+		{
+			name:  "amount range overlaps the product interval",
+			query: repositories.ProductSearchQuery{Amount: repositories.FloatRange{Min: &minAmount, Max: &maxAmount}},
+			want: bson.M{
+				"min_amount": bson.M{"$not": bson.M{"$gt": maxAmount}},
+				"max_amount": bson.M{"$not": bson.M{"$lt": minAmount}},
+			},
+		},
+		{
+			name:  "open ended term range",
+			query: repositories.ProductSearchQuery{TermMonths: repositories.IntRange{Min: &minTerm}},
+			want:  bson.M{"term_months": bson.M{"$gte": minTerm}},
+		},
+		{
+			name:  "term range",
+			query: repositories.ProductSearchQuery{TermMonths: repositories.IntRange{Min: &minTerm, Max: &maxTerm}},
+			want:  bson.M{"term_months": bson.M{"$gte": minTerm, "$lte": maxTerm}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := productSearchFilter(tt.query, tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected filter %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProductSearchSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    repositories.Sort
+		hasText bool
+		want    bson.D
+	}{
+		{
+			name:    "relevance orders by text score",
+			sort:    repositories.Sort{Field: repositories.SortByRelevance},
+			hasText: true,
+			want:    bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}},
+		},
+		{
+			name: "relevance without text falls back to name",
+			sort: repositories.Sort{Field: repositories.SortByRelevance},
+			want: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name: "field breaks ties by id in the same direction",
+			sort: repositories.Sort{Field: "interest_rate", Descending: true},
+			want: bson.D{{Key: "interest_rate", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name: "id",
+			sort: repositories.Sort{Field: repositories.SortByID},
+			want: bson.D{{Key: "_id", Value: 1}},
+		},
+		{
+			name: "unknown field orders by id",
+			sort: repositories.Sort{Field: "partner_name", Descending: true},
+			want: bson.D{{Key: "_id", Value: -1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := productSearchSort(tt.sort, tt.hasText); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected sort %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProductSearchFacets_Result(t *testing.T) {
+	var ids []primitive.ObjectID
+	items := bson.A{}
+	for _, name := range []string{"Crédito pessoal", "Consignado INSS", "Consignado privado"} {
+		product := &entities.Product{
+			ID:          primitive.NewObjectID(),
+			Name:        name,
+			ProductType: valueobjects.ProductTypePersonalLoan,
+			Category:    valueobjects.ProductCategoryPersonal,
+			PartnerID:   primitive.NewObjectID(),
+		}
+		ids = append(ids, product.ID)
+		items = append(items, models.NewProductSearchDocument(product, nil))
+	}
+
+	// Shaped like the output of the $facet stage; buckets whose value is not a string are
+	// dropped.
+	raw, err := bson.Marshal(bson.M{
+		"items": items,
+		"total": bson.A{bson.M{"count": int64(7)}},
+		"product_types": bson.A{
+			bson.M{"_id": "personal_loan", "count": int64(4)},
+			bson.M{"_id": "payroll_loan", "count": int64(3)},
+		},
+		"categories":    bson.A{bson.M{"_id": "personal", "count": int64(7)}, bson.M{"_id": int32(1), "count": int64(1)}},
+		"partner_types": bson.A{},
+	})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	var facets productSearchFacets
+	if err := bson.Unmarshal(raw, &facets); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	sort := repositories.Sort{Field: "name"}
+	tests := []struct {
+		name      string
+		limit     int
+		wantIDs   []primitive.ObjectID
+		wantMore  bool
+		wantAfter int
+	}{
+		{name: "extra item signals another page", limit: 2, wantIDs: ids[:2], wantMore: true, wantAfter: 12},
+		{name: "last page", limit: 3, wantIDs: ids},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := facets.result(sort, 10, tt.limit)
+
+			var got []primitive.ObjectID
+			for _, product := range result.Products {
+				got = append(got, product.ID)
+			}
+			if !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Fatalf("expected products %v, got %v", tt.wantIDs, got)
+			}
+			if result.Page.HasMore != tt.wantMore || result.Page.Limit != tt.limit {
+				t.Fatalf("expected has_more=%t limit=%d, got %+v", tt.wantMore, tt.limit, result.Page)
+			}
+			if tt.wantMore {
+				offset, err := repositories.DecodeOffsetCursor(result.Page.NextCursor, sort)
+				if err != nil || offset != tt.wantAfter {
+					t.Fatalf("expected a cursor to offset %d, got (%d, %v)", tt.wantAfter, offset, err)
+				}
+			} else if result.Page.NextCursor != "" {
+				t.Fatalf("expected no cursor on the last page, got %q", result.Page.NextCursor)
+			}
+
+			if result.Total != 7 {
+				t.Fatalf("expected a total of 7, got %d", result.Total)
+			}
+			wantFacets := repositories.ProductFacets{
+				ProductTypes: []repositories.FacetCount{{Value: "personal_loan", Count: 4}, {Value: "payroll_loan", Count: 3}},
+				Categories:   []repositories.FacetCount{{Value: "personal", Count: 7}},
+				PartnerTypes: []repositories.FacetCount{},
+				RiskLevels:   []repositories.FacetCount{},
+			}
+			if !reflect.DeepEqual(result.Facets, wantFacets) {
+				t.Fatalf("expected facets %+v, got %+v", wantFacets, result.Facets)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ScreeningReviewRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewScreeningReviewRepositoryMongo(collection *mongo.Collection) repositories.ScreeningReviewRepository {
+	return &ScreeningReviewRepositoryMongo{collection: collection}
+}
+
+func (r *ScreeningReviewRepositoryMongo) CreateReview(ctx context.Context, review *entities.ScreeningReview) error {
+	if review.ID.IsZero() {
+		review.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, models.NewScreeningReviewDocument(review))
+	return err
+}
+
+func (r *ScreeningReviewRepositoryMongo) GetReviewByID(ctx context.Context, id primitive.ObjectID) (*entities.ScreeningReview, error) {
+	var doc models.ScreeningReviewDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+func (r *ScreeningReviewRepositoryMongo) UpdateReview(ctx context.Context, review *entities.ScreeningReview) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": review.ID}, bson.M{"$set": models.NewScreeningReviewDocument(review)})
+	return err
+}
+
+var reviewSortFields = map[string]sortField{
+	"created_at": {path: "created_at", kind: sortKindTime},
+	"score":      {path: "score", kind: sortKindInt},
+}
+
+func (r *ScreeningReviewRepositoryMongo) ListReviews(ctx context.Context, query repositories.ScreeningReviewQuery) ([]*entities.ScreeningReview, repositories.PageInfo, error) {
+	filter := bson.M{}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.Stage != "" {
+		filter["stage"] = query.Stage
+	}
+
+	page, err := newPageQuery(filter, query.Page, reviewSortFields)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	cursor, err := r.collection.Find(ctx, page.filter, page.options)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.ScreeningReviewDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	count, hasMore := page.limit(len(docs))
+	reviews := make([]*entities.ScreeningReview, 0, count)
+	for _, doc := range docs[:count] {
+		reviews = append(reviews, doc.ToEntity())
+	}
+
+	var lastValue interface{}
+	var lastID primitive.ObjectID
+	if count > 0 {
+		last := docs[count-1]
+		lastID = last.ID
+		switch page.page.Sort.Field {
+		case "created_at":
+			lastValue = last.CreatedAt
+		case "score":
+			lastValue = last.Score
+		}
+	}
+
+	return reviews, page.info(hasMore, lastValue, lastID), nil
+}
@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScreeningSignalRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewScreeningSignalRepositoryMongo(collection *mongo.Collection) *ScreeningSignalRepositoryMongo {
+	return &ScreeningSignalRepositoryMongo{collection: collection}
+}
+
+// EnsureIndexes creates the index the velocity and shared-value rules query by kind and value,
+// and the one erasure deletes a consumer's signals through.
+func (r *ScreeningSignalRepositoryMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "kind", Value: 1}, {Key: "value", Value: 1}, {Key: "recorded_at", Value: 1}},
+			Options: options.Index().SetName("screening_signals_value"),
+		},
+		{
+			Keys:    bson.D{{Key: "consumer_id", Value: 1}},
+			Options: options.Index().SetName("screening_signals_consumer"),
+		},
+	})
+	return err
+}
+
+func (r *ScreeningSignalRepositoryMongo) RecordSignals(ctx context.Context, signals []*entities.ScreeningSignal) error {
+	if len(signals) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(signals))
+	for _, signal := range signals {
+		if signal.ID.IsZero() {
+			signal.ID = primitive.NewObjectID()
+		}
+		docs = append(docs, models.NewScreeningSignalDocument(signal))
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *ScreeningSignalRepositoryMongo) CountConsumers(ctx context.Context, kind, value string, excludeConsumerID primitive.ObjectID) (int, error) {
+	filter := bson.M{"kind": kind, "value": value}
+	if !excludeConsumerID.IsZero() {
+		filter["consumer_id"] = bson.M{"$ne": excludeConsumerID}
+	}
+
+	consumers, err := r.collection.Distinct(ctx, "consumer_id", filter)
+	if err != nil {
+		return 0, err
+	}
+	return len(consumers), nil
+}
+
+func (r *ScreeningSignalRepositoryMongo) CountSince(ctx context.Context, kind, value string, since time.Time) (int, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"kind":        kind,
+		"value":       value,
+		"recorded_at": bson.M{"$gte": since},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *ScreeningSignalRepositoryMongo) DeleteConsumerSignals(ctx context.Context, consumerID primitive.ObjectID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"consumer_id": consumerID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UnitOfWorkMongo runs units of work as multi-document transactions. They need a replica set
+// or a sharded cluster; check SupportsTransactions before using it against a standalone server.
+type UnitOfWorkMongo struct {
+	client *mongo.Client
+}
+
+func NewUnitOfWorkMongo(client *mongo.Client) repositories.UnitOfWork {
+	return &UnitOfWorkMongo{client: client}
+}
+
+// Do hands fn a session context, which the repositories pass on to the driver, so their calls
+// take part in the transaction. The driver retries fn on transient errors and the commit on
+// unknown results; hooks registered by an attempt that was rolled back are discarded.
+func (u *UnitOfWorkMongo) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if repositories.InUnitOfWork(ctx) {
+		return fn(ctx)
+	}
+
+	session, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	var hooks *repositories.CommitHooks
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		var txCtx context.Context
+		txCtx, hooks = repositories.WithCommitHooks(sessionCtx)
+		return nil, fn(txCtx)
+	})
+	if err != nil {
+		return err
+	}
+
+	hooks.Run(ctx)
+	return nil
+}
+
+// SupportsTransactions reports whether the server is a replica set member or a mongos router.
+func SupportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
@@ -19,10 +19,32 @@ type UserRepositoryMongo struct {
 	collection *mongo.Collection
 }
 
+// userProjection leaves out the audit timestamps written by cmd/seed_user.
+var userProjection = bson.M{
+	"_id":           1,
+	"password_hash": 1,
+	"email":         1,
+	"active":        1,
+	"role":          1,
+	"permissions":   1,
+	"profile_type":  1,
+	"profile_id":    1,
+}
+
 func NewUserRepositoryMongo(collection *mongo.Collection) *UserRepositoryMongo {
 	return &UserRepositoryMongo{collection: collection}
 }
 
+// EnsureIndexes creates the unique email index CreateUser relies on to report
+// ErrUserAlreadyExists. Creating an index that already exists is a no-op.
+func (r *UserRepositoryMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetName("users_email_unique").SetUnique(true),
+	})
+	return err
+}
+
 func (r *UserRepositoryMongo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	if r == nil || r.collection == nil {
 		return nil, nil
@@ -34,14 +56,7 @@ func (r *UserRepositoryMongo) FindByEmail(ctx context.Context, email string) (*e
 	}
 
 	filter := bson.M{"email": email}
-	opts := options.FindOne().SetProjection(bson.M{
-		"_id":           1,
-		"password_hash": 1,
-		"email":         1,
-		"active":        1,
-		"role":          1,
-		"permissions":   1,
-	})
+	opts := options.FindOne().SetProjection(userProjection)
 
 	var doc models.UserDocument
 	if err := r.collection.FindOne(ctx, filter, opts).Decode(&doc); err != nil {
@@ -64,14 +79,7 @@ func (r *UserRepositoryMongo) FindByID(ctx context.Context, id primitive.ObjectI
 	}
 
 	filter := bson.M{"_id": id}
-	opts := options.FindOne().SetProjection(bson.M{
-		"_id":           1,
-		"password_hash": 1,
-		"email":         1,
-		"active":        1,
-		"role":          1,
-		"permissions":   1,
-	})
+	opts := options.FindOne().SetProjection(userProjection)
 
 	var doc models.UserDocument
 	if err := r.collection.FindOne(ctx, filter, opts).Decode(&doc); err != nil {
@@ -0,0 +1,145 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookDeliveryRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepositoryMongo(collection *mongo.Collection) *WebhookDeliveryRepositoryMongo {
+	return &WebhookDeliveryRepositoryMongo{collection: collection}
+}
+
+// EnsureIndexes creates the indexes ClaimDueDeliveries and ListDeliveries walk.
+func (r *WebhookDeliveryRepositoryMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("webhook_deliveries_claim"),
+		},
+		{
+			Keys:    bson.D{{Key: "partner_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("webhook_deliveries_partner"),
+		},
+	})
+	return err
+}
+
+func (r *WebhookDeliveryRepositoryMongo) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.ID.IsZero() {
+			delivery.ID = primitive.NewObjectID()
+		}
+		docs = append(docs, models.NewWebhookDeliveryDocument(delivery))
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *WebhookDeliveryRepositoryMongo) GetDeliveryByID(ctx context.Context, id primitive.ObjectID) (*entities.WebhookDelivery, error) {
+	var doc models.WebhookDeliveryDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+// UpdateDelivery replaces the whole document so fields cleared by the entity, such as the last
+// error after a success, do not linger.
+func (r *WebhookDeliveryRepositoryMongo) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": delivery.ID}, models.NewWebhookDeliveryDocument(delivery))
+	return err
+}
+
+func (r *WebhookDeliveryRepositoryMongo) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          entities.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var deliveries []*entities.WebhookDelivery
+	for len(deliveries) < limit {
+		var doc models.WebhookDeliveryDocument
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, doc.ToEntity())
+	}
+	return deliveries, nil
+}
+
+var deliverySortFields = map[string]sortField{
+	"created_at": {path: "created_at", kind: sortKindTime},
+}
+
+func (r *WebhookDeliveryRepositoryMongo) ListDeliveries(ctx context.Context, query repositories.WebhookDeliveryQuery) ([]*entities.WebhookDelivery, repositories.PageInfo, error) {
+	filter := bson.M{"partner_id": query.PartnerID}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	page, err := newPageQuery(filter, query.Page, deliverySortFields)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	cursor, err := r.collection.Find(ctx, page.filter, page.options)
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.WebhookDeliveryDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	count, hasMore := page.limit(len(docs))
+	deliveries := make([]*entities.WebhookDelivery, 0, count)
+	for _, doc := range docs[:count] {
+		deliveries = append(deliveries, doc.ToEntity())
+	}
+
+	var lastValue interface{}
+	var lastID primitive.ObjectID
+	if count > 0 {
+		last := docs[count-1]
+		lastID = last.ID
+		if page.page.Sort.Field == "created_at" {
+			lastValue = last.CreatedAt
+		}
+	}
+
+	return deliveries, page.info(hasMore, lastValue, lastID), nil
+}
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/persistence/mongodb/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookSubscriptionRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookSubscriptionRepositoryMongo(collection *mongo.Collection) repositories.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryMongo{collection: collection}
+}
+
+func (r *WebhookSubscriptionRepositoryMongo) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	if subscription.ID.IsZero() {
+		subscription.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, models.NewWebhookSubscriptionDocument(subscription))
+	return err
+}
+
+func (r *WebhookSubscriptionRepositoryMongo) GetSubscriptionByID(ctx context.Context, id primitive.ObjectID) (*entities.WebhookSubscription, error) {
+	var doc models.WebhookSubscriptionDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+func (r *WebhookSubscriptionRepositoryMongo) UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": subscription.ID}, models.NewWebhookSubscriptionDocument(subscription))
+	return err
+}
+
+func (r *WebhookSubscriptionRepositoryMongo) DeleteSubscription(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *WebhookSubscriptionRepositoryMongo) ListSubscriptionsByPartner(ctx context.Context, partnerID primitive.ObjectID) ([]*entities.WebhookSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"partner_id": partnerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.WebhookSubscriptionDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*entities.WebhookSubscription, 0, len(docs))
+	for _, doc := range docs {
+		subscriptions = append(subscriptions, doc.ToEntity())
+	}
+	return subscriptions, nil
+}
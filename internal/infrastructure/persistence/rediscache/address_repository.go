@@ -2,9 +2,6 @@ package rediscache
 
 import (
 	"context"
-	"encoding/json"
-	"log"
-	"time"
 
 	goredis "github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,20 +10,18 @@ import (
 )
 
 type addressRepository struct {
-	repo   repositories.AddressRepository
-	client *goredis.Client
-	ttl    time.Duration
+	repo  repositories.AddressRepository
+	cache *loader
 }
 
-func NewAddressRepository(client *goredis.Client, ttl time.Duration, repo repositories.AddressRepository) repositories.AddressRepository {
+func NewAddressRepository(client goredis.UniversalClient, options Options, repo repositories.AddressRepository) repositories.AddressRepository {
 	if client == nil || repo == nil {
 		return repo
 	}
 
 	return &addressRepository{
-		repo:   repo,
-		client: client,
-		ttl:    mergeTTL(ttl, time.Minute),
+		repo:  repo,
+		cache: newLoader(client, options),
 	}
 }
 
@@ -35,30 +30,18 @@ func (r *addressRepository) GetAddressByID(ctx context.Context, id primitive.Obj
 		return nil, nil
 	}
 
-	key := buildIDKey("addresses", id.Hex())
-	if data, err := r.client.Get(ctx, key).Bytes(); err == nil {
-		var cached entities.Address
-		if unmarshalErr := json.Unmarshal(data, &cached); unmarshalErr == nil {
-			log.Printf("cache: hit resource=addresses operation=get id=%s source=redis", id.Hex())
-			return &cached, nil
-		} else {
-			log.Printf("cache: stale resource=addresses operation=get id=%s error=%v", id.Hex(), unmarshalErr)
-			_ = r.client.Del(ctx, key).Err()
-		}
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.GetAddressByID(ctx, id)
 	}
 
-	address, err := r.repo.GetAddressByID(ctx, id)
+	address, cached, err := load(ctx, r.cache, buildIDKey("addresses", id.Hex()), func(ctx context.Context) (*entities.Address, error) {
+		return r.repo.GetAddressByID(ctx, id)
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if address != nil {
-		log.Printf("cache: miss resource=addresses operation=get id=%s source=mongo", id.Hex())
-		_ = r.saveAddress(ctx, key, address)
-	} else {
-		log.Printf("cache: miss resource=addresses operation=get id=%s source=mongo result=empty", id.Hex())
-	}
-
+	logLookup("addresses", id, cached, address != nil)
 	return address, nil
 }
 
@@ -67,11 +50,13 @@ func (r *addressRepository) CreateAddress(ctx context.Context, address *entities
 		return err
 	}
 
-	if address != nil {
-		_ = r.saveAddress(ctx, buildIDKey("addresses", address.ID.Hex()), address)
-	}
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		if address != nil {
+			_ = r.cache.store(ctx, buildIDKey("addresses", address.ID.Hex()), address)
+		}
 
-	_ = invalidateResourceLists(ctx, r.client, "addresses")
+		r.cache.invalidateLists(ctx, "addresses")
+	})
 
 	return nil
 }
@@ -81,11 +66,13 @@ func (r *addressRepository) UpdateAddress(ctx context.Context, address *entities
 		return err
 	}
 
-	if address != nil && !address.ID.IsZero() {
-		_ = r.saveAddress(ctx, buildIDKey("addresses", address.ID.Hex()), address)
-	}
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		if address != nil && !address.ID.IsZero() {
+			r.cache.invalidate(ctx, buildIDKey("addresses", address.ID.Hex()))
+		}
 
-	_ = invalidateResourceLists(ctx, r.client, "addresses")
+		r.cache.invalidateLists(ctx, "addresses")
+	})
 
 	return nil
 }
@@ -95,54 +82,63 @@ func (r *addressRepository) DeleteAddress(ctx context.Context, id primitive.Obje
 		return err
 	}
 
-	_ = r.client.Del(ctx, buildIDKey("addresses", id.Hex())).Err()
-	_ = invalidateResourceLists(ctx, r.client, "addresses")
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		r.cache.invalidate(ctx, buildIDKey("addresses", id.Hex()))
+		r.cache.invalidateLists(ctx, "addresses")
+	})
 
 	return nil
 }
 
-func (r *addressRepository) ListAddresses(ctx context.Context, filter map[string]interface{}) ([]*entities.Address, error) {
-	key := buildListKey("addresses", filter)
-	if data, err := r.client.Get(ctx, key).Bytes(); err == nil {
-		var cached []*entities.Address
-		if unmarshalErr := json.Unmarshal(data, &cached); unmarshalErr == nil {
-			log.Printf("cache: hit resource=addresses operation=list key=%s source=redis count=%d", key, len(cached))
-			return cached, nil
-		} else {
-			log.Printf("cache: stale resource=addresses operation=list key=%s error=%v", key, unmarshalErr)
-			_ = r.client.Del(ctx, key).Err()
-		}
+func (r *addressRepository) ListAddresses(ctx context.Context, query repositories.AddressQuery) ([]*entities.Address, repositories.PageInfo, error) {
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.ListAddresses(ctx, query)
 	}
 
-	addresses, err := r.repo.ListAddresses(ctx, filter)
-	if err != nil {
-		return nil, err
+	filter := map[string]interface{}{}
+	if query.City != "" {
+		filter["city"] = query.City
 	}
-
-	if addresses != nil {
-		if payload, marshalErr := json.Marshal(addresses); marshalErr == nil {
-			_ = r.client.Set(ctx, key, payload, r.ttl).Err()
-		}
+	if query.State != "" {
+		filter["state"] = query.State
 	}
-
-	log.Printf("cache: miss resource=addresses operation=list key=%s source=mongo count=%d", key, len(addresses))
-
-	return addresses, nil
-}
-
-func (r *addressRepository) saveAddress(ctx context.Context, key string, address *entities.Address) error {
-	if address == nil {
-		return nil
+	if query.PostalCode != "" {
+		filter["postal_code"] = query.PostalCode
+	}
+	if query.Type != "" {
+		filter["type"] = query.Type
+	}
+	key, keyErr := r.cache.listKey(ctx, "addresses", withPagination(filter, query.Page))
+	if keyErr != nil {
+		return r.repo.ListAddresses(ctx, query)
 	}
 
-	payload, err := json.Marshal(address)
+	list, cached, err := load(ctx, r.cache, key, func(ctx context.Context) (*cachedPage[*entities.Address], error) {
+		items, page, err := r.repo.ListAddresses(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return &cachedPage[*entities.Address]{Items: items, Page: page}, nil
+	}, func(list *cachedPage[*entities.Address]) bool {
+		return list.Items != nil
+	})
 	if err != nil {
-		return err
+		return nil, repositories.PageInfo{}, err
 	}
 
-	if err := r.client.Set(ctx, key, payload, r.ttl).Err(); err != nil {
-		return err
-	}
+	logListLookup("addresses", key, cached, len(list.Items))
+	return list.Items, list.Page, nil
+}
 
-	return nil
+func (r *addressRepository) Resource() string {
+	return "addresses"
+}
+
+// Warm caches every address under its ID.
+func (r *addressRepository) Warm(ctx context.Context) (int, error) {
+	return warm(ctx, r.cache, "addresses", func(ctx context.Context, page repositories.Pagination) ([]*entities.Address, repositories.PageInfo, error) {
+		return r.repo.ListAddresses(ctx, repositories.AddressQuery{Page: page})
+	}, func(address *entities.Address) primitive.ObjectID {
+		return address.ID
+	}, nil)
 }
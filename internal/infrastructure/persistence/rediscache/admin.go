@@ -0,0 +1,252 @@
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"katseye/internal/domain/repositories"
+)
+
+const flushBatchSize = 500
+
+// cachedResources are the key prefixes the admin API may inspect and flush. Token revocations
+// share the Redis database but are not a cache and stay out of reach.
+var cachedResources = map[string]bool{
+	"addresses": true,
+	"consumers": true,
+	"partners":  true,
+	"products":  true,
+	"users":     true,
+}
+
+// credentialResources hold password hashes, so their values are never shown.
+var credentialResources = map[string]bool{
+	"users": true,
+}
+
+// Warmer is implemented by the decorators whose resource can be loaded ahead of traffic.
+type Warmer interface {
+	Resource() string
+	Warm(ctx context.Context) (int, error)
+}
+
+// Admin implements repositories.CacheAdmin over the keys written by the decorators.
+type Admin struct {
+	client  goredis.UniversalClient
+	options Options
+	warmers map[string]Warmer
+}
+
+func NewAdmin(client goredis.UniversalClient, options Options) *Admin {
+	if client == nil {
+		return nil
+	}
+
+	return &Admin{
+		client:  client,
+		options: options,
+		warmers: make(map[string]Warmer),
+	}
+}
+
+// Register makes the given decorators warmable; values that cannot warm are ignored.
+func (a *Admin) Register(repos ...interface{}) {
+	if a == nil {
+		return
+	}
+	for _, repo := range repos {
+		if warmer, ok := repo.(Warmer); ok {
+			a.warmers[warmer.Resource()] = warmer
+		}
+	}
+}
+
+func (a *Admin) Stats(ctx context.Context) (repositories.CacheStats, error) {
+	stats := repositories.CacheStats{
+		Counters: a.options.Metrics.Counters(),
+		Local:    a.options.Local.stats(),
+	}
+
+	keys, err := a.client.DBSize(ctx).Result()
+	if err != nil {
+		log.Printf("cache: stats unavailable error=%v", err)
+		return stats, nil
+	}
+	stats.Available, stats.Keys = true, keys
+
+	var used int64
+	err = forEachShard(ctx, a.client, func(ctx context.Context, shard goredis.UniversalClient) error {
+		info, err := shard.Info(ctx, "memory").Result()
+		if err != nil {
+			return err
+		}
+		atomic.AddInt64(&used, infoValue(info, "used_memory"))
+		return nil
+	})
+	if err == nil {
+		stats.UsedMemoryBytes = used
+	}
+	return stats, nil
+}
+
+func (a *Admin) InspectKey(ctx context.Context, key string) (*repositories.CacheKeyInfo, error) {
+	resource, _ := describeKey(key)
+	if !cachedResources[resource] {
+		return nil, repositories.ErrUnknownCacheResource
+	}
+
+	data, err := a.client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := &repositories.CacheKeyInfo{Key: key, Size: len(data)}
+	if ttl, err := a.client.PTTL(ctx, key).Result(); err == nil && ttl > 0 {
+		info.TTL = ttl
+	}
+	_, info.Local = a.options.Local.get(key)
+
+	// Entities and list pages are wrapped in an entry; pointers and counters are stored bare.
+	entry, err := decodeEntryHeader(data)
+	switch {
+	case err == nil:
+		info.Missing, info.Expires, info.Codec, info.Schema = entry.Missing, entry.Expires, entry.Codec, entry.Schema
+		info.Value = entry.Value
+		// BSON values are shown as extended JSON.
+		if entry.Codec == CodecBSON && len(entry.Value) > 0 {
+			info.Value = json.RawMessage(bson.Raw(entry.Value).String())
+		}
+	case json.Valid(data):
+		info.Value = data
+	default:
+		info.Value, _ = json.Marshal(string(data))
+	}
+
+	if credentialResources[resource] && len(info.Value) > 0 {
+		info.Value, info.Redacted = nil, true
+	}
+	return info, nil
+}
+
+// FlushResource deletes the resource's keys, bumps its list generation so pages being filled
+// from older data are orphaned too, and clears the resource's local tier on every replica.
+func (a *Admin) FlushResource(ctx context.Context, resource string) (int64, error) {
+	if !cachedResources[resource] {
+		return 0, repositories.ErrUnknownCacheResource
+	}
+
+	// Each shard is scanned on its own node, while the deletions go through the client so they
+	// reach whichever node owns the key.
+	generationKey := listGenerationKey(resource)
+	var deleted int64
+	err := forEachShard(ctx, a.client, func(ctx context.Context, shard goredis.UniversalClient) error {
+		batch := make([]string, 0, flushBatchSize)
+		remove := func() error {
+			removed, err := deleteKeys(ctx, a.client, batch...)
+			atomic.AddInt64(&deleted, removed)
+			batch = batch[:0]
+			return err
+		}
+
+		iter := shard.Scan(ctx, 0, resource+":*", flushBatchSize).Iterator()
+		for iter.Next(ctx) {
+			if key := iter.Val(); key != generationKey {
+				batch = append(batch, key)
+			}
+			if len(batch) == flushBatchSize {
+				if err := remove(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		return remove()
+	})
+	if err != nil {
+		return atomic.LoadInt64(&deleted), err
+	}
+
+	if err := invalidateResourceLists(ctx, a.client, resource); err != nil {
+		return deleted, err
+	}
+	a.options.Local.flush(ctx, resource)
+
+	log.Printf("cache: flushed resource=%s keys=%d", resource, deleted)
+	return deleted, nil
+}
+
+func (a *Admin) WarmResource(ctx context.Context, resource string) (int, error) {
+	if !cachedResources[resource] {
+		return 0, repositories.ErrUnknownCacheResource
+	}
+
+	warmer, ok := a.warmers[resource]
+	if !ok {
+		return 0, repositories.ErrCacheResourceNotWarmable
+	}
+
+	warmed, err := warmer.Warm(ctx)
+	log.Printf("cache: warmed resource=%s entries=%d", resource, warmed)
+	return warmed, err
+}
+
+// warm pages through the backing repository and caches every entity under its ID. The other
+// replicas are not notified: whatever they hold locally is already bounded by the local TTL.
+func warm[T any](ctx context.Context, l *loader, resource string, list func(context.Context, repositories.Pagination) ([]*T, repositories.PageInfo, error), id func(*T) primitive.ObjectID, keep func(*T) bool) (int, error) {
+	page := repositories.Pagination{Limit: repositories.MaxPageLimit}
+	warmed := 0
+
+	for {
+		items, info, err := list(ctx, page)
+		if err != nil {
+			return warmed, err
+		}
+
+		for _, item := range items {
+			if item == nil || (keep != nil && !keep(item)) {
+				continue
+			}
+			entry, err := l.entry(item)
+			if err != nil {
+				return warmed, err
+			}
+			key := buildIDKey(resource, id(item).Hex())
+			if err := l.write(ctx, key, entry, l.options.Local.mark(key)); err != nil {
+				return warmed, err
+			}
+			warmed++
+		}
+
+		if !info.HasMore || info.NextCursor == "" {
+			return warmed, nil
+		}
+		page.Cursor = info.NextCursor
+	}
+}
+
+// infoValue reads an integer field from the output of INFO.
+func infoValue(info, field string) int64 {
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && name == field {
+			parsed, _ := strconv.ParseInt(value, 10, 64)
+			return parsed
+		}
+	}
+	return 0
+}
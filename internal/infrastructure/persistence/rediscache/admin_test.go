@@ -0,0 +1,129 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/persistence/memory"
+)
+
+func TestAdmin_WarmsInspectsAndFlushesResources(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	options := Options{TTL: time.Minute, Metrics: NewMetrics()}
+
+	// The partners are written below the decorator, as if they were already in Mongo.
+	backing := memory.NewPartnerRepository()
+	ids := make([]primitive.ObjectID, 0, 3)
+	for _, name := range []string{"Banco A", "Banco B", "Banco C"} {
+		partner := &entities.Partner{ID: primitive.NewObjectID(), Name: name, Type: valueobjects.PartnerTypeBank}
+		if err := backing.CreatePartner(ctx, partner); err != nil {
+			t.Fatalf("CreatePartner returned error: %v", err)
+		}
+		ids = append(ids, partner.ID)
+	}
+	partners := NewPartnerRepository(client, options, backing)
+
+	admin := NewAdmin(client, options)
+	admin.Register(partners)
+
+	if warmed, err := admin.WarmResource(ctx, "partners"); err != nil || warmed != 3 {
+		t.Fatalf("expected three warmed partners, got %d (%v)", warmed, err)
+	}
+	if _, err := partners.GetPartnerByID(ctx, ids[0]); err != nil {
+		t.Fatalf("GetPartnerByID returned error: %v", err)
+	}
+	if _, err := partners.GetPartnerByID(ctx, primitive.NewObjectID()); err != nil {
+		t.Fatalf("GetPartnerByID returned error: %v", err)
+	}
+
+	stats, _ := admin.Stats(ctx)
+	counts := make(map[string]uint64)
+	for _, counter := range stats.Counters {
+		counts[counter.Resource+"/"+counter.Operation+"/"+counter.Outcome] = counter.Count
+	}
+	if counts["partners/get/hit"] != 1 || counts["partners/get/miss"] != 1 {
+		t.Fatalf("expected one hit and one miss, got %v", counts)
+	}
+	if !stats.Available || stats.Keys < 3 {
+		t.Fatalf("expected Redis stats, got %+v", stats)
+	}
+
+	info, err := admin.InspectKey(ctx, buildIDKey("partners", ids[1].Hex()))
+	if err != nil || info == nil || len(info.Value) == 0 || info.Expires.IsZero() || info.TTL <= 0 || info.Codec != CodecJSON || info.Schema == "" {
+		t.Fatalf("expected the warmed entry to be described, got %+v (%v)", info, err)
+	}
+
+	deleted, err := admin.FlushResource(ctx, "partners")
+	if err != nil || deleted < 3 {
+		t.Fatalf("expected the partner keys to be flushed, got %d (%v)", deleted, err)
+	}
+	if info, _ := admin.InspectKey(ctx, buildIDKey("partners", ids[1].Hex())); info != nil {
+		t.Fatalf("expected the entry to be gone after the flush, got %+v", info)
+	}
+
+	if _, err := admin.WarmResource(ctx, "users"); !errors.Is(err, repositories.ErrCacheResourceNotWarmable) {
+		t.Fatalf("expected users not to be warmable, got %v", err)
+	}
+	if _, err := admin.InspectKey(ctx, tokenRevocationNamespace+"abc"); !errors.Is(err, repositories.ErrUnknownCacheResource) {
+		t.Fatalf("expected token revocations to stay out of reach, got %v", err)
+	}
+}
+
+func TestAdmin_RedactsCredentials(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	users := NewUserRepository(client, Options{TTL: time.Minute}, memory.NewUserRepository())
+	user := &entities.User{
+		ID:           primitive.NewObjectID(),
+		Email:        "admin@example.com",
+		PasswordHash: "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z2cHqh5V1aQYF8Rz6qKJY0eK",
+		Active:       true,
+		Role:         entities.RoleAdmin,
+	}
+	if err := users.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser returned error: %v", err)
+	}
+
+	info, err := NewAdmin(client, Options{}).InspectKey(ctx, buildEmailKey(user.Email))
+	if err != nil || info == nil {
+		t.Fatalf("expected the user entry, got %+v (%v)", info, err)
+	}
+	if !info.Redacted || info.Value != nil {
+		t.Fatalf("expected the password hash to be withheld, got %s", info.Value)
+	}
+}
+
+func TestAdmin_FlushesEveryClusterShard(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := goredis.NewClusterClient(&goredis.ClusterOptions{Addrs: []string{server.Addr()}})
+	t.Cleanup(func() { _ = client.Close() })
+
+	for _, key := range []string{buildIDKey("products", "a"), buildIDKey("products", "b"), buildIDKey("partners", "c")} {
+		if err := client.Set(ctx, key, `{"value":{}}`, time.Minute).Err(); err != nil {
+			t.Fatalf("Set returned error: %v", err)
+		}
+	}
+
+	admin := NewAdmin(client, Options{})
+	deleted, err := admin.FlushResource(ctx, "products")
+	if err != nil || deleted != 2 {
+		t.Fatalf("expected both product keys to be flushed, got %d (%v)", deleted, err)
+	}
+	if !server.Exists(buildIDKey("partners", "c")) {
+		t.Fatal("expected the other resources to be left alone")
+	}
+	if stats, _ := admin.Stats(ctx); !stats.Available || stats.Keys == 0 {
+		t.Fatalf("expected the cluster to be described, got %+v", stats)
+	}
+}
@@ -0,0 +1,197 @@
+package rediscache
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+const (
+	CodecJSON = "json"
+	CodecBSON = "bson"
+
+	// entryFormat starts every entry; bump it when the header below changes.
+	entryFormat = "kc1"
+)
+
+// Codec encodes the values the decorators cache. Its name is stored with every entry, so
+// switching codecs makes the entries written by the previous one misses instead of garbage.
+type Codec interface {
+	Name() string
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte, value interface{}) error
+}
+
+// NewCodec returns the codec called name: json, readable in redis-cli and the admin API, or
+// bson, a compact binary encoding. An empty name selects json.
+func NewCodec(name string) (Codec, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", CodecJSON:
+		return jsonCodec{}, nil
+	case CodecBSON:
+		return bsonCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache codec %q", name)
+	}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return CodecJSON }
+
+func (jsonCodec) Marshal(value interface{}) ([]byte, error) { return json.Marshal(value) }
+
+func (jsonCodec) Unmarshal(data []byte, value interface{}) error { return json.Unmarshal(data, value) }
+
+// bsonCodec relies on the BSON encoding the value objects already implement for Mongo. Times
+// are kept to the millisecond, as Mongo keeps them.
+type bsonCodec struct{}
+
+func (bsonCodec) Name() string { return CodecBSON }
+
+func (bsonCodec) Marshal(value interface{}) ([]byte, error) { return bson.Marshal(value) }
+
+func (bsonCodec) Unmarshal(data []byte, value interface{}) error { return bson.Unmarshal(data, value) }
+
+// encodeEntry writes the entry as a header line followed by the encoded value:
+//
+//	kc1 <codec> <schema> <expires, unix ms> <cost, µs> <v|m>\n<value>
+//
+// where m marks a remembered absence, which has no value.
+func encodeEntry(entry *cacheEntry) []byte {
+	flag := "v"
+	if entry.Missing {
+		flag = "m"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s %s %d %d %s\n", entryFormat, entry.Codec, entry.Schema, entry.Expires.UnixMilli(), entry.Cost.Microseconds(), flag)
+	b.Write(entry.Value)
+	return b.Bytes()
+}
+
+var errUnversionedEntry = errors.New("unversioned entry")
+
+func decodeEntryHeader(data []byte) (*cacheEntry, error) {
+	header, value, found := bytes.Cut(data, []byte("\n"))
+	fields := strings.Fields(string(header))
+	if !found || len(fields) != 6 || fields[0] != entryFormat {
+		return nil, errUnversionedEntry
+	}
+
+	expires, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed expiry: %w", err)
+	}
+	cost, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cost: %w", err)
+	}
+
+	entry := &cacheEntry{
+		Codec:   fields[1],
+		Schema:  fields[2],
+		Expires: time.UnixMilli(expires),
+		Cost:    time.Duration(cost) * time.Microsecond,
+		Missing: fields[5] == "m",
+	}
+	if !entry.Missing {
+		if len(value) == 0 {
+			return nil, errors.New("entry without a value")
+		}
+		entry.Value = value
+	}
+	return entry, nil
+}
+
+var schemas sync.Map
+
+// schemaOf fingerprints the shape of the cached type: exported fields with their names, tags and
+// types, recursively. A deploy that changes an entity changes the fingerprint, and the entries
+// written before it are recomputed instead of decoded into the wrong shape. Types that encode
+// themselves, like time.Time and the value objects, count by name only.
+func schemaOf(t reflect.Type) string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "nil"
+	}
+	if schema, ok := schemas.Load(t); ok {
+		return schema.(string)
+	}
+
+	var b strings.Builder
+	describeType(&b, t, make(map[reflect.Type]bool))
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(b.String()))
+	schema := strconv.FormatUint(hash.Sum64(), 16)
+
+	schemas.Store(t, schema)
+	return schema
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	bsonMarshalerType = reflect.TypeOf((*bsoncodec.ValueMarshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*interface{ MarshalText() ([]byte, error) })(nil)).Elem()
+)
+
+func describeType(b *strings.Builder, t reflect.Type, seen map[reflect.Type]bool) {
+	if t.Name() != "" && (implements(t, jsonMarshalerType) || implements(t, bsonMarshalerType) || implements(t, textMarshalerType)) {
+		b.WriteString(t.String())
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		b.WriteString("*")
+		describeType(b, t.Elem(), seen)
+	case reflect.Slice:
+		b.WriteString("[]")
+		describeType(b, t.Elem(), seen)
+	case reflect.Array:
+		fmt.Fprintf(b, "[%d]", t.Len())
+		describeType(b, t.Elem(), seen)
+	case reflect.Map:
+		b.WriteString("map[")
+		describeType(b, t.Key(), seen)
+		b.WriteString("]")
+		describeType(b, t.Elem(), seen)
+	case reflect.Struct:
+		if seen[t] {
+			b.WriteString(t.String())
+			return
+		}
+		seen[t] = true
+		b.WriteString("struct{")
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fmt.Fprintf(b, "%s %q %q ", field.Name, field.Tag.Get("json"), field.Tag.Get("bson"))
+			describeType(b, field.Type, seen)
+			b.WriteString(";")
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString(t.String())
+		b.WriteString("/")
+		b.WriteString(t.Kind().String())
+	}
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -0,0 +1,102 @@
+package rediscache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/repositories/repositorytest"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	"katseye/internal/infrastructure/persistence/memory"
+)
+
+// The conformance suites run once more with the binary codec, since the entities must survive
+// its encoding as they do JSON's.
+func TestRepositoryConformance_BSONCodec(t *testing.T) {
+	options := testOptions
+	options.Codec = bsonCodec{}
+
+	t.Run("products", func(t *testing.T) {
+		repositorytest.ProductRepository(t, func(t *testing.T) repositories.ProductRepository {
+			return NewProductRepository(newTestClient(t), options, memory.NewProductRepository())
+		})
+	})
+	t.Run("partners", func(t *testing.T) {
+		repositorytest.PartnerRepository(t, func(t *testing.T) repositories.PartnerRepository {
+			return NewPartnerRepository(newTestClient(t), options, memory.NewPartnerRepository())
+		})
+	})
+	t.Run("addresses", func(t *testing.T) {
+		repositorytest.AddressRepository(t, func(t *testing.T) repositories.AddressRepository {
+			return NewAddressRepository(newTestClient(t), options, memory.NewAddressRepository())
+		})
+	})
+	t.Run("consumers", func(t *testing.T) {
+		repositorytest.ConsumerRepository(t, func(t *testing.T) repositories.ConsumerRepository {
+			cached := NewConsumerRepository(newTestClient(t), options, memory.NewConsumerRepository())
+			return fieldencryption.NewConsumerRepository(newTestCipher(t), newTestPolicy(t), cached)
+		})
+	})
+	t.Run("users", func(t *testing.T) {
+		repositorytest.UserRepository(t, func(t *testing.T) repositories.UserRepository {
+			return NewUserRepository(newTestClient(t), options, memory.NewUserRepository())
+		})
+	})
+}
+
+// probeV2 is probe after a deploy that added a field.
+type probeV2 struct {
+	Version int64
+	Label   string
+}
+
+func TestLoad_RecomputesEntriesOfAnotherVersion(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	var calls atomic.Int64
+	old := newLoader(client, Options{TTL: time.Minute})
+	if _, _, err := load(ctx, old, "probes:id:1", countingFetch(&calls, nil, true), nil); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	fetchV2 := func(context.Context) (*probeV2, error) {
+		return &probeV2{Version: calls.Add(1), Label: "v2"}, nil
+	}
+	current := newLoader(client, Options{TTL: time.Minute})
+	value, cached, err := load(ctx, current, "probes:id:1", fetchV2, nil)
+	if err != nil || cached || value.Label != "v2" {
+		t.Fatalf("expected an entry of the old schema to be recomputed, got (%+v, %v, %v)", value, cached, err)
+	}
+	if value, cached, _ := load(ctx, current, "probes:id:1", fetchV2, nil); !cached || value.Version != 2 {
+		t.Fatalf("expected the recomputed entry to be served, got (%+v, %v)", value, cached)
+	}
+
+	binary := newLoader(client, Options{TTL: time.Minute, Codec: bsonCodec{}})
+	value, cached, err = load(ctx, binary, "probes:id:1", fetchV2, nil)
+	if err != nil || cached || value.Version != 3 {
+		t.Fatalf("expected an entry of another codec to be recomputed, got (%+v, %v, %v)", value, cached, err)
+	}
+
+	// Entries from before the versioned header are dropped the same way.
+	if err := client.Set(ctx, "probes:id:2", `{"value":{"Version":9},"expires":"2099-01-01T00:00:00Z"}`, time.Minute).Err(); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if value, cached, _ := load(ctx, binary, "probes:id:2", fetchV2, nil); cached || value.Version == 9 {
+		t.Fatalf("expected an unversioned entry to be recomputed, got (%+v, %v)", value, cached)
+	}
+}
+
+func TestNewCodec(t *testing.T) {
+	for name, want := range map[string]string{"": CodecJSON, "json": CodecJSON, " BSON ": CodecBSON} {
+		codec, err := NewCodec(name)
+		if err != nil || codec.Name() != want {
+			t.Fatalf("NewCodec(%q) = (%v, %v), want %s", name, codec, err, want)
+		}
+	}
+	if _, err := NewCodec("msgpack"); err == nil {
+		t.Fatalf("expected an unknown codec to be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+package rediscache
+
+import (
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/repositories/repositorytest"
+	"katseye/internal/domain/security"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	"katseye/internal/infrastructure/persistence/memory"
+)
+
+// The decorators run over the in-memory repositories, so any difference from the memory
+// conformance results comes from the cache.
+
+// testOptions turns on every read-path feature so the suites cover negative entries and locking.
+var testOptions = Options{TTL: time.Minute, NegativeTTL: 30 * time.Second, LockTTL: time.Second}
+
+func newTestClient(t *testing.T) *goredis.Client {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// withLocalCache adds an in-process tier to testOptions for the resources served from it in
+// production.
+func withLocalCache(t *testing.T, client *goredis.Client) Options {
+	t.Helper()
+
+	options := testOptions
+	options.Local = NewLocalCache(client, LocalLimits{Size: 100, TTL: time.Minute}, nil)
+	t.Cleanup(options.Local.Close)
+	return options
+}
+
+func TestProductRepositoryConformance(t *testing.T) {
+	repositorytest.ProductRepository(t, func(t *testing.T) repositories.ProductRepository {
+		client := newTestClient(t)
+		return NewProductRepository(client, withLocalCache(t, client), memory.NewProductRepository())
+	})
+}
+
+func TestPartnerRepositoryConformance(t *testing.T) {
+	repositorytest.PartnerRepository(t, func(t *testing.T) repositories.PartnerRepository {
+		client := newTestClient(t)
+		return NewPartnerRepository(client, withLocalCache(t, client), memory.NewPartnerRepository())
+	})
+}
+
+func TestAddressRepositoryConformance(t *testing.T) {
+	repositorytest.AddressRepository(t, func(t *testing.T) repositories.AddressRepository {
+		return NewAddressRepository(newTestClient(t), testOptions, memory.NewAddressRepository())
+	})
+}
+
+// Consumers are only cached once sealed, so the decorator is exercised in its production position.
+func TestConsumerRepositoryConformance(t *testing.T) {
+	repositorytest.ConsumerRepository(t, func(t *testing.T) repositories.ConsumerRepository {
+		cached := NewConsumerRepository(newTestClient(t), testOptions, memory.NewConsumerRepository())
+		return fieldencryption.NewConsumerRepository(newTestCipher(t), newTestPolicy(t), cached)
+	})
+}
+
+func TestUserRepositoryConformance(t *testing.T) {
+	repositorytest.UserRepository(t, func(t *testing.T) repositories.UserRepository {
+		client := newTestClient(t)
+		return NewUserRepository(client, withLocalCache(t, client), memory.NewUserRepository())
+	})
+}
+
+func TestTokenStoreConformance(t *testing.T) {
+	repositorytest.TokenStore(t, func(t *testing.T) security.TokenStore {
+		return NewTokenStore(newTestClient(t), TokenStoreOptions{})
+	})
+}
@@ -0,0 +1,233 @@
+package rediscache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+)
+
+type consumerRepository struct {
+	repo   repositories.ConsumerRepository
+	client goredis.UniversalClient
+	cache  *loader
+}
+
+// NewConsumerRepository caches consumers as they cross the persistence boundary. It belongs
+// below field encryption: a consumer still holding a sensitive field in plaintext is never
+// written to Redis, and neither is a list page containing one.
+func NewConsumerRepository(client goredis.UniversalClient, options Options, repo repositories.ConsumerRepository) repositories.ConsumerRepository {
+	if client == nil || repo == nil {
+		return repo
+	}
+
+	return &consumerRepository{
+		repo:   repo,
+		client: client,
+		cache:  newLoader(client, options),
+	}
+}
+
+func (r *consumerRepository) GetConsumerByID(ctx context.Context, id primitive.ObjectID) (*entities.Consumer, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.GetConsumerByID(ctx, id)
+	}
+
+	consumer, cached, err := load(ctx, r.cache, buildIDKey("consumers", id.Hex()), func(ctx context.Context) (*entities.Consumer, error) {
+		return r.repo.GetConsumerByID(ctx, id)
+	}, func(consumer *entities.Consumer) bool {
+		return !fieldencryption.HasPlaintext(consumer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logLookup("consumers", id, cached, consumer != nil)
+	return consumer, nil
+}
+
+// GetConsumerByDocument keeps only a pointer from the hashed document to the consumer ID. The
+// pointer is trusted only while the entry it leads to still carries the same document, so
+// updates and deletes need not know which documents pointed at the consumer.
+func (r *consumerRepository) GetConsumerByDocument(ctx context.Context, documentNumber string) (*entities.Consumer, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	if documentNumber == "" || repositories.InUnitOfWork(ctx) {
+		return r.repo.GetConsumerByDocument(ctx, documentNumber)
+	}
+
+	key := buildDocumentKey(documentNumber)
+	if value, err := r.client.Get(ctx, key).Result(); err == nil {
+		id, parseErr := primitive.ObjectIDFromHex(value)
+		if parseErr == nil {
+			if cached := peek[entities.Consumer](ctx, r.cache, buildIDKey("consumers", id.Hex())); cached != nil && consumerDocument(cached) == documentNumber {
+				r.cache.options.Metrics.recordKey(key, outcomeHit)
+				log.Printf("cache: hit resource=consumers operation=get_by_document id=%s source=redis", id.Hex())
+				return cached, nil
+			}
+		}
+		_ = r.client.Del(ctx, key).Err()
+	}
+	r.cache.options.Metrics.recordKey(key, outcomeMiss)
+
+	consumer, err := r.repo.GetConsumerByDocument(ctx, documentNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if consumer == nil {
+		log.Printf("cache: miss resource=consumers operation=get_by_document source=mongo result=empty")
+		return nil, nil
+	}
+
+	log.Printf("cache: miss resource=consumers operation=get_by_document id=%s source=mongo", consumer.ID.Hex())
+	if r.saveConsumer(ctx, consumer) {
+		_ = r.client.Set(ctx, key, consumer.ID.Hex(), r.cache.ttl(key)).Err()
+	}
+
+	return consumer, nil
+}
+
+func (r *consumerRepository) CreateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	if err := r.repo.CreateConsumer(ctx, consumer); err != nil {
+		return err
+	}
+
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		// A consumer that cannot be cached must still replace a remembered absence.
+		if !r.saveConsumer(ctx, consumer) && consumer != nil {
+			r.cache.invalidate(ctx, buildIDKey("consumers", consumer.ID.Hex()))
+		}
+		r.cache.invalidateLists(ctx, "consumers")
+	})
+
+	return nil
+}
+
+func (r *consumerRepository) UpdateConsumer(ctx context.Context, consumer *entities.Consumer) error {
+	if err := r.repo.UpdateConsumer(ctx, consumer); err != nil {
+		return err
+	}
+
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		if consumer != nil && !consumer.ID.IsZero() {
+			r.cache.invalidate(ctx, buildIDKey("consumers", consumer.ID.Hex()))
+		}
+
+		r.cache.invalidateLists(ctx, "consumers")
+	})
+
+	return nil
+}
+
+func (r *consumerRepository) DeleteConsumer(ctx context.Context, id primitive.ObjectID) error {
+	if err := r.repo.DeleteConsumer(ctx, id); err != nil {
+		return err
+	}
+
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		r.cache.invalidate(ctx, buildIDKey("consumers", id.Hex()))
+		r.cache.invalidateLists(ctx, "consumers")
+	})
+
+	return nil
+}
+
+func (r *consumerRepository) ListConsumers(ctx context.Context, query repositories.ConsumerQuery) ([]*entities.Consumer, repositories.PageInfo, error) {
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.ListConsumers(ctx, query)
+	}
+
+	filter := map[string]interface{}{}
+	if query.Type != "" {
+		filter["type"] = query.Type
+	}
+	key, keyErr := r.cache.listKey(ctx, "consumers", withPagination(filter, query.Page))
+	if keyErr != nil {
+		return r.repo.ListConsumers(ctx, query)
+	}
+
+	list, cached, err := load(ctx, r.cache, key, func(ctx context.Context) (*cachedPage[*entities.Consumer], error) {
+		items, page, err := r.repo.ListConsumers(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return &cachedPage[*entities.Consumer]{Items: items, Page: page}, nil
+	}, func(list *cachedPage[*entities.Consumer]) bool {
+		return list.Items != nil && !anyHasPlaintext(list.Items)
+	})
+	if err != nil {
+		return nil, repositories.PageInfo{}, err
+	}
+
+	logListLookup("consumers", key, cached, len(list.Items))
+	return list.Items, list.Page, nil
+}
+
+func (r *consumerRepository) Resource() string {
+	return "consumers"
+}
+
+// Warm caches every sealed consumer under its ID; consumers still exposing plaintext are skipped
+// as they are on reads.
+func (r *consumerRepository) Warm(ctx context.Context) (int, error) {
+	return warm(ctx, r.cache, "consumers", func(ctx context.Context, page repositories.Pagination) ([]*entities.Consumer, repositories.PageInfo, error) {
+		return r.repo.ListConsumers(ctx, repositories.ConsumerQuery{Page: page})
+	}, func(consumer *entities.Consumer) primitive.ObjectID {
+		return consumer.ID
+	}, func(consumer *entities.Consumer) bool {
+		return !consumer.ID.IsZero() && !fieldencryption.HasPlaintext(consumer)
+	})
+}
+
+// saveConsumer reports whether the consumer was cached; consumers exposing plaintext are skipped.
+func (r *consumerRepository) saveConsumer(ctx context.Context, consumer *entities.Consumer) bool {
+	if consumer == nil || consumer.ID.IsZero() || fieldencryption.HasPlaintext(consumer) {
+		return false
+	}
+
+	return r.cache.store(ctx, buildIDKey("consumers", consumer.ID.Hex()), consumer) == nil
+}
+
+func anyHasPlaintext(consumers []*entities.Consumer) bool {
+	for _, consumer := range consumers {
+		if fieldencryption.HasPlaintext(consumer) {
+			return true
+		}
+	}
+	return false
+}
+
+// consumerDocument returns the value GetConsumerByDocument matches on: the sealed token when the
+// document is encrypted, the stored document number otherwise.
+func consumerDocument(consumer *entities.Consumer) string {
+	if sealed := consumer.SealedFields[fieldencryption.FieldDocumentNumber]; sealed != "" {
+		return sealed
+	}
+
+	switch {
+	case consumer.PersonalData.Individual != nil:
+		return consumer.PersonalData.Individual.DocumentNumber
+	case consumer.PersonalData.Business != nil:
+		return consumer.PersonalData.Business.DocumentNumber
+	}
+	return ""
+}
+
+// buildDocumentKey hashes the document so neither plaintext nor ciphertext ends up in key names.
+func buildDocumentKey(documentNumber string) string {
+	sum := sha256.Sum256([]byte(documentNumber))
+	return "consumers:document:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,168 @@
+package rediscache
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/crypto/envelope"
+	fieldencryption "katseye/internal/infrastructure/persistence/encryption"
+	"katseye/internal/infrastructure/persistence/memory"
+)
+
+type testKeyStore struct {
+	keys []envelope.WrappedKey
+}
+
+func (s *testKeyStore) LoadKeys(context.Context) ([]envelope.WrappedKey, error) {
+	return s.keys, nil
+}
+
+func (s *testKeyStore) SaveKey(_ context.Context, key envelope.WrappedKey) error {
+	for i := range s.keys {
+		if s.keys[i].ID == key.ID {
+			s.keys[i] = key
+			return nil
+		}
+	}
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func (s *testKeyStore) InsertKey(ctx context.Context, key envelope.WrappedKey) error {
+	for _, existing := range s.keys {
+		if existing.ID == key.ID {
+			return envelope.ErrKeyExists
+		}
+	}
+	return s.SaveKey(ctx, key)
+}
+
+func newTestCipher(t *testing.T) *envelope.KeyRing {
+	t.Helper()
+
+	master := make([]byte, 32)
+	if _, err := rand.Read(master); err != nil {
+		t.Fatalf("generating master key: %v", err)
+	}
+
+	ring, err := envelope.NewKeyRing(context.Background(), master, &testKeyStore{})
+	if err != nil {
+		t.Fatalf("NewKeyRing returned error: %v", err)
+	}
+	return ring
+}
+
+func newTestPolicy(t *testing.T) fieldencryption.Policy {
+	t.Helper()
+
+	policy, err := fieldencryption.NewPolicy(
+		[]string{"document_number", "birth_date", "monthly_income", "annual_revenue", "estimated_income", "email", "phone", "secondary_phone"},
+		[]string{"document_number"},
+	)
+	if err != nil {
+		t.Fatalf("NewPolicy returned error: %v", err)
+	}
+	return policy
+}
+
+func newSensitiveConsumer() *entities.Consumer {
+	return &entities.Consumer{
+		ID:   primitive.NewObjectID(),
+		Type: valueobjects.ConsumerTypeIndividual,
+		PersonalData: entities.ConsumerPersonalData{
+			Individual: &entities.ConsumerIndividualData{FullName: "Maria Silva", DocumentNumber: "52998224725"},
+		},
+		Contact: entities.ConsumerContactInformation{Email: "maria@example.com"},
+	}
+}
+
+func TestConsumerRepository_CachesOnlySealedConsumers(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	base := memory.NewConsumerRepository()
+	cached := NewConsumerRepository(client, testOptions, base)
+	sealed := fieldencryption.NewConsumerRepository(newTestCipher(t), newTestPolicy(t), cached)
+
+	consumer := newSensitiveConsumer()
+	if err := sealed.CreateConsumer(ctx, consumer); err != nil {
+		t.Fatalf("CreateConsumer returned error: %v", err)
+	}
+	if _, err := sealed.GetConsumerByDocument(ctx, "52998224725"); err != nil {
+		t.Fatalf("GetConsumerByDocument returned error: %v", err)
+	}
+
+	payload, err := client.Get(ctx, buildIDKey("consumers", consumer.ID.Hex())).Result()
+	if err != nil {
+		t.Fatalf("expected the sealed consumer to be cached: %v", err)
+	}
+	for _, plaintext := range []string{"52998224725", "maria@example.com"} {
+		if strings.Contains(payload, plaintext) {
+			t.Fatalf("cached payload exposes %q: %s", plaintext, payload)
+		}
+	}
+
+	keys, err := client.Keys(ctx, "consumers:document:*").Result()
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("expected one document pointer, got %v (%v)", keys, err)
+	}
+	if strings.Contains(keys[0], "52998224725") {
+		t.Fatalf("document pointer key exposes the document: %s", keys[0])
+	}
+
+	// Removing the consumer behind the cache's back shows the next lookup is served by Redis.
+	if err := base.DeleteConsumer(ctx, consumer.ID); err != nil {
+		t.Fatalf("DeleteConsumer returned error: %v", err)
+	}
+	hit, err := sealed.GetConsumerByDocument(ctx, "52998224725")
+	if err != nil || hit == nil || hit.ID != consumer.ID {
+		t.Fatalf("expected the cached consumer, got (%+v, %v)", hit, err)
+	}
+	if hit.PersonalData.Individual.DocumentNumber != "52998224725" || hit.Contact.Email != "maria@example.com" {
+		t.Fatalf("expected the cached consumer to be opened, got %+v", hit)
+	}
+
+	if err := cached.DeleteConsumer(ctx, consumer.ID); err != nil {
+		t.Fatalf("DeleteConsumer returned error: %v", err)
+	}
+	if gone, err := sealed.GetConsumerByDocument(ctx, "52998224725"); err != nil || gone != nil {
+		t.Fatalf("expected the deleted consumer to be gone, got (%+v, %v)", gone, err)
+	}
+}
+
+func TestConsumerRepository_SkipsPlaintextConsumers(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	repo := NewConsumerRepository(client, testOptions, memory.NewConsumerRepository())
+
+	consumer := newSensitiveConsumer()
+	if err := repo.CreateConsumer(ctx, consumer); err != nil {
+		t.Fatalf("CreateConsumer returned error: %v", err)
+	}
+	if stored, err := repo.GetConsumerByID(ctx, consumer.ID); err != nil || stored == nil {
+		t.Fatalf("GetConsumerByID returned (%v, %v)", stored, err)
+	}
+	if stored, err := repo.GetConsumerByDocument(ctx, "52998224725"); err != nil || stored == nil {
+		t.Fatalf("GetConsumerByDocument returned (%v, %v)", stored, err)
+	}
+
+	if listed, _, err := repo.ListConsumers(ctx, repositories.ConsumerQuery{}); err != nil || len(listed) != 1 {
+		t.Fatalf("ListConsumers returned (%v, %v)", listed, err)
+	}
+
+	// The list generation counter is the only consumer key allowed to exist.
+	for _, pattern := range []string{"consumers:id:*", "consumers:document:*", "consumers:list:*:*"} {
+		keys, err := client.Keys(ctx, pattern).Result()
+		if err != nil {
+			t.Fatalf("listing keys: %v", err)
+		}
+		if len(keys) != 0 {
+			t.Fatalf("expected no consumer entries for plaintext data, got %v", keys)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package rediscache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"katseye/internal/domain/creditbureau"
+	"katseye/internal/domain/security"
+)
+
+type creditBureauProvider struct {
+	provider  creditbureau.Provider
+	client    goredis.UniversalClient
+	keySecret []byte
+	cipher    security.FieldCipher
+}
+
+// NewCreditBureauProvider caches bureau answers for as long as the bureau allows, taken from
+// the TTL of each report, so refreshes inside that window do not pay for a new query. Cached
+// reports keep their original retrieval time. Keys are derived from the document with
+// keySecret, and reports, which carry income estimates and negative records, are sealed with
+// cipher so the cache only holds ciphertext, as it does for consumers. Without a secret or a
+// cipher nothing is cached.
+func NewCreditBureauProvider(client goredis.UniversalClient, provider creditbureau.Provider, keySecret []byte, cipher security.FieldCipher) creditbureau.Provider {
+	if client == nil || provider == nil || len(keySecret) == 0 || cipher == nil {
+		return provider
+	}
+
+	return &creditBureauProvider{
+		provider:  provider,
+		client:    client,
+		keySecret: keySecret,
+		cipher:    cipher,
+	}
+}
+
+func (p *creditBureauProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *creditBureauProvider) Score(ctx context.Context, document string) (creditbureau.ScoreReport, error) {
+	return cachedBureauReport(ctx, p, "score", document, func(report creditbureau.ScoreReport) time.Duration {
+		return report.TTL
+	}, p.provider.Score)
+}
+
+func (p *creditBureauProvider) NegativeRecords(ctx context.Context, document string) (creditbureau.NegativeRecordsReport, error) {
+	return cachedBureauReport(ctx, p, "negative_records", document, func(report creditbureau.NegativeRecordsReport) time.Duration {
+		return report.TTL
+	}, p.provider.NegativeRecords)
+}
+
+func (p *creditBureauProvider) EstimateIncome(ctx context.Context, document string) (creditbureau.IncomeEstimate, error) {
+	return cachedBureauReport(ctx, p, "income", document, func(report creditbureau.IncomeEstimate) time.Duration {
+		return report.TTL
+	}, p.provider.EstimateIncome)
+}
+
+func cachedBureauReport[T any](
+	ctx context.Context,
+	p *creditBureauProvider,
+	operation, document string,
+	ttlOf func(T) time.Duration,
+	fetch func(context.Context, string) (T, error),
+) (T, error) {
+	key := creditBureauKey(p.keySecret, p.provider.Name(), operation, document)
+	// The field binds the ciphertext to the operation, so one report cannot be read as another.
+	field := "credit_bureau." + operation
+	if data, err := p.client.Get(ctx, key).Result(); err == nil {
+		var cached T
+		if openErr := p.open(field, data, &cached); openErr == nil {
+			log.Printf("cache: hit resource=credit_bureau operation=%s source=redis", operation)
+			return cached, nil
+		} else {
+			log.Printf("cache: stale resource=credit_bureau operation=%s error=%v", operation, openErr)
+			_ = p.client.Del(ctx, key).Err()
+		}
+	}
+
+	report, err := fetch(ctx, document)
+	if err != nil {
+		return report, err
+	}
+
+	log.Printf("cache: miss resource=credit_bureau operation=%s source=%s", operation, p.provider.Name())
+	if ttl := ttlOf(report); ttl > 0 {
+		if sealed, sealErr := p.seal(field, report); sealErr == nil {
+			_ = p.client.Set(ctx, key, sealed, ttl).Err()
+		} else {
+			log.Printf("cache: skip resource=credit_bureau operation=%s error=%v", operation, sealErr)
+		}
+	}
+
+	return report, nil
+}
+
+func (p *creditBureauProvider) seal(field string, report interface{}) (string, error) {
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	return p.cipher.Encrypt(field, payload, false)
+}
+
+func (p *creditBureauProvider) open(field, sealed string, report interface{}) error {
+	payload, err := p.cipher.Decrypt(field, sealed)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, report)
+}
+
+// creditBureauKey keys the document with an HMAC so CPFs and CNPJs never show up in Redis keys.
+// A plain hash would not do: the document space is small enough to hash every candidate.
+func creditBureauKey(secret []byte, provider, operation, document string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(document))
+	return fmt.Sprintf("credit_bureau:%s:%s:%s", provider, operation, hex.EncodeToString(mac.Sum(nil)))
+}
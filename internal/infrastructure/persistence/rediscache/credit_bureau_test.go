@@ -0,0 +1,147 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"katseye/internal/domain/creditbureau"
+	bureauproviders "katseye/internal/infrastructure/creditbureau"
+)
+
+type countingBureau struct {
+	creditbureau.Provider
+	scoreCalls int
+}
+
+func (b *countingBureau) Score(ctx context.Context, document string) (creditbureau.ScoreReport, error) {
+	b.scoreCalls++
+	return b.Provider.Score(ctx, document)
+}
+
+var testBureauKeySecret = []byte("credit-bureau-test-secret")
+
+// reversingCipher stands in for the key ring: it binds values to their field and hides the
+// plaintext well enough to tell whether anything readable reached Redis.
+type reversingCipher struct{}
+
+func (reversingCipher) Encrypt(field string, plaintext []byte, _ bool) (string, error) {
+	return "sealed:" + field + ":" + base64.StdEncoding.EncodeToString(reversed(plaintext)), nil
+}
+
+func (reversingCipher) Decrypt(field string, ciphertext string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(ciphertext, "sealed:"+field+":")
+	if !ok {
+		return nil, errors.New("ciphertext sealed for another field")
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return reversed(plaintext), nil
+}
+
+func (reversingCipher) SearchTokens(string, []byte) ([]string, error) {
+	return nil, nil
+}
+
+func reversed(value []byte) []byte {
+	out := make([]byte, len(value))
+	for i, b := range value {
+		out[len(value)-1-i] = b
+	}
+	return out
+}
+
+func TestCreditBureauProvider_CachesWithBureauTTL(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+
+	bureau := &countingBureau{Provider: bureauproviders.NewStubProvider(bureauproviders.StubConfig{Seed: "test", TTL: 2 * time.Hour})}
+	provider := NewCreditBureauProvider(client, bureau, testBureauKeySecret, reversingCipher{})
+
+	const document = "52998224725"
+	first, err := provider.Score(ctx, document)
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	second, err := provider.Score(ctx, document)
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+
+	if bureau.scoreCalls != 1 {
+		t.Fatalf("expected the bureau to be queried once, got %d", bureau.scoreCalls)
+	}
+	if second.Score != first.Score || !second.RetrievedAt.Equal(first.RetrievedAt) || second.Source != "stub" {
+		t.Fatalf("expected the cached report with its original attribution, got %+v", second)
+	}
+
+	key := creditBureauKey(testBureauKeySecret, "stub", "score", document)
+	if key == creditBureauKey([]byte("another-secret"), "stub", "score", document) {
+		t.Fatalf("expected the key to depend on the secret")
+	}
+	cached, err := server.Get(key)
+	if err != nil {
+		t.Fatalf("expected the report to be cached: %v", err)
+	}
+	if !strings.HasPrefix(cached, "sealed:credit_bureau.score:") || strings.Contains(cached, "stub") {
+		t.Fatalf("expected the cached report to be sealed, got %q", cached)
+	}
+	if ttl := server.TTL(key); ttl != 2*time.Hour {
+		t.Fatalf("expected the bureau TTL on the cached report, got %s", ttl)
+	}
+
+	server.FastForward(2 * time.Hour)
+	if _, err := provider.Score(ctx, document); err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	if bureau.scoreCalls != 2 {
+		t.Fatalf("expected the bureau to be queried again after the TTL, got %d calls", bureau.scoreCalls)
+	}
+}
+
+func TestCreditBureauProvider_DoesNotCacheNotFound(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+
+	const document = "11222333000181"
+	provider := NewCreditBureauProvider(client, bureauproviders.NewStubProvider(bureauproviders.StubConfig{
+		Profiles: map[string]bureauproviders.StubProfile{"11.222.333/0001-81": {NotFound: true}},
+	}), testBureauKeySecret, reversingCipher{})
+
+	if _, err := provider.EstimateIncome(ctx, document); err != creditbureau.ErrDocumentNotFound {
+		t.Fatalf("expected ErrDocumentNotFound, got %v", err)
+	}
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Fatalf("expected nothing cached, got %v", keys)
+	}
+}
+
+func TestCreditBureauProvider_DoesNotCacheWithoutCipher(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+
+	bureau := &countingBureau{Provider: bureauproviders.NewStubProvider(bureauproviders.StubConfig{Seed: "test", TTL: 2 * time.Hour})}
+	provider := NewCreditBureauProvider(client, bureau, testBureauKeySecret, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.Score(ctx, "52998224725"); err != nil {
+			t.Fatalf("Score returned error: %v", err)
+		}
+	}
+	if bureau.scoreCalls != 2 {
+		t.Fatalf("expected every call to reach the bureau, got %d calls", bureau.scoreCalls)
+	}
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Fatalf("expected nothing cached without a cipher, got %v", keys)
+	}
+}
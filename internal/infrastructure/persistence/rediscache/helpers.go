@@ -2,43 +2,50 @@ package rediscache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
 	"time"
 
 	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"katseye/internal/domain/repositories"
 )
 
-func mergeTTL(requested, fallback time.Duration) time.Duration {
-	if requested <= 0 {
-		return fallback
-	}
-	return requested
+// cachedPage stores a list result together with its pagination metadata.
+type cachedPage[T any] struct {
+	Items []T                   `json:"items"`
+	Page  repositories.PageInfo `json:"page"`
 }
 
-func deleteByPattern(ctx context.Context, client *goredis.Client, pattern string) error {
-	if client == nil {
-		return nil
+// withPagination adds the normalized page parameters to a list filter so every page gets its own key.
+func withPagination(filter map[string]interface{}, page repositories.Pagination) map[string]interface{} {
+	page = page.Normalize()
+	if filter == nil {
+		filter = make(map[string]interface{})
 	}
 
-	iter := client.Scan(ctx, 0, pattern, 0).Iterator()
-	for iter.Next(ctx) {
-		if err := client.Del(ctx, iter.Val()).Err(); err != nil {
-			return err
-		}
+	filter["sort"] = page.Sort.String()
+	filter["limit"] = page.Limit
+	if page.Cursor != "" {
+		filter["cursor"] = page.Cursor
 	}
 
-	if err := iter.Err(); err != nil {
-		return err
-	}
+	return filter
+}
 
-	return nil
+func mergeTTL(requested, fallback time.Duration) time.Duration {
+	if requested <= 0 {
+		return fallback
+	}
+	return requested
 }
 
-func buildListKey(resource string, filter map[string]interface{}) string {
+func buildListKey(resource string, generation int64, filter map[string]interface{}) string {
 	if len(filter) == 0 {
-		return fmt.Sprintf("%s:list:all", resource)
+		return fmt.Sprintf("%s:list:%d:all", resource, generation)
 	}
 
 	keys := make([]string, 0, len(filter))
@@ -52,14 +59,102 @@ func buildListKey(resource string, filter map[string]interface{}) string {
 		parts = append(parts, fmt.Sprintf("%s=%v", key, filter[key]))
 	}
 
-	return fmt.Sprintf("%s:list:%s", resource, strings.Join(parts, "&"))
+	return fmt.Sprintf("%s:list:%d:%s", resource, generation, strings.Join(parts, "&"))
+}
+
+func listGenerationKey(resource string) string {
+	return resource + ":list:generation"
+}
+
+// listGeneration reads the counter embedded in every list key of the resource. A missing
+// counter is seeded from the clock rather than zero, so one lost to eviction or a flush cannot
+// come back to a generation whose pages are still cached.
+func listGeneration(ctx context.Context, client goredis.UniversalClient, resource string) (int64, error) {
+	key := listGenerationKey(resource)
+
+	generation, err := client.Get(ctx, key).Int64()
+	if !errors.Is(err, goredis.Nil) {
+		return generation, err
+	}
+
+	if err := client.SetNX(ctx, key, time.Now().UnixNano(), 0).Err(); err != nil {
+		return 0, err
+	}
+	return client.Get(ctx, key).Int64()
 }
 
 func buildIDKey(resource, id string) string {
 	return fmt.Sprintf("%s:id:%s", resource, id)
 }
 
-func invalidateResourceLists(ctx context.Context, client *goredis.Client, resource string) error {
-	pattern := fmt.Sprintf("%s:list:*", resource)
-	return deleteByPattern(ctx, client, pattern)
+// invalidateResourceLists bumps the resource's list generation, orphaning every cached page in
+// one step; the orphaned pages expire with their TTL.
+func invalidateResourceLists(ctx context.Context, client goredis.UniversalClient, resource string) error {
+	if client == nil {
+		return nil
+	}
+
+	key := listGenerationKey(resource)
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.SetNX(ctx, key, time.Now().UnixNano(), 0)
+		pipe.Incr(ctx, key)
+		return nil
+	})
+	return err
+}
+
+// deleteKeys removes the keys with one DEL each, sent as a pipeline: in a cluster a single DEL
+// may only name keys of the same hash slot.
+func deleteKeys(ctx context.Context, client goredis.UniversalClient, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	deletions := make([]*goredis.IntCmd, len(keys))
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i, key := range keys {
+			deletions[i] = pipe.Del(ctx, key)
+		}
+		return nil
+	})
+
+	var deleted int64
+	for _, deletion := range deletions {
+		deleted += deletion.Val()
+	}
+	return deleted, err
+}
+
+// forEachShard runs fn on every master of a cluster, concurrently, or once on any other
+// client. Commands that walk or describe the keyspace, such as SCAN and INFO, only see the
+// node they run on.
+func forEachShard(ctx context.Context, client goredis.UniversalClient, fn func(context.Context, goredis.UniversalClient) error) error {
+	if cluster, ok := client.(*goredis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *goredis.Client) error {
+			return fn(ctx, node)
+		})
+	}
+	return fn(ctx, client)
+}
+
+func logLookup(resource string, id primitive.ObjectID, cached, found bool) {
+	outcome, source := "miss", "mongo"
+	if cached {
+		outcome, source = "hit", "redis"
+	}
+
+	if found {
+		log.Printf("cache: %s resource=%s operation=get id=%s source=%s", outcome, resource, id.Hex(), source)
+	} else {
+		log.Printf("cache: %s resource=%s operation=get id=%s source=%s result=empty", outcome, resource, id.Hex(), source)
+	}
+}
+
+func logListLookup(resource, key string, cached bool, count int) {
+	outcome, source := "miss", "mongo"
+	if cached {
+		outcome, source = "hit", "redis"
+	}
+
+	log.Printf("cache: %s resource=%s operation=list key=%s source=%s count=%d", outcome, resource, key, source, count)
 }
@@ -0,0 +1,75 @@
+package rediscache
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListKey_InvalidationOrphansPagesFilledConcurrently(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	l := newLoader(client, Options{})
+	filter := map[string]interface{}{"type": "bank"}
+
+	// A reader resolves its key, then a write commits and invalidates before the reader stores
+	// the page it read from the old data.
+	before, err := l.listKey(ctx, "partners", filter)
+	if err != nil {
+		t.Fatalf("listKey returned error: %v", err)
+	}
+	if err := invalidateResourceLists(ctx, client, "partners"); err != nil {
+		t.Fatalf("invalidateResourceLists returned error: %v", err)
+	}
+	if err := client.Set(ctx, before, "stale", 0).Err(); err != nil {
+		t.Fatalf("storing page: %v", err)
+	}
+
+	after, err := l.listKey(ctx, "partners", filter)
+	if err != nil {
+		t.Fatalf("listKey returned error: %v", err)
+	}
+	if after == before {
+		t.Fatalf("expected a new key after invalidation, got %s twice", after)
+	}
+	if exists, _ := client.Exists(ctx, after).Result(); exists != 0 {
+		t.Fatalf("expected the stale page to be unreachable under %s", after)
+	}
+
+	other, err := l.listKey(ctx, "products", filter)
+	if err != nil {
+		t.Fatalf("listKey returned error: %v", err)
+	}
+	if generation, _ := listGeneration(ctx, client, "products"); other != buildListKey("products", generation, filter) {
+		t.Fatalf("expected products to keep their own generation, got %s", other)
+	}
+}
+
+func TestListGeneration_LostCounterDoesNotRewind(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	first, err := listGeneration(ctx, client, "addresses")
+	if err != nil {
+		t.Fatalf("listGeneration returned error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := invalidateResourceLists(ctx, client, "addresses"); err != nil {
+			t.Fatalf("invalidateResourceLists returned error: %v", err)
+		}
+	}
+	bumped, _ := listGeneration(ctx, client, "addresses")
+	if bumped != first+3 {
+		t.Fatalf("expected generation %d, got %d", first+3, bumped)
+	}
+
+	if err := client.Del(ctx, listGenerationKey("addresses")).Err(); err != nil {
+		t.Fatalf("deleting counter: %v", err)
+	}
+	reseeded, err := listGeneration(ctx, client, "addresses")
+	if err != nil {
+		t.Fatalf("listGeneration returned error: %v", err)
+	}
+	if reseeded <= bumped {
+		t.Fatalf("expected the reseeded generation to move past %d, got %d", bumped, reseeded)
+	}
+}
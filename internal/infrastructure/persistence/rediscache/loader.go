@@ -0,0 +1,453 @@
+package rediscache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	mathrand "math/rand/v2"
+	"reflect"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	lockPollInterval = 25 * time.Millisecond
+	refreshTimeout   = 5 * time.Second
+)
+
+// Options tunes how the decorators fill and keep their entries.
+type Options struct {
+	// TTL is how long an entity or list page stays cached.
+	TTL time.Duration
+	// ListTTL, when set, replaces TTL for list pages.
+	ListTTL time.Duration
+	// Resources overrides TTL and ListTTL per resource.
+	Resources map[string]ResourceTTL
+	// Codec encodes the cached values; nil selects JSON.
+	Codec Codec
+	// NegativeTTL is how long a lookup that found nothing is remembered. Zero disables it.
+	NegativeTTL time.Duration
+	// LockTTL enables a Redis lock around recomputing a key, so a single instance queries the
+	// database while the others wait for its result. Zero keeps coalescing per process.
+	LockTTL time.Duration
+	// RefreshBeta scales probabilistic early refresh: the larger it is, the earlier hot entries
+	// are recomputed ahead of their expiry. Zero disables it.
+	RefreshBeta float64
+	// Local, when set, answers reads from process memory before going to Redis.
+	Local *LocalCache
+	// Metrics, when set, counts hits, misses, stale entries and Redis errors.
+	Metrics *Metrics
+}
+
+// ResourceTTL is how long one resource's entities (Get) and list pages (List) stay cached; zero
+// keeps the default.
+type ResourceTTL struct {
+	Get  time.Duration
+	List time.Duration
+}
+
+// cacheEntry is what the decorators store under a key. Missing marks a negative entry; Cost is
+// how long the value took to compute and drives early refresh. Codec and Schema tell whether
+// this build can decode Value.
+type cacheEntry struct {
+	Codec   string
+	Schema  string
+	Value   []byte
+	Missing bool
+	Cost    time.Duration
+	Expires time.Time
+}
+
+// localEntry keeps the raw payload next to the decoded entry so an early refresh started from
+// an in-process hit can still compare against what Redis holds.
+type localEntry struct {
+	entry *cacheEntry
+	raw   []byte
+}
+
+// computeFunc reads the backing repository and reports whether the result may be stored.
+type computeFunc func(ctx context.Context) (entry *cacheEntry, storable bool, err error)
+
+type loader struct {
+	client  goredis.UniversalClient
+	options Options
+	group   singleflight.Group
+}
+
+func newLoader(client goredis.UniversalClient, options Options) *loader {
+	options.TTL = mergeTTL(options.TTL, time.Minute)
+	if options.Codec == nil {
+		options.Codec = jsonCodec{}
+	}
+	return &loader{client: client, options: options}
+}
+
+// load returns the value cached under key, computing it with fetch on a miss. Concurrent misses
+// for a key share one fetch, and every caller decodes its own copy of the result. The boolean
+// reports whether the answer, including a remembered absence, came from Redis. Values rejected
+// by keep are returned but not stored.
+func load[T any](ctx context.Context, l *loader, key string, fetch func(context.Context) (*T, error), keep func(*T) bool) (*T, bool, error) {
+	compute := func(ctx context.Context) (*cacheEntry, bool, error) {
+		started := time.Now()
+		value, err := fetch(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if value == nil {
+			return &cacheEntry{Codec: l.options.Codec.Name(), Schema: schemaOf(reflect.TypeFor[T]()), Missing: true}, true, nil
+		}
+
+		entry, err := l.entry(value)
+		if err != nil {
+			return nil, false, err
+		}
+		entry.Cost = time.Since(started)
+		return entry, keep == nil || keep(value), nil
+	}
+
+	if entry, raw := l.read(ctx, key); entry != nil {
+		if l.refreshDue(entry, time.Now()) {
+			l.refresh(ctx, key, raw, compute)
+		}
+		if entry.Missing {
+			l.options.Metrics.recordKey(key, outcomeHit)
+			return nil, true, nil
+		}
+		value, err := decodeEntry[T](l.options.Codec, entry)
+		if err == nil {
+			l.options.Metrics.recordKey(key, outcomeHit)
+			return value, true, nil
+		}
+		l.evict(ctx, key, err.Error())
+	}
+	l.options.Metrics.recordKey(key, outcomeMiss)
+
+	result, err, _ := l.group.Do(key, func() (interface{}, error) {
+		return l.fill(context.WithoutCancel(ctx), key, compute)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	entry := result.(*cacheEntry)
+	if entry.Missing {
+		return nil, false, nil
+	}
+	value, err := decodeEntry[T](l.options.Codec, entry)
+	if err != nil {
+		// Reachable when another instance filled the key with an entry this build cannot decode,
+		// or when fetch returned something the codec cannot round-trip.
+		value, err := fetch(ctx)
+		return value, false, err
+	}
+	return value, false, nil
+}
+
+// peek returns the value cached under key without falling back to the repository.
+func peek[T any](ctx context.Context, l *loader, key string) *T {
+	entry, _ := l.read(ctx, key)
+	if entry == nil || entry.Missing {
+		return nil
+	}
+	value, _ := decodeEntry[T](l.options.Codec, entry)
+	return value
+}
+
+// decodeEntry refuses entries written by another codec or for another shape of T, so the
+// caller recomputes them.
+func decodeEntry[T any](codec Codec, entry *cacheEntry) (*T, error) {
+	if entry.Codec != codec.Name() {
+		return nil, fmt.Errorf("written by the %s codec", entry.Codec)
+	}
+	if schema := schemaOf(reflect.TypeFor[T]()); entry.Schema != schema {
+		return nil, fmt.Errorf("schema %s, expected %s", entry.Schema, schema)
+	}
+
+	var value T
+	if err := codec.Unmarshal(entry.Value, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// entry encodes value with the configured codec, tagged with its schema.
+func (l *loader) entry(value interface{}) (*cacheEntry, error) {
+	payload, err := l.options.Codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheEntry{Codec: l.options.Codec.Name(), Schema: schemaOf(reflect.TypeOf(value)), Value: payload}, nil
+}
+
+// store caches a value the caller just wrote. Other replicas drop their copy, which may be a
+// remembered absence.
+func (l *loader) store(ctx context.Context, key string, value interface{}) error {
+	entry, err := l.entry(value)
+	if err != nil {
+		return err
+	}
+
+	mark := l.options.Local.mark(key)
+	err = l.write(ctx, key, entry, mark)
+	l.options.Local.publish(ctx, key)
+	return err
+}
+
+// invalidate removes the keys from Redis and from every replica's local tier.
+func (l *loader) invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if _, err := deleteKeys(ctx, l.client, keys...); err != nil {
+		l.options.Metrics.recordKey(keys[0], outcomeError)
+	}
+	l.options.Local.invalidate(ctx, keys...)
+}
+
+// listKey resolves the key of a list page under the resource's current generation. Callers
+// resolve it before querying the backing store, so a page read from data older than a
+// concurrent invalidation lands under the superseded generation and is never served.
+func (l *loader) listKey(ctx context.Context, resource string, filter map[string]interface{}) (string, error) {
+	key := listGenerationKey(resource)
+	if generation, ok := l.options.Local.get(key); ok {
+		return buildListKey(resource, generation.(int64), filter), nil
+	}
+
+	mark := l.options.Local.mark(key)
+	generation, err := listGeneration(ctx, l.client, resource)
+	if err != nil {
+		l.options.Metrics.record(resource, "list", outcomeError)
+		return "", err
+	}
+	l.options.Local.set(key, generation, time.Time{}, mark)
+	return buildListKey(resource, generation, filter), nil
+}
+
+// invalidateLists orphans every cached page of the resource. The generation is bumped in Redis
+// before replicas drop their local copy of it, so none can pick the old one up again.
+func (l *loader) invalidateLists(ctx context.Context, resource string) {
+	if err := invalidateResourceLists(ctx, l.client, resource); err != nil {
+		l.options.Metrics.record(resource, "list", outcomeError)
+	}
+	l.options.Local.invalidate(ctx, listGenerationKey(resource))
+}
+
+func (l *loader) read(ctx context.Context, key string) (*cacheEntry, []byte) {
+	if cached, ok := l.options.Local.get(key); ok {
+		local := cached.(localEntry)
+		return local.entry, local.raw
+	}
+
+	mark := l.options.Local.mark(key)
+	data, err := l.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, goredis.Nil) {
+			l.options.Metrics.recordKey(key, outcomeError)
+		}
+		return nil, nil
+	}
+
+	entry, err := decodeEntryHeader(data)
+	if err != nil {
+		l.evict(ctx, key, err.Error())
+		return nil, nil
+	}
+
+	l.options.Local.set(key, localEntry{entry: entry, raw: data}, entry.Expires, mark)
+	return entry, data
+}
+
+func (l *loader) evict(ctx context.Context, key, reason string) {
+	log.Printf("cache: stale key=%s reason=%q", key, reason)
+	l.options.Metrics.recordKey(key, outcomeStale)
+	_ = l.client.Del(ctx, key).Err()
+	l.options.Local.drop(key)
+}
+
+// fill computes and stores key on behalf of every caller waiting on it. With the Redis lock
+// enabled, an instance that loses the lock waits for the winner's entry instead of querying the
+// database itself, and only computes on its own if the entry does not show up in time.
+func (l *loader) fill(ctx context.Context, key string, compute computeFunc) (*cacheEntry, error) {
+	mark := l.options.Local.mark(key)
+	unlock, owner := l.lock(ctx, key)
+	defer unlock()
+
+	if !owner {
+		if entry := l.await(ctx, key); entry != nil {
+			return entry, nil
+		}
+	}
+
+	entry, storable, err := compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if storable {
+		_ = l.write(ctx, key, entry, mark)
+	}
+	return entry, nil
+}
+
+// write stores the entry in Redis and, unless the key was invalidated since mark was taken, in
+// the local tier.
+func (l *loader) write(ctx context.Context, key string, entry *cacheEntry, mark uint64) error {
+	payload, ttl, ok := l.encode(key, entry)
+	if !ok {
+		return nil
+	}
+	if err := l.client.Set(ctx, key, payload, ttl).Err(); err != nil {
+		l.options.Metrics.recordKey(key, outcomeError)
+		return err
+	}
+	l.options.Local.set(key, localEntry{entry: entry, raw: payload}, entry.Expires, mark)
+	return nil
+}
+
+// encode stamps the entry's expiry and reports false for negative entries when they are disabled.
+func (l *loader) encode(key string, entry *cacheEntry) ([]byte, time.Duration, bool) {
+	ttl := l.ttl(key)
+	if entry.Missing {
+		ttl = l.options.NegativeTTL
+	}
+	if ttl <= 0 {
+		return nil, 0, false
+	}
+
+	entry.Expires = time.Now().Add(ttl)
+	return encodeEntry(entry), ttl, true
+}
+
+// ttl is how long the value under key stays cached, by its resource and whether it is a list
+// page.
+func (l *loader) ttl(key string) time.Duration {
+	resource, operation := describeKey(key)
+	override := l.options.Resources[resource]
+
+	if operation == "list" {
+		return mergeTTL(override.List, mergeTTL(l.options.ListTTL, l.options.TTL))
+	}
+	return mergeTTL(override.Get, l.options.TTL)
+}
+
+// refreshDue implements probabilistic early expiration: the closer the entry is to expiring and
+// the more expensive it was to compute, the likelier a reader is picked to recompute it.
+func (l *loader) refreshDue(entry *cacheEntry, now time.Time) bool {
+	if l.options.RefreshBeta <= 0 || entry.Missing || entry.Cost <= 0 {
+		return false
+	}
+
+	lead := time.Duration(float64(entry.Cost) * l.options.RefreshBeta * -math.Log(1-mathrand.Float64()))
+	return !now.Add(lead).Before(entry.Expires)
+}
+
+// replaceScript swaps the entry only if it is still the one the refresh started from, so a
+// refresh never resurrects an entry a write evicted in the meantime.
+var replaceScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] == "" then
+	return redis.call("DEL", KEYS[1])
+end
+return redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3]) and 1 or 0
+`)
+
+// refresh recomputes key in the background while the current entry keeps being served.
+func (l *loader) refresh(ctx context.Context, key string, current []byte, compute computeFunc) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		_, _, _ = l.group.Do(key+"|refresh", func() (interface{}, error) {
+			unlock, owner := l.lock(ctx, key)
+			defer unlock()
+			if !owner {
+				return nil, nil
+			}
+
+			mark := l.options.Local.mark(key)
+			entry, storable, err := compute(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			var payload []byte
+			var ttl time.Duration
+			if storable {
+				payload, ttl, _ = l.encode(key, entry)
+			}
+			replaced, err := replaceScript.Run(ctx, l.client, []string{key}, current, payload, ttl.Milliseconds()).Int()
+			if err != nil {
+				l.options.Metrics.recordKey(key, outcomeError)
+				return nil, err
+			}
+			if replaced == 0 {
+				return nil, nil
+			}
+			if payload == nil {
+				l.options.Local.drop(key)
+			} else {
+				l.options.Local.set(key, localEntry{entry: entry, raw: payload}, entry.Expires, mark)
+			}
+			return nil, nil
+		})
+	}()
+}
+
+var unlockScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// lock takes the cross-instance lock for key when it is enabled. Without it every caller owns
+// the key, which leaves coalescing to the per-process singleflight group.
+func (l *loader) lock(ctx context.Context, key string) (func(), bool) {
+	if l.options.LockTTL <= 0 {
+		return func() {}, true
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return func() {}, true
+	}
+	value := hex.EncodeToString(token)
+	lockKey := key + ":lock"
+
+	acquired, err := l.client.SetNX(ctx, lockKey, value, l.options.LockTTL).Result()
+	if err != nil {
+		// Redis trouble must not keep the database from answering.
+		return func() {}, true
+	}
+	if !acquired {
+		return func() {}, false
+	}
+
+	return func() {
+		_ = unlockScript.Run(context.WithoutCancel(ctx), l.client, []string{lockKey}, value).Err()
+	}, true
+}
+
+// await polls for the entry another instance is computing, for at most the lock TTL.
+func (l *loader) await(ctx context.Context, key string) *cacheEntry {
+	deadline := time.Now().Add(l.options.LockTTL)
+	for time.Now().Before(deadline) {
+		if entry, _ := l.read(ctx, key); entry != nil {
+			return entry
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(lockPollInterval):
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package rediscache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type probe struct {
+	Version int64
+}
+
+// countingFetch returns a fetch that counts its calls and blocks until release is closed.
+func countingFetch(calls *atomic.Int64, release <-chan struct{}, found bool) func(context.Context) (*probe, error) {
+	return func(context.Context) (*probe, error) {
+		version := calls.Add(1)
+		if release != nil {
+			<-release
+		}
+		if !found {
+			return nil, nil
+		}
+		return &probe{Version: version}, nil
+	}
+}
+
+func TestLoad_CoalescesConcurrentMisses(t *testing.T) {
+	ctx := context.Background()
+	l := newLoader(newTestClient(t), Options{TTL: time.Minute})
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	fetch := countingFetch(&calls, release, true)
+
+	var wg sync.WaitGroup
+	results := make([]*probe, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, _ = load(ctx, l, "probes:id:1", fetch, nil)
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected a single fetch, got %d", got)
+	}
+	for i, result := range results {
+		if result == nil || result.Version != 1 {
+			t.Fatalf("caller %d got %+v", i, result)
+		}
+		if i > 0 && result == results[0] {
+			t.Fatalf("callers must not share the decoded value")
+		}
+	}
+}
+
+func TestLoad_RemembersMissingKeys(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	var calls atomic.Int64
+	fetch := countingFetch(&calls, nil, false)
+
+	l := newLoader(client, Options{TTL: time.Minute, NegativeTTL: time.Second})
+	for i := 0; i < 3; i++ {
+		value, cached, err := load(ctx, l, "probes:id:gone", fetch, nil)
+		if err != nil || value != nil {
+			t.Fatalf("expected (nil, nil), got (%+v, %v)", value, err)
+		}
+		if cached != (i > 0) {
+			t.Fatalf("lookup %d: expected cached=%v", i, i > 0)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected one fetch for a remembered absence, got %d", got)
+	}
+	if ttl := client.PTTL(ctx, "probes:id:gone").Val(); ttl <= 0 || ttl > time.Second {
+		t.Fatalf("expected the negative entry to use the negative TTL, got %s", ttl)
+	}
+
+	disabled := newLoader(client, Options{TTL: time.Minute})
+	calls.Store(0)
+	for i := 0; i < 2; i++ {
+		_, _, _ = load(ctx, disabled, "probes:id:other", fetch, nil)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected negative caching to be off without a negative TTL, got %d fetches", got)
+	}
+}
+
+func TestLoad_LockLetsOneInstanceFill(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	options := Options{TTL: time.Minute, LockTTL: 2 * time.Second}
+
+	// Two loaders share Redis but not a singleflight group, like two API instances.
+	first, second := newLoader(client, options), newLoader(client, options)
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	fetch := countingFetch(&calls, release, true)
+
+	done := make(chan *probe)
+	go func() {
+		value, _, _ := load(ctx, first, "probes:id:2", fetch, nil)
+		done <- value
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	go func() {
+		value, _, _ := load(ctx, second, "probes:id:2", fetch, nil)
+		done <- value
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if value := <-done; value == nil || value.Version != 1 {
+			t.Fatalf("expected the winner's value, got %+v", value)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the waiting instance to reuse the entry, got %d fetches", got)
+	}
+	if exists := client.Exists(ctx, "probes:id:2:lock").Val(); exists != 0 {
+		t.Fatalf("expected the lock to be released")
+	}
+}
+
+func TestLoad_RefreshesHotEntriesEarly(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	var calls atomic.Int64
+	fetch := func(context.Context) (*probe, error) {
+		time.Sleep(time.Millisecond)
+		return &probe{Version: calls.Add(1)}, nil
+	}
+
+	// A huge beta makes every hit due for refresh.
+	l := newLoader(client, Options{TTL: time.Minute, RefreshBeta: 1e9})
+	if value, _, _ := load(ctx, l, "probes:id:3", fetch, nil); value == nil || value.Version != 1 {
+		t.Fatalf("expected the first value, got %+v", value)
+	}
+
+	value, cached, _ := load(ctx, l, "probes:id:3", fetch, nil)
+	if !cached || value == nil || value.Version != 1 {
+		t.Fatalf("expected the current entry to be served while refreshing, got %+v (cached=%v)", value, cached)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if refreshed := peek[probe](ctx, l, "probes:id:3"); refreshed != nil && refreshed.Version == 2 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected the entry to be refreshed in the background")
+}
+
+func TestLoad_TTLPerResourceAndOperation(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	var calls atomic.Int64
+	fetch := countingFetch(&calls, nil, true)
+
+	l := newLoader(client, Options{
+		TTL:       time.Minute,
+		ListTTL:   10 * time.Second,
+		Resources: map[string]ResourceTTL{"probes": {Get: time.Hour}, "samples": {List: 5 * time.Second}},
+	})
+	for key, want := range map[string]time.Duration{
+		"probes:id:1":      time.Hour,
+		"probes:list:1:a":  10 * time.Second,
+		"samples:id:1":     time.Minute,
+		"samples:list:1:a": 5 * time.Second,
+	} {
+		if _, _, err := load(ctx, l, key, fetch, nil); err != nil {
+			t.Fatalf("load %s: %v", key, err)
+		}
+		if ttl := client.PTTL(ctx, key).Val(); ttl <= want-time.Second || ttl > want {
+			t.Fatalf("%s: expected a TTL of %s, got %s", key, want, ttl)
+		}
+	}
+}
+
+func TestReplaceScript_DoesNotResurrectEvictedEntries(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := replaceScript.Run(ctx, client, []string{"probes:id:4"}, "old", "new", 60000).Err(); err != nil {
+		t.Fatalf("running script: %v", err)
+	}
+	if exists := client.Exists(ctx, "probes:id:4").Val(); exists != 0 {
+		t.Fatalf("expected an evicted entry to stay evicted")
+	}
+
+	client.Set(ctx, "probes:id:4", "old", time.Minute)
+	if err := replaceScript.Run(ctx, client, []string{"probes:id:4"}, "old", "new", 60000).Err(); err != nil {
+		t.Fatalf("running script: %v", err)
+	}
+	if got := client.Get(ctx, "probes:id:4").Val(); got != "new" {
+		t.Fatalf("expected the entry to be replaced, got %q", got)
+	}
+}
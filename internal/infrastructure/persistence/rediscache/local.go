@@ -0,0 +1,380 @@
+package rediscache
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"katseye/internal/domain/repositories"
+)
+
+const invalidationChannel = "katseye:cache:invalidate"
+
+// LocalLimits bounds the in-process tier of one resource. A non-positive size disables it.
+type LocalLimits struct {
+	Size int
+	TTL  time.Duration
+}
+
+// LocalCache is an in-process tier in front of Redis holding one LRU per resource, named by
+// the first segment of the key. Replicas tell each other which keys to drop over Redis pub/sub;
+// a missed message is bounded by the local TTL, and every (re)subscription clears the tier
+// since messages may have been lost while disconnected.
+type LocalCache struct {
+	client   goredis.UniversalClient
+	origin   string
+	defaults LocalLimits
+	limits   map[string]LocalLimits
+
+	mu        sync.Mutex
+	resources map[string]*lru
+
+	pubsub *goredis.PubSub
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// invalidation names single keys to drop, or whole resources to clear.
+type invalidation struct {
+	Origin    string   `json:"origin"`
+	Keys      []string `json:"keys,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+}
+
+// NewLocalCache starts listening for invalidations from other replicas. Overrides replace the
+// defaults for the resources they name.
+func NewLocalCache(client goredis.UniversalClient, defaults LocalLimits, overrides map[string]LocalLimits) *LocalCache {
+	if client == nil {
+		return nil
+	}
+
+	origin := make([]byte, 8)
+	_, _ = rand.Read(origin)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &LocalCache{
+		client:    client,
+		origin:    hex.EncodeToString(origin),
+		defaults:  defaults,
+		limits:    overrides,
+		resources: make(map[string]*lru),
+		pubsub:    client.Subscribe(ctx, invalidationChannel),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go c.listen(ctx)
+
+	return c
+}
+
+// Close stops listening for invalidations. Closing the subscription is what unblocks a pending
+// receive; cancelling the context only keeps the listener from reconnecting.
+func (c *LocalCache) Close() {
+	if c == nil {
+		return
+	}
+	c.cancel()
+	_ = c.pubsub.Close()
+	<-c.done
+}
+
+// mark returns the version of the key's tier, to be passed to set once the value has been read
+// from Redis. Any drop in between moves the version on and the value is not kept.
+func (c *LocalCache) mark(key string) uint64 {
+	if c == nil {
+		return 0
+	}
+	tier := c.tier(key)
+	if tier == nil {
+		return 0
+	}
+	return tier.currentVersion()
+}
+
+func (c *LocalCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	tier := c.tier(key)
+	if tier == nil {
+		return nil, false
+	}
+	return tier.get(key, time.Now())
+}
+
+// set keeps value until the earlier of the resource's local TTL and expires, when given.
+func (c *LocalCache) set(key string, value interface{}, expires time.Time, mark uint64) {
+	if c == nil {
+		return
+	}
+	tier := c.tier(key)
+	if tier == nil {
+		return
+	}
+
+	now := time.Now()
+	deadline := now.Add(tier.ttl)
+	if !expires.IsZero() && expires.Before(deadline) {
+		deadline = expires
+	}
+	tier.set(key, value, deadline, now, mark)
+}
+
+func (c *LocalCache) drop(keys ...string) {
+	if c == nil {
+		return
+	}
+	for _, key := range keys {
+		if tier := c.tier(key); tier != nil {
+			tier.remove(key)
+		}
+	}
+}
+
+// invalidate drops the keys here and asks the other replicas to do the same.
+func (c *LocalCache) invalidate(ctx context.Context, keys ...string) {
+	if c == nil || len(keys) == 0 {
+		return
+	}
+
+	c.drop(keys...)
+	c.publish(ctx, keys...)
+}
+
+// publish asks the other replicas to drop the keys.
+func (c *LocalCache) publish(ctx context.Context, keys ...string) {
+	if c == nil || len(keys) == 0 {
+		return
+	}
+	c.broadcast(ctx, invalidation{Origin: c.origin, Keys: keys})
+}
+
+// flush clears the resource's tier here and on the other replicas.
+func (c *LocalCache) flush(ctx context.Context, resource string) {
+	if c == nil {
+		return
+	}
+	if tier := c.tier(resource); tier != nil {
+		tier.clear()
+	}
+	c.broadcast(ctx, invalidation{Origin: c.origin, Resources: []string{resource}})
+}
+
+func (c *LocalCache) broadcast(ctx context.Context, event invalidation) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if err := c.client.Publish(ctx, invalidationChannel, payload).Err(); err != nil {
+		log.Printf("cache: local invalidation not broadcast keys=%d resources=%v error=%v", len(event.Keys), event.Resources, err)
+	}
+}
+
+// stats describes the enabled tiers, sorted by resource.
+func (c *LocalCache) stats() []repositories.CacheTierStats {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	tiers := make(map[string]*lru, len(c.resources))
+	for resource, tier := range c.resources {
+		if tier != nil {
+			tiers[resource] = tier
+		}
+	}
+	c.mu.Unlock()
+
+	stats := make([]repositories.CacheTierStats, 0, len(tiers))
+	for resource, tier := range tiers {
+		stats = append(stats, repositories.CacheTierStats{
+			Resource: resource,
+			Entries:  tier.len(),
+			Capacity: tier.size,
+			TTL:      tier.ttl,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Resource < stats[j].Resource })
+	return stats
+}
+
+func (c *LocalCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tier := range c.resources {
+		if tier != nil {
+			tier.clear()
+		}
+	}
+}
+
+func (c *LocalCache) tier(key string) *lru {
+	resource, _, _ := strings.Cut(key, ":")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tier, ok := c.resources[resource]; ok {
+		return tier
+	}
+
+	limits, ok := c.limits[resource]
+	if !ok {
+		limits = c.defaults
+	}
+	var tier *lru
+	if limits.Size > 0 && limits.TTL > 0 {
+		tier = newLRU(limits.Size, limits.TTL)
+	}
+	c.resources[resource] = tier
+	return tier
+}
+
+func (c *LocalCache) listen(ctx context.Context) {
+	defer close(c.done)
+
+	for {
+		received, err := c.pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("cache: local invalidation subscription error=%v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		switch message := received.(type) {
+		case *goredis.Subscription:
+			c.clear()
+		case *goredis.Message:
+			var event invalidation
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil || event.Origin == c.origin {
+				continue
+			}
+			c.drop(event.Keys...)
+			for _, resource := range event.Resources {
+				if tier := c.tier(resource); tier != nil {
+					tier.clear()
+				}
+			}
+		}
+	}
+}
+
+// lru is a size- and time-bounded least recently used map. Its version moves on with every
+// removal so fills that raced with one can be told apart.
+type lru struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	version uint64
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (l *lru) currentVersion() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.version
+}
+
+func (l *lru) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *lru) get(key string, now time.Time) (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !now.Before(entry.expires) {
+		l.order.Remove(element)
+		delete(l.entries, key)
+		return nil, false
+	}
+
+	l.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (l *lru) set(key string, value interface{}, expires, now time.Time, mark uint64) {
+	if !now.Before(expires) {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if mark != l.version {
+		return
+	}
+
+	if element, ok := l.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		l.order.MoveToFront(element)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *lru) remove(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.version++
+	if element, ok := l.entries[key]; ok {
+		l.order.Remove(element)
+		delete(l.entries, key)
+	}
+}
+
+func (l *lru) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.version++
+	l.order.Init()
+	l.entries = make(map[string]*list.Element, l.size)
+}
@@ -0,0 +1,107 @@
+package rediscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestLRU_EvictsLeastRecentlyUsedAndExpired(t *testing.T) {
+	now := time.Now()
+	cache := newLRU(2, time.Minute)
+
+	cache.set("a", 1, now.Add(time.Minute), now, 0)
+	cache.set("b", 2, now.Add(time.Minute), now, 0)
+	if _, ok := cache.get("a", now); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	cache.set("c", 3, now.Add(time.Minute), now, 0)
+
+	if _, ok := cache.get("b", now); ok {
+		t.Fatalf("expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.get("a", now.Add(2*time.Minute)); ok {
+		t.Fatalf("expected an expired entry to be dropped")
+	}
+
+	mark := cache.currentVersion()
+	cache.remove("c")
+	cache.set("c", 4, now.Add(time.Minute), now, mark)
+	if _, ok := cache.get("c", now); ok {
+		t.Fatalf("expected a fill that raced with a removal to be discarded")
+	}
+}
+
+func TestLocalCache_PerResourceLimits(t *testing.T) {
+	client := newTestClient(t)
+	local := NewLocalCache(client, LocalLimits{Size: 10, TTL: time.Minute}, map[string]LocalLimits{
+		"consumers": {},
+	})
+	t.Cleanup(local.Close)
+
+	local.set("products:id:1", "product", time.Time{}, local.mark("products:id:1"))
+	local.set("consumers:id:1", "consumer", time.Time{}, local.mark("consumers:id:1"))
+
+	if _, ok := local.get("products:id:1"); !ok {
+		t.Fatalf("expected products to use the default limits")
+	}
+	if _, ok := local.get("consumers:id:1"); ok {
+		t.Fatalf("expected the override to disable the consumers tier")
+	}
+}
+
+func TestLocalCache_ReplicasDropInvalidatedEntries(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	limits := LocalLimits{Size: 10, TTL: time.Minute}
+
+	// Two loaders with their own local tier share Redis, like two API replicas.
+	first := newLoader(client, Options{TTL: time.Minute, Local: NewLocalCache(client, limits, nil)})
+	second := newLoader(client, Options{TTL: time.Minute, Local: NewLocalCache(client, limits, nil)})
+	t.Cleanup(first.options.Local.Close)
+	t.Cleanup(second.options.Local.Close)
+	waitForSubscribers(t, client, invalidationChannel, 2)
+
+	fetch := func(version int64) func(context.Context) (*probe, error) {
+		return func(context.Context) (*probe, error) { return &probe{Version: version}, nil }
+	}
+	for _, l := range []*loader{first, second} {
+		if value, _, _ := load(ctx, l, "probes:id:1", fetch(1), nil); value == nil || value.Version != 1 {
+			t.Fatalf("expected the first version, got %+v", value)
+		}
+	}
+
+	// Redis alone changing is invisible to a replica holding the entry locally.
+	client.Del(ctx, "probes:id:1")
+	if value := peek[probe](ctx, second, "probes:id:1"); value == nil {
+		t.Fatalf("expected the second replica to answer from its local tier")
+	}
+
+	if err := first.store(ctx, "probes:id:1", &probe{Version: 2}); err != nil {
+		t.Fatalf("store returned error: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if value := peek[probe](ctx, second, "probes:id:1"); value != nil && value.Version == 2 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected the second replica to drop its stale entry")
+}
+
+// waitForSubscribers blocks until every local tier is listening, so no invalidation is missed.
+func waitForSubscribers(t *testing.T, client *goredis.Client, channel string, want int64) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if counts, err := client.PubSubNumSub(context.Background(), channel).Result(); err == nil && counts[channel] >= want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %d subscribers on %s", want, channel)
+}
@@ -0,0 +1,104 @@
+package rediscache
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"katseye/internal/domain/repositories"
+)
+
+const (
+	outcomeHit   = "hit"
+	outcomeMiss  = "miss"
+	outcomeStale = "stale"
+	outcomeError = "error"
+)
+
+// Metrics counts lookups per resource, operation and outcome. A nil Metrics counts nothing.
+type Metrics struct {
+	mu       sync.Mutex
+	counters map[counterKey]uint64
+}
+
+type counterKey struct {
+	resource  string
+	operation string
+	outcome   string
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{counters: make(map[counterKey]uint64)}
+}
+
+func (m *Metrics) record(resource, operation, outcome string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.counters[counterKey{resource: resource, operation: operation, outcome: outcome}]++
+	m.mu.Unlock()
+}
+
+// recordKey attributes the outcome to the resource and operation the key belongs to.
+func (m *Metrics) recordKey(key, outcome string) {
+	if m == nil {
+		return
+	}
+	resource, operation := describeKey(key)
+	m.record(resource, operation, outcome)
+}
+
+// Counters returns the counters sorted by resource, operation and outcome.
+func (m *Metrics) Counters() []repositories.CacheCounter {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	counters := make([]repositories.CacheCounter, 0, len(m.counters))
+	for key, count := range m.counters {
+		counters = append(counters, repositories.CacheCounter{
+			Resource:  key.resource,
+			Operation: key.operation,
+			Outcome:   key.outcome,
+			Count:     count,
+		})
+	}
+	m.mu.Unlock()
+
+	sort.Slice(counters, func(i, j int) bool {
+		a, b := counters[i], counters[j]
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		return a.Outcome < b.Outcome
+	})
+	return counters
+}
+
+// describeKey maps a key such as "products:id:<hex>" to its resource and the repository
+// operation served from it.
+func describeKey(key string) (string, string) {
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) < 2 {
+		return parts[0], "unknown"
+	}
+
+	switch parts[1] {
+	case "id":
+		return parts[0], "get"
+	case "list":
+		return parts[0], "list"
+	case "email":
+		return parts[0], "find_by_email"
+	case "document":
+		return parts[0], "get_by_document"
+	default:
+		return parts[0], parts[1]
+	}
+}
@@ -2,9 +2,6 @@ package rediscache
 
 import (
 	"context"
-	"encoding/json"
-	"log"
-	"time"
 
 	goredis "github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,20 +10,18 @@ import (
 )
 
 type partnerRepository struct {
-	repo   repositories.PartnerRepository
-	client *goredis.Client
-	ttl    time.Duration
+	repo  repositories.PartnerRepository
+	cache *loader
 }
 
-func NewPartnerRepository(client *goredis.Client, ttl time.Duration, repo repositories.PartnerRepository) repositories.PartnerRepository {
+func NewPartnerRepository(client goredis.UniversalClient, options Options, repo repositories.PartnerRepository) repositories.PartnerRepository {
 	if client == nil || repo == nil {
 		return repo
 	}
 
 	return &partnerRepository{
-		repo:   repo,
-		client: client,
-		ttl:    mergeTTL(ttl, time.Minute),
+		repo:  repo,
+		cache: newLoader(client, options),
 	}
 }
 
@@ -35,30 +30,18 @@ func (r *partnerRepository) GetPartnerByID(ctx context.Context, id primitive.Obj
 		return nil, nil
 	}
 
-	key := buildIDKey("partners", id.Hex())
-	if data, err := r.client.Get(ctx, key).Bytes(); err == nil {
-		var cached entities.Partner
-		if unmarshalErr := json.Unmarshal(data, &cached); unmarshalErr == nil {
-			log.Printf("cache: hit resource=partners operation=get id=%s source=redis", id.Hex())
-			return &cached, nil
-		} else {
-			log.Printf("cache: stale resource=partners operation=get id=%s error=%v", id.Hex(), unmarshalErr)
-			_ = r.client.Del(ctx, key).Err()
-		}
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.GetPartnerByID(ctx, id)
 	}
 
-	partner, err := r.repo.GetPartnerByID(ctx, id)
+	partner, cached, err := load(ctx, r.cache, buildIDKey("partners", id.Hex()), func(ctx context.Context) (*entities.Partner, error) {
+		return r.repo.GetPartnerByID(ctx, id)
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if partner != nil {
-		log.Printf("cache: miss resource=partners operation=get id=%s source=mongo", id.Hex())
-		_ = r.savePartner(ctx, key, partner)
-	} else {
-		log.Printf("cache: miss resource=partners operation=get id=%s source=mongo result=empty", id.Hex())
-	}
-
+	logLookup("partners", id, cached, partner != nil)
 	return partner, nil
 }
 
@@ -67,11 +50,13 @@ func (r *partnerRepository) CreatePartner(ctx context.Context, partner *entities
 		return err
 	}
 
-	if partner != nil {
-		_ = r.savePartner(ctx, buildIDKey("partners", partner.ID.Hex()), partner)
-	}
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		if partner != nil {
+			_ = r.cache.store(ctx, buildIDKey("partners", partner.ID.Hex()), partner)
+		}
 
-	_ = invalidateResourceLists(ctx, r.client, "partners")
+		r.cache.invalidateLists(ctx, "partners")
+	})
 
 	return nil
 }
@@ -81,11 +66,13 @@ func (r *partnerRepository) UpdatePartner(ctx context.Context, partner *entities
 		return err
 	}
 
-	if partner != nil && !partner.ID.IsZero() {
-		_ = r.savePartner(ctx, buildIDKey("partners", partner.ID.Hex()), partner)
-	}
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		if partner != nil && !partner.ID.IsZero() {
+			r.cache.invalidate(ctx, buildIDKey("partners", partner.ID.Hex()))
+		}
 
-	_ = invalidateResourceLists(ctx, r.client, "partners")
+		r.cache.invalidateLists(ctx, "partners")
+	})
 
 	return nil
 }
@@ -95,54 +82,57 @@ func (r *partnerRepository) DeletePartner(ctx context.Context, id primitive.Obje
 		return err
 	}
 
-	_ = r.client.Del(ctx, buildIDKey("partners", id.Hex())).Err()
-	_ = invalidateResourceLists(ctx, r.client, "partners")
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		r.cache.invalidate(ctx, buildIDKey("partners", id.Hex()))
+		r.cache.invalidateLists(ctx, "partners")
+	})
 
 	return nil
 }
 
-func (r *partnerRepository) ListPartners(ctx context.Context, filter map[string]interface{}) ([]*entities.Partner, error) {
-	key := buildListKey("partners", filter)
-	if data, err := r.client.Get(ctx, key).Bytes(); err == nil {
-		var cached []*entities.Partner
-		if unmarshalErr := json.Unmarshal(data, &cached); unmarshalErr == nil {
-			log.Printf("cache: hit resource=partners operation=list key=%s source=redis count=%d", key, len(cached))
-			return cached, nil
-		} else {
-			log.Printf("cache: stale resource=partners operation=list key=%s error=%v", key, unmarshalErr)
-			_ = r.client.Del(ctx, key).Err()
-		}
+func (r *partnerRepository) ListPartners(ctx context.Context, query repositories.PartnerQuery) ([]*entities.Partner, repositories.PageInfo, error) {
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.ListPartners(ctx, query)
 	}
 
-	partners, err := r.repo.ListPartners(ctx, filter)
-	if err != nil {
-		return nil, err
+	filter := map[string]interface{}{}
+	if query.Type != "" {
+		filter["type"] = query.Type
 	}
-
-	if partners != nil {
-		if payload, marshalErr := json.Marshal(partners); marshalErr == nil {
-			_ = r.client.Set(ctx, key, payload, r.ttl).Err()
-		}
+	if query.AcceptedType != "" {
+		filter["accepted_type"] = query.AcceptedType
 	}
-
-	log.Printf("cache: miss resource=partners operation=list key=%s source=mongo count=%d", key, len(partners))
-
-	return partners, nil
-}
-
-func (r *partnerRepository) savePartner(ctx context.Context, key string, partner *entities.Partner) error {
-	if partner == nil {
-		return nil
+	key, keyErr := r.cache.listKey(ctx, "partners", withPagination(filter, query.Page))
+	if keyErr != nil {
+		return r.repo.ListPartners(ctx, query)
 	}
 
-	payload, err := json.Marshal(partner)
+	list, cached, err := load(ctx, r.cache, key, func(ctx context.Context) (*cachedPage[*entities.Partner], error) {
+		items, page, err := r.repo.ListPartners(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return &cachedPage[*entities.Partner]{Items: items, Page: page}, nil
+	}, func(list *cachedPage[*entities.Partner]) bool {
+		return list.Items != nil
+	})
 	if err != nil {
-		return err
+		return nil, repositories.PageInfo{}, err
 	}
 
-	if err := r.client.Set(ctx, key, payload, r.ttl).Err(); err != nil {
-		return err
-	}
+	logListLookup("partners", key, cached, len(list.Items))
+	return list.Items, list.Page, nil
+}
 
-	return nil
+func (r *partnerRepository) Resource() string {
+	return "partners"
+}
+
+// Warm caches every partner under its ID.
+func (r *partnerRepository) Warm(ctx context.Context) (int, error) {
+	return warm(ctx, r.cache, "partners", func(ctx context.Context, page repositories.Pagination) ([]*entities.Partner, repositories.PageInfo, error) {
+		return r.repo.ListPartners(ctx, repositories.PartnerQuery{Page: page})
+	}, func(partner *entities.Partner) primitive.ObjectID {
+		return partner.ID
+	}, nil)
 }
@@ -2,9 +2,6 @@ package rediscache
 
 import (
 	"context"
-	"encoding/json"
-	"log"
-	"time"
 
 	goredis "github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,20 +10,18 @@ import (
 )
 
 type productRepository struct {
-	repo   repositories.ProductRepository
-	client *goredis.Client
-	ttl    time.Duration
+	repo  repositories.ProductRepository
+	cache *loader
 }
 
-func NewProductRepository(client *goredis.Client, ttl time.Duration, repo repositories.ProductRepository) repositories.ProductRepository {
+func NewProductRepository(client goredis.UniversalClient, options Options, repo repositories.ProductRepository) repositories.ProductRepository {
 	if client == nil || repo == nil {
 		return repo
 	}
 
 	return &productRepository{
-		repo:   repo,
-		client: client,
-		ttl:    mergeTTL(ttl, time.Minute),
+		repo:  repo,
+		cache: newLoader(client, options),
 	}
 }
 
@@ -35,30 +30,20 @@ func (r *productRepository) GetProductByID(ctx context.Context, id primitive.Obj
 		return nil, nil
 	}
 
-	key := buildIDKey("products", id.Hex())
-	if data, err := r.client.Get(ctx, key).Bytes(); err == nil {
-		var cached entities.Product
-		if unmarshalErr := json.Unmarshal(data, &cached); unmarshalErr == nil {
-			log.Printf("cache: hit resource=products operation=get id=%s source=redis", id.Hex())
-			return &cached, nil
-		} else {
-			log.Printf("cache: stale resource=products operation=get id=%s error=%v", id.Hex(), unmarshalErr)
-			_ = r.client.Del(ctx, key).Err()
-		}
+	// Inside a unit of work the cache may disagree with uncommitted writes, and what is read
+	// must not be cached before it commits.
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.GetProductByID(ctx, id)
 	}
 
-	product, err := r.repo.GetProductByID(ctx, id)
+	product, cached, err := load(ctx, r.cache, buildIDKey("products", id.Hex()), func(ctx context.Context) (*entities.Product, error) {
+		return r.repo.GetProductByID(ctx, id)
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if product != nil {
-		log.Printf("cache: miss resource=products operation=get id=%s source=mongo", id.Hex())
-		_ = r.saveProduct(ctx, key, product)
-	} else {
-		log.Printf("cache: miss resource=products operation=get id=%s source=mongo result=empty", id.Hex())
-	}
-
+	logLookup("products", id, cached, product != nil)
 	return product, nil
 }
 
@@ -67,11 +52,13 @@ func (r *productRepository) CreateProduct(ctx context.Context, product *entities
 		return err
 	}
 
-	if product != nil {
-		_ = r.saveProduct(ctx, buildIDKey("products", product.ID.Hex()), product)
-	}
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		if product != nil {
+			_ = r.cache.store(ctx, buildIDKey("products", product.ID.Hex()), product)
+		}
 
-	_ = invalidateResourceLists(ctx, r.client, "products")
+		r.cache.invalidateLists(ctx, "products")
+	})
 
 	return nil
 }
@@ -81,11 +68,14 @@ func (r *productRepository) UpdateProduct(ctx context.Context, product *entities
 		return err
 	}
 
-	if product != nil && !product.ID.IsZero() {
-		_ = r.saveProduct(ctx, buildIDKey("products", product.ID.Hex()), product)
-	}
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		// The update may have matched nothing, so the entry is evicted rather than rewritten.
+		if product != nil && !product.ID.IsZero() {
+			r.cache.invalidate(ctx, buildIDKey("products", product.ID.Hex()))
+		}
 
-	_ = invalidateResourceLists(ctx, r.client, "products")
+		r.cache.invalidateLists(ctx, "products")
+	})
 
 	return nil
 }
@@ -95,54 +85,60 @@ func (r *productRepository) DeleteProduct(ctx context.Context, id primitive.Obje
 		return err
 	}
 
-	_ = r.client.Del(ctx, buildIDKey("products", id.Hex())).Err()
-	_ = invalidateResourceLists(ctx, r.client, "products")
+	repositories.AfterCommit(ctx, func(ctx context.Context) {
+		r.cache.invalidate(ctx, buildIDKey("products", id.Hex()))
+		r.cache.invalidateLists(ctx, "products")
+	})
 
 	return nil
 }
 
-func (r *productRepository) ListProducts(ctx context.Context, filter map[string]interface{}) ([]*entities.Product, error) {
-	key := buildListKey("products", filter)
-	if data, err := r.client.Get(ctx, key).Bytes(); err == nil {
-		var cached []*entities.Product
-		if unmarshalErr := json.Unmarshal(data, &cached); unmarshalErr == nil {
-			log.Printf("cache: hit resource=products operation=list key=%s source=redis count=%d", key, len(cached))
-			return cached, nil
-		} else {
-			log.Printf("cache: stale resource=products operation=list key=%s error=%v", key, unmarshalErr)
-			_ = r.client.Del(ctx, key).Err()
-		}
+func (r *productRepository) ListProducts(ctx context.Context, query repositories.ProductQuery) ([]*entities.Product, repositories.PageInfo, error) {
+	if repositories.InUnitOfWork(ctx) {
+		return r.repo.ListProducts(ctx, query)
 	}
 
-	products, err := r.repo.ListProducts(ctx, filter)
-	if err != nil {
-		return nil, err
+	filter := map[string]interface{}{}
+	if !query.PartnerID.IsZero() {
+		filter["partner_id"] = query.PartnerID.Hex()
 	}
-
-	if products != nil {
-		if payload, marshalErr := json.Marshal(products); marshalErr == nil {
-			_ = r.client.Set(ctx, key, payload, r.ttl).Err()
-		}
+	if query.ProductType != "" {
+		filter["product_type"] = query.ProductType
 	}
-
-	log.Printf("cache: miss resource=products operation=list key=%s source=mongo count=%d", key, len(products))
-
-	return products, nil
-}
-
-func (r *productRepository) saveProduct(ctx context.Context, key string, product *entities.Product) error {
-	if product == nil {
-		return nil
+	if query.Category != "" {
+		filter["category"] = query.Category
+	}
+	key, keyErr := r.cache.listKey(ctx, "products", withPagination(filter, query.Page))
+	if keyErr != nil {
+		return r.repo.ListProducts(ctx, query)
 	}
 
-	payload, err := json.Marshal(product)
+	list, cached, err := load(ctx, r.cache, key, func(ctx context.Context) (*cachedPage[*entities.Product], error) {
+		items, page, err := r.repo.ListProducts(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return &cachedPage[*entities.Product]{Items: items, Page: page}, nil
+	}, func(list *cachedPage[*entities.Product]) bool {
+		return list.Items != nil
+	})
 	if err != nil {
-		return err
+		return nil, repositories.PageInfo{}, err
 	}
 
-	if err := r.client.Set(ctx, key, payload, r.ttl).Err(); err != nil {
-		return err
-	}
+	logListLookup("products", key, cached, len(list.Items))
+	return list.Items, list.Page, nil
+}
 
-	return nil
+func (r *productRepository) Resource() string {
+	return "products"
+}
+
+// Warm caches every product under its ID.
+func (r *productRepository) Warm(ctx context.Context) (int, error) {
+	return warm(ctx, r.cache, "products", func(ctx context.Context, page repositories.Pagination) ([]*entities.Product, repositories.PageInfo, error) {
+		return r.repo.ListProducts(ctx, repositories.ProductQuery{Page: page})
+	}, func(product *entities.Product) primitive.ObjectID {
+		return product.ID
+	}, nil)
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"log"
 	"strings"
+	"sync"
 	"time"
 
 	goredis "github.com/redis/go-redis/v9"
@@ -13,23 +16,48 @@ import (
 
 const (
 	tokenRevocationNamespace = "auth:revoked:"
+	revocationChannel        = "katseye:auth:revoked"
 	minimumRevocationTTL     = time.Minute
+	revocationScanCount      = 500
 )
 
 var _ security.TokenStore = (*TokenStore)(nil)
 
+// TokenStoreOptions sets how revocation checks behave while Redis cannot be reached.
+type TokenStoreOptions struct {
+	// FailOpen accepts tokens whose revocation cannot be checked; by default the check fails.
+	FailOpen bool
+	// Replica keeps every instance's revocations in process memory, fed over pub/sub, so recent
+	// logouts are still honoured while Redis is unreachable.
+	Replica bool
+}
+
 // TokenStore persists revoked token metadata into Redis.
 type TokenStore struct {
-	client *goredis.Client
+	client  goredis.UniversalClient
+	options TokenStoreOptions
+	replica *revocationReplica
 }
 
 // NewTokenStore creates a TokenStore backed by the provided Redis client.
-func NewTokenStore(client *goredis.Client) *TokenStore {
+func NewTokenStore(client goredis.UniversalClient, options TokenStoreOptions) *TokenStore {
 	if client == nil {
 		return nil
 	}
 
-	return &TokenStore{client: client}
+	store := &TokenStore{client: client, options: options}
+	if options.Replica {
+		store.replica = newRevocationReplica(client)
+	}
+	return store
+}
+
+// Close stops following the revocations of other instances.
+func (s *TokenStore) Close() {
+	if s == nil {
+		return
+	}
+	s.replica.close()
 }
 
 // Revoke stores the token hash with a TTL matching the remaining token lifetime.
@@ -49,10 +77,20 @@ func (s *TokenStore) Revoke(ctx context.Context, token string, expiresAt time.Ti
 		ttl = minimumRevocationTTL
 	}
 
-	return s.client.Set(ctx, revocationKey(token), "revoked", ttl).Err()
+	// The replica is updated first so this instance honours the logout even if Redis fails.
+	key := revocationKey(token)
+	s.replica.add(key, time.Now().Add(ttl))
+
+	if err := s.client.Set(ctx, key, "revoked", ttl).Err(); err != nil {
+		return err
+	}
+	s.replica.publish(ctx, key, time.Now().Add(ttl))
+	return nil
 }
 
-// IsRevoked checks whether the token hash exists in Redis.
+// IsRevoked checks whether the token hash exists in Redis. Without an answer from Redis the
+// token is reported revoked by the replica if it knows it, and otherwise accepted or refused
+// as FailOpen says.
 func (s *TokenStore) IsRevoked(ctx context.Context, token string) (bool, error) {
 	if s == nil || s.client == nil {
 		return false, nil
@@ -63,8 +101,16 @@ func (s *TokenStore) IsRevoked(ctx context.Context, token string) (bool, error)
 		return false, nil
 	}
 
-	exists, err := s.client.Exists(ctx, revocationKey(token)).Result()
+	key := revocationKey(token)
+	if s.replica.has(key) {
+		return true, nil
+	}
+
+	exists, err := s.client.Exists(ctx, key).Result()
 	if err != nil {
+		if s.options.FailOpen {
+			return false, nil
+		}
 		return false, err
 	}
 
@@ -75,3 +121,160 @@ func revocationKey(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return tokenRevocationNamespace + hex.EncodeToString(hash[:])
 }
+
+// revocationReplica mirrors the revocation keys held in Redis. Instances announce new
+// revocations on a channel, and each (re)subscription reloads the keys from Redis since
+// announcements may have been missed while disconnected.
+type revocationReplica struct {
+	client goredis.UniversalClient
+
+	mu      sync.Mutex
+	revoked map[string]time.Time
+
+	pubsub *goredis.PubSub
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type revocationEvent struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+}
+
+func newRevocationReplica(client goredis.UniversalClient) *revocationReplica {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &revocationReplica{
+		client:  client,
+		revoked: make(map[string]time.Time),
+		pubsub:  client.Subscribe(ctx, revocationChannel),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go r.listen(ctx)
+
+	return r
+}
+
+func (r *revocationReplica) close() {
+	if r == nil {
+		return
+	}
+	r.cancel()
+	_ = r.pubsub.Close()
+	<-r.done
+}
+
+func (r *revocationReplica) has(key string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expires, ok := r.revoked[key]
+	return ok && time.Now().Before(expires)
+}
+
+// add records the revocation, dropping the ones whose tokens have expired.
+func (r *revocationReplica) add(key string, expires time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for existing, until := range r.revoked {
+		if !now.Before(until) {
+			delete(r.revoked, existing)
+		}
+	}
+	if expires.After(r.revoked[key]) {
+		r.revoked[key] = expires
+	}
+}
+
+func (r *revocationReplica) publish(ctx context.Context, key string, expires time.Time) {
+	if r == nil {
+		return
+	}
+
+	payload, err := json.Marshal(revocationEvent{Key: key, Expires: expires})
+	if err != nil {
+		return
+	}
+	if err := r.client.Publish(ctx, revocationChannel, payload).Err(); err != nil {
+		log.Printf("auth: revocation not broadcast error=%v", err)
+	}
+}
+
+func (r *revocationReplica) listen(ctx context.Context) {
+	defer close(r.done)
+
+	for {
+		received, err := r.pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("auth: revocation subscription error=%v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		switch message := received.(type) {
+		case *goredis.Subscription:
+			if err := r.load(ctx); err != nil {
+				log.Printf("auth: revocations not reloaded error=%v", err)
+			}
+		case *goredis.Message:
+			var event revocationEvent
+			if err := json.Unmarshal([]byte(message.Payload), &event); err == nil && strings.HasPrefix(event.Key, tokenRevocationNamespace) {
+				r.add(event.Key, event.Expires)
+			}
+		}
+	}
+}
+
+// load copies every revocation key from Redis along with its remaining lifetime.
+func (r *revocationReplica) load(ctx context.Context) error {
+	return forEachShard(ctx, r.client, func(ctx context.Context, shard goredis.UniversalClient) error {
+		var cursor uint64
+		for {
+			keys, next, err := shard.Scan(ctx, cursor, tokenRevocationNamespace+"*", revocationScanCount).Result()
+			if err != nil {
+				return err
+			}
+
+			if len(keys) > 0 {
+				ttls := make([]*goredis.DurationCmd, len(keys))
+				if _, err := shard.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+					for i, key := range keys {
+						ttls[i] = pipe.PTTL(ctx, key)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+
+				now := time.Now()
+				for i, key := range keys {
+					if ttl := ttls[i].Val(); ttl > 0 {
+						r.add(key, now.Add(ttl))
+					}
+				}
+			}
+
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	})
+}
@@ -14,7 +14,7 @@ func TestTokenStore_RevokeAndCheck(t *testing.T) {
 	server := miniredis.RunT(t)
 	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
 
-	store := NewTokenStore(client)
+	store := NewTokenStore(client, TokenStoreOptions{})
 	if store == nil {
 		t.Fatal("expected token store instance")
 	}
@@ -44,7 +44,7 @@ func TestTokenStore_RevokeWithPastExpirationUsesMinimumTTL(t *testing.T) {
 	server := miniredis.RunT(t)
 	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
 
-	store := NewTokenStore(client)
+	store := NewTokenStore(client, TokenStoreOptions{})
 	const token = "expired.jwt.token"
 
 	if err := store.Revoke(ctx, token, time.Now().Add(-time.Hour)); err != nil {
@@ -57,3 +57,51 @@ func TestTokenStore_RevokeWithPastExpirationUsesMinimumTTL(t *testing.T) {
 		t.Fatalf("expected TTL around %s, got %s", minimumRevocationTTL, ttl)
 	}
 }
+
+func TestTokenStore_FailurePolicyAndReplica(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	newClient := func() *goredis.Client {
+		client := goredis.NewClient(&goredis.Options{Addr: server.Addr(), MaxRetries: -1})
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+
+	// Revoked before the replicas start, so they can only learn it from the initial load.
+	if err := NewTokenStore(newClient(), TokenStoreOptions{}).Revoke(ctx, "earlier.jwt.token", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+
+	closed := NewTokenStore(newClient(), TokenStoreOptions{})
+	open := NewTokenStore(newClient(), TokenStoreOptions{FailOpen: true})
+	replica := NewTokenStore(newClient(), TokenStoreOptions{Replica: true})
+	t.Cleanup(replica.Close)
+	peer := NewTokenStore(newClient(), TokenStoreOptions{Replica: true})
+	t.Cleanup(peer.Close)
+	waitForSubscribers(t, newClient(), revocationChannel, 2)
+
+	if err := peer.Revoke(ctx, "later.jwt.token", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for !replica.replica.has(revocationKey("later.jwt.token")) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the replica to learn the peer's revocation")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	server.Close()
+
+	if _, err := closed.IsRevoked(ctx, "other.jwt.token"); err == nil {
+		t.Fatal("expected the check to fail closed without Redis")
+	}
+	if revoked, err := open.IsRevoked(ctx, "other.jwt.token"); err != nil || revoked {
+		t.Fatalf("expected the check to fail open without Redis, got %t (%v)", revoked, err)
+	}
+	for _, token := range []string{"earlier.jwt.token", "later.jwt.token"} {
+		if revoked, err := replica.IsRevoked(ctx, token); err != nil || !revoked {
+			t.Fatalf("expected the replica to keep %s revoked, got %t (%v)", token, revoked, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package searchindex
+
+import (
+	"context"
+	"log"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type partnerRepository struct {
+	repo  repositories.PartnerRepository
+	index repositories.ProductSearchIndex
+}
+
+// NewPartnerRepository propagates partner name and type changes to the indexed products, and
+// drops them when the partner is deleted.
+func NewPartnerRepository(index repositories.ProductSearchIndex, repo repositories.PartnerRepository) repositories.PartnerRepository {
+	if index == nil || repo == nil {
+		return repo
+	}
+
+	return &partnerRepository{repo: repo, index: index}
+}
+
+func (r *partnerRepository) GetPartnerByID(ctx context.Context, id primitive.ObjectID) (*entities.Partner, error) {
+	return r.repo.GetPartnerByID(ctx, id)
+}
+
+func (r *partnerRepository) CreatePartner(ctx context.Context, partner *entities.Partner) error {
+	return r.repo.CreatePartner(ctx, partner)
+}
+
+func (r *partnerRepository) UpdatePartner(ctx context.Context, partner *entities.Partner) error {
+	if err := r.repo.UpdatePartner(ctx, partner); err != nil {
+		return err
+	}
+
+	if err := r.index.UpdatePartner(ctx, partner); err != nil {
+		log.Printf("search: partner update failed resource=products partner_id=%s error=%v", partner.ID.Hex(), err)
+	}
+	return nil
+}
+
+func (r *partnerRepository) DeletePartner(ctx context.Context, id primitive.ObjectID) error {
+	if err := r.repo.DeletePartner(ctx, id); err != nil {
+		return err
+	}
+
+	if err := r.index.RemovePartner(ctx, id); err != nil {
+		log.Printf("search: partner remove failed resource=products partner_id=%s error=%v", id.Hex(), err)
+	}
+	return nil
+}
+
+func (r *partnerRepository) ListPartners(ctx context.Context, query repositories.PartnerQuery) ([]*entities.Partner, repositories.PageInfo, error) {
+	return r.repo.ListPartners(ctx, query)
+}
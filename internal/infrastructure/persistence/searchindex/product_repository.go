@@ -0,0 +1,84 @@
+// Package searchindex keeps a ProductSearchIndex in sync with catalog writes by decorating the
+// product and partner repositories. Index failures are logged and never fail the primary write;
+// the reindex_products command rebuilds the projection when it drifts.
+package searchindex
+
+import (
+	"context"
+	"log"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type productRepository struct {
+	repo     repositories.ProductRepository
+	index    repositories.ProductSearchIndex
+	partners repositories.PartnerRepository
+}
+
+func NewProductRepository(index repositories.ProductSearchIndex, partners repositories.PartnerRepository, repo repositories.ProductRepository) repositories.ProductRepository {
+	if index == nil || repo == nil {
+		return repo
+	}
+
+	return &productRepository{repo: repo, index: index, partners: partners}
+}
+
+func (r *productRepository) GetProductByID(ctx context.Context, id primitive.ObjectID) (*entities.Product, error) {
+	return r.repo.GetProductByID(ctx, id)
+}
+
+func (r *productRepository) CreateProduct(ctx context.Context, product *entities.Product) error {
+	if err := r.repo.CreateProduct(ctx, product); err != nil {
+		return err
+	}
+
+	r.indexProduct(ctx, product)
+	return nil
+}
+
+func (r *productRepository) UpdateProduct(ctx context.Context, product *entities.Product) error {
+	if err := r.repo.UpdateProduct(ctx, product); err != nil {
+		return err
+	}
+
+	r.indexProduct(ctx, product)
+	return nil
+}
+
+func (r *productRepository) DeleteProduct(ctx context.Context, id primitive.ObjectID) error {
+	if err := r.repo.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+
+	if err := r.index.RemoveProduct(ctx, id); err != nil {
+		log.Printf("search: remove failed resource=products id=%s error=%v", id.Hex(), err)
+	}
+	return nil
+}
+
+func (r *productRepository) ListProducts(ctx context.Context, query repositories.ProductQuery) ([]*entities.Product, repositories.PageInfo, error) {
+	return r.repo.ListProducts(ctx, query)
+}
+
+func (r *productRepository) indexProduct(ctx context.Context, product *entities.Product) {
+	if product == nil {
+		return
+	}
+
+	var partner *entities.Partner
+	if r.partners != nil && !product.PartnerID.IsZero() {
+		found, err := r.partners.GetPartnerByID(ctx, product.PartnerID)
+		if err != nil {
+			log.Printf("search: partner lookup failed resource=products id=%s partner_id=%s error=%v", product.ID.Hex(), product.PartnerID.Hex(), err)
+		}
+		partner = found
+	}
+
+	if err := r.index.IndexProduct(ctx, product, partner); err != nil {
+		log.Printf("search: index failed resource=products id=%s error=%v", product.ID.Hex(), err)
+	}
+}
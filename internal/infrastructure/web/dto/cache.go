@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"katseye/internal/domain/repositories"
+)
+
+// CacheStatsResponse expõe os contadores do cache e o estado do Redis e do cache em memória.
+type CacheStatsResponse struct {
+	Available       bool                     `json:"available"`
+	Keys            int64                    `json:"keys"`
+	UsedMemoryBytes int64                    `json:"used_memory_bytes"`
+	Counters        []CacheCounterResponse   `json:"counters"`
+	Local           []CacheTierStatsResponse `json:"local"`
+}
+
+// CacheCounterResponse é o total de consultas de um recurso e operação com um resultado.
+type CacheCounterResponse struct {
+	Resource  string `json:"resource"`
+	Operation string `json:"operation"`
+	Outcome   string `json:"outcome"`
+	Count     uint64 `json:"count"`
+}
+
+// CacheTierStatsResponse descreve o cache em memória de um recurso na réplica que respondeu.
+type CacheTierStatsResponse struct {
+	Resource   string `json:"resource"`
+	Entries    int    `json:"entries"`
+	Capacity   int    `json:"capacity"`
+	TTLSeconds int64  `json:"ttl_seconds"`
+}
+
+func NewCacheStatsResponse(stats repositories.CacheStats) CacheStatsResponse {
+	resp := CacheStatsResponse{
+		Available:       stats.Available,
+		Keys:            stats.Keys,
+		UsedMemoryBytes: stats.UsedMemoryBytes,
+		Counters:        make([]CacheCounterResponse, 0, len(stats.Counters)),
+		Local:           make([]CacheTierStatsResponse, 0, len(stats.Local)),
+	}
+
+	for _, counter := range stats.Counters {
+		resp.Counters = append(resp.Counters, CacheCounterResponse{
+			Resource:  counter.Resource,
+			Operation: counter.Operation,
+			Outcome:   counter.Outcome,
+			Count:     counter.Count,
+		})
+	}
+	for _, tier := range stats.Local {
+		resp.Local = append(resp.Local, CacheTierStatsResponse{
+			Resource:   tier.Resource,
+			Entries:    tier.Entries,
+			Capacity:   tier.Capacity,
+			TTLSeconds: int64(tier.TTL / time.Second),
+		})
+	}
+
+	return resp
+}
+
+// CacheKeyResponse expõe uma chave do cache, com o codec e a versão de schema que a gravaram; o
+// valor é omitido para recursos com credenciais.
+type CacheKeyResponse struct {
+	Key        string          `json:"key"`
+	TTLSeconds int64           `json:"ttl_seconds"`
+	SizeBytes  int             `json:"size_bytes"`
+	Missing    bool            `json:"missing"`
+	ExpiresAt  *time.Time      `json:"expires_at,omitempty"`
+	Codec      string          `json:"codec,omitempty"`
+	Schema     string          `json:"schema,omitempty"`
+	Local      bool            `json:"local"`
+	Redacted   bool            `json:"redacted"`
+	Value      json.RawMessage `json:"value,omitempty"`
+}
+
+func NewCacheKeyResponse(info *repositories.CacheKeyInfo) *CacheKeyResponse {
+	if info == nil {
+		return nil
+	}
+
+	resp := &CacheKeyResponse{
+		Key:        info.Key,
+		TTLSeconds: int64(info.TTL / time.Second),
+		SizeBytes:  info.Size,
+		Missing:    info.Missing,
+		Codec:      info.Codec,
+		Schema:     info.Schema,
+		Local:      info.Local,
+		Redacted:   info.Redacted,
+		Value:      info.Value,
+	}
+	if !info.Expires.IsZero() {
+		expires := info.Expires
+		resp.ExpiresAt = &expires
+	}
+
+	return resp
+}
+
+// CacheOperationResponse informa quantas entradas uma limpeza ou um aquecimento afetou.
+type CacheOperationResponse struct {
+	Resource string `json:"resource"`
+	Keys     int64  `json:"keys"`
+}
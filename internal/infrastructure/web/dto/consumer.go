@@ -7,6 +7,7 @@ import (
 
 	"katseye/internal/domain/entities"
 	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/masking"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -59,18 +60,18 @@ type ConsumerContactRequest struct {
 }
 
 type ConsumerCreditProfileRequest struct {
-	CreditScore             int     `json:"credit_score"`
-	MonthlyIncome           float64 `json:"monthly_income"`
-	AnnualRevenue           float64 `json:"annual_revenue"`
-	CreditLimitRequested    float64 `json:"credit_limit_requested"`
-	CreditLimitApproved     float64 `json:"credit_limit_approved"`
-	OutstandingDebt         float64 `json:"outstanding_debt"`
-	RiskLevel               string  `json:"risk_level"`
-	DelinquencyProbability  float64 `json:"delinquency_probability"`
-	EmploymentStatus        string  `json:"employment_status"`
-	YearsInCurrentJob       int     `json:"years_in_current_job"`
-	YearsInBusiness         int     `json:"years_in_business"`
-	BankingRelationshipRank string  `json:"banking_relationship_rank"`
+	CreditScore             int                `json:"credit_score"`
+	MonthlyIncome           valueobjects.Money `json:"monthly_income"`
+	AnnualRevenue           valueobjects.Money `json:"annual_revenue"`
+	CreditLimitRequested    valueobjects.Money `json:"credit_limit_requested"`
+	CreditLimitApproved     valueobjects.Money `json:"credit_limit_approved"`
+	OutstandingDebt         valueobjects.Money `json:"outstanding_debt"`
+	RiskLevel               string             `json:"risk_level"`
+	DelinquencyProbability  float64            `json:"delinquency_probability"`
+	EmploymentStatus        string             `json:"employment_status"`
+	YearsInCurrentJob       int                `json:"years_in_current_job"`
+	YearsInBusiness         int                `json:"years_in_business"`
+	BankingRelationshipRank string             `json:"banking_relationship_rank"`
 }
 
 type ConsumerResponse struct {
@@ -83,8 +84,10 @@ type ConsumerResponse struct {
 	AdditionalAddressIDs []string                      `json:"additional_address_ids"`
 	ContractedProducts   []string                      `json:"contracted_products"`
 	UserID               string                        `json:"user_id,omitempty"`
+	Screening            *ConsumerScreeningResponse    `json:"screening,omitempty"`
 	CreatedAt            time.Time                     `json:"created_at"`
 	UpdatedAt            time.Time                     `json:"updated_at"`
+	ErasedAt             *time.Time                    `json:"erased_at,omitempty"`
 }
 
 type ConsumerPersonalDataResponse struct {
@@ -93,19 +96,19 @@ type ConsumerPersonalDataResponse struct {
 }
 
 type ConsumerIndividualDataResponse struct {
-	FullName       string    `json:"full_name"`
-	SocialName     string    `json:"social_name,omitempty"`
-	DocumentNumber string    `json:"document_number"`
-	BirthDate      time.Time `json:"birth_date"`
-	Nationality    string    `json:"nationality,omitempty"`
-	MaritalStatus  string    `json:"marital_status,omitempty"`
-	Occupation     string    `json:"occupation,omitempty"`
+	FullName       string     `json:"full_name"`
+	SocialName     string     `json:"social_name,omitempty"`
+	DocumentNumber string     `json:"document_number" mask:"document"`
+	BirthDate      *time.Time `json:"birth_date,omitempty" mask:"redact"`
+	Nationality    string     `json:"nationality,omitempty"`
+	MaritalStatus  string     `json:"marital_status,omitempty"`
+	Occupation     string     `json:"occupation,omitempty"`
 }
 
 type ConsumerBusinessDataResponse struct {
 	CorporateName     string    `json:"corporate_name"`
 	TradeName         string    `json:"trade_name,omitempty"`
-	DocumentNumber    string    `json:"document_number"`
+	DocumentNumber    string    `json:"document_number" mask:"document"`
 	IncorporationDate time.Time `json:"incorporation_date"`
 	LegalNature       string    `json:"legal_nature,omitempty"`
 	StateRegistration string    `json:"state_registration,omitempty"`
@@ -113,24 +116,37 @@ type ConsumerBusinessDataResponse struct {
 }
 
 type ConsumerContactResponse struct {
-	Email          string `json:"email"`
-	Phone          string `json:"phone"`
-	SecondaryPhone string `json:"secondary_phone,omitempty"`
+	Email          string `json:"email" mask:"email"`
+	Phone          string `json:"phone" mask:"phone"`
+	SecondaryPhone string `json:"secondary_phone,omitempty" mask:"phone"`
 }
 
 type ConsumerCreditProfileResponse struct {
-	CreditScore             int     `json:"credit_score"`
-	MonthlyIncome           float64 `json:"monthly_income"`
-	AnnualRevenue           float64 `json:"annual_revenue"`
-	CreditLimitRequested    float64 `json:"credit_limit_requested"`
-	CreditLimitApproved     float64 `json:"credit_limit_approved"`
-	OutstandingDebt         float64 `json:"outstanding_debt"`
-	RiskLevel               string  `json:"risk_level,omitempty"`
-	DelinquencyProbability  float64 `json:"delinquency_probability"`
-	EmploymentStatus        string  `json:"employment_status,omitempty"`
-	YearsInCurrentJob       int     `json:"years_in_current_job"`
-	YearsInBusiness         int     `json:"years_in_business"`
-	BankingRelationshipRank string  `json:"banking_relationship_rank,omitempty"`
+	CreditScore             int                   `json:"credit_score"`
+	MonthlyIncome           *valueobjects.Money   `json:"monthly_income,omitempty" mask:"redact"`
+	AnnualRevenue           *valueobjects.Money   `json:"annual_revenue,omitempty" mask:"redact"`
+	CreditLimitRequested    valueobjects.Money    `json:"credit_limit_requested"`
+	CreditLimitApproved     valueobjects.Money    `json:"credit_limit_approved"`
+	OutstandingDebt         valueobjects.Money    `json:"outstanding_debt"`
+	RiskLevel               string                `json:"risk_level,omitempty"`
+	DelinquencyProbability  float64               `json:"delinquency_probability"`
+	EmploymentStatus        string                `json:"employment_status,omitempty"`
+	YearsInCurrentJob       int                   `json:"years_in_current_job"`
+	YearsInBusiness         int                   `json:"years_in_business"`
+	BankingRelationshipRank string                `json:"banking_relationship_rank,omitempty"`
+	Bureau                  *CreditBureauResponse `json:"bureau,omitempty"`
+}
+
+// CreditBureauResponse mostra o que o bureau informou na última atualização, com origem e datas.
+type CreditBureauResponse struct {
+	Source                     string              `json:"source"`
+	RefreshedAt                time.Time           `json:"refreshed_at"`
+	ScoreRetrievedAt           time.Time           `json:"score_retrieved_at"`
+	NegativeRecords            int                 `json:"negative_records"`
+	NegativeRecordsAmount      valueobjects.Money  `json:"negative_records_amount"`
+	NegativeRecordsRetrievedAt time.Time           `json:"negative_records_retrieved_at"`
+	EstimatedIncome            *valueobjects.Money `json:"estimated_income,omitempty" mask:"redact"`
+	IncomeRetrievedAt          time.Time           `json:"income_retrieved_at"`
 }
 
 func (req *ConsumerRequest) ToEntity(id primitive.ObjectID) (*entities.Consumer, error) {
@@ -300,6 +316,7 @@ func NewConsumerResponse(consumer *entities.Consumer) ConsumerResponse {
 		PrimaryAddressID:     consumer.PrimaryAddressID.Hex(),
 		AdditionalAddressIDs: objectIDSliceToHex(consumer.AdditionalAddressIDs),
 		ContractedProducts:   objectIDSliceToHex(consumer.ContractedProducts),
+		Screening:            newConsumerScreeningResponse(consumer.Screening),
 		CreatedAt:            consumer.CreatedAt,
 		UpdatedAt:            consumer.UpdatedAt,
 	}
@@ -307,6 +324,10 @@ func NewConsumerResponse(consumer *entities.Consumer) ConsumerResponse {
 	if !consumer.UserID.IsZero() {
 		response.UserID = consumer.UserID.Hex()
 	}
+	if consumer.IsErased() {
+		erasedAt := consumer.ErasedAt
+		response.ErasedAt = &erasedAt
+	}
 
 	return response
 }
@@ -333,7 +354,7 @@ func newPersonalDataResponse(data entities.ConsumerPersonalData) ConsumerPersona
 			FullName:       individual.FullName,
 			SocialName:     individual.SocialName,
 			DocumentNumber: individual.DocumentNumber,
-			BirthDate:      individual.BirthDate,
+			BirthDate:      timePointer(individual.BirthDate),
 			Nationality:    individual.Nationality,
 			MaritalStatus:  individual.MaritalStatus,
 			Occupation:     individual.Occupation,
@@ -367,8 +388,8 @@ func newContactResponse(contact entities.ConsumerContactInformation) ConsumerCon
 func newCreditProfileResponse(profile entities.ConsumerCreditProfile) ConsumerCreditProfileResponse {
 	return ConsumerCreditProfileResponse{
 		CreditScore:             profile.CreditScore,
-		MonthlyIncome:           profile.MonthlyIncome,
-		AnnualRevenue:           profile.AnnualRevenue,
+		MonthlyIncome:           moneyPointer(profile.MonthlyIncome),
+		AnnualRevenue:           moneyPointer(profile.AnnualRevenue),
 		CreditLimitRequested:    profile.CreditLimitRequested,
 		CreditLimitApproved:     profile.CreditLimitApproved,
 		OutstandingDebt:         profile.OutstandingDebt,
@@ -378,9 +399,45 @@ func newCreditProfileResponse(profile entities.ConsumerCreditProfile) ConsumerCr
 		YearsInCurrentJob:       profile.YearsInCurrentJob,
 		YearsInBusiness:         profile.YearsInBusiness,
 		BankingRelationshipRank: profile.BankingRelationshipRank,
+		Bureau:                  newCreditBureauResponse(profile.Bureau),
 	}
 }
 
+func newCreditBureauResponse(bureau entities.CreditBureauData) *CreditBureauResponse {
+	if bureau.IsZero() {
+		return nil
+	}
+
+	return &CreditBureauResponse{
+		Source:                     bureau.Source,
+		RefreshedAt:                bureau.RefreshedAt,
+		ScoreRetrievedAt:           bureau.ScoreRetrievedAt,
+		NegativeRecords:            bureau.NegativeRecords,
+		NegativeRecordsAmount:      bureau.NegativeRecordsAmount,
+		NegativeRecordsRetrievedAt: bureau.NegativeRecordsRetrievedAt,
+		EstimatedIncome:            moneyPointer(bureau.EstimatedIncome),
+		IncomeRetrievedAt:          bureau.IncomeRetrievedAt,
+	}
+}
+
+// MaskConsumerResponse hides the fields tagged with masking rules in place.
+func MaskConsumerResponse(response *ConsumerResponse) {
+	masking.Struct(response)
+}
+
+// MaskConsumerResponseList hides the fields tagged with masking rules of every item in place.
+func MaskConsumerResponseList(responses []ConsumerResponse) {
+	masking.Struct(responses)
+}
+
+func timePointer(value time.Time) *time.Time {
+	return &value
+}
+
+func moneyPointer(value valueobjects.Money) *valueobjects.Money {
+	return &value
+}
+
 func objectIDSliceToHex(values []primitive.ObjectID) []string {
 	if len(values) == 0 {
 		return nil
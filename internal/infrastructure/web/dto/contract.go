@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// ContractRequest traz o valor e o prazo simulados antes da contratação; ambos são opcionais.
+type ContractRequest struct {
+	Amount     valueobjects.Money `json:"amount"`
+	TermMonths int                `json:"term_months"`
+}
+
+// ToDomain converte o payload nos termos de contratação do serviço.
+func (req ContractRequest) ToDomain() services.ContractTerms {
+	return services.ContractTerms{
+		Amount:     req.Amount,
+		TermMonths: req.TermMonths,
+	}
+}
+
+// ContractResponse expõe um contrato com o snapshot da faixa de preço aplicada.
+type ContractResponse struct {
+	ID                    string                `json:"id"`
+	ConsumerID            string                `json:"consumer_id"`
+	ProductID             string                `json:"product_id"`
+	PartnerID             string                `json:"partner_id,omitempty"`
+	ProductType           string                `json:"product_type"`
+	Status                string                `json:"status"`
+	Amount                valueobjects.Money    `json:"amount,omitzero"`
+	TermMonths            int                   `json:"term_months,omitempty"`
+	InterestRate          valueobjects.Rate     `json:"interest_rate"`
+	Installment           valueobjects.Money    `json:"installment,omitzero"`
+	CETMonthly            valueobjects.Rate     `json:"cet_monthly,omitzero"`
+	PricingTier           *entities.PricingTier `json:"pricing_tier,omitempty"`
+	IndexedRate           *IndexedRateResponse  `json:"indexed_rate,omitempty"`
+	FirstDueDate          string                `json:"first_due_date,omitempty"`
+	EarlyRepaymentAllowed bool                  `json:"early_repayment_allowed"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+	CancelledAt           *time.Time            `json:"cancelled_at,omitempty"`
+	SettledAt             *time.Time            `json:"settled_at,omitempty"`
+}
+
+// NewContractResponse converte a entidade em DTO de resposta.
+func NewContractResponse(contract *entities.Contract) ContractResponse {
+	if contract == nil {
+		return ContractResponse{}
+	}
+
+	response := ContractResponse{
+		ID:                    contract.ID.Hex(),
+		ConsumerID:            contract.ConsumerID.Hex(),
+		ProductID:             contract.ProductID.Hex(),
+		ProductType:           contract.ProductType.String(),
+		Status:                string(contract.Status),
+		Amount:                contract.Amount,
+		TermMonths:            contract.TermMonths,
+		InterestRate:          contract.InterestRate,
+		Installment:           contract.Installment,
+		CETMonthly:            contract.CETMonthly,
+		PricingTier:           contract.PricingTier,
+		IndexedRate:           newIndexedRateResponse(contract.IndexedRate, contract.IndexValue),
+		EarlyRepaymentAllowed: contract.EarlyRepaymentAllowed,
+		CreatedAt:             contract.CreatedAt,
+		UpdatedAt:             contract.UpdatedAt,
+	}
+
+	if !contract.PartnerID.IsZero() {
+		response.PartnerID = contract.PartnerID.Hex()
+	}
+	if !contract.FirstDueDate.IsZero() {
+		response.FirstDueDate = contract.FirstDueDate.Format(isoDateLayout)
+	}
+	if !contract.CancelledAt.IsZero() {
+		cancelledAt := contract.CancelledAt
+		response.CancelledAt = &cancelledAt
+	}
+	if !contract.SettledAt.IsZero() {
+		settledAt := contract.SettledAt
+		response.SettledAt = &settledAt
+	}
+
+	return response
+}
+
+// NewContractResponseList converte contratos em DTOs.
+func NewContractResponseList(contracts []*entities.Contract) []ContractResponse {
+	if len(contracts) == 0 {
+		return nil
+	}
+
+	responses := make([]ContractResponse, 0, len(contracts))
+	for _, contract := range contracts {
+		if contract == nil {
+			continue
+		}
+		responses = append(responses, NewContractResponse(contract))
+	}
+	return responses
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "katseye/internal/domain/services"
+
+// HealthResponse traz o estado geral da API e o de cada dependência verificada.
+type HealthResponse struct {
+	Status     string                    `json:"status"`
+	Components []HealthComponentResponse `json:"components"`
+}
+
+// HealthComponentResponse descreve uma dependência, como o estado do circuit breaker do Redis.
+type HealthComponentResponse struct {
+	Name    string            `json:"name"`
+	Status  string            `json:"status"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func NewHealthResponse(report services.HealthReport) HealthResponse {
+	resp := HealthResponse{
+		Status:     report.Status,
+		Components: make([]HealthComponentResponse, 0, len(report.Components)),
+	}
+
+	for _, component := range report.Components {
+		resp.Components = append(resp.Components, HealthComponentResponse{
+			Name:    component.Name,
+			Status:  component.Status,
+			Details: component.Details,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"katseye/internal/domain/entities"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// IndexSeriesRequest representa o lançamento manual de valores de um indexador.
+type IndexSeriesRequest struct {
+	Source string              `json:"source"`
+	Values []IndexValueRequest `json:"values"`
+}
+
+// IndexValueRequest é uma observação do indexador; rate é a taxa anual em percentual.
+type IndexValueRequest struct {
+	Date string            `json:"date"`
+	Rate valueobjects.Rate `json:"rate"`
+}
+
+// ToDomain converte o payload em valores do indexador informado na rota.
+func (req *IndexSeriesRequest) ToDomain(index valueobjects.RateIndex) ([]*entities.IndexValue, error) {
+	if req == nil {
+		return nil, fmt.Errorf("index series request is nil")
+	}
+
+	values := make([]*entities.IndexValue, 0, len(req.Values))
+	for i, item := range req.Values {
+		date, err := time.Parse(isoDateLayout, item.Date)
+		if err != nil {
+			return nil, fmt.Errorf("values[%d]: invalid date %q, expected YYYY-MM-DD", i, item.Date)
+		}
+		values = append(values, &entities.IndexValue{
+			Index:  index,
+			Date:   date,
+			Rate:   item.Rate,
+			Source: req.Source,
+		})
+	}
+
+	return values, nil
+}
+
+// IndexValueResponse expõe uma observação armazenada do indexador.
+type IndexValueResponse struct {
+	Index      string            `json:"index"`
+	Date       string            `json:"date"`
+	Rate       valueobjects.Rate `json:"rate"`
+	Source     string            `json:"source,omitempty"`
+	RecordedAt time.Time         `json:"recorded_at"`
+}
+
+// IndexSeriesResponse lista os valores de um indexador e o mais recente, usado nas projeções.
+type IndexSeriesResponse struct {
+	Index  string               `json:"index"`
+	Latest *IndexValueResponse  `json:"latest,omitempty"`
+	Values []IndexValueResponse `json:"values"`
+}
+
+// NewIndexValueResponse converte a entidade em DTO de resposta.
+func NewIndexValueResponse(value *entities.IndexValue) *IndexValueResponse {
+	if value == nil {
+		return nil
+	}
+
+	return &IndexValueResponse{
+		Index:      value.Index.String(),
+		Date:       value.Date.Format(isoDateLayout),
+		Rate:       value.Rate,
+		Source:     value.Source,
+		RecordedAt: value.RecordedAt,
+	}
+}
+
+// NewIndexSeriesResponse monta a resposta com a série e o último valor conhecido.
+func NewIndexSeriesResponse(index valueobjects.RateIndex, latest *entities.IndexValue, values []*entities.IndexValue) IndexSeriesResponse {
+	response := IndexSeriesResponse{
+		Index:  index.String(),
+		Latest: NewIndexValueResponse(latest),
+		Values: make([]IndexValueResponse, 0, len(values)),
+	}
+
+	for _, value := range values {
+		if item := NewIndexValueResponse(value); item != nil {
+			response.Values = append(response.Values, *item)
+		}
+	}
+
+	return response
+}
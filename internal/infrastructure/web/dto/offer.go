@@ -0,0 +1,263 @@
+package dto
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// OfferRequest representa o pedido de comparação de ofertas de crédito.
+type OfferRequest struct {
+	ConsumerID  string             `json:"consumer_id"`
+	Amount      valueobjects.Money `json:"amount"`
+	TermMonths  int                `json:"term_months"`
+	ProductType string             `json:"product_type"`
+}
+
+// ToDomain converte o payload na requisição do serviço de ofertas.
+func (req *OfferRequest) ToDomain() (services.OfferRequest, error) {
+	if req == nil {
+		return services.OfferRequest{}, fmt.Errorf("offer request is nil")
+	}
+
+	consumerID, err := primitive.ObjectIDFromHex(req.ConsumerID)
+	if err != nil {
+		return services.OfferRequest{}, fmt.Errorf("invalid consumer id: %w", err)
+	}
+
+	productType, err := valueobjects.NewProductType(req.ProductType)
+	if err != nil {
+		return services.OfferRequest{}, fmt.Errorf("invalid product type: %w", err)
+	}
+
+	return services.OfferRequest{
+		ConsumerID:  consumerID,
+		Amount:      req.Amount,
+		TermMonths:  req.TermMonths,
+		ProductType: productType,
+	}, nil
+}
+
+// OfferResultResponse lista as ofertas elegíveis em ordem de ranking e as recusadas com motivos.
+type OfferResultResponse struct {
+	ConsumerID  string                  `json:"consumer_id"`
+	Amount      valueobjects.Money      `json:"amount"`
+	TermMonths  int                     `json:"term_months"`
+	ProductType string                  `json:"product_type"`
+	Offers      []OfferResponse         `json:"offers"`
+	Rejected    []RejectedOfferResponse `json:"rejected"`
+}
+
+type OfferResponse struct {
+	Rank               int                `json:"rank"`
+	ProductID          string             `json:"product_id"`
+	ProductName        string             `json:"product_name"`
+	PartnerID          string             `json:"partner_id"`
+	PartnerName        string             `json:"partner_name"`
+	PricingTier        string             `json:"pricing_tier,omitempty"`
+	InterestRate       valueobjects.Rate  `json:"interest_rate"`
+	Installment        valueobjects.Money `json:"installment"`
+	UpfrontFees        valueobjects.Money `json:"upfront_fees"`
+	TotalPaid          valueobjects.Money `json:"total_paid"`
+	TotalCost          valueobjects.Money `json:"total_cost"`
+	CETMonthly         valueobjects.Rate  `json:"cet_monthly"`
+	CETAnnual          valueobjects.Rate  `json:"cet_annual"`
+	ProcessingTimeDays int                `json:"processing_time_days,omitempty"`
+	Reasons            []string           `json:"reasons"`
+}
+
+type RejectedOfferResponse struct {
+	ProductID   string   `json:"product_id"`
+	ProductName string   `json:"product_name"`
+	PartnerID   string   `json:"partner_id"`
+	PartnerName string   `json:"partner_name"`
+	Reasons     []string `json:"reasons"`
+}
+
+// NewOfferResultResponse converte o resultado do serviço em DTO de resposta.
+func NewOfferResultResponse(result *services.OfferResult) OfferResultResponse {
+	if result == nil {
+		return OfferResultResponse{}
+	}
+
+	response := OfferResultResponse{
+		ConsumerID:  result.Request.ConsumerID.Hex(),
+		Amount:      result.Request.Amount,
+		TermMonths:  result.Request.TermMonths,
+		ProductType: result.Request.ProductType.String(),
+		Offers:      make([]OfferResponse, 0, len(result.Offers)),
+		Rejected:    make([]RejectedOfferResponse, 0, len(result.Rejected)),
+	}
+
+	for _, offer := range result.Offers {
+		response.Offers = append(response.Offers, OfferResponse{
+			Rank:               offer.Rank,
+			ProductID:          offer.Product.ID.Hex(),
+			ProductName:        offer.Product.Name,
+			PartnerID:          offer.Partner.ID.Hex(),
+			PartnerName:        offer.Partner.Name,
+			PricingTier:        pricingTierName(offer.Tier),
+			InterestRate:       offer.Quote.MonthlyInterestRate,
+			Installment:        offer.Quote.Installment,
+			UpfrontFees:        offer.Quote.UpfrontFees,
+			TotalPaid:          offer.Quote.TotalPaid,
+			TotalCost:          offer.Quote.TotalCost,
+			CETMonthly:         offer.Quote.CETMonthly,
+			CETAnnual:          offer.Quote.CETAnnual,
+			ProcessingTimeDays: offer.ProcessingTimeDays,
+			Reasons:            offer.Reasons,
+		})
+	}
+
+	for _, rejected := range result.Rejected {
+		response.Rejected = append(response.Rejected, RejectedOfferResponse{
+			ProductID:   rejected.Product.ID.Hex(),
+			ProductName: rejected.Product.Name,
+			PartnerID:   rejected.Partner.ID.Hex(),
+			PartnerName: rejected.Partner.Name,
+			Reasons:     rejected.Reasons,
+		})
+	}
+
+	return response
+}
+
+// SimulationRequest representa o pedido de simulação de um produto para um consumidor.
+type SimulationRequest struct {
+	ConsumerID string             `json:"consumer_id"`
+	ProductID  string             `json:"product_id"`
+	Amount     valueobjects.Money `json:"amount"`
+	TermMonths int                `json:"term_months"`
+}
+
+// ToDomain converte o payload na requisição de simulação do serviço de ofertas.
+func (req *SimulationRequest) ToDomain() (services.SimulationRequest, error) {
+	if req == nil {
+		return services.SimulationRequest{}, fmt.Errorf("simulation request is nil")
+	}
+
+	consumerID, err := primitive.ObjectIDFromHex(req.ConsumerID)
+	if err != nil {
+		return services.SimulationRequest{}, fmt.Errorf("invalid consumer id: %w", err)
+	}
+
+	productID, err := primitive.ObjectIDFromHex(req.ProductID)
+	if err != nil {
+		return services.SimulationRequest{}, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	return services.SimulationRequest{
+		ConsumerID: consumerID,
+		ProductID:  productID,
+		Amount:     req.Amount,
+		TermMonths: req.TermMonths,
+	}, nil
+}
+
+// SimulationResponse expõe a cotação calculada com a faixa de preço aplicada ao consumidor.
+type SimulationResponse struct {
+	ProductID    string                `json:"product_id"`
+	ProductName  string                `json:"product_name"`
+	PartnerID    string                `json:"partner_id,omitempty"`
+	PartnerName  string                `json:"partner_name,omitempty"`
+	Amount       valueobjects.Money    `json:"amount"`
+	TermMonths   int                   `json:"term_months"`
+	PricingTier  *entities.PricingTier `json:"pricing_tier,omitempty"`
+	IndexedRate  *IndexedRateResponse  `json:"indexed_rate,omitempty"`
+	Eligible     bool                  `json:"eligible"`
+	InterestRate valueobjects.Rate     `json:"interest_rate"`
+	Installment  valueobjects.Money    `json:"installment"`
+	UpfrontFees  valueobjects.Money    `json:"upfront_fees"`
+	TotalPaid    valueobjects.Money    `json:"total_paid"`
+	TotalCost    valueobjects.Money    `json:"total_cost"`
+	CETMonthly   valueobjects.Rate     `json:"cet_monthly"`
+	CETAnnual    valueobjects.Rate     `json:"cet_annual"`
+	Reasons      []string              `json:"reasons,omitempty"`
+	Schedule     []InstallmentResponse `json:"schedule,omitempty"`
+}
+
+// IndexedRateResponse mostra como a taxa pós-fixada foi projetada a partir do último valor do índice.
+type IndexedRateResponse struct {
+	Index         string            `json:"index"`
+	Spread        valueobjects.Rate `json:"spread"`
+	Cap           valueobjects.Rate `json:"cap,omitzero"`
+	Floor         valueobjects.Rate `json:"floor,omitzero"`
+	IndexDate     string            `json:"index_date"`
+	IndexRate     valueobjects.Rate `json:"index_rate"`
+	EffectiveRate valueobjects.Rate `json:"effective_annual_rate"`
+}
+
+// InstallmentResponse é uma parcela projetada do cronograma de pagamentos.
+type InstallmentResponse struct {
+	Number    int                `json:"number"`
+	Payment   valueobjects.Money `json:"payment"`
+	Interest  valueobjects.Money `json:"interest"`
+	Principal valueobjects.Money `json:"principal"`
+	Balance   valueobjects.Money `json:"balance"`
+}
+
+// NewSimulationResponse converte a simulação do serviço em DTO de resposta.
+func NewSimulationResponse(simulation *services.Simulation) SimulationResponse {
+	if simulation == nil || simulation.Product == nil {
+		return SimulationResponse{}
+	}
+
+	response := SimulationResponse{
+		ProductID:    simulation.Product.ID.Hex(),
+		ProductName:  simulation.Product.Name,
+		Amount:       simulation.Request.Amount,
+		TermMonths:   simulation.Request.TermMonths,
+		PricingTier:  simulation.Tier,
+		Eligible:     simulation.Eligible,
+		InterestRate: simulation.Quote.MonthlyInterestRate,
+		Installment:  simulation.Quote.Installment,
+		UpfrontFees:  simulation.Quote.UpfrontFees,
+		TotalPaid:    simulation.Quote.TotalPaid,
+		TotalCost:    simulation.Quote.TotalCost,
+		CETMonthly:   simulation.Quote.CETMonthly,
+		CETAnnual:    simulation.Quote.CETAnnual,
+		Reasons:      simulation.Reasons,
+	}
+
+	if simulation.Partner != nil {
+		response.PartnerID = simulation.Partner.ID.Hex()
+		response.PartnerName = simulation.Partner.Name
+	}
+
+	response.IndexedRate = newIndexedRateResponse(simulation.IndexedRate, simulation.IndexValue)
+
+	if simulation.Eligible {
+		for _, installment := range simulation.Quote.Schedule() {
+			response.Schedule = append(response.Schedule, InstallmentResponse(installment))
+		}
+	}
+
+	return response
+}
+
+func newIndexedRateResponse(indexed *entities.IndexedRate, value *entities.IndexValue) *IndexedRateResponse {
+	if indexed == nil || value == nil {
+		return nil
+	}
+
+	return &IndexedRateResponse{
+		Index:         indexed.Index.String(),
+		Spread:        indexed.Spread,
+		Cap:           indexed.Cap,
+		Floor:         indexed.Floor,
+		IndexDate:     value.Date.Format(isoDateLayout),
+		IndexRate:     value.Rate,
+		EffectiveRate: indexed.AnnualRate(value.Rate),
+	}
+}
+
+func pricingTierName(tier *entities.PricingTier) string {
+	if tier == nil {
+		return ""
+	}
+	return tier.Name
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+)
+
+// ConsumerDataExportResponse representa o pacote de portabilidade de dados de um consumidor.
+type ConsumerDataExportResponse struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Consumer    ConsumerResponse     `json:"consumer"`
+	Addresses   []AddressResponse    `json:"addresses"`
+	User        *UserResponse        `json:"user,omitempty"`
+	Products    []ProductResponse    `json:"contracted_products"`
+	Contracts   []ContractResponse   `json:"contracts"`
+	AuditEvents []AuditEventResponse `json:"audit_events"`
+}
+
+// AuditEventResponse representa um evento de auditoria exposto via HTTP.
+type AuditEventResponse struct {
+	ID         string            `json:"id"`
+	Action     string            `json:"action"`
+	ActorID    string            `json:"actor_id,omitempty"`
+	Details    map[string]string `json:"details,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// ErasureReportResponse representa o relatório assinado de eliminação de dados.
+type ErasureReportResponse struct {
+	ConsumerID         string                       `json:"consumer_id"`
+	RequestedBy        string                       `json:"requested_by,omitempty"`
+	CompletedAt        time.Time                    `json:"completed_at"`
+	Items              []services.ErasureReportItem `json:"items"`
+	SignatureAlgorithm string                       `json:"signature_algorithm"`
+	Signature          string                       `json:"signature"`
+}
+
+// NewConsumerDataExportResponse converte a exportação do domínio em DTO.
+func NewConsumerDataExportResponse(export *services.ConsumerDataExport) ConsumerDataExportResponse {
+	if export == nil {
+		return ConsumerDataExportResponse{}
+	}
+
+	response := ConsumerDataExportResponse{
+		GeneratedAt: export.GeneratedAt,
+		Consumer:    NewConsumerResponse(export.Consumer),
+		Addresses:   NewAddressResponseList(export.Addresses),
+		Products:    NewProductResponseList(export.ContractedProducts),
+		Contracts:   NewContractResponseList(export.Contracts),
+		AuditEvents: NewAuditEventResponseList(export.AuditEvents),
+	}
+
+	if export.User != nil {
+		user := NewUserResponse(export.User)
+		response.User = &user
+	}
+
+	return response
+}
+
+// NewAuditEventResponseList converte eventos de auditoria em DTOs.
+func NewAuditEventResponseList(events []*entities.AuditEvent) []AuditEventResponse {
+	if len(events) == 0 {
+		return nil
+	}
+
+	responses := make([]AuditEventResponse, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		response := AuditEventResponse{
+			ID:         event.ID.Hex(),
+			Action:     event.Action,
+			Details:    event.Details,
+			OccurredAt: event.OccurredAt,
+		}
+		if !event.ActorID.IsZero() {
+			response.ActorID = event.ActorID.Hex()
+		}
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
+// NewErasureReportResponse converte o relatório de eliminação em DTO.
+func NewErasureReportResponse(report *services.ErasureReport) ErasureReportResponse {
+	if report == nil {
+		return ErasureReportResponse{}
+	}
+
+	response := ErasureReportResponse{
+		ConsumerID:         report.ConsumerID.Hex(),
+		CompletedAt:        report.CompletedAt,
+		Items:              report.Items,
+		SignatureAlgorithm: report.SignatureAlgorithm,
+		Signature:          report.Signature,
+	}
+
+	if !report.RequestedBy.IsZero() {
+		response.RequestedBy = report.RequestedBy.Hex()
+	}
+
+	return response
+}
@@ -11,21 +11,23 @@ import (
 
 // ProductRequest representa o payload de entrada para criação/atualização de produtos.
 type ProductRequest struct {
-	Name        string                     `json:"product_name"`
-	Category    string                     `json:"product_category"`
-	PartnerID   string                     `json:"partner_id"`
-	ProductType string                     `json:"product_type"`
-	Attributes  entities.ProductAttributes `json:"product_attributes"`
+	Name         string                     `json:"product_name"`
+	Category     string                     `json:"product_category"`
+	PartnerID    string                     `json:"partner_id"`
+	ProductType  string                     `json:"product_type"`
+	Attributes   entities.ProductAttributes `json:"product_attributes"`
+	PricingTiers []entities.PricingTier     `json:"pricing_tiers,omitempty"`
 }
 
 // ProductResponse padroniza a saída HTTP para recursos de produto.
 type ProductResponse struct {
-	ID          string                     `json:"id"`
-	Name        string                     `json:"product_name"`
-	Category    string                     `json:"product_category"`
-	PartnerID   string                     `json:"partner_id"`
-	ProductType string                     `json:"product_type"`
-	Attributes  entities.ProductAttributes `json:"product_attributes"`
+	ID           string                     `json:"id"`
+	Name         string                     `json:"product_name"`
+	Category     string                     `json:"product_category"`
+	PartnerID    string                     `json:"partner_id"`
+	ProductType  string                     `json:"product_type"`
+	Attributes   entities.ProductAttributes `json:"product_attributes"`
+	PricingTiers []entities.PricingTier     `json:"pricing_tiers,omitempty"`
 }
 
 // ToEntity converte o DTO em uma entidade de domínio pronta para validação.
@@ -45,12 +47,13 @@ func (req *ProductRequest) ToEntity(id primitive.ObjectID) (*entities.Product, e
 	}
 
 	product := &entities.Product{
-		ID:          id,
-		Name:        req.Name,
-		Category:    valueobjects.ProductCategory(req.Category),
-		Attributes:  req.Attributes,
-		PartnerID:   partnerID,
-		ProductType: productType,
+		ID:           id,
+		Name:         req.Name,
+		Category:     valueobjects.ProductCategory(req.Category),
+		Attributes:   req.Attributes,
+		PartnerID:    partnerID,
+		ProductType:  productType,
+		PricingTiers: req.PricingTiers,
 	}
 
 	return product, nil
@@ -63,12 +66,13 @@ func NewProductResponse(product *entities.Product) ProductResponse {
 	}
 
 	response := ProductResponse{
-		ID:          product.ID.Hex(),
-		Name:        product.Name,
-		Category:    string(product.Category),
-		PartnerID:   product.PartnerID.Hex(),
-		ProductType: product.ProductType.String(),
-		Attributes:  product.Attributes,
+		ID:           product.ID.Hex(),
+		Name:         product.Name,
+		Category:     string(product.Category),
+		PartnerID:    product.PartnerID.Hex(),
+		ProductType:  product.ProductType.String(),
+		Attributes:   product.Attributes,
+		PricingTiers: product.PricingTiers,
 	}
 
 	return response
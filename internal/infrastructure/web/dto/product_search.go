@@ -0,0 +1,50 @@
+package dto
+
+import "katseye/internal/domain/repositories"
+
+// ProductSearchResponse agrupa os produtos encontrados, o total e as contagens por faceta.
+type ProductSearchResponse struct {
+	Items  []ProductResponse     `json:"items"`
+	Total  int64                 `json:"total"`
+	Facets ProductFacetsResponse `json:"facets"`
+}
+
+// ProductFacetsResponse expõe as contagens calculadas sobre todos os resultados da busca.
+type ProductFacetsResponse struct {
+	ProductTypes []FacetCountResponse `json:"product_type"`
+	Categories   []FacetCountResponse `json:"product_category"`
+	PartnerTypes []FacetCountResponse `json:"partner_type"`
+	RiskLevels   []FacetCountResponse `json:"risk_level"`
+}
+
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// NewProductSearchResponse converte o resultado da busca em DTO de resposta.
+func NewProductSearchResponse(result repositories.ProductSearchResult) ProductSearchResponse {
+	items := NewProductResponseList(result.Products)
+	if items == nil {
+		items = []ProductResponse{}
+	}
+
+	return ProductSearchResponse{
+		Items: items,
+		Total: result.Total,
+		Facets: ProductFacetsResponse{
+			ProductTypes: newFacetCountResponses(result.Facets.ProductTypes),
+			Categories:   newFacetCountResponses(result.Facets.Categories),
+			PartnerTypes: newFacetCountResponses(result.Facets.PartnerTypes),
+			RiskLevels:   newFacetCountResponses(result.Facets.RiskLevels),
+		},
+	}
+}
+
+func newFacetCountResponses(counts []repositories.FacetCount) []FacetCountResponse {
+	responses := make([]FacetCountResponse, 0, len(counts))
+	for _, count := range counts {
+		responses = append(responses, FacetCountResponse{Value: count.Value, Count: count.Count})
+	}
+	return responses
+}
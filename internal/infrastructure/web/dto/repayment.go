@@ -0,0 +1,146 @@
+package dto
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
+)
+
+// PaymentRequest registra um pagamento recebido; type é "regular" (padrão) ou "early_repayment".
+// Uma quitação antecipada sem valor liquida todo o saldo do contrato.
+type PaymentRequest struct {
+	Type   string             `json:"type"`
+	Amount valueobjects.Money `json:"amount"`
+	PaidAt *time.Time         `json:"paid_at"`
+}
+
+// ToDomain converte o payload na requisição de pagamento do serviço.
+func (req PaymentRequest) ToDomain() services.PaymentRequest {
+	paymentType := entities.PaymentType(req.Type)
+	if req.Type == "" {
+		paymentType = entities.PaymentTypeRegular
+	}
+
+	request := services.PaymentRequest{
+		Type:   paymentType,
+		Amount: req.Amount,
+	}
+	if req.PaidAt != nil {
+		request.PaidAt = *req.PaidAt
+	}
+	return request
+}
+
+// ContractInstallmentResponse expõe uma parcela com os encargos de atraso e o saldo devedor.
+type ContractInstallmentResponse struct {
+	ID           string             `json:"id"`
+	ContractID   string             `json:"contract_id"`
+	Number       int                `json:"number"`
+	DueDate      string             `json:"due_date"`
+	Status       string             `json:"status"`
+	Amount       valueobjects.Money `json:"amount"`
+	Principal    valueobjects.Money `json:"principal"`
+	Interest     valueobjects.Money `json:"interest"`
+	Discount     valueobjects.Money `json:"discount,omitzero"`
+	Paid         valueobjects.Money `json:"paid"`
+	Fine         valueobjects.Money `json:"fine,omitzero"`
+	LateInterest valueobjects.Money `json:"late_interest,omitzero"`
+	Outstanding  valueobjects.Money `json:"outstanding"`
+	DaysOverdue  int                `json:"days_overdue,omitempty"`
+	PaidAt       *time.Time         `json:"paid_at,omitempty"`
+}
+
+// NewContractInstallmentResponse converte a entidade em DTO de resposta.
+func NewContractInstallmentResponse(installment *entities.Installment) ContractInstallmentResponse {
+	if installment == nil {
+		return ContractInstallmentResponse{}
+	}
+
+	response := ContractInstallmentResponse{
+		ID:           installment.ID.Hex(),
+		ContractID:   installment.ContractID.Hex(),
+		Number:       installment.Number,
+		DueDate:      installment.DueDate.Format(isoDateLayout),
+		Status:       string(installment.Status),
+		Amount:       installment.Amount,
+		Principal:    installment.Principal,
+		Interest:     installment.Interest,
+		Discount:     installment.Discount,
+		Paid:         installment.Paid.Add(installment.ChargesPaid),
+		Fine:         installment.Fine,
+		LateInterest: installment.LateInterest,
+		Outstanding:  installment.Outstanding(),
+		DaysOverdue:  installment.DaysOverdue,
+	}
+	if !installment.PaidAt.IsZero() {
+		paidAt := installment.PaidAt
+		response.PaidAt = &paidAt
+	}
+	return response
+}
+
+// NewContractInstallmentResponseList converte a lista de parcelas em DTOs.
+func NewContractInstallmentResponseList(installments []*entities.Installment) []ContractInstallmentResponse {
+	responses := make([]ContractInstallmentResponse, 0, len(installments))
+	for _, installment := range installments {
+		responses = append(responses, NewContractInstallmentResponse(installment))
+	}
+	return responses
+}
+
+// PaymentAllocationResponse mostra quanto do pagamento foi para cada parcela.
+type PaymentAllocationResponse struct {
+	InstallmentNumber int                `json:"installment_number"`
+	Amount            valueobjects.Money `json:"amount"`
+	InterestWaived    valueobjects.Money `json:"interest_waived,omitzero"`
+}
+
+// PaymentResponse expõe um pagamento lançado e sua distribuição entre as parcelas.
+type PaymentResponse struct {
+	ID             string                      `json:"id"`
+	ContractID     string                      `json:"contract_id"`
+	Type           string                      `json:"type"`
+	Amount         valueobjects.Money          `json:"amount"`
+	InterestWaived valueobjects.Money          `json:"interest_waived,omitzero"`
+	Allocations    []PaymentAllocationResponse `json:"allocations"`
+	PaidAt         time.Time                   `json:"paid_at"`
+	CreatedAt      time.Time                   `json:"created_at"`
+}
+
+// NewPaymentResponse converte a entidade em DTO de resposta.
+func NewPaymentResponse(payment *entities.Payment) PaymentResponse {
+	if payment == nil {
+		return PaymentResponse{}
+	}
+
+	allocations := make([]PaymentAllocationResponse, 0, len(payment.Allocations))
+	for _, allocation := range payment.Allocations {
+		allocations = append(allocations, PaymentAllocationResponse{
+			InstallmentNumber: allocation.InstallmentNumber,
+			Amount:            allocation.Amount,
+			InterestWaived:    allocation.InterestWaived,
+		})
+	}
+
+	return PaymentResponse{
+		ID:             payment.ID.Hex(),
+		ContractID:     payment.ContractID.Hex(),
+		Type:           string(payment.Type),
+		Amount:         payment.Amount,
+		InterestWaived: payment.InterestWaived(),
+		Allocations:    allocations,
+		PaidAt:         payment.PaidAt,
+		CreatedAt:      payment.CreatedAt,
+	}
+}
+
+// NewPaymentResponseList converte a lista de pagamentos em DTOs.
+func NewPaymentResponseList(payments []*entities.Payment) []PaymentResponse {
+	responses := make([]PaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		responses = append(responses, NewPaymentResponse(payment))
+	}
+	return responses
+}
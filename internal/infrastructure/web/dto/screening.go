@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"time"
+
+	"katseye/internal/domain/entities"
+)
+
+// ConsumerScreeningResponse expõe o resultado da triagem de fraude feita no cadastro.
+type ConsumerScreeningResponse struct {
+	Score      int       `json:"score"`
+	Status     string    `json:"status"`
+	ScreenedAt time.Time `json:"screened_at"`
+}
+
+func newConsumerScreeningResponse(screening entities.ConsumerScreening) *ConsumerScreeningResponse {
+	if screening == (entities.ConsumerScreening{}) {
+		return nil
+	}
+
+	return &ConsumerScreeningResponse{
+		Score:      screening.Score,
+		Status:     string(screening.Status),
+		ScreenedAt: screening.ScreenedAt,
+	}
+}
+
+// ReviewDecisionRequest registra a decisão do analista; as observações são opcionais.
+type ReviewDecisionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// ScreeningReviewResponse expõe um item da fila de revisão manual com as regras disparadas.
+type ScreeningReviewResponse struct {
+	ID         string                  `json:"id"`
+	Stage      string                  `json:"stage"`
+	ConsumerID string                  `json:"consumer_id"`
+	ContractID string                  `json:"contract_id,omitempty"`
+	Score      int                     `json:"score"`
+	Flags      []ScreeningFlagResponse `json:"flags"`
+	Status     string                  `json:"status"`
+	ReviewerID string                  `json:"reviewer_id,omitempty"`
+	Notes      string                  `json:"notes,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+	ReviewedAt *time.Time              `json:"reviewed_at,omitempty"`
+}
+
+type ScreeningFlagResponse struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// NewScreeningReviewResponse converte a entidade em DTO de resposta.
+func NewScreeningReviewResponse(review *entities.ScreeningReview) ScreeningReviewResponse {
+	if review == nil {
+		return ScreeningReviewResponse{}
+	}
+
+	flags := make([]ScreeningFlagResponse, 0, len(review.Flags))
+	for _, flag := range review.Flags {
+		flags = append(flags, ScreeningFlagResponse(flag))
+	}
+
+	response := ScreeningReviewResponse{
+		ID:         review.ID.Hex(),
+		Stage:      string(review.Stage),
+		ConsumerID: review.ConsumerID.Hex(),
+		Score:      review.Score,
+		Flags:      flags,
+		Status:     string(review.Status),
+		Notes:      review.Notes,
+		CreatedAt:  review.CreatedAt,
+	}
+	if !review.ContractID.IsZero() {
+		response.ContractID = review.ContractID.Hex()
+	}
+	if !review.ReviewerID.IsZero() {
+		response.ReviewerID = review.ReviewerID.Hex()
+	}
+	if !review.ReviewedAt.IsZero() {
+		response.ReviewedAt = timePointer(review.ReviewedAt)
+	}
+
+	return response
+}
+
+// NewScreeningReviewResponseList converte a lista de entidades em DTOs de resposta.
+func NewScreeningReviewResponseList(reviews []*entities.ScreeningReview) []ScreeningReviewResponse {
+	responses := make([]ScreeningReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		responses = append(responses, NewScreeningReviewResponse(review))
+	}
+	return responses
+}
@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookSubscriptionRequest cadastra ou altera uma inscrição de webhook do parceiro. Sem
+// secret, um segredo é gerado na criação e mantido nas alterações.
+type WebhookSubscriptionRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	Secret     string   `json:"secret"`
+	Active     *bool    `json:"active"`
+}
+
+// ToEntity converte o payload na entidade de domínio; inscrições nascem ativas.
+func (req WebhookSubscriptionRequest) ToEntity(partnerID, id primitive.ObjectID) *entities.WebhookSubscription {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &entities.WebhookSubscription{
+		ID:         id,
+		PartnerID:  partnerID,
+		URL:        req.URL,
+		EventTypes: append([]string(nil), req.EventTypes...),
+		Secret:     req.Secret,
+		Active:     active,
+	}
+}
+
+// WebhookSubscriptionResponse expõe a inscrição; o segredo só é devolvido na criação.
+type WebhookSubscriptionResponse struct {
+	ID         string    `json:"id"`
+	PartnerID  string    `json:"partner_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewWebhookSubscriptionResponse converte a entidade em DTO de resposta sem o segredo.
+func NewWebhookSubscriptionResponse(subscription *entities.WebhookSubscription) WebhookSubscriptionResponse {
+	if subscription == nil {
+		return WebhookSubscriptionResponse{}
+	}
+
+	return WebhookSubscriptionResponse{
+		ID:         subscription.ID.Hex(),
+		PartnerID:  subscription.PartnerID.Hex(),
+		URL:        subscription.URL,
+		EventTypes: append([]string(nil), subscription.EventTypes...),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+// NewWebhookSubscriptionResponseList converte a lista de entidades em DTOs de resposta.
+func NewWebhookSubscriptionResponseList(subscriptions []*entities.WebhookSubscription) []WebhookSubscriptionResponse {
+	responses := make([]WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, NewWebhookSubscriptionResponse(subscription))
+	}
+	return responses
+}
+
+// WebhookDeliveryResponse expõe uma entrega com o corpo enviado e o resultado das tentativas.
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// NewWebhookDeliveryResponse converte a entidade em DTO de resposta.
+func NewWebhookDeliveryResponse(delivery *entities.WebhookDelivery) WebhookDeliveryResponse {
+	if delivery == nil {
+		return WebhookDeliveryResponse{}
+	}
+
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID.Hex(),
+		SubscriptionID: delivery.SubscriptionID.Hex(),
+		EventID:        delivery.EventID.Hex(),
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.Status == entities.WebhookDeliveryPending && !delivery.NextAttemptAt.IsZero() {
+		response.NextAttemptAt = timePointer(delivery.NextAttemptAt)
+	}
+	if !delivery.LastAttemptAt.IsZero() {
+		response.LastAttemptAt = timePointer(delivery.LastAttemptAt)
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		response.DeliveredAt = timePointer(delivery.DeliveredAt)
+	}
+
+	return response
+}
+
+// NewWebhookDeliveryResponseList converte a lista de entidades em DTOs de resposta.
+func NewWebhookDeliveryResponseList(deliveries []*entities.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, NewWebhookDeliveryResponse(delivery))
+	}
+	return responses
+}
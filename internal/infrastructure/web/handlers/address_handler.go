@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"strings"
+
+	"katseye/internal/domain/repositories"
 	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
 	"katseye/internal/infrastructure/web/dto"
 	"katseye/internal/infrastructure/web/response"
 
@@ -118,13 +122,39 @@ func (h *AddressHandler) DeleteAddress(c *gin.Context) {
 }
 
 func (h *AddressHandler) ListAddresses(c *gin.Context) {
-	filter := make(map[string]interface{})
+	page, err := parsePagination(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	query := repositories.AddressQuery{
+		City:       strings.TrimSpace(c.Query("city")),
+		State:      strings.TrimSpace(c.Query("state")),
+		PostalCode: strings.TrimSpace(c.Query("postal_code")),
+		Page:       page,
+	}
+
+	if raw := c.Query("type"); raw != "" {
+		addressType := valueobjects.AddressType(strings.ToLower(strings.TrimSpace(raw)))
+		switch addressType {
+		case valueobjects.AddressTypeHome, valueobjects.AddressTypeWork:
+			query.Type = addressType
+		default:
+			response.NewBadRequestResponse(c, "Invalid address type", "type must be home or work")
+			return
+		}
+	}
 
-	addresses, err := h.addressService.ListAddresses(c.Request.Context(), filter)
+	addresses, pageInfo, err := h.addressService.ListAddresses(c.Request.Context(), query)
 	if err != nil {
+		if isListQueryError(err) {
+			response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+			return
+		}
 		response.NewInternalServerErrorResponse(c, "Failed to retrieve addresses", err.Error())
 		return
 	}
 
-	response.NewSuccessResponse(c, "Addresses retrieved successfully", dto.NewAddressResponseList(addresses))
+	response.NewPaginatedResponse(c, "Addresses retrieved successfully", dto.NewAddressResponseList(addresses), paginationMeta(pageInfo))
 }
@@ -1,9 +1,7 @@
 package handlers
 
 import (
-	"context"
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 
@@ -18,32 +16,29 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-const defaultTokenTTL = 24 * time.Hour
 const claimsContextKey = "jwt_claims"
 const rawTokenContextKey = "jwt_raw_token"
 
 type AuthHandler struct {
-	authService     *services.AuthService
-	tokenService    *services.TokenService
-	partnerService  *services.PartnerService
-	consumerService *services.ConsumerService
-	secret          []byte
-	tokenTTL        time.Duration
+	authService    *services.AuthService
+	tokenService   *services.TokenService
+	accountService *services.UserAccountService
+	secret         []byte
+	tokenTTL       time.Duration
 }
 
-func NewAuthHandler(service *services.AuthService, tokenService *services.TokenService, partnerService *services.PartnerService, consumerService *services.ConsumerService, secret string) *AuthHandler {
+func NewAuthHandler(service *services.AuthService, tokenService *services.TokenService, accountService *services.UserAccountService, secret string) *AuthHandler {
 	secret = strings.TrimSpace(secret)
 	if service == nil || secret == "" {
 		return nil
 	}
 
 	return &AuthHandler{
-		authService:     service,
-		tokenService:    tokenService,
-		partnerService:  partnerService,
-		consumerService: consumerService,
-		secret:          []byte(secret),
-		tokenTTL:        defaultTokenTTL,
+		authService:    service,
+		tokenService:   tokenService,
+		accountService: accountService,
+		secret:         []byte(secret),
+		tokenTTL:       services.TokenTTL,
 	}
 }
 
@@ -125,7 +120,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 }
 
 func (h *AuthHandler) CreateUser(c *gin.Context) {
-	if h == nil || h.authService == nil {
+	if h == nil || h.accountService == nil {
 		response.NewInternalServerErrorResponse(c, "Authentication service unavailable", "handler not configured")
 		return
 	}
@@ -177,78 +172,25 @@ func (h *AuthHandler) CreateUser(c *gin.Context) {
 		}
 	}
 
-	ctx := c.Request.Context()
-
-	switch profileType {
-	case entities.ProfileTypePartnerManager:
-		if h.partnerService == nil {
-			response.NewInternalServerErrorResponse(c, "Partner service unavailable", "partner service not configured")
-			return
-		}
-		partner, err := h.partnerService.GetPartnerByID(ctx, profileID)
-		if err != nil {
-			response.NewInternalServerErrorResponse(c, "Failed to validate partner", err.Error())
-			return
-		}
-		if partner == nil {
-			response.NewNotFoundResponse(c, "Partner not found", "linked partner does not exist")
-			return
-		}
-	case entities.ProfileTypeConsumer:
-		if h.consumerService == nil {
-			response.NewInternalServerErrorResponse(c, "Consumer service unavailable", "consumer service not configured")
-			return
-		}
-		consumer, err := h.consumerService.GetConsumerByID(ctx, profileID)
-		if err != nil {
-			response.NewInternalServerErrorResponse(c, "Failed to validate consumer", err.Error())
-			return
-		}
-		if consumer == nil {
-			response.NewNotFoundResponse(c, "Consumer not found", "linked consumer does not exist")
-			return
-		}
-		if consumer.HasLinkedUser() {
-			response.NewConflictResponse(c, "Consumer already linked", "the consumer already has an authentication profile")
-			return
-		}
-	}
-
-	user, err := h.authService.CreateUser(ctx, email, password, active, role, req.Permissions, profileType, profileID)
+	user, err := h.accountService.CreateUser(c.Request.Context(), services.NewUserAccount{
+		Email:       email,
+		Password:    password,
+		Active:      active,
+		Role:        role,
+		Permissions: req.Permissions,
+		ProfileType: profileType,
+		ProfileID:   profileID,
+	})
 	if err != nil {
-		switch err {
-		case services.ErrInvalidUserData:
-			response.NewBadRequestResponse(c, "Invalid user data", err.Error())
-		case services.ErrInvalidRole:
-			response.NewBadRequestResponse(c, "Invalid role", err.Error())
-		case services.ErrInvalidProfileType:
-			response.NewBadRequestResponse(c, "Invalid profile type", err.Error())
-		case services.ErrUserAlreadyExists:
-			response.NewConflictResponse(c, "User already exists", err.Error())
-		default:
-			response.NewInternalServerErrorResponse(c, "Failed to create user", err.Error())
-		}
+		respondUserAccountError(c, "Failed to create user", err)
 		return
 	}
 
-	switch profileType {
-	case entities.ProfileTypePartnerManager:
-		if err := h.partnerService.AssignManagerProfile(ctx, profileID, user.ID); err != nil {
-			h.handleProfileLinkError(c, user.ID, err)
-			return
-		}
-	case entities.ProfileTypeConsumer:
-		if err := h.consumerService.AttachUserProfile(ctx, profileID, user.ID); err != nil {
-			h.handleProfileLinkError(c, user.ID, err)
-			return
-		}
-	}
-
 	response.NewCreatedResponse(c, "User created successfully", dto.NewUserResponse(user))
 }
 
 func (h *AuthHandler) DeleteUser(c *gin.Context) {
-	if h == nil || h.authService == nil {
+	if h == nil || h.accountService == nil {
 		response.NewInternalServerErrorResponse(c, "Authentication service unavailable", "handler not configured")
 		return
 	}
@@ -275,57 +217,8 @@ func (h *AuthHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	ctx := c.Request.Context()
-	target, err := h.authService.GetUserByID(ctx, id)
-	if err != nil {
-		switch err {
-		case services.ErrInvalidUserData:
-			response.NewBadRequestResponse(c, "Invalid user data", err.Error())
-		case services.ErrUserNotFound:
-			response.NewNotFoundResponse(c, "User not found", err.Error())
-		default:
-			response.NewInternalServerErrorResponse(c, "Failed to retrieve user", err.Error())
-		}
-		return
-	}
-	if target == nil {
-		response.NewNotFoundResponse(c, "User not found", "user does not exist")
-		return
-	}
-
-	rollback := func(context.Context) error { return nil }
-
-	switch target.ProfileType {
-	case entities.ProfileTypePartnerManager:
-		if err := h.preparePartnerManagerRemoval(ctx, target); err != nil {
-			h.respondProfileRemovalError(c, err)
-			return
-		}
-		rollback = func(ctx context.Context) error {
-			return h.reassignPartnerManager(ctx, target)
-		}
-	case entities.ProfileTypeConsumer:
-		if err := h.prepareConsumerDetachment(ctx, target); err != nil {
-			h.respondProfileRemovalError(c, err)
-			return
-		}
-		rollback = func(ctx context.Context) error {
-			return h.consumerService.AttachUserProfile(ctx, target.ProfileID, target.ID)
-		}
-	}
-
-	if err := h.authService.DeleteUser(ctx, id); err != nil {
-		if revertErr := rollback(ctx); revertErr != nil {
-			err = fmt.Errorf("%w; rollback failed: %v", err, revertErr)
-		}
-		switch err {
-		case services.ErrInvalidUserData:
-			response.NewBadRequestResponse(c, "Invalid user data", err.Error())
-		case services.ErrUserNotFound:
-			response.NewNotFoundResponse(c, "User not found", err.Error())
-		default:
-			response.NewInternalServerErrorResponse(c, "Failed to delete user", err.Error())
-		}
+	if err := h.accountService.DeleteUser(c.Request.Context(), id); err != nil {
+		respondUserAccountError(c, "Failed to delete user", err)
 		return
 	}
 
@@ -455,95 +348,39 @@ func (h *AuthHandler) generateToken(user *entities.User) (string, error) {
 	return token.SignedString(h.secret)
 }
 
-func (h *AuthHandler) handleProfileLinkError(c *gin.Context, userID primitive.ObjectID, linkErr error) {
-	if linkErr == nil {
-		return
-	}
-
-	if rollbackErr := h.rollbackUser(c.Request.Context(), userID); rollbackErr != nil {
-		linkErr = fmt.Errorf("%w; rollback failed: %v", linkErr, rollbackErr)
-	}
-
-	switch {
-	case errors.Is(linkErr, services.ErrPartnerNotFound):
-		response.NewNotFoundResponse(c, "Partner not found", linkErr.Error())
-	case errors.Is(linkErr, services.ErrPartnerManagerAlreadyLinked):
-		response.NewConflictResponse(c, "Manager already linked to partner", linkErr.Error())
-	case errors.Is(linkErr, services.ErrPartnerRepositoryUnavailable):
-		response.NewInternalServerErrorResponse(c, "Partner service unavailable", linkErr.Error())
-	case errors.Is(linkErr, services.ErrConsumerNotFound):
-		response.NewNotFoundResponse(c, "Consumer not found", linkErr.Error())
-	case errors.Is(linkErr, services.ErrConsumerUserAlreadyLinked):
-		response.NewConflictResponse(c, "Consumer already linked", linkErr.Error())
-	case errors.Is(linkErr, services.ErrConsumerRepositoryUnavailable):
-		response.NewInternalServerErrorResponse(c, "Consumer service unavailable", linkErr.Error())
-	default:
-		response.NewInternalServerErrorResponse(c, "Failed to link user profile", linkErr.Error())
-	}
-}
-
-func (h *AuthHandler) rollbackUser(ctx context.Context, userID primitive.ObjectID) error {
-	if h == nil || h.authService == nil || userID.IsZero() {
-		return nil
-	}
-
-	if err := h.authService.DeleteUser(ctx, userID); err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			return nil
-		}
-		return err
-	}
-
-	return nil
-}
-
-func (h *AuthHandler) preparePartnerManagerRemoval(ctx context.Context, user *entities.User) error {
-	if h == nil || h.partnerService == nil {
-		return services.ErrPartnerRepositoryUnavailable
-	}
-	if user == nil || user.ProfileID.IsZero() {
-		return nil
-	}
-
-	return h.partnerService.RemoveManagerProfile(ctx, user.ProfileID, user.ID)
-}
-
-func (h *AuthHandler) reassignPartnerManager(ctx context.Context, user *entities.User) error {
-	if h == nil || h.partnerService == nil || user == nil || user.ProfileID.IsZero() {
-		return nil
-	}
-
-	return h.partnerService.AssignManagerProfile(ctx, user.ProfileID, user.ID)
-}
-
-func (h *AuthHandler) prepareConsumerDetachment(ctx context.Context, user *entities.User) error {
-	if h == nil || h.consumerService == nil {
-		return services.ErrConsumerRepositoryUnavailable
-	}
-	if user == nil || user.ProfileID.IsZero() {
-		return nil
-	}
-
-	return h.consumerService.DetachUserProfile(ctx, user.ProfileID)
-}
-
-func (h *AuthHandler) respondProfileRemovalError(c *gin.Context, err error) {
+// respondUserAccountError maps the errors of creating or deleting a user and of linking or
+// unlinking its profile.
+func respondUserAccountError(c *gin.Context, message string, err error) {
 	switch {
+	case errors.Is(err, services.ErrInvalidUserData):
+		response.NewBadRequestResponse(c, "Invalid user data", err.Error())
+	case errors.Is(err, services.ErrInvalidRole):
+		response.NewBadRequestResponse(c, "Invalid role", err.Error())
+	case errors.Is(err, services.ErrInvalidProfileType):
+		response.NewBadRequestResponse(c, "Invalid profile type", err.Error())
+	case errors.Is(err, services.ErrUserAlreadyExists):
+		response.NewConflictResponse(c, "User already exists", err.Error())
+	case errors.Is(err, services.ErrUserNotFound):
+		response.NewNotFoundResponse(c, "User not found", err.Error())
+	case errors.Is(err, services.ErrPartnerNotFound):
+		response.NewNotFoundResponse(c, "Partner not found", err.Error())
+	case errors.Is(err, services.ErrPartnerManagerAlreadyLinked):
+		response.NewConflictResponse(c, "Manager already linked to partner", err.Error())
 	case errors.Is(err, services.ErrPartnerManagerRequired):
 		response.NewConflictResponse(c, "Partner must retain a manager", err.Error())
 	case errors.Is(err, services.ErrPartnerManagerNotLinked):
 		response.NewNotFoundResponse(c, "Manager not linked to partner", err.Error())
-	case errors.Is(err, services.ErrPartnerNotFound):
-		response.NewNotFoundResponse(c, "Partner not found", err.Error())
 	case errors.Is(err, services.ErrPartnerRepositoryUnavailable):
 		response.NewInternalServerErrorResponse(c, "Partner service unavailable", err.Error())
-	case errors.Is(err, services.ErrConsumerUserNotLinked):
-		response.NewNotFoundResponse(c, "Consumer user link not found", err.Error())
 	case errors.Is(err, services.ErrConsumerNotFound):
 		response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+	case errors.Is(err, services.ErrConsumerUserAlreadyLinked):
+		response.NewConflictResponse(c, "Consumer already linked", err.Error())
+	case errors.Is(err, services.ErrConsumerUserNotLinked):
+		response.NewNotFoundResponse(c, "Consumer user link not found", err.Error())
 	case errors.Is(err, services.ErrConsumerRepositoryUnavailable):
 		response.NewInternalServerErrorResponse(c, "Consumer service unavailable", err.Error())
 	default:
-		response.NewInternalServerErrorResponse(c, "Failed to update linked profile", err.Error())
+		response.NewInternalServerErrorResponse(c, message, err.Error())
 	}
 }
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type CacheHandler struct {
+	cacheService *services.CacheService
+}
+
+func NewCacheHandler(cacheService *services.CacheService) *CacheHandler {
+	return &CacheHandler{cacheService: cacheService}
+}
+
+// GetStats returns the lookup counters, Redis key count and memory, and this replica's local tiers.
+func (h *CacheHandler) GetStats(c *gin.Context) {
+	if h == nil || h.cacheService == nil {
+		response.NewInternalServerErrorResponse(c, "Cache service unavailable", "cache service not configured")
+		return
+	}
+
+	stats, err := h.cacheService.Stats(c.Request.Context())
+	if err != nil {
+		respondCacheError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Cache stats retrieved successfully", dto.NewCacheStatsResponse(stats))
+}
+
+// InspectKey describes the key given in the key query parameter.
+func (h *CacheHandler) InspectKey(c *gin.Context) {
+	if h == nil || h.cacheService == nil {
+		response.NewInternalServerErrorResponse(c, "Cache service unavailable", "cache service not configured")
+		return
+	}
+
+	info, err := h.cacheService.InspectKey(c.Request.Context(), c.Query("key"))
+	if err != nil {
+		respondCacheError(c, err)
+		return
+	}
+	if info == nil {
+		response.NewNotFoundResponse(c, "Cache key not found", "key is not cached")
+		return
+	}
+
+	response.NewSuccessResponse(c, "Cache key retrieved successfully", dto.NewCacheKeyResponse(info))
+}
+
+// FlushResource drops every cached entry of the resource on all replicas.
+func (h *CacheHandler) FlushResource(c *gin.Context) {
+	if h == nil || h.cacheService == nil {
+		response.NewInternalServerErrorResponse(c, "Cache service unavailable", "cache service not configured")
+		return
+	}
+
+	resource := c.Param("resource")
+	deleted, err := h.cacheService.FlushResource(c.Request.Context(), resource)
+	if err != nil {
+		respondCacheError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Cache flushed successfully", dto.CacheOperationResponse{Resource: resource, Keys: deleted})
+}
+
+// WarmResource loads every entity of the resource from the database into the cache.
+func (h *CacheHandler) WarmResource(c *gin.Context) {
+	if h == nil || h.cacheService == nil {
+		response.NewInternalServerErrorResponse(c, "Cache service unavailable", "cache service not configured")
+		return
+	}
+
+	resource := c.Param("resource")
+	warmed, err := h.cacheService.WarmResource(c.Request.Context(), resource)
+	if err != nil {
+		respondCacheError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Cache warmed successfully", dto.CacheOperationResponse{Resource: resource, Keys: int64(warmed)})
+}
+
+// Metrics renders the cache counters in the Prometheus text exposition format.
+func (h *CacheHandler) Metrics(c *gin.Context) {
+	if h == nil || h.cacheService == nil {
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+
+	stats, err := h.cacheService.Stats(c.Request.Context())
+	if err != nil {
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("# HELP katseye_cache_lookups_total Cache lookups by resource, operation and outcome.\n")
+	b.WriteString("# TYPE katseye_cache_lookups_total counter\n")
+	for _, counter := range stats.Counters {
+		fmt.Fprintf(&b, "katseye_cache_lookups_total{resource=%q,operation=%q,outcome=%q} %d\n", counter.Resource, counter.Operation, counter.Outcome, counter.Count)
+	}
+
+	b.WriteString("# HELP katseye_cache_up Whether Redis answered the last scrape.\n")
+	b.WriteString("# TYPE katseye_cache_up gauge\n")
+	up := 0
+	if stats.Available {
+		up = 1
+	}
+	fmt.Fprintf(&b, "katseye_cache_up %d\n", up)
+	if stats.Available {
+		b.WriteString("# HELP katseye_cache_keys Keys in the Redis database.\n")
+		b.WriteString("# TYPE katseye_cache_keys gauge\n")
+		fmt.Fprintf(&b, "katseye_cache_keys %d\n", stats.Keys)
+		b.WriteString("# HELP katseye_cache_used_memory_bytes Memory used by Redis.\n")
+		b.WriteString("# TYPE katseye_cache_used_memory_bytes gauge\n")
+		fmt.Fprintf(&b, "katseye_cache_used_memory_bytes %d\n", stats.UsedMemoryBytes)
+	}
+
+	if len(stats.Local) > 0 {
+		b.WriteString("# HELP katseye_cache_local_entries Entries held in the in-process tier.\n")
+		b.WriteString("# TYPE katseye_cache_local_entries gauge\n")
+		for _, tier := range stats.Local {
+			fmt.Fprintf(&b, "katseye_cache_local_entries{resource=%q} %d\n", tier.Resource, tier.Entries)
+		}
+	}
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
+
+func respondCacheError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCacheKeyRequired):
+		response.NewBadRequestResponse(c, "Invalid cache key", err.Error())
+	case errors.Is(err, repositories.ErrUnknownCacheResource):
+		response.NewNotFoundResponse(c, "Cache resource not found", err.Error())
+	case errors.Is(err, repositories.ErrCacheResourceNotWarmable):
+		response.NewUnprocessableEntityResponse(c, "Cache resource cannot be warmed", err.Error())
+	case errors.Is(err, services.ErrCacheUnavailable):
+		response.NewInternalServerErrorResponse(c, "Cache unavailable", err.Error())
+	default:
+		response.NewInternalServerErrorResponse(c, "Failed to process cache operation", err.Error())
+	}
+}
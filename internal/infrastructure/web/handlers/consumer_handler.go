@@ -2,13 +2,17 @@ package handlers
 
 import (
 	"errors"
+	"io"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
 	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
 	"katseye/internal/infrastructure/web/dto"
+	webmiddleware "katseye/internal/infrastructure/web/middleware"
 	"katseye/internal/infrastructure/web/response"
 )
 
@@ -48,7 +52,7 @@ func (h *ConsumerHandler) GetConsumer(c *gin.Context) {
 		return
 	}
 
-	response.NewSuccessResponse(c, "Consumer retrieved successfully", dto.NewConsumerResponse(consumer))
+	response.NewSuccessResponse(c, "Consumer retrieved successfully", consumerPayload(c, consumer))
 }
 
 func (h *ConsumerHandler) CreateConsumer(c *gin.Context) {
@@ -84,7 +88,12 @@ func (h *ConsumerHandler) CreateConsumer(c *gin.Context) {
 		return
 	}
 
-	response.NewCreatedResponse(c, "Consumer created successfully", dto.NewConsumerResponse(consumer))
+	if consumer.Screening.Status == entities.ReviewStatusPending {
+		response.NewAcceptedResponse(c, "Consumer created and held for manual review", consumerPayload(c, consumer))
+		return
+	}
+
+	response.NewCreatedResponse(c, "Consumer created successfully", consumerPayload(c, consumer))
 }
 
 func (h *ConsumerHandler) UpdateConsumer(c *gin.Context) {
@@ -122,13 +131,15 @@ func (h *ConsumerHandler) UpdateConsumer(c *gin.Context) {
 			response.NewBadRequestResponse(c, "Consumer validation failed", err.Error())
 		case errors.Is(err, services.ErrConsumerNotFound):
 			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+		case errors.Is(err, entities.ErrConsumerErased):
+			response.NewConflictResponse(c, "Consumer data erased", err.Error())
 		default:
 			response.NewBadRequestResponse(c, "Unable to update consumer", err.Error())
 		}
 		return
 	}
 
-	response.NewSuccessResponse(c, "Consumer updated successfully", dto.NewConsumerResponse(consumer))
+	response.NewSuccessResponse(c, "Consumer updated successfully", consumerPayload(c, consumer))
 }
 
 func (h *ConsumerHandler) DeleteConsumer(c *gin.Context) {
@@ -173,9 +184,33 @@ func (h *ConsumerHandler) ListConsumers(c *gin.Context) {
 		return
 	}
 
-	consumers, err := h.consumerService.ListConsumers(c.Request.Context(), map[string]interface{}{})
+	if documentNumber := c.Query("document_number"); documentNumber != "" {
+		h.findConsumerByDocument(c, documentNumber)
+		return
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	query := repositories.ConsumerQuery{Page: page}
+
+	if raw := c.Query("type"); raw != "" {
+		consumerType, err := valueobjects.NewConsumerType(raw)
+		if err != nil {
+			response.NewBadRequestResponse(c, "Invalid consumer type", err.Error())
+			return
+		}
+		query.Type = consumerType
+	}
+
+	consumers, pageInfo, err := h.consumerService.ListConsumers(c.Request.Context(), query)
 	if err != nil {
 		switch {
+		case isListQueryError(err):
+			response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
 		case errors.Is(err, services.ErrConsumerRepositoryUnavailable):
 			response.NewInternalServerErrorResponse(c, "Consumer data unavailable", err.Error())
 		default:
@@ -184,7 +219,27 @@ func (h *ConsumerHandler) ListConsumers(c *gin.Context) {
 		return
 	}
 
-	response.NewSuccessResponse(c, "Consumers retrieved successfully", dto.NewConsumerResponseList(consumers))
+	response.NewPaginatedResponse(c, "Consumers retrieved successfully", consumerListPayload(c, consumers), paginationMeta(pageInfo))
+}
+
+func (h *ConsumerHandler) findConsumerByDocument(c *gin.Context, documentNumber string) {
+	consumer, err := h.consumerService.GetConsumerByDocument(c.Request.Context(), documentNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrConsumerRepositoryUnavailable):
+			response.NewInternalServerErrorResponse(c, "Consumer data unavailable", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to retrieve consumers", err.Error())
+		}
+		return
+	}
+
+	var consumers []*entities.Consumer
+	if consumer != nil {
+		consumers = append(consumers, consumer)
+	}
+
+	response.NewSuccessResponse(c, "Consumers retrieved successfully", consumerListPayload(c, consumers))
 }
 
 func (h *ConsumerHandler) ContractProduct(c *gin.Context) {
@@ -205,16 +260,36 @@ func (h *ConsumerHandler) ContractProduct(c *gin.Context) {
 		return
 	}
 
-	if err := h.consumerService.ContractProduct(c.Request.Context(), consumerID, productID); err != nil {
+	// The simulated amount and term are optional; an empty body contracts at the tier rate only.
+	var req dto.ContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.NewBadRequestResponse(c, "Invalid request body", err.Error())
+		return
+	}
+
+	contract, err := h.consumerService.ContractProduct(c.Request.Context(), consumerID, productID, req.ToDomain())
+	if err != nil {
 		switch {
+		case errors.Is(err, services.ErrInvalidContractTerms):
+			response.NewBadRequestResponse(c, "Invalid contract terms", err.Error())
 		case errors.Is(err, services.ErrConsumerNotFound):
 			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
 		case errors.Is(err, services.ErrProductNotFound):
 			response.NewNotFoundResponse(c, "Product not found", err.Error())
 		case errors.Is(err, entities.ErrConsumerProductAlreadyLinked):
 			response.NewConflictResponse(c, "Product already contracted", err.Error())
+		case errors.Is(err, entities.ErrConsumerErased):
+			response.NewConflictResponse(c, "Consumer data erased", err.Error())
+		case errors.Is(err, entities.ErrConsumerUnderReview):
+			response.NewConflictResponse(c, "Consumer held for manual review", err.Error())
+		case errors.Is(err, entities.ErrConsumerReviewRejected):
+			response.NewUnprocessableEntityResponse(c, "Consumer rejected on manual review", err.Error())
+		case errors.Is(err, services.ErrProductNotEligible):
+			response.NewUnprocessableEntityResponse(c, "Consumer not eligible for product", err.Error())
 		case errors.Is(err, services.ErrConsumerRepositoryUnavailable),
-			errors.Is(err, services.ErrProductRepositoryUnavailable):
+			errors.Is(err, services.ErrProductRepositoryUnavailable),
+			errors.Is(err, services.ErrContractRepositoryUnavailable),
+			errors.Is(err, services.ErrScreeningRepositoryUnavailable):
 			response.NewInternalServerErrorResponse(c, "Operation unavailable", err.Error())
 		default:
 			response.NewInternalServerErrorResponse(c, "Failed to contract product", err.Error())
@@ -228,7 +303,12 @@ func (h *ConsumerHandler) ContractProduct(c *gin.Context) {
 		return
 	}
 
-	response.NewSuccessResponse(c, "Product contracted successfully", dto.NewConsumerResponse(updatedConsumer))
+	if contract.IsPendingReview() {
+		response.NewAcceptedResponse(c, "Contract held for manual review", consumerPayload(c, updatedConsumer))
+		return
+	}
+
+	response.NewSuccessResponse(c, "Product contracted successfully", consumerPayload(c, updatedConsumer))
 }
 
 func (h *ConsumerHandler) RemoveProduct(c *gin.Context) {
@@ -255,6 +335,8 @@ func (h *ConsumerHandler) RemoveProduct(c *gin.Context) {
 			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
 		case errors.Is(err, entities.ErrConsumerProductNotLinked):
 			response.NewNotFoundResponse(c, "Relationship not found", err.Error())
+		case errors.Is(err, services.ErrContractOutstandingBalance):
+			response.NewConflictResponse(c, "Contract has an outstanding balance", err.Error())
 		case errors.Is(err, services.ErrConsumerRepositoryUnavailable):
 			response.NewInternalServerErrorResponse(c, "Consumer data unavailable", err.Error())
 		default:
@@ -269,5 +351,57 @@ func (h *ConsumerHandler) RemoveProduct(c *gin.Context) {
 		return
 	}
 
-	response.NewSuccessResponse(c, "Product contract removed successfully", dto.NewConsumerResponse(updatedConsumer))
+	response.NewSuccessResponse(c, "Product contract removed successfully", consumerPayload(c, updatedConsumer))
+}
+
+// ListContracts returns the contracts recorded for the consumer, including cancelled ones.
+func (h *ConsumerHandler) ListContracts(c *gin.Context) {
+	if h == nil || h.consumerService == nil {
+		response.NewInternalServerErrorResponse(c, "Consumer service unavailable", "consumer service not configured")
+		return
+	}
+
+	consumerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid consumer ID", err.Error())
+		return
+	}
+
+	contracts, err := h.consumerService.ListContracts(c.Request.Context(), consumerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrConsumerNotFound):
+			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+		case errors.Is(err, services.ErrConsumerRepositoryUnavailable),
+			errors.Is(err, services.ErrContractRepositoryUnavailable):
+			response.NewInternalServerErrorResponse(c, "Contract data unavailable", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to list contracts", err.Error())
+		}
+		return
+	}
+
+	payload := dto.NewContractResponseList(contracts)
+	if payload == nil {
+		payload = []dto.ContractResponse{}
+	}
+
+	response.NewSuccessResponse(c, "Contracts retrieved successfully", payload)
+}
+
+// consumerPayload shapes the consumer DTO, masking personal data unless the caller may view it.
+func consumerPayload(c *gin.Context, consumer *entities.Consumer) dto.ConsumerResponse {
+	payload := dto.NewConsumerResponse(consumer)
+	if !webmiddleware.HasPermission(c, entities.PermissionViewConsumerPII) {
+		dto.MaskConsumerResponse(&payload)
+	}
+	return payload
+}
+
+func consumerListPayload(c *gin.Context, consumers []*entities.Consumer) []dto.ConsumerResponse {
+	payload := dto.NewConsumerResponseList(consumers)
+	if !webmiddleware.HasPermission(c, entities.PermissionViewConsumerPII) {
+		dto.MaskConsumerResponseList(payload)
+	}
+	return payload
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/creditbureau"
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type CreditProfileHandler struct {
+	creditProfileService *services.CreditProfileService
+}
+
+func NewCreditProfileHandler(creditProfileService *services.CreditProfileService) *CreditProfileHandler {
+	return &CreditProfileHandler{creditProfileService: creditProfileService}
+}
+
+// RefreshCreditProfile updates the consumer's score and risk indicators from the credit bureau
+// and returns the updated consumer.
+func (h *CreditProfileHandler) RefreshCreditProfile(c *gin.Context) {
+	if h == nil || h.creditProfileService == nil {
+		response.NewInternalServerErrorResponse(c, "Credit profile service unavailable", "credit profile service not configured")
+		return
+	}
+
+	consumerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid consumer ID", err.Error())
+		return
+	}
+
+	consumer, err := h.creditProfileService.RefreshCreditProfile(c.Request.Context(), consumerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrConsumerNotFound):
+			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+		case errors.Is(err, entities.ErrConsumerErased):
+			response.NewConflictResponse(c, "Consumer data erased", err.Error())
+		case errors.Is(err, creditbureau.ErrInvalidDocument),
+			errors.Is(err, creditbureau.ErrDocumentNotFound):
+			response.NewUnprocessableEntityResponse(c, "Credit bureau has no data for consumer", err.Error())
+		case errors.Is(err, services.ErrCreditBureauFailure):
+			response.NewBadGatewayResponse(c, "Credit bureau query failed", err.Error())
+		case errors.Is(err, services.ErrConsumerRepositoryUnavailable),
+			errors.Is(err, services.ErrCreditBureauUnavailable):
+			response.NewInternalServerErrorResponse(c, "Operation unavailable", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to refresh credit profile", err.Error())
+		}
+		return
+	}
+
+	response.NewSuccessResponse(c, "Credit profile refreshed successfully", consumerPayload(c, consumer))
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type HealthHandler struct {
+	healthService *services.HealthService
+}
+
+func NewHealthHandler(healthService *services.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Check reports each dependency. It answers 503 only when one is down, so a degraded cache does
+// not take the replica out of the load balancer.
+func (h *HealthHandler) Check(c *gin.Context) {
+	if h == nil || h.healthService == nil {
+		response.NewInternalServerErrorResponse(c, "Health service unavailable", "health service not configured")
+		return
+	}
+
+	report := h.healthService.Check(c.Request.Context())
+	if report.Status == repositories.HealthStatusDown {
+		c.JSON(http.StatusServiceUnavailable, response.SuccessResponse{
+			Code:    http.StatusServiceUnavailable,
+			Message: "Service unavailable",
+			Data:    dto.NewHealthResponse(report),
+		})
+		return
+	}
+
+	response.NewSuccessResponse(c, "Health check completed", dto.NewHealthResponse(report))
+}
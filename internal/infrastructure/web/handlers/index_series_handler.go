@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+const indexSeriesManualSource = "manual"
+
+type IndexSeriesHandler struct {
+	indexSeriesService *services.IndexSeriesService
+}
+
+func NewIndexSeriesHandler(indexSeriesService *services.IndexSeriesService) *IndexSeriesHandler {
+	return &IndexSeriesHandler{indexSeriesService: indexSeriesService}
+}
+
+// GetIndexSeries returns the stored values of an index between the optional from and to dates,
+// together with the latest value used to project floating rates.
+func (h *IndexSeriesHandler) GetIndexSeries(c *gin.Context) {
+	if h == nil || h.indexSeriesService == nil {
+		response.NewInternalServerErrorResponse(c, "Index series service unavailable", "index series service not configured")
+		return
+	}
+
+	index, err := valueobjects.NewRateIndex(c.Param("index"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid rate index", err.Error())
+		return
+	}
+
+	from, err := queryDate(c, "from")
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid from date", err.Error())
+		return
+	}
+	to, err := queryDate(c, "to")
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid to date", err.Error())
+		return
+	}
+
+	values, err := h.indexSeriesService.ListIndexValues(c.Request.Context(), index, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrInvalidRange):
+			response.NewBadRequestResponse(c, "Invalid date range", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to retrieve index series", err.Error())
+		}
+		return
+	}
+
+	latest, err := h.indexSeriesService.GetLatestIndexValue(c.Request.Context(), index)
+	if err != nil {
+		response.NewInternalServerErrorResponse(c, "Failed to retrieve index series", err.Error())
+		return
+	}
+
+	response.NewSuccessResponse(c, "Index series retrieved successfully", dto.NewIndexSeriesResponse(index, latest, values))
+}
+
+// RecordIndexValues stores values for an index. The body is either JSON or, with a text/csv
+// content type, a CSV file in the format accepted by the import command.
+func (h *IndexSeriesHandler) RecordIndexValues(c *gin.Context) {
+	if h == nil || h.indexSeriesService == nil {
+		response.NewInternalServerErrorResponse(c, "Index series service unavailable", "index series service not configured")
+		return
+	}
+
+	index, err := valueobjects.NewRateIndex(c.Param("index"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid rate index", err.Error())
+		return
+	}
+
+	var values []*entities.IndexValue
+	source := indexSeriesManualSource
+
+	if strings.HasPrefix(c.ContentType(), "text/csv") {
+		comma := ','
+		if delimiter := c.Query("delimiter"); delimiter != "" {
+			comma = []rune(delimiter)[0]
+		}
+		values, err = services.ParseIndexSeriesCSV(c.Request.Body, services.IndexSeriesCSVOptions{Index: index, Comma: comma})
+		if err != nil {
+			response.NewBadRequestResponse(c, "Invalid index series file", err.Error())
+			return
+		}
+	} else {
+		var req dto.IndexSeriesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.NewBadRequestResponse(c, "Invalid request body", err.Error())
+			return
+		}
+		if values, err = req.ToDomain(index); err != nil {
+			response.NewBadRequestResponse(c, "Invalid index values", err.Error())
+			return
+		}
+		if strings.TrimSpace(req.Source) != "" {
+			source = strings.TrimSpace(req.Source)
+		}
+	}
+
+	for _, value := range values {
+		if value.Index != index {
+			response.NewBadRequestResponse(c, "Invalid index values", "values must belong to the index in the path")
+			return
+		}
+	}
+
+	if err := h.indexSeriesService.RecordIndexValues(c.Request.Context(), values, source); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidIndexValue):
+			response.NewBadRequestResponse(c, "Invalid index values", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to record index values", err.Error())
+		}
+		return
+	}
+
+	latest, err := h.indexSeriesService.GetLatestIndexValue(c.Request.Context(), index)
+	if err != nil {
+		response.NewInternalServerErrorResponse(c, "Failed to retrieve index series", err.Error())
+		return
+	}
+
+	response.NewCreatedResponse(c, "Index values recorded successfully", dto.NewIndexSeriesResponse(index, latest, values))
+}
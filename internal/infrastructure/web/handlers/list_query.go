@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/infrastructure/web/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePagination reads the sort, limit and cursor query parameters shared by every list endpoint.
+func parsePagination(c *gin.Context) (repositories.Pagination, error) {
+	page := repositories.Pagination{
+		Cursor: strings.TrimSpace(c.Query("cursor")),
+		Sort:   repositories.ParseSort(c.Query("sort")),
+	}
+
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return repositories.Pagination{}, fmt.Errorf("%w: %s", repositories.ErrInvalidPageLimit, raw)
+		}
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+// queryValues splits a comma separated query parameter, also accepting repeated parameters.
+func queryValues(c *gin.Context, name string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(name) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func queryFloat(c *gin.Context, name string) (*float64, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", name)
+	}
+	return &value, nil
+}
+
+func queryInt(c *gin.Context, name string) (*int, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", name)
+	}
+	return &value, nil
+}
+
+func queryDate(c *gin.Context, name string) (time.Time, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	value, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+	}
+	return value, nil
+}
+
+func isListQueryError(err error) bool {
+	return errors.Is(err, repositories.ErrInvalidPageLimit) ||
+		errors.Is(err, repositories.ErrInvalidSortField) ||
+		errors.Is(err, repositories.ErrInvalidCursor) ||
+		errors.Is(err, repositories.ErrInvalidRange)
+}
+
+func paginationMeta(page repositories.PageInfo) response.PaginationMeta {
+	return response.PaginationMeta{
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type OfferHandler struct {
+	offerService *services.OfferService
+}
+
+func NewOfferHandler(offerService *services.OfferService) *OfferHandler {
+	return &OfferHandler{offerService: offerService}
+}
+
+// FindBestOffers ranks the eligible products of every partner for a consumer request.
+// Consumers may only request offers for their own profile.
+func (h *OfferHandler) FindBestOffers(c *gin.Context) {
+	if h == nil || h.offerService == nil {
+		response.NewInternalServerErrorResponse(c, "Offer service unavailable", "offer service not configured")
+		return
+	}
+
+	var req dto.OfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.NewBadRequestResponse(c, "Invalid request body", err.Error())
+		return
+	}
+
+	request, err := req.ToDomain()
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid offer request", err.Error())
+		return
+	}
+
+	if profileID, isConsumer := consumerProfileID(c); isConsumer && profileID != request.ConsumerID {
+		response.NewForbiddenResponse(c, "Access denied", "consumers can only request offers for their own profile")
+		return
+	}
+
+	result, err := h.offerService.FindBestOffers(c.Request.Context(), request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidOfferRequest):
+			response.NewBadRequestResponse(c, "Invalid offer request", err.Error())
+		case errors.Is(err, services.ErrConsumerNotFound):
+			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+		case errors.Is(err, entities.ErrConsumerErased):
+			response.NewConflictResponse(c, "Consumer data erased", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to compute offers", err.Error())
+		}
+		return
+	}
+
+	response.NewSuccessResponse(c, "Offers computed successfully", dto.NewOfferResultResponse(result))
+}
+
+// SimulateProduct quotes one product for a consumer with the pricing tier that matches their
+// credit profile. Ineligible requests still return the tier and the failed rules.
+func (h *OfferHandler) SimulateProduct(c *gin.Context) {
+	if h == nil || h.offerService == nil {
+		response.NewInternalServerErrorResponse(c, "Offer service unavailable", "offer service not configured")
+		return
+	}
+
+	var req dto.SimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.NewBadRequestResponse(c, "Invalid request body", err.Error())
+		return
+	}
+
+	request, err := req.ToDomain()
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid simulation request", err.Error())
+		return
+	}
+
+	if profileID, isConsumer := consumerProfileID(c); isConsumer && profileID != request.ConsumerID {
+		response.NewForbiddenResponse(c, "Access denied", "consumers can only simulate products for their own profile")
+		return
+	}
+
+	simulation, err := h.offerService.Simulate(c.Request.Context(), request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidOfferRequest):
+			response.NewBadRequestResponse(c, "Invalid simulation request", err.Error())
+		case errors.Is(err, services.ErrConsumerNotFound):
+			response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+		case errors.Is(err, services.ErrProductNotFound):
+			response.NewNotFoundResponse(c, "Product not found", err.Error())
+		case errors.Is(err, entities.ErrConsumerErased):
+			response.NewConflictResponse(c, "Consumer data erased", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to simulate product", err.Error())
+		}
+		return
+	}
+
+	response.NewSuccessResponse(c, "Simulation computed successfully", dto.NewSimulationResponse(simulation))
+}
+
+// consumerProfileID reports whether the caller authenticated with a consumer profile and, if so,
+// the consumer it is bound to.
+func consumerProfileID(c *gin.Context) (primitive.ObjectID, bool) {
+	return profileReferenceID(c, entities.ProfileTypeConsumer)
+}
+
+// profileReferenceID reports whether the caller authenticated with the given profile type and,
+// if so, the record its profile_reference_id claim points to. A missing or malformed reference
+// yields the zero ID, which matches nothing.
+func profileReferenceID(c *gin.Context, profileType entities.UserProfileType) (primitive.ObjectID, bool) {
+	rawClaims, exists := c.Get(claimsContextKey)
+	if !exists {
+		return primitive.NilObjectID, false
+	}
+
+	claims, ok := rawClaims.(jwt.MapClaims)
+	if !ok {
+		return primitive.NilObjectID, false
+	}
+
+	claimed, _ := claims["profile_type"].(string)
+	if entities.UserProfileType(strings.ToLower(strings.TrimSpace(claimed))) != profileType {
+		return primitive.NilObjectID, false
+	}
+
+	reference, _ := claims["profile_reference_id"].(string)
+	id, err := primitive.ObjectIDFromHex(strings.TrimSpace(reference))
+	if err != nil {
+		return primitive.NilObjectID, true
+	}
+
+	return id, true
+}
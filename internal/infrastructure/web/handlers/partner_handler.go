@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"katseye/internal/domain/repositories"
 	"katseye/internal/domain/services"
+	valueobjects "katseye/internal/domain/value_objects"
 	"katseye/internal/infrastructure/web/dto"
 	"katseye/internal/infrastructure/web/response"
 
@@ -118,13 +120,41 @@ func (h *PartnerHandler) DeletePartner(c *gin.Context) {
 }
 
 func (h *PartnerHandler) ListPartners(c *gin.Context) {
-	filter := make(map[string]interface{})
+	page, err := parsePagination(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	query := repositories.PartnerQuery{Page: page}
+
+	if raw := c.Query("type"); raw != "" {
+		partnerType, err := valueobjects.NewPartnerType(raw)
+		if err != nil {
+			response.NewBadRequestResponse(c, "Invalid partner type", err.Error())
+			return
+		}
+		query.Type = partnerType
+	}
+
+	if raw := c.Query("accepted_type"); raw != "" {
+		productType, err := valueobjects.NewProductType(raw)
+		if err != nil {
+			response.NewBadRequestResponse(c, "Invalid product type", err.Error())
+			return
+		}
+		query.AcceptedType = productType
+	}
 
-	partners, err := h.partnerService.ListPartners(c.Request.Context(), filter)
+	partners, pageInfo, err := h.partnerService.ListPartners(c.Request.Context(), query)
 	if err != nil {
+		if isListQueryError(err) {
+			response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+			return
+		}
 		response.NewInternalServerErrorResponse(c, "Failed to list partners", err.Error())
 		return
 	}
 
-	response.NewSuccessResponse(c, "Partners retrieved successfully", dto.NewPartnerResponseList(partners))
+	response.NewPaginatedResponse(c, "Partners retrieved successfully", dto.NewPartnerResponseList(partners), paginationMeta(pageInfo))
 }
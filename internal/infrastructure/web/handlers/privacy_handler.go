@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type PrivacyHandler struct {
+	privacyService *services.PrivacyService
+}
+
+func NewPrivacyHandler(privacyService *services.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{privacyService: privacyService}
+}
+
+func (h *PrivacyHandler) ExportConsumerData(c *gin.Context) {
+	if h == nil || h.privacyService == nil {
+		response.NewInternalServerErrorResponse(c, "Privacy service unavailable", "privacy service not configured")
+		return
+	}
+
+	consumerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid consumer ID", err.Error())
+		return
+	}
+
+	export, err := h.privacyService.ExportConsumerData(c.Request.Context(), consumerID, requestActorID(c))
+	if err != nil {
+		h.respondPrivacyError(c, err, "Failed to export consumer data")
+		return
+	}
+
+	response.NewSuccessResponse(c, "Consumer data exported successfully", dto.NewConsumerDataExportResponse(export))
+}
+
+func (h *PrivacyHandler) EraseConsumerData(c *gin.Context) {
+	if h == nil || h.privacyService == nil {
+		response.NewInternalServerErrorResponse(c, "Privacy service unavailable", "privacy service not configured")
+		return
+	}
+
+	consumerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid consumer ID", err.Error())
+		return
+	}
+
+	report, err := h.privacyService.EraseConsumerData(c.Request.Context(), consumerID, requestActorID(c))
+	if err != nil {
+		h.respondPrivacyError(c, err, "Failed to erase consumer data")
+		return
+	}
+
+	response.NewSuccessResponse(c, "Consumer data erased successfully", dto.NewErasureReportResponse(report))
+}
+
+func (h *PrivacyHandler) respondPrivacyError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrConsumerNotFound):
+		response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+	case errors.Is(err, services.ErrConsumerAlreadyErased):
+		response.NewConflictResponse(c, "Consumer data already erased", err.Error())
+	case errors.Is(err, services.ErrConsumerRepositoryUnavailable),
+		errors.Is(err, services.ErrPrivacySigningKeyUnavailable):
+		response.NewInternalServerErrorResponse(c, "Privacy operation unavailable", err.Error())
+	default:
+		response.NewInternalServerErrorResponse(c, fallback, err.Error())
+	}
+}
+
+// requestActorID returns the authenticated user identifier, or a nil ID when unavailable.
+func requestActorID(c *gin.Context) primitive.ObjectID {
+	rawClaims, exists := c.Get(claimsContextKey)
+	if !exists {
+		return primitive.NilObjectID
+	}
+
+	claims, ok := rawClaims.(jwt.MapClaims)
+	if !ok {
+		return primitive.NilObjectID
+	}
+
+	sub, ok := claims["sub"].(string)
+	if !ok {
+		return primitive.NilObjectID
+	}
+
+	id, err := primitive.ObjectIDFromHex(strings.TrimSpace(sub))
+	if err != nil {
+		return primitive.NilObjectID
+	}
+
+	return id
+}
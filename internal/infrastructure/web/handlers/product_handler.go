@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
+	"katseye/internal/domain/repositories"
 	"katseye/internal/domain/services"
 	valueobjects "katseye/internal/domain/value_objects"
 	"katseye/internal/infrastructure/web/dto"
@@ -15,12 +18,14 @@ import (
 type ProductHandler struct {
 	productService  *services.ProductService
 	templateService *services.ProductTemplateService
+	searchService   *services.ProductSearchService
 }
 
-func NewProductHandler(productService *services.ProductService, templateService *services.ProductTemplateService) *ProductHandler {
+func NewProductHandler(productService *services.ProductService, templateService *services.ProductTemplateService, searchService *services.ProductSearchService) *ProductHandler {
 	return &ProductHandler{
 		productService:  productService,
 		templateService: templateService,
+		searchService:   searchService,
 	}
 }
 
@@ -141,15 +146,161 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 }
 
 func (h *ProductHandler) ListProducts(c *gin.Context) {
-	filter := make(map[string]interface{})
+	page, err := parsePagination(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	query := repositories.ProductQuery{Page: page}
+
+	if raw := c.Query("partner_id"); raw != "" {
+		partnerID, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			response.NewBadRequestResponse(c, "Invalid partner ID", err.Error())
+			return
+		}
+		query.PartnerID = partnerID
+	}
+
+	if raw := c.Query("product_type"); raw != "" {
+		productType, err := valueobjects.NewProductType(raw)
+		if err != nil {
+			response.NewBadRequestResponse(c, "Invalid product type", err.Error())
+			return
+		}
+		query.ProductType = productType
+	}
+
+	if raw := c.Query("category"); raw != "" {
+		category := valueobjects.ProductCategory(strings.ToLower(strings.TrimSpace(raw)))
+		switch category {
+		case valueobjects.ProductCategoryPersonal, valueobjects.ProductCategoryBusiness, valueobjects.ProductCategoryOthers:
+			query.Category = category
+		default:
+			response.NewBadRequestResponse(c, "Invalid product category", "category must be personal, business or others")
+			return
+		}
+	}
 
-	products, err := h.productService.ListProducts(c.Request.Context(), filter)
+	products, pageInfo, err := h.productService.ListProducts(c.Request.Context(), query)
 	if err != nil {
+		if isListQueryError(err) {
+			response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+			return
+		}
 		response.NewInternalServerErrorResponse(c, "Failed to retrieve products", err.Error())
 		return
 	}
 
-	response.NewSuccessResponse(c, "Products retrieved successfully", dto.NewProductResponseList(products))
+	response.NewPaginatedResponse(c, "Products retrieved successfully", dto.NewProductResponseList(products), paginationMeta(pageInfo))
+}
+
+// SearchProducts runs a catalog search. Multi-valued filters accept comma separated values and
+// every facet is counted over the full result set, not only the returned page.
+func (h *ProductHandler) SearchProducts(c *gin.Context) {
+	if h == nil || h.searchService == nil {
+		response.NewInternalServerErrorResponse(c, "Product search unavailable", "search service not configured")
+		return
+	}
+
+	query, err := parseProductSearchQuery(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid search parameters", err.Error())
+		return
+	}
+
+	result, err := h.searchService.SearchProducts(c.Request.Context(), query)
+	if err != nil {
+		switch {
+		case isListQueryError(err):
+			response.NewBadRequestResponse(c, "Invalid search parameters", err.Error())
+		case errors.Is(err, services.ErrProductSearchUnavailable):
+			response.NewInternalServerErrorResponse(c, "Product search unavailable", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to search products", err.Error())
+		}
+		return
+	}
+
+	response.NewPaginatedResponse(c, "Products retrieved successfully", dto.NewProductSearchResponse(result), paginationMeta(result.Page))
+}
+
+func parseProductSearchQuery(c *gin.Context) (repositories.ProductSearchQuery, error) {
+	page, err := parsePagination(c)
+	if err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+
+	query := repositories.ProductSearchQuery{
+		Text: strings.TrimSpace(c.Query("q")),
+		Page: page,
+	}
+
+	if raw := c.Query("partner_id"); raw != "" {
+		partnerID, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			return repositories.ProductSearchQuery{}, fmt.Errorf("invalid partner_id: %w", err)
+		}
+		query.PartnerID = partnerID
+	}
+
+	for _, raw := range queryValues(c, "product_type") {
+		productType, err := valueobjects.NewProductType(raw)
+		if err != nil {
+			return repositories.ProductSearchQuery{}, fmt.Errorf("invalid product_type %q: %w", raw, err)
+		}
+		query.ProductTypes = append(query.ProductTypes, productType)
+	}
+
+	for _, raw := range queryValues(c, "category") {
+		category := valueobjects.ProductCategory(strings.ToLower(raw))
+		switch category {
+		case valueobjects.ProductCategoryPersonal, valueobjects.ProductCategoryBusiness, valueobjects.ProductCategoryOthers:
+			query.Categories = append(query.Categories, category)
+		default:
+			return repositories.ProductSearchQuery{}, fmt.Errorf("invalid category %q", raw)
+		}
+	}
+
+	for _, raw := range queryValues(c, "partner_type") {
+		partnerType, err := valueobjects.NewPartnerType(raw)
+		if err != nil {
+			return repositories.ProductSearchQuery{}, fmt.Errorf("invalid partner_type %q: %w", raw, err)
+		}
+		query.PartnerTypes = append(query.PartnerTypes, partnerType)
+	}
+
+	for _, raw := range queryValues(c, "risk_level") {
+		riskLevel := valueobjects.RiskLevel(strings.ToLower(raw))
+		switch riskLevel {
+		case valueobjects.RiskLevelLow, valueobjects.RiskLevelMedium, valueobjects.RiskLevelHigh:
+			query.RiskLevels = append(query.RiskLevels, riskLevel)
+		default:
+			return repositories.ProductSearchQuery{}, fmt.Errorf("invalid risk_level %q", raw)
+		}
+	}
+
+	if query.InterestRate.Min, err = queryFloat(c, "min_interest_rate"); err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+	if query.InterestRate.Max, err = queryFloat(c, "max_interest_rate"); err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+	if query.Amount.Min, err = queryFloat(c, "min_amount"); err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+	if query.Amount.Max, err = queryFloat(c, "max_amount"); err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+	if query.TermMonths.Min, err = queryInt(c, "min_term_months"); err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+	if query.TermMonths.Max, err = queryInt(c, "max_term_months"); err != nil {
+		return repositories.ProductSearchQuery{}, err
+	}
+
+	return query, nil
 }
 
 func (h *ProductHandler) ListProductTemplates(c *gin.Context) {
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"katseye/internal/domain/repositories"
+	valueobjects "katseye/internal/domain/value_objects"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func searchContext(rawQuery string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/products/search?"+rawQuery, nil)
+	return c
+}
+
+func TestParseProductSearchQuery(t *testing.T) {
+	partnerID := primitive.NewObjectID()
+	minRate, maxAmount := 1.25, 50000.0
+	minTerm, maxTerm := 6, 48
+
+	tests := []struct {
+		name     string
+		rawQuery string
+		want     repositories.ProductSearchQuery
+	}{
+		{name: "no parameters", want: repositories.ProductSearchQuery{}},
+		{
+			name:     "text and pagination",
+			rawQuery: "q=+consignado+&limit=20&cursor=abc&sort=-interest_rate",
+			want: repositories.ProductSearchQuery{
+				Text: "consignado",
+				Page: repositories.Pagination{Limit: 20, Cursor: "abc", Sort: repositories.Sort{Field: "interest_rate", Descending: true}},
+			},
+		},
+		{
+			name:     "comma separated and repeated lists",
+			rawQuery: "partner_id=" + partnerID.Hex() + "&product_type=personal_loan,payroll_loan&category=Personal&category=business&partner_type=BANK&risk_level=low,+high",
+			want: repositories.ProductSearchQuery{
+				PartnerID:    partnerID,
+				ProductTypes: []valueobjects.ProductType{valueobjects.ProductTypePersonalLoan, valueobjects.ProductTypePayrollLoan},
+				Categories:   []valueobjects.ProductCategory{valueobjects.ProductCategoryPersonal, valueobjects.ProductCategoryBusiness},
+				PartnerTypes: []valueobjects.PartnerType{valueobjects.PartnerTypeBank},
+				RiskLevels:   []valueobjects.RiskLevel{valueobjects.RiskLevelLow, valueobjects.RiskLevelHigh},
+			},
+		},
+		{
+			name:     "ranges",
+			rawQuery: "min_interest_rate=1.25&max_amount=50000&min_term_months=6&max_term_months=48",
+			want: repositories.ProductSearchQuery{
+				InterestRate: repositories.FloatRange{Min: &minRate},
+				Amount:       repositories.FloatRange{Max: &maxAmount},
+				TermMonths:   repositories.IntRange{Min: &minTerm, Max: &maxTerm},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProductSearchQuery(searchContext(tt.rawQuery))
+			if err != nil {
+				t.Fatalf("parseProductSearchQuery returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected query %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseProductSearchQueryRejectsInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		wantErr  string
+	}{
+		{name: "partner id", rawQuery: "partner_id=not-an-id", wantErr: "invalid partner_id"},
+		{name: "product type", rawQuery: "product_type=personal_loan,mortgage", wantErr: `invalid product_type "mortgage"`},
+		{name: "category", rawQuery: "category=retail", wantErr: `invalid category "retail"`},
+		{name: "partner type", rawQuery: "partner_type=broker", wantErr: `invalid partner_type "broker"`},
+		{name: "risk level", rawQuery: "risk_level=extreme", wantErr: `invalid risk_level "extreme"`},
+		{name: "interest rate", rawQuery: "max_interest_rate=high", wantErr: "max_interest_rate must be a number"},
+		{name: "amount", rawQuery: "min_amount=1.000,00", wantErr: "min_amount must be a number"},
+		{name: "term", rawQuery: "min_term_months=6.5", wantErr: "min_term_months must be an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseProductSearchQuery(searchContext(tt.rawQuery))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	for _, limit := range []string{"0", "-1", "ten"} {
+		t.Run("limit "+limit, func(t *testing.T) {
+			if _, err := parseProductSearchQuery(searchContext("limit=" + limit)); !errors.Is(err, repositories.ErrInvalidPageLimit) {
+				t.Fatalf("expected ErrInvalidPageLimit, got %v", err)
+			}
+		})
+	}
+}
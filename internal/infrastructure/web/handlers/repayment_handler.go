@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type RepaymentHandler struct {
+	repaymentService *services.RepaymentService
+}
+
+func NewRepaymentHandler(repaymentService *services.RepaymentService) *RepaymentHandler {
+	return &RepaymentHandler{repaymentService: repaymentService}
+}
+
+// ListInstallments returns the installment schedule of a contract with its late charges.
+func (h *RepaymentHandler) ListInstallments(c *gin.Context) {
+	if h == nil || h.repaymentService == nil {
+		response.NewInternalServerErrorResponse(c, "Repayment service unavailable", "repayment service not configured")
+		return
+	}
+
+	consumerID, contractID, ok := contractParams(c)
+	if !ok {
+		return
+	}
+
+	installments, err := h.repaymentService.ListInstallments(c.Request.Context(), consumerID, contractID)
+	if err != nil {
+		respondRepaymentError(c, "Failed to list installments", err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Installments retrieved successfully", dto.NewContractInstallmentResponseList(installments))
+}
+
+// ListPayments returns the payments posted to a contract.
+func (h *RepaymentHandler) ListPayments(c *gin.Context) {
+	if h == nil || h.repaymentService == nil {
+		response.NewInternalServerErrorResponse(c, "Repayment service unavailable", "repayment service not configured")
+		return
+	}
+
+	consumerID, contractID, ok := contractParams(c)
+	if !ok {
+		return
+	}
+
+	payments, err := h.repaymentService.ListPayments(c.Request.Context(), consumerID, contractID)
+	if err != nil {
+		respondRepaymentError(c, "Failed to list payments", err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Payments retrieved successfully", dto.NewPaymentResponseList(payments))
+}
+
+// PostPayment records a full, partial or early repayment against a contract.
+func (h *RepaymentHandler) PostPayment(c *gin.Context) {
+	if h == nil || h.repaymentService == nil {
+		response.NewInternalServerErrorResponse(c, "Repayment service unavailable", "repayment service not configured")
+		return
+	}
+
+	consumerID, contractID, ok := contractParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.NewBadRequestResponse(c, "Invalid request body", err.Error())
+		return
+	}
+
+	payment, err := h.repaymentService.PostPayment(c.Request.Context(), consumerID, contractID, req.ToDomain())
+	if err != nil {
+		respondRepaymentError(c, "Failed to post payment", err)
+		return
+	}
+
+	response.NewCreatedResponse(c, "Payment posted successfully", dto.NewPaymentResponse(payment))
+}
+
+func contractParams(c *gin.Context) (primitive.ObjectID, primitive.ObjectID, bool) {
+	consumerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid consumer ID", err.Error())
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+
+	contractID, err := primitive.ObjectIDFromHex(c.Param("contract_id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid contract ID", err.Error())
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+
+	return consumerID, contractID, true
+}
+
+func respondRepaymentError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPayment):
+		response.NewBadRequestResponse(c, "Invalid payment", err.Error())
+	case errors.Is(err, services.ErrContractNotFound):
+		response.NewNotFoundResponse(c, "Contract not found", err.Error())
+	case errors.Is(err, services.ErrContractNotActive):
+		response.NewConflictResponse(c, "Contract is not active", err.Error())
+	case errors.Is(err, services.ErrEarlyRepaymentNotAllowed):
+		response.NewUnprocessableEntityResponse(c, "Early repayment not allowed", err.Error())
+	case errors.Is(err, services.ErrPaymentExceedsBalance):
+		response.NewUnprocessableEntityResponse(c, "Payment exceeds balance", err.Error())
+	case errors.Is(err, services.ErrContractWithoutInstallments):
+		response.NewUnprocessableEntityResponse(c, "Contract has no installments", err.Error())
+	case errors.Is(err, repositories.ErrConcurrentUpdate):
+		response.NewConflictResponse(c, "Installments changed while the payment was applied", err.Error())
+	case errors.Is(err, services.ErrInstallmentRepositoryUnavailable),
+		errors.Is(err, services.ErrPaymentRepositoryUnavailable),
+		errors.Is(err, services.ErrContractRepositoryUnavailable):
+		response.NewInternalServerErrorResponse(c, "Repayment data unavailable", err.Error())
+	default:
+		response.NewInternalServerErrorResponse(c, message, err.Error())
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type ScreeningHandler struct {
+	screeningService *services.ScreeningService
+}
+
+func NewScreeningHandler(screeningService *services.ScreeningService) *ScreeningHandler {
+	return &ScreeningHandler{screeningService: screeningService}
+}
+
+// ListReviews returns the manual review queue, optionally filtered by status and stage.
+func (h *ScreeningHandler) ListReviews(c *gin.Context) {
+	if h == nil || h.screeningService == nil {
+		response.NewInternalServerErrorResponse(c, "Screening service unavailable", "screening service not configured")
+		return
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	query := repositories.ScreeningReviewQuery{Page: page}
+
+	if raw := c.Query("status"); raw != "" {
+		status := entities.ReviewStatus(raw)
+		if err := status.Validate(); err != nil {
+			response.NewBadRequestResponse(c, "Invalid review status", err.Error())
+			return
+		}
+		query.Status = status
+	}
+
+	if raw := c.Query("stage"); raw != "" {
+		stage := entities.ScreeningStage(raw)
+		if err := stage.Validate(); err != nil {
+			response.NewBadRequestResponse(c, "Invalid screening stage", err.Error())
+			return
+		}
+		query.Stage = stage
+	}
+
+	reviews, pageInfo, err := h.screeningService.ListReviews(c.Request.Context(), query)
+	if err != nil {
+		switch {
+		case isListQueryError(err):
+			response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		case errors.Is(err, services.ErrScreeningRepositoryUnavailable):
+			response.NewInternalServerErrorResponse(c, "Screening data unavailable", err.Error())
+		default:
+			response.NewInternalServerErrorResponse(c, "Failed to retrieve reviews", err.Error())
+		}
+		return
+	}
+
+	response.NewPaginatedResponse(c, "Reviews retrieved successfully", dto.NewScreeningReviewResponseList(reviews), paginationMeta(pageInfo))
+}
+
+func (h *ScreeningHandler) GetReview(c *gin.Context) {
+	if h == nil || h.screeningService == nil {
+		response.NewInternalServerErrorResponse(c, "Screening service unavailable", "screening service not configured")
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	review, err := h.screeningService.GetReview(c.Request.Context(), id)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Review retrieved successfully", dto.NewScreeningReviewResponse(review))
+}
+
+// ApproveReview releases the held consumer or contract.
+func (h *ScreeningHandler) ApproveReview(c *gin.Context) {
+	h.decideReview(c, true)
+}
+
+// RejectReview blocks the held consumer or cancels the held contract.
+func (h *ScreeningHandler) RejectReview(c *gin.Context) {
+	h.decideReview(c, false)
+}
+
+func (h *ScreeningHandler) decideReview(c *gin.Context, approved bool) {
+	if h == nil || h.screeningService == nil {
+		response.NewInternalServerErrorResponse(c, "Screening service unavailable", "screening service not configured")
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid review ID", err.Error())
+		return
+	}
+
+	var req dto.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.NewBadRequestResponse(c, "Invalid request body", err.Error())
+		return
+	}
+
+	review, err := h.screeningService.DecideReview(c.Request.Context(), id, approved, requestActorID(c), req.Notes)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	message := "Review approved successfully"
+	if !approved {
+		message = "Review rejected successfully"
+	}
+	response.NewSuccessResponse(c, message, dto.NewScreeningReviewResponse(review))
+}
+
+func respondScreeningError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		response.NewNotFoundResponse(c, "Review not found", err.Error())
+	case errors.Is(err, services.ErrConsumerNotFound):
+		response.NewNotFoundResponse(c, "Consumer not found", err.Error())
+	case errors.Is(err, entities.ErrReviewAlreadyDecided),
+		errors.Is(err, services.ErrReviewSubjectChanged):
+		response.NewConflictResponse(c, "Review cannot be decided", err.Error())
+	case errors.Is(err, services.ErrScreeningRepositoryUnavailable),
+		errors.Is(err, services.ErrConsumerRepositoryUnavailable),
+		errors.Is(err, services.ErrContractRepositoryUnavailable):
+		response.NewInternalServerErrorResponse(c, "Operation unavailable", err.Error())
+	default:
+		response.NewInternalServerErrorResponse(c, "Failed to process review", err.Error())
+	}
+}
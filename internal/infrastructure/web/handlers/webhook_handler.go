@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/repositories"
+	"katseye/internal/domain/services"
+	"katseye/internal/infrastructure/web/dto"
+	"katseye/internal/infrastructure/web/response"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	partnerID, ok := h.partnerParam(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context(), partnerID)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Webhook subscriptions retrieved successfully", dto.NewWebhookSubscriptionResponseList(subscriptions))
+}
+
+// CreateSubscription registers a subscription and returns its signing secret, which is not
+// shown again.
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	partnerID, ok := h.partnerParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.NewBadRequestResponse(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	subscription := req.ToEntity(partnerID, primitive.NilObjectID)
+	if err := h.webhookService.CreateSubscription(c.Request.Context(), subscription); err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	payload := dto.NewWebhookSubscriptionResponse(subscription)
+	payload.Secret = subscription.Secret
+	response.NewCreatedResponse(c, "Webhook subscription created successfully", payload)
+}
+
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	partnerID, subscriptionID, ok := h.subscriptionParams(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), partnerID, subscriptionID)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Webhook subscription retrieved successfully", dto.NewWebhookSubscriptionResponse(subscription))
+}
+
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	partnerID, subscriptionID, ok := h.subscriptionParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.NewBadRequestResponse(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	subscription := req.ToEntity(partnerID, subscriptionID)
+	if err := h.webhookService.UpdateSubscription(c.Request.Context(), subscription); err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Webhook subscription updated successfully", dto.NewWebhookSubscriptionResponse(subscription))
+}
+
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	partnerID, subscriptionID, ok := h.subscriptionParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), partnerID, subscriptionID); err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	response.NewSuccessResponse(c, "Webhook subscription deleted successfully", nil)
+}
+
+// ListDeliveries returns the partner's deliveries, newest first by default; status=dead_letter
+// lists the deliveries that exhausted their retries.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	partnerID, ok := h.partnerParam(c)
+	if !ok {
+		return
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+		return
+	}
+	if c.Query("sort") == "" {
+		page.Sort = repositories.Sort{Field: "created_at", Descending: true}
+	}
+
+	query := repositories.WebhookDeliveryQuery{PartnerID: partnerID, Page: page}
+
+	if raw := c.Query("status"); raw != "" {
+		status := entities.WebhookDeliveryStatus(raw)
+		if err := status.Validate(); err != nil {
+			response.NewBadRequestResponse(c, "Invalid delivery status", err.Error())
+			return
+		}
+		query.Status = status
+	}
+
+	deliveries, pageInfo, err := h.webhookService.ListDeliveries(c.Request.Context(), query)
+	if err != nil {
+		if isListQueryError(err) {
+			response.NewBadRequestResponse(c, "Invalid pagination parameters", err.Error())
+			return
+		}
+		respondWebhookError(c, err)
+		return
+	}
+
+	response.NewPaginatedResponse(c, "Webhook deliveries retrieved successfully", dto.NewWebhookDeliveryResponseList(deliveries), paginationMeta(pageInfo))
+}
+
+// RedeliverDelivery queues a delivery again; it is sent on the next pass of the delivery job.
+func (h *WebhookHandler) RedeliverDelivery(c *gin.Context) {
+	partnerID, ok := h.partnerParam(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := primitive.ObjectIDFromHex(c.Param("delivery_id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid delivery ID", err.Error())
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), partnerID, deliveryID)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	response.NewAcceptedResponse(c, "Webhook delivery queued for redelivery", dto.NewWebhookDeliveryResponse(delivery))
+}
+
+func (h *WebhookHandler) partnerParam(c *gin.Context) (primitive.ObjectID, bool) {
+	if h == nil || h.webhookService == nil {
+		response.NewInternalServerErrorResponse(c, "Webhook service unavailable", "webhook service not configured")
+		return primitive.NilObjectID, false
+	}
+
+	partnerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid partner ID", err.Error())
+		return primitive.NilObjectID, false
+	}
+	// Partner managers hold the edit permission too, but only for the partner they belong to.
+	if profileID, isManager := profileReferenceID(c, entities.ProfileTypePartnerManager); isManager && profileID != partnerID {
+		response.NewForbiddenResponse(c, "Access denied", "partner managers can only manage their own partner's webhooks")
+		return primitive.NilObjectID, false
+	}
+	return partnerID, true
+}
+
+func (h *WebhookHandler) subscriptionParams(c *gin.Context) (primitive.ObjectID, primitive.ObjectID, bool) {
+	partnerID, ok := h.partnerParam(c)
+	if !ok {
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+
+	subscriptionID, err := primitive.ObjectIDFromHex(c.Param("webhook_id"))
+	if err != nil {
+		response.NewBadRequestResponse(c, "Invalid webhook subscription ID", err.Error())
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return partnerID, subscriptionID, true
+}
+
+func respondWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrWebhookSubscriptionNotFound):
+		response.NewNotFoundResponse(c, "Webhook subscription not found", err.Error())
+	case errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		response.NewNotFoundResponse(c, "Webhook delivery not found", err.Error())
+	case errors.Is(err, services.ErrPartnerNotFound):
+		response.NewNotFoundResponse(c, "Partner not found", err.Error())
+	case errors.Is(err, entities.ErrInvalidWebhookURL),
+		errors.Is(err, entities.ErrInvalidWebhookEvent),
+		errors.Is(err, entities.ErrWebhookSecretTooShort):
+		response.NewBadRequestResponse(c, "Invalid webhook subscription", err.Error())
+	case errors.Is(err, services.ErrWebhookRepositoryUnavailable):
+		response.NewInternalServerErrorResponse(c, "Webhook data unavailable", err.Error())
+	default:
+		response.NewInternalServerErrorResponse(c, "Failed to process webhook request", err.Error())
+	}
+}
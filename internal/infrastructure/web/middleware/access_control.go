@@ -68,3 +68,90 @@ func RequireProfileTypes(allowed ...entities.UserProfileType) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequirePermissions ensures the authenticated user holds every provided permission,
+// combining the permissions granted by the role claim with the custom ones in the token.
+func RequirePermissions(required ...string) gin.HandlerFunc {
+	normalized := make([]string, 0, len(required))
+	for _, permission := range required {
+		permission = strings.TrimSpace(strings.ToLower(permission))
+		if permission == "" {
+			continue
+		}
+		normalized = append(normalized, permission)
+	}
+
+	if len(normalized) == 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		rawClaims, exists := c.Get(contextKeyClaims)
+		if !exists {
+			response.NewForbiddenResponse(c, "Access denied", "permission information not available")
+			c.Abort()
+			return
+		}
+
+		claims, ok := rawClaims.(jwt.MapClaims)
+		if !ok {
+			response.NewForbiddenResponse(c, "Access denied", "invalid permission claims")
+			c.Abort()
+			return
+		}
+
+		granted := claimedPermissions(claims)
+		for _, permission := range normalized {
+			if _, allowed := granted[permission]; !allowed {
+				response.NewForbiddenResponse(c, "Access denied", "insufficient permissions")
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the authenticated user in the context holds the permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	if c == nil {
+		return false
+	}
+	rawClaims, exists := c.Get(contextKeyClaims)
+	if !exists {
+		return false
+	}
+	claims, ok := rawClaims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	_, granted := claimedPermissions(claims)[strings.TrimSpace(strings.ToLower(permission))]
+	return granted
+}
+
+func claimedPermissions(claims jwt.MapClaims) map[string]struct{} {
+	granted := make(map[string]struct{})
+
+	if rawRole, ok := claims["role"]; ok {
+		role := entities.Role(strings.TrimSpace(strings.ToLower(fmt.Sprint(rawRole))))
+		for _, permission := range entities.GetRolePermissions(role) {
+			granted[permission] = struct{}{}
+		}
+	}
+
+	switch custom := claims["permissions"].(type) {
+	case []interface{}:
+		for _, permission := range custom {
+			granted[strings.TrimSpace(strings.ToLower(fmt.Sprint(permission)))] = struct{}{}
+		}
+	case []string:
+		for _, permission := range custom {
+			granted[strings.TrimSpace(strings.ToLower(permission))] = struct{}{}
+		}
+	}
+
+	return granted
+}
@@ -44,9 +44,11 @@ func WithPublicPaths(paths ...string) JWTOption {
 	}
 }
 
-// TokenRevocationChecker reports whether a token has been revoked.
+// TokenRevocationChecker reports whether a token, or every token of its subject, has been
+// revoked.
 type TokenRevocationChecker interface {
 	IsTokenRevoked(ctx context.Context, token string) (bool, error)
+	IsSubjectRevoked(ctx context.Context, subject string) (bool, error)
 }
 
 // WithTokenRevocationChecker sets the component responsible for checking token revocation.
@@ -105,8 +107,14 @@ func NewJWTAuthMiddleware(secret string, opts ...JWTOption) (gin.HandlerFunc, er
 
 		if config.revocationChecker != nil {
 			revoked, revocationErr := config.revocationChecker.IsTokenRevoked(c.Request.Context(), tokenString)
+			if revocationErr == nil && !revoked {
+				subject, _ := token.Claims.GetSubject()
+				revoked, revocationErr = config.revocationChecker.IsSubjectRevoked(c.Request.Context(), subject)
+			}
 			if revocationErr != nil {
-				response.NewInternalServerErrorResponse(c, "Token validation error", revocationErr.Error())
+				// The revocation store is unreachable and its policy is to fail closed; the
+				// client may retry once it is back.
+				response.NewErrorResponse(c, http.StatusServiceUnavailable, "Token validation unavailable", revocationErr.Error())
 				c.Abort()
 				return
 			}
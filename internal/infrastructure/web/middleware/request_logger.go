@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"katseye/internal/infrastructure/masking"
+)
+
+// NewRequestLogger mirrors gin's default access log while masking personal data that may be
+// present in the query string (e.g. document_number lookups).
+func NewRequestLogger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		path := param.Path
+		if index := strings.IndexByte(path, '?'); index >= 0 {
+			path = path[:index+1] + masking.Query(path[index+1:])
+		}
+
+		return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			param.StatusCode,
+			param.Latency,
+			param.ClientIP,
+			param.Method,
+			path,
+			param.ErrorMessage,
+		)
+	})
+}
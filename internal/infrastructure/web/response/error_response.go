@@ -52,3 +52,7 @@ func NewUnprocessableEntityResponse(c *gin.Context, message string, err string)
 func NewTooManyRequestsResponse(c *gin.Context, message string, err string) {
 	NewErrorResponse(c, http.StatusTooManyRequests, message, err)
 }
+
+func NewBadGatewayResponse(c *gin.Context, message string, err string) {
+	NewErrorResponse(c, http.StatusBadGateway, message, err)
+}
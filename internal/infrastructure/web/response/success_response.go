@@ -10,6 +10,17 @@ type SuccessResponse struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+type PaginationMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+type paginatedMeta struct {
+	Pagination PaginationMeta `json:"pagination"`
 }
 
 func NewSuccessResponse(c *gin.Context, message string, data interface{}) {
@@ -21,6 +32,16 @@ func NewSuccessResponse(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusOK, response)
 }
 
+func NewPaginatedResponse(c *gin.Context, message string, data interface{}, pagination PaginationMeta) {
+	response := SuccessResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    data,
+		Meta:    paginatedMeta{Pagination: pagination},
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 func NewCreatedResponse(c *gin.Context, message string, data interface{}) {
 	response := SuccessResponse{
 		Code:    http.StatusCreated,
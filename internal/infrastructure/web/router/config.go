@@ -19,4 +19,13 @@ func ConfigureRoutes(r gin.IRouter, h Handlers) {
 	registerPartnerRoutes(r, h.Partner)
 	registerAddressRoutes(r, h.Address)
 	registerConsumerRoutes(r, h.Consumer)
+	registerPrivacyRoutes(r, h.Privacy)
+	registerOfferRoutes(r, h.Offer)
+	registerIndexSeriesRoutes(r, h.IndexSeries)
+	registerRepaymentRoutes(r, h.Repayment)
+	registerCreditProfileRoutes(r, h.CreditProfile)
+	registerScreeningRoutes(r, h.Screening)
+	registerWebhookRoutes(r, h.Webhook)
+	registerCacheRoutes(r, h.Cache)
+	registerHealthRoutes(r, h.Health)
 }
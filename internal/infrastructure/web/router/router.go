@@ -7,18 +7,28 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"katseye/internal/infrastructure/web/handlers"
+	webmiddleware "katseye/internal/infrastructure/web/middleware"
 )
 
 type Handlers struct {
-	Product  *handlers.ProductHandler
-	Partner  *handlers.PartnerHandler
-	Address  *handlers.AddressHandler
-	Consumer *handlers.ConsumerHandler
-	Auth     *handlers.AuthHandler
+	Product       *handlers.ProductHandler
+	Partner       *handlers.PartnerHandler
+	Address       *handlers.AddressHandler
+	Consumer      *handlers.ConsumerHandler
+	Auth          *handlers.AuthHandler
+	Privacy       *handlers.PrivacyHandler
+	Offer         *handlers.OfferHandler
+	IndexSeries   *handlers.IndexSeriesHandler
+	Repayment     *handlers.RepaymentHandler
+	CreditProfile *handlers.CreditProfileHandler
+	Screening     *handlers.ScreeningHandler
+	Webhook       *handlers.WebhookHandler
+	Cache         *handlers.CacheHandler
+	Health        *handlers.HealthHandler
 }
 
 type Server struct {
-	engine *gin.Engine
+	engine     *gin.Engine
 	httpServer *http.Server
 }
 
@@ -27,7 +37,8 @@ func New(cfg Config) *Server {
 		gin.SetMode(mode)
 	}
 
-	engine := gin.Default()
+	engine := gin.New()
+	engine.Use(webmiddleware.NewRequestLogger(), gin.Recovery())
 
 	if len(cfg.Middlewares) > 0 {
 		engine.Use(cfg.Middlewares...)
@@ -45,7 +56,7 @@ func New(cfg Config) *Server {
 	}
 
 	httpServer := &http.Server{
-		Addr: address,
+		Addr:    address,
 		Handler: engine,
 	}
 
@@ -12,6 +12,14 @@ var partnerAccessibleProfiles = []entities.UserProfileType{
 	entities.ProfileTypeServiceAccount,
 }
 
+// catalogSearchProfiles may browse the product catalog and compare offers; consumers get no
+// write access to catalog resources.
+var catalogSearchProfiles = []entities.UserProfileType{
+	entities.ProfileTypePartnerManager,
+	entities.ProfileTypeServiceAccount,
+	entities.ProfileTypeConsumer,
+}
+
 func registerAuthRoutes(r gin.IRouter, handler *handlers.AuthHandler) {
 	if handler == nil {
 		return
@@ -31,6 +39,8 @@ func registerProductRoutes(r gin.IRouter, handler *handlers.ProductHandler) {
 		return
 	}
 
+	r.GET("/products/search", webmiddleware.RequireProfileTypes(catalogSearchProfiles...), handler.SearchProducts)
+
 	products := r.Group("/products")
 	products.Use(webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...))
 	products.GET("", handler.ListProducts)
@@ -82,6 +92,135 @@ func registerConsumerRoutes(r gin.IRouter, handler *handlers.ConsumerHandler) {
 	customers.GET("/:id", handler.GetConsumer)
 	customers.PUT("/:id", handler.UpdateConsumer)
 	customers.DELETE("/:id", handler.DeleteConsumer)
+	customers.GET("/:id/contracts", handler.ListContracts)
 	customers.POST("/:id/products/:product_id", handler.ContractProduct)
 	customers.DELETE("/:id/products/:product_id", handler.RemoveProduct)
 }
+
+func registerPrivacyRoutes(r gin.IRouter, handler *handlers.PrivacyHandler) {
+	if handler == nil {
+		return
+	}
+
+	privacy := r.Group("/privacy")
+	privacy.Use(
+		webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...),
+		webmiddleware.RequirePermissions(entities.PermissionManagePrivacy),
+	)
+	privacy.GET("/consumers/:id/export", handler.ExportConsumerData)
+	privacy.POST("/consumers/:id/erasure", handler.EraseConsumerData)
+}
+
+func registerOfferRoutes(r gin.IRouter, handler *handlers.OfferHandler) {
+	if handler == nil {
+		return
+	}
+
+	offers := r.Group("/offers")
+	offers.Use(webmiddleware.RequireProfileTypes(catalogSearchProfiles...))
+	offers.POST("", handler.FindBestOffers)
+	offers.POST("/simulation", handler.SimulateProduct)
+}
+
+func registerIndexSeriesRoutes(r gin.IRouter, handler *handlers.IndexSeriesHandler) {
+	if handler == nil {
+		return
+	}
+
+	series := r.Group("/admin/index-series")
+	series.Use(
+		webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...),
+		webmiddleware.RequirePermissions(entities.PermissionManageProducts),
+	)
+	series.GET("/:index", handler.GetIndexSeries)
+	series.POST("/:index", handler.RecordIndexValues)
+}
+
+func registerRepaymentRoutes(r gin.IRouter, handler *handlers.RepaymentHandler) {
+	if handler == nil {
+		return
+	}
+
+	contracts := r.Group("/customers/:id/contracts/:contract_id")
+	contracts.Use(webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...))
+	contracts.GET("/installments", handler.ListInstallments)
+	contracts.GET("/payments", handler.ListPayments)
+	contracts.POST("/payments", handler.PostPayment)
+}
+
+func registerCreditProfileRoutes(r gin.IRouter, handler *handlers.CreditProfileHandler) {
+	if handler == nil {
+		return
+	}
+
+	r.POST("/customers/:id/credit-profile/refresh", webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...), handler.RefreshCreditProfile)
+}
+
+func registerScreeningRoutes(r gin.IRouter, handler *handlers.ScreeningHandler) {
+	if handler == nil {
+		return
+	}
+
+	reviews := r.Group("/admin/screening/reviews")
+	reviews.Use(
+		webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...),
+		webmiddleware.RequirePermissions(entities.PermissionReviewScreening),
+	)
+	reviews.GET("", handler.ListReviews)
+	reviews.GET("/:id", handler.GetReview)
+	reviews.POST("/:id/approve", handler.ApproveReview)
+	reviews.POST("/:id/reject", handler.RejectReview)
+}
+
+func registerWebhookRoutes(r gin.IRouter, handler *handlers.WebhookHandler) {
+	if handler == nil {
+		return
+	}
+
+	access := []gin.HandlerFunc{
+		webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...),
+		webmiddleware.RequirePermissions(entities.PermissionEditPartners),
+	}
+
+	subscriptions := r.Group("/partners/:id/webhooks")
+	subscriptions.Use(access...)
+	subscriptions.GET("", handler.ListSubscriptions)
+	subscriptions.POST("", handler.CreateSubscription)
+	subscriptions.GET("/:webhook_id", handler.GetSubscription)
+	subscriptions.PUT("/:webhook_id", handler.UpdateSubscription)
+	subscriptions.DELETE("/:webhook_id", handler.DeleteSubscription)
+
+	deliveries := r.Group("/partners/:id/webhook-deliveries")
+	deliveries.Use(access...)
+	deliveries.GET("", handler.ListDeliveries)
+	deliveries.POST("/:delivery_id/redeliver", handler.RedeliverDelivery)
+}
+
+// registerCacheRoutes exposes the cache counters to scrapers without a token; managing the
+// cache needs the cache permission.
+func registerCacheRoutes(r gin.IRouter, handler *handlers.CacheHandler) {
+	if handler == nil {
+		return
+	}
+
+	r.GET("/metrics", handler.Metrics)
+
+	cache := r.Group("/admin/cache")
+	cache.Use(
+		webmiddleware.RequireProfileTypes(partnerAccessibleProfiles...),
+		webmiddleware.RequirePermissions(entities.PermissionManageCache),
+	)
+	cache.GET("/stats", handler.GetStats)
+	cache.GET("/keys", handler.InspectKey)
+	cache.DELETE("/resources/:resource", handler.FlushResource)
+	cache.POST("/resources/:resource/warm", handler.WarmResource)
+}
+
+// registerHealthRoutes exposes the health check to probes without a token.
+func registerHealthRoutes(r gin.IRouter, handler *handlers.HealthHandler) {
+	if handler == nil {
+		return
+	}
+
+	r.GET("/health", handler.Check)
+}
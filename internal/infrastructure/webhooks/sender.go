@@ -0,0 +1,143 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"katseye/internal/domain/entities"
+	"katseye/internal/domain/services"
+)
+
+// Headers sent with every delivery. The signature is "v1=" followed by the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the subscription secret; receivers should reject requests whose
+// timestamp is too far from their clock to prevent replays.
+const (
+	SignatureHeader = "X-Katseye-Signature"
+	TimestampHeader = "X-Katseye-Timestamp"
+	EventHeader     = "X-Katseye-Event"
+	DeliveryHeader  = "X-Katseye-Delivery"
+
+	signatureVersion = "v1"
+	userAgent        = "katseye-webhooks/1"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside tolerance")
+	ErrForbiddenAddress = errors.New("webhook host resolves to a non-public address")
+)
+
+// HTTPSender posts deliveries over HTTP. Any 2xx response counts as accepted.
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ services.WebhookSender = (*HTTPSender)(nil)
+
+// NewHTTPSender returns a sender that only connects to public addresses. The check runs on the
+// address actually dialed, after DNS resolution, so a host name cannot be repointed at the
+// internal network once its subscription was accepted.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return newHTTPSender(timeout, entities.IsPublicWebhookAddress)
+}
+
+func newHTTPSender(timeout time.Duration, allowed func(netip.Addr) bool) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !allowed(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would be the address dialed, hiding the subscriber's from the check.
+	transport.Proxy = nil
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// Redirects are not followed so a subscription cannot be pointed elsewhere silently.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (int, error) {
+	timestamp := s.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID.Hex())
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign computes the signature header value for a body sent at the given Unix timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a received delivery, as a subscriber
+// would. It is exported for receivers written in Go and for tests.
+func Verify(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration, now time.Time) error {
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(timestampHeader), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > tolerance || skew < -tolerance {
+		return ErrStaleTimestamp
+	}
+
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimSpace(signatureHeader))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
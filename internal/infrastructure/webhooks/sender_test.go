@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"katseye/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestDelivery() *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		EventType: entities.WebhookEventProductCreated,
+		Payload:   []byte(`{"type":"product.created","data":{"product_id":"p1"}}`),
+		Status:    entities.WebhookDeliveryPending,
+	}
+}
+
+// newTestSender allows loopback addresses, where the test receivers listen.
+func newTestSender() *HTTPSender {
+	return newHTTPSender(time.Second, func(netip.Addr) bool { return true })
+}
+
+func TestHTTPSender_SignsDeliveries(t *testing.T) {
+	delivery := newTestDelivery()
+
+	var verifyErr error
+	var event, deliveryID string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verifyErr = Verify(testSecret, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body, 5*time.Minute, time.Now())
+		event, deliveryID = r.Header.Get(EventHeader), r.Header.Get(DeliveryHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	subscription := &entities.WebhookSubscription{URL: receiver.URL, Secret: testSecret, Active: true}
+	status, err := newTestSender().Send(context.Background(), subscription, delivery)
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+	if verifyErr != nil {
+		t.Fatalf("receiver could not verify the signature: %v", verifyErr)
+	}
+	if event != delivery.EventType || deliveryID != delivery.ID.Hex() {
+		t.Fatalf("unexpected event headers %q %q", event, deliveryID)
+	}
+}
+
+func TestHTTPSender_ReportsRejectedDeliveries(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	subscription := &entities.WebhookSubscription{URL: receiver.URL, Secret: testSecret, Active: true}
+	status, err := newTestSender().Send(context.Background(), subscription, newTestDelivery())
+	if err == nil || status != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 failure, got status %d err %v", status, err)
+	}
+}
+
+func TestHTTPSender_RefusesInternalAddresses(t *testing.T) {
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	subscription := &entities.WebhookSubscription{URL: receiver.URL, Secret: testSecret, Active: true}
+	_, err := NewHTTPSender(time.Second).Send(context.Background(), subscription, newTestDelivery())
+	if !errors.Is(err, ErrForbiddenAddress) || called {
+		t.Fatalf("expected the loopback receiver to be refused, got %v (called=%t)", err, called)
+	}
+}
+
+func TestVerify_RejectsTamperedAndStaleDeliveries(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Unix(1_700_000_000, 0)
+	signature := Sign(testSecret, now.Unix(), body)
+	timestamp := "1700000000"
+
+	if err := Verify(testSecret, timestamp, signature, []byte(`{"id":"2"}`), time.Minute, now); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for a tampered body, got %v", err)
+	}
+	if err := Verify(testSecret, timestamp, signature, body, time.Minute, now.Add(time.Hour)); err != ErrStaleTimestamp {
+		t.Fatalf("expected ErrStaleTimestamp, got %v", err)
+	}
+	if err := Verify(testSecret, timestamp, signature, body, time.Minute, now); err != nil {
+		t.Fatalf("expected a valid signature, got %v", err)
+	}
+}